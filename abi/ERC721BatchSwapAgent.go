@@ -0,0 +1,1117 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ERC721BatchSwapAgentMetaData contains all meta data concerning the ERC721BatchSwapAgent contract.
+var ERC721BatchSwapAgentMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapTxHash\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"tokenAddr\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"fromChainId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256[]\",\"name\":\"tokenIds\",\"type\":\"uint256[]\"}],\"name\":\"BatchBackwardSwapFilled\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"mirroredTokenAddr\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"dstChainId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256[]\",\"name\":\"tokenIds\",\"type\":\"uint256[]\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"feeAmount\",\"type\":\"uint256\"}],\"name\":\"BatchBackwardSwapStarted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapTxHash\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"fromTokenAddr\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"mirroredTokenAddr\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"fromChainId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256[]\",\"name\":\"tokenIds\",\"type\":\"uint256[]\"}],\"name\":\"BatchSwapFilled\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"tokenAddr\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"dstChainId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256[]\",\"name\":\"tokenIds\",\"type\":\"uint256[]\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"feeAmount\",\"type\":\"uint256\"}],\"name\":\"BatchSwapStarted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"swapTxHash\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"fromTokenAddr\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"fromChainId\",\"type\":\"uint256\"},{\"internalType\":\"uint256[]\",\"name\":\"tokenIds\",\"type\":\"uint256[]\"},{\"internalType\":\"string[]\",\"name\":\"tokenURIs\",\"type\":\"string[]\"}],\"name\":\"batchFill\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"tokenAddr\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256[]\",\"name\":\"tokenIds\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256\",\"name\":\"dstChainId\",\"type\":\"uint256\"}],\"name\":\"batchSwap\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"filledToken\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// ERC721BatchSwapAgentABI is the input ABI used to generate the binding from.
+// Deprecated: Use ERC721BatchSwapAgentMetaData.ABI instead.
+var ERC721BatchSwapAgentABI = ERC721BatchSwapAgentMetaData.ABI
+
+// ERC721BatchSwapAgent is an auto generated Go binding around an Ethereum contract.
+type ERC721BatchSwapAgent struct {
+	ERC721BatchSwapAgentCaller     // Read-only binding to the contract
+	ERC721BatchSwapAgentTransactor // Write-only binding to the contract
+	ERC721BatchSwapAgentFilterer   // Log filterer for contract events
+}
+
+// ERC721BatchSwapAgentCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ERC721BatchSwapAgentCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC721BatchSwapAgentTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ERC721BatchSwapAgentTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC721BatchSwapAgentFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ERC721BatchSwapAgentFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC721BatchSwapAgentSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ERC721BatchSwapAgentSession struct {
+	Contract     *ERC721BatchSwapAgent // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts         // Call options to use throughout this session
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// ERC721BatchSwapAgentCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ERC721BatchSwapAgentCallerSession struct {
+	Contract *ERC721BatchSwapAgentCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts               // Call options to use throughout this session
+}
+
+// ERC721BatchSwapAgentTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ERC721BatchSwapAgentTransactorSession struct {
+	Contract     *ERC721BatchSwapAgentTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts               // Transaction auth options to use throughout this session
+}
+
+// ERC721BatchSwapAgentRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ERC721BatchSwapAgentRaw struct {
+	Contract *ERC721BatchSwapAgent // Generic contract binding to access the raw methods on
+}
+
+// ERC721BatchSwapAgentCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ERC721BatchSwapAgentCallerRaw struct {
+	Contract *ERC721BatchSwapAgentCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ERC721BatchSwapAgentTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ERC721BatchSwapAgentTransactorRaw struct {
+	Contract *ERC721BatchSwapAgentTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewERC721BatchSwapAgent creates a new instance of ERC721BatchSwapAgent, bound to a specific deployed contract.
+func NewERC721BatchSwapAgent(address common.Address, backend bind.ContractBackend) (*ERC721BatchSwapAgent, error) {
+	contract, err := bindERC721BatchSwapAgent(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC721BatchSwapAgent{ERC721BatchSwapAgentCaller: ERC721BatchSwapAgentCaller{contract: contract}, ERC721BatchSwapAgentTransactor: ERC721BatchSwapAgentTransactor{contract: contract}, ERC721BatchSwapAgentFilterer: ERC721BatchSwapAgentFilterer{contract: contract}}, nil
+}
+
+// NewERC721BatchSwapAgentCaller creates a new read-only instance of ERC721BatchSwapAgent, bound to a specific deployed contract.
+func NewERC721BatchSwapAgentCaller(address common.Address, caller bind.ContractCaller) (*ERC721BatchSwapAgentCaller, error) {
+	contract, err := bindERC721BatchSwapAgent(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC721BatchSwapAgentCaller{contract: contract}, nil
+}
+
+// NewERC721BatchSwapAgentTransactor creates a new write-only instance of ERC721BatchSwapAgent, bound to a specific deployed contract.
+func NewERC721BatchSwapAgentTransactor(address common.Address, transactor bind.ContractTransactor) (*ERC721BatchSwapAgentTransactor, error) {
+	contract, err := bindERC721BatchSwapAgent(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC721BatchSwapAgentTransactor{contract: contract}, nil
+}
+
+// NewERC721BatchSwapAgentFilterer creates a new log filterer instance of ERC721BatchSwapAgent, bound to a specific deployed contract.
+func NewERC721BatchSwapAgentFilterer(address common.Address, filterer bind.ContractFilterer) (*ERC721BatchSwapAgentFilterer, error) {
+	contract, err := bindERC721BatchSwapAgent(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC721BatchSwapAgentFilterer{contract: contract}, nil
+}
+
+// bindERC721BatchSwapAgent binds a generic wrapper to an already deployed contract.
+func bindERC721BatchSwapAgent(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ERC721BatchSwapAgentABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ERC721BatchSwapAgent.Contract.ERC721BatchSwapAgentCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.Contract.ERC721BatchSwapAgentTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.Contract.ERC721BatchSwapAgentTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ERC721BatchSwapAgent.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.Contract.contract.Transact(opts, method, params...)
+}
+
+// FilledToken is a free data retrieval call binding the contract method 0x26ed1cd4.
+//
+// Solidity: function filledToken(bytes32 , uint256 ) view returns(bool)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentCaller) FilledToken(opts *bind.CallOpts, arg0 [32]byte, arg1 *big.Int) (bool, error) {
+	var out []interface{}
+	err := _ERC721BatchSwapAgent.contract.Call(opts, &out, "filledToken", arg0, arg1)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// FilledToken is a free data retrieval call binding the contract method 0x26ed1cd4.
+//
+// Solidity: function filledToken(bytes32 , uint256 ) view returns(bool)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentSession) FilledToken(arg0 [32]byte, arg1 *big.Int) (bool, error) {
+	return _ERC721BatchSwapAgent.Contract.FilledToken(&_ERC721BatchSwapAgent.CallOpts, arg0, arg1)
+}
+
+// FilledToken is a free data retrieval call binding the contract method 0x26ed1cd4.
+//
+// Solidity: function filledToken(bytes32 , uint256 ) view returns(bool)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentCallerSession) FilledToken(arg0 [32]byte, arg1 *big.Int) (bool, error) {
+	return _ERC721BatchSwapAgent.Contract.FilledToken(&_ERC721BatchSwapAgent.CallOpts, arg0, arg1)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ERC721BatchSwapAgent.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentSession) Owner() (common.Address, error) {
+	return _ERC721BatchSwapAgent.Contract.Owner(&_ERC721BatchSwapAgent.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentCallerSession) Owner() (common.Address, error) {
+	return _ERC721BatchSwapAgent.Contract.Owner(&_ERC721BatchSwapAgent.CallOpts)
+}
+
+// BatchFill is a paid mutator transaction binding the contract method 0xab7f9388.
+//
+// Solidity: function batchFill(bytes32 swapTxHash, address fromTokenAddr, address recipient, uint256 fromChainId, uint256[] tokenIds, string[] tokenURIs) returns()
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentTransactor) BatchFill(opts *bind.TransactOpts, swapTxHash [32]byte, fromTokenAddr common.Address, recipient common.Address, fromChainId *big.Int, tokenIds []*big.Int, tokenURIs []string) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.contract.Transact(opts, "batchFill", swapTxHash, fromTokenAddr, recipient, fromChainId, tokenIds, tokenURIs)
+}
+
+// BatchFill is a paid mutator transaction binding the contract method 0xab7f9388.
+//
+// Solidity: function batchFill(bytes32 swapTxHash, address fromTokenAddr, address recipient, uint256 fromChainId, uint256[] tokenIds, string[] tokenURIs) returns()
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentSession) BatchFill(swapTxHash [32]byte, fromTokenAddr common.Address, recipient common.Address, fromChainId *big.Int, tokenIds []*big.Int, tokenURIs []string) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.Contract.BatchFill(&_ERC721BatchSwapAgent.TransactOpts, swapTxHash, fromTokenAddr, recipient, fromChainId, tokenIds, tokenURIs)
+}
+
+// BatchFill is a paid mutator transaction binding the contract method 0xab7f9388.
+//
+// Solidity: function batchFill(bytes32 swapTxHash, address fromTokenAddr, address recipient, uint256 fromChainId, uint256[] tokenIds, string[] tokenURIs) returns()
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentTransactorSession) BatchFill(swapTxHash [32]byte, fromTokenAddr common.Address, recipient common.Address, fromChainId *big.Int, tokenIds []*big.Int, tokenURIs []string) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.Contract.BatchFill(&_ERC721BatchSwapAgent.TransactOpts, swapTxHash, fromTokenAddr, recipient, fromChainId, tokenIds, tokenURIs)
+}
+
+// BatchSwap is a paid mutator transaction binding the contract method 0xc10a2f8e.
+//
+// Solidity: function batchSwap(address tokenAddr, address recipient, uint256[] tokenIds, uint256 dstChainId) payable returns()
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentTransactor) BatchSwap(opts *bind.TransactOpts, tokenAddr common.Address, recipient common.Address, tokenIds []*big.Int, dstChainId *big.Int) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.contract.Transact(opts, "batchSwap", tokenAddr, recipient, tokenIds, dstChainId)
+}
+
+// BatchSwap is a paid mutator transaction binding the contract method 0xc10a2f8e.
+//
+// Solidity: function batchSwap(address tokenAddr, address recipient, uint256[] tokenIds, uint256 dstChainId) payable returns()
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentSession) BatchSwap(tokenAddr common.Address, recipient common.Address, tokenIds []*big.Int, dstChainId *big.Int) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.Contract.BatchSwap(&_ERC721BatchSwapAgent.TransactOpts, tokenAddr, recipient, tokenIds, dstChainId)
+}
+
+// BatchSwap is a paid mutator transaction binding the contract method 0xc10a2f8e.
+//
+// Solidity: function batchSwap(address tokenAddr, address recipient, uint256[] tokenIds, uint256 dstChainId) payable returns()
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentTransactorSession) BatchSwap(tokenAddr common.Address, recipient common.Address, tokenIds []*big.Int, dstChainId *big.Int) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.Contract.BatchSwap(&_ERC721BatchSwapAgent.TransactOpts, tokenAddr, recipient, tokenIds, dstChainId)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.Contract.TransferOwnership(&_ERC721BatchSwapAgent.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _ERC721BatchSwapAgent.Contract.TransferOwnership(&_ERC721BatchSwapAgent.TransactOpts, newOwner)
+}
+
+// ERC721BatchSwapAgentBatchBackwardSwapFilledIterator is returned from FilterBatchBackwardSwapFilled and is used to iterate over the raw logs and unpacked data for BatchBackwardSwapFilled events raised by the ERC721BatchSwapAgent contract.
+type ERC721BatchSwapAgentBatchBackwardSwapFilledIterator struct {
+	Event *ERC721BatchSwapAgentBatchBackwardSwapFilled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ERC721BatchSwapAgentBatchBackwardSwapFilledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ERC721BatchSwapAgentBatchBackwardSwapFilled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ERC721BatchSwapAgentBatchBackwardSwapFilled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ERC721BatchSwapAgentBatchBackwardSwapFilledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ERC721BatchSwapAgentBatchBackwardSwapFilledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ERC721BatchSwapAgentBatchBackwardSwapFilled represents a BatchBackwardSwapFilled event raised by the ERC721BatchSwapAgent contract.
+type ERC721BatchSwapAgentBatchBackwardSwapFilled struct {
+	SwapTxHash  [32]byte
+	TokenAddr   common.Address
+	Recipient   common.Address
+	FromChainId *big.Int
+	TokenIds    []*big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterBatchBackwardSwapFilled is a free log retrieval operation binding the contract event 0x54faef4874df11e37be1bad287c971f0732522c379dba3c400f4dec6271b1eee.
+//
+// Solidity: event BatchBackwardSwapFilled(bytes32 indexed swapTxHash, address indexed tokenAddr, address indexed recipient, uint256 fromChainId, uint256[] tokenIds)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) FilterBatchBackwardSwapFilled(opts *bind.FilterOpts, swapTxHash [][32]byte, tokenAddr []common.Address, recipient []common.Address) (*ERC721BatchSwapAgentBatchBackwardSwapFilledIterator, error) {
+
+	var swapTxHashRule []interface{}
+	for _, swapTxHashItem := range swapTxHash {
+		swapTxHashRule = append(swapTxHashRule, swapTxHashItem)
+	}
+	var tokenAddrRule []interface{}
+	for _, tokenAddrItem := range tokenAddr {
+		tokenAddrRule = append(tokenAddrRule, tokenAddrItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _ERC721BatchSwapAgent.contract.FilterLogs(opts, "BatchBackwardSwapFilled", swapTxHashRule, tokenAddrRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC721BatchSwapAgentBatchBackwardSwapFilledIterator{contract: _ERC721BatchSwapAgent.contract, event: "BatchBackwardSwapFilled", logs: logs, sub: sub}, nil
+}
+
+// WatchBatchBackwardSwapFilled is a free log subscription operation binding the contract event 0x54faef4874df11e37be1bad287c971f0732522c379dba3c400f4dec6271b1eee.
+//
+// Solidity: event BatchBackwardSwapFilled(bytes32 indexed swapTxHash, address indexed tokenAddr, address indexed recipient, uint256 fromChainId, uint256[] tokenIds)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) WatchBatchBackwardSwapFilled(opts *bind.WatchOpts, sink chan<- *ERC721BatchSwapAgentBatchBackwardSwapFilled, swapTxHash [][32]byte, tokenAddr []common.Address, recipient []common.Address) (event.Subscription, error) {
+
+	var swapTxHashRule []interface{}
+	for _, swapTxHashItem := range swapTxHash {
+		swapTxHashRule = append(swapTxHashRule, swapTxHashItem)
+	}
+	var tokenAddrRule []interface{}
+	for _, tokenAddrItem := range tokenAddr {
+		tokenAddrRule = append(tokenAddrRule, tokenAddrItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _ERC721BatchSwapAgent.contract.WatchLogs(opts, "BatchBackwardSwapFilled", swapTxHashRule, tokenAddrRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ERC721BatchSwapAgentBatchBackwardSwapFilled)
+				if err := _ERC721BatchSwapAgent.contract.UnpackLog(event, "BatchBackwardSwapFilled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBatchBackwardSwapFilled is a log parse operation binding the contract event 0x54faef4874df11e37be1bad287c971f0732522c379dba3c400f4dec6271b1eee.
+//
+// Solidity: event BatchBackwardSwapFilled(bytes32 indexed swapTxHash, address indexed tokenAddr, address indexed recipient, uint256 fromChainId, uint256[] tokenIds)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) ParseBatchBackwardSwapFilled(log types.Log) (*ERC721BatchSwapAgentBatchBackwardSwapFilled, error) {
+	event := new(ERC721BatchSwapAgentBatchBackwardSwapFilled)
+	if err := _ERC721BatchSwapAgent.contract.UnpackLog(event, "BatchBackwardSwapFilled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ERC721BatchSwapAgentBatchBackwardSwapStartedIterator is returned from FilterBatchBackwardSwapStarted and is used to iterate over the raw logs and unpacked data for BatchBackwardSwapStarted events raised by the ERC721BatchSwapAgent contract.
+type ERC721BatchSwapAgentBatchBackwardSwapStartedIterator struct {
+	Event *ERC721BatchSwapAgentBatchBackwardSwapStarted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ERC721BatchSwapAgentBatchBackwardSwapStartedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ERC721BatchSwapAgentBatchBackwardSwapStarted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ERC721BatchSwapAgentBatchBackwardSwapStarted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ERC721BatchSwapAgentBatchBackwardSwapStartedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ERC721BatchSwapAgentBatchBackwardSwapStartedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ERC721BatchSwapAgentBatchBackwardSwapStarted represents a BatchBackwardSwapStarted event raised by the ERC721BatchSwapAgent contract.
+type ERC721BatchSwapAgentBatchBackwardSwapStarted struct {
+	MirroredTokenAddr common.Address
+	Sender            common.Address
+	Recipient         common.Address
+	DstChainId        *big.Int
+	TokenIds          []*big.Int
+	FeeAmount         *big.Int
+	Raw               types.Log // Blockchain specific contextual infos
+}
+
+// FilterBatchBackwardSwapStarted is a free log retrieval operation binding the contract event 0x2dee945350d3b57638b42a3626b235563addad42ace4d3edee6b1fe5a604b6f6.
+//
+// Solidity: event BatchBackwardSwapStarted(address indexed mirroredTokenAddr, address indexed sender, address indexed recipient, uint256 dstChainId, uint256[] tokenIds, uint256 feeAmount)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) FilterBatchBackwardSwapStarted(opts *bind.FilterOpts, mirroredTokenAddr []common.Address, sender []common.Address, recipient []common.Address) (*ERC721BatchSwapAgentBatchBackwardSwapStartedIterator, error) {
+
+	var mirroredTokenAddrRule []interface{}
+	for _, mirroredTokenAddrItem := range mirroredTokenAddr {
+		mirroredTokenAddrRule = append(mirroredTokenAddrRule, mirroredTokenAddrItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _ERC721BatchSwapAgent.contract.FilterLogs(opts, "BatchBackwardSwapStarted", mirroredTokenAddrRule, senderRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC721BatchSwapAgentBatchBackwardSwapStartedIterator{contract: _ERC721BatchSwapAgent.contract, event: "BatchBackwardSwapStarted", logs: logs, sub: sub}, nil
+}
+
+// WatchBatchBackwardSwapStarted is a free log subscription operation binding the contract event 0x2dee945350d3b57638b42a3626b235563addad42ace4d3edee6b1fe5a604b6f6.
+//
+// Solidity: event BatchBackwardSwapStarted(address indexed mirroredTokenAddr, address indexed sender, address indexed recipient, uint256 dstChainId, uint256[] tokenIds, uint256 feeAmount)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) WatchBatchBackwardSwapStarted(opts *bind.WatchOpts, sink chan<- *ERC721BatchSwapAgentBatchBackwardSwapStarted, mirroredTokenAddr []common.Address, sender []common.Address, recipient []common.Address) (event.Subscription, error) {
+
+	var mirroredTokenAddrRule []interface{}
+	for _, mirroredTokenAddrItem := range mirroredTokenAddr {
+		mirroredTokenAddrRule = append(mirroredTokenAddrRule, mirroredTokenAddrItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _ERC721BatchSwapAgent.contract.WatchLogs(opts, "BatchBackwardSwapStarted", mirroredTokenAddrRule, senderRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ERC721BatchSwapAgentBatchBackwardSwapStarted)
+				if err := _ERC721BatchSwapAgent.contract.UnpackLog(event, "BatchBackwardSwapStarted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBatchBackwardSwapStarted is a log parse operation binding the contract event 0x2dee945350d3b57638b42a3626b235563addad42ace4d3edee6b1fe5a604b6f6.
+//
+// Solidity: event BatchBackwardSwapStarted(address indexed mirroredTokenAddr, address indexed sender, address indexed recipient, uint256 dstChainId, uint256[] tokenIds, uint256 feeAmount)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) ParseBatchBackwardSwapStarted(log types.Log) (*ERC721BatchSwapAgentBatchBackwardSwapStarted, error) {
+	event := new(ERC721BatchSwapAgentBatchBackwardSwapStarted)
+	if err := _ERC721BatchSwapAgent.contract.UnpackLog(event, "BatchBackwardSwapStarted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ERC721BatchSwapAgentBatchSwapFilledIterator is returned from FilterBatchSwapFilled and is used to iterate over the raw logs and unpacked data for BatchSwapFilled events raised by the ERC721BatchSwapAgent contract.
+type ERC721BatchSwapAgentBatchSwapFilledIterator struct {
+	Event *ERC721BatchSwapAgentBatchSwapFilled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ERC721BatchSwapAgentBatchSwapFilledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ERC721BatchSwapAgentBatchSwapFilled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ERC721BatchSwapAgentBatchSwapFilled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ERC721BatchSwapAgentBatchSwapFilledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ERC721BatchSwapAgentBatchSwapFilledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ERC721BatchSwapAgentBatchSwapFilled represents a BatchSwapFilled event raised by the ERC721BatchSwapAgent contract.
+type ERC721BatchSwapAgentBatchSwapFilled struct {
+	SwapTxHash        [32]byte
+	FromTokenAddr     common.Address
+	Recipient         common.Address
+	MirroredTokenAddr common.Address
+	FromChainId       *big.Int
+	TokenIds          []*big.Int
+	Raw               types.Log // Blockchain specific contextual infos
+}
+
+// FilterBatchSwapFilled is a free log retrieval operation binding the contract event 0x723747609f5970548360128fe31aa9b01491654b882ca5ccfa8f93813635fc8b.
+//
+// Solidity: event BatchSwapFilled(bytes32 indexed swapTxHash, address indexed fromTokenAddr, address indexed recipient, address mirroredTokenAddr, uint256 fromChainId, uint256[] tokenIds)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) FilterBatchSwapFilled(opts *bind.FilterOpts, swapTxHash [][32]byte, fromTokenAddr []common.Address, recipient []common.Address) (*ERC721BatchSwapAgentBatchSwapFilledIterator, error) {
+
+	var swapTxHashRule []interface{}
+	for _, swapTxHashItem := range swapTxHash {
+		swapTxHashRule = append(swapTxHashRule, swapTxHashItem)
+	}
+	var fromTokenAddrRule []interface{}
+	for _, fromTokenAddrItem := range fromTokenAddr {
+		fromTokenAddrRule = append(fromTokenAddrRule, fromTokenAddrItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _ERC721BatchSwapAgent.contract.FilterLogs(opts, "BatchSwapFilled", swapTxHashRule, fromTokenAddrRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC721BatchSwapAgentBatchSwapFilledIterator{contract: _ERC721BatchSwapAgent.contract, event: "BatchSwapFilled", logs: logs, sub: sub}, nil
+}
+
+// WatchBatchSwapFilled is a free log subscription operation binding the contract event 0x723747609f5970548360128fe31aa9b01491654b882ca5ccfa8f93813635fc8b.
+//
+// Solidity: event BatchSwapFilled(bytes32 indexed swapTxHash, address indexed fromTokenAddr, address indexed recipient, address mirroredTokenAddr, uint256 fromChainId, uint256[] tokenIds)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) WatchBatchSwapFilled(opts *bind.WatchOpts, sink chan<- *ERC721BatchSwapAgentBatchSwapFilled, swapTxHash [][32]byte, fromTokenAddr []common.Address, recipient []common.Address) (event.Subscription, error) {
+
+	var swapTxHashRule []interface{}
+	for _, swapTxHashItem := range swapTxHash {
+		swapTxHashRule = append(swapTxHashRule, swapTxHashItem)
+	}
+	var fromTokenAddrRule []interface{}
+	for _, fromTokenAddrItem := range fromTokenAddr {
+		fromTokenAddrRule = append(fromTokenAddrRule, fromTokenAddrItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _ERC721BatchSwapAgent.contract.WatchLogs(opts, "BatchSwapFilled", swapTxHashRule, fromTokenAddrRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ERC721BatchSwapAgentBatchSwapFilled)
+				if err := _ERC721BatchSwapAgent.contract.UnpackLog(event, "BatchSwapFilled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBatchSwapFilled is a log parse operation binding the contract event 0x723747609f5970548360128fe31aa9b01491654b882ca5ccfa8f93813635fc8b.
+//
+// Solidity: event BatchSwapFilled(bytes32 indexed swapTxHash, address indexed fromTokenAddr, address indexed recipient, address mirroredTokenAddr, uint256 fromChainId, uint256[] tokenIds)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) ParseBatchSwapFilled(log types.Log) (*ERC721BatchSwapAgentBatchSwapFilled, error) {
+	event := new(ERC721BatchSwapAgentBatchSwapFilled)
+	if err := _ERC721BatchSwapAgent.contract.UnpackLog(event, "BatchSwapFilled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ERC721BatchSwapAgentBatchSwapStartedIterator is returned from FilterBatchSwapStarted and is used to iterate over the raw logs and unpacked data for BatchSwapStarted events raised by the ERC721BatchSwapAgent contract.
+type ERC721BatchSwapAgentBatchSwapStartedIterator struct {
+	Event *ERC721BatchSwapAgentBatchSwapStarted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ERC721BatchSwapAgentBatchSwapStartedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ERC721BatchSwapAgentBatchSwapStarted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ERC721BatchSwapAgentBatchSwapStarted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ERC721BatchSwapAgentBatchSwapStartedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ERC721BatchSwapAgentBatchSwapStartedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ERC721BatchSwapAgentBatchSwapStarted represents a BatchSwapStarted event raised by the ERC721BatchSwapAgent contract.
+type ERC721BatchSwapAgentBatchSwapStarted struct {
+	TokenAddr  common.Address
+	Sender     common.Address
+	Recipient  common.Address
+	DstChainId *big.Int
+	TokenIds   []*big.Int
+	FeeAmount  *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterBatchSwapStarted is a free log retrieval operation binding the contract event 0x3a8cd0090a75390819d5e600eca468e52b0535cc89d9900bddd75f2f53232dd3.
+//
+// Solidity: event BatchSwapStarted(address indexed tokenAddr, address indexed sender, address indexed recipient, uint256 dstChainId, uint256[] tokenIds, uint256 feeAmount)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) FilterBatchSwapStarted(opts *bind.FilterOpts, tokenAddr []common.Address, sender []common.Address, recipient []common.Address) (*ERC721BatchSwapAgentBatchSwapStartedIterator, error) {
+
+	var tokenAddrRule []interface{}
+	for _, tokenAddrItem := range tokenAddr {
+		tokenAddrRule = append(tokenAddrRule, tokenAddrItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _ERC721BatchSwapAgent.contract.FilterLogs(opts, "BatchSwapStarted", tokenAddrRule, senderRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC721BatchSwapAgentBatchSwapStartedIterator{contract: _ERC721BatchSwapAgent.contract, event: "BatchSwapStarted", logs: logs, sub: sub}, nil
+}
+
+// WatchBatchSwapStarted is a free log subscription operation binding the contract event 0x3a8cd0090a75390819d5e600eca468e52b0535cc89d9900bddd75f2f53232dd3.
+//
+// Solidity: event BatchSwapStarted(address indexed tokenAddr, address indexed sender, address indexed recipient, uint256 dstChainId, uint256[] tokenIds, uint256 feeAmount)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) WatchBatchSwapStarted(opts *bind.WatchOpts, sink chan<- *ERC721BatchSwapAgentBatchSwapStarted, tokenAddr []common.Address, sender []common.Address, recipient []common.Address) (event.Subscription, error) {
+
+	var tokenAddrRule []interface{}
+	for _, tokenAddrItem := range tokenAddr {
+		tokenAddrRule = append(tokenAddrRule, tokenAddrItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _ERC721BatchSwapAgent.contract.WatchLogs(opts, "BatchSwapStarted", tokenAddrRule, senderRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ERC721BatchSwapAgentBatchSwapStarted)
+				if err := _ERC721BatchSwapAgent.contract.UnpackLog(event, "BatchSwapStarted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBatchSwapStarted is a log parse operation binding the contract event 0x3a8cd0090a75390819d5e600eca468e52b0535cc89d9900bddd75f2f53232dd3.
+//
+// Solidity: event BatchSwapStarted(address indexed tokenAddr, address indexed sender, address indexed recipient, uint256 dstChainId, uint256[] tokenIds, uint256 feeAmount)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) ParseBatchSwapStarted(log types.Log) (*ERC721BatchSwapAgentBatchSwapStarted, error) {
+	event := new(ERC721BatchSwapAgentBatchSwapStarted)
+	if err := _ERC721BatchSwapAgent.contract.UnpackLog(event, "BatchSwapStarted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ERC721BatchSwapAgentOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the ERC721BatchSwapAgent contract.
+type ERC721BatchSwapAgentOwnershipTransferredIterator struct {
+	Event *ERC721BatchSwapAgentOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ERC721BatchSwapAgentOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ERC721BatchSwapAgentOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ERC721BatchSwapAgentOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ERC721BatchSwapAgentOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ERC721BatchSwapAgentOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ERC721BatchSwapAgentOwnershipTransferred represents a OwnershipTransferred event raised by the ERC721BatchSwapAgent contract.
+type ERC721BatchSwapAgentOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*ERC721BatchSwapAgentOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _ERC721BatchSwapAgent.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC721BatchSwapAgentOwnershipTransferredIterator{contract: _ERC721BatchSwapAgent.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *ERC721BatchSwapAgentOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _ERC721BatchSwapAgent.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ERC721BatchSwapAgentOwnershipTransferred)
+				if err := _ERC721BatchSwapAgent.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_ERC721BatchSwapAgent *ERC721BatchSwapAgentFilterer) ParseOwnershipTransferred(log types.Log) (*ERC721BatchSwapAgentOwnershipTransferred, error) {
+	event := new(ERC721BatchSwapAgentOwnershipTransferred)
+	if err := _ERC721BatchSwapAgent.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
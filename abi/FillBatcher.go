@@ -0,0 +1,201 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// FillBatcherMetaData contains all meta data concerning the FillBatcher contract.
+var FillBatcherMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"bytes[]\",\"name\":\"fills\",\"type\":\"bytes[]\"}],\"name\":\"fillBatch\",\"outputs\":[{\"internalType\":\"bool[]\",\"name\":\"filled\",\"type\":\"bool[]\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// FillBatcherABI is the input ABI used to generate the binding from.
+// Deprecated: Use FillBatcherMetaData.ABI instead.
+var FillBatcherABI = FillBatcherMetaData.ABI
+
+// FillBatcher is an auto generated Go binding around an Ethereum contract.
+type FillBatcher struct {
+	FillBatcherCaller     // Read-only binding to the contract
+	FillBatcherTransactor // Write-only binding to the contract
+	FillBatcherFilterer   // Log filterer for contract events
+}
+
+// FillBatcherCaller is an auto generated read-only Go binding around an Ethereum contract.
+type FillBatcherCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FillBatcherTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type FillBatcherTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FillBatcherFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type FillBatcherFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FillBatcherSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type FillBatcherSession struct {
+	Contract     *FillBatcher      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// FillBatcherCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type FillBatcherCallerSession struct {
+	Contract *FillBatcherCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// FillBatcherTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type FillBatcherTransactorSession struct {
+	Contract     *FillBatcherTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// FillBatcherRaw is an auto generated low-level Go binding around an Ethereum contract.
+type FillBatcherRaw struct {
+	Contract *FillBatcher // Generic contract binding to access the raw methods on
+}
+
+// FillBatcherCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type FillBatcherCallerRaw struct {
+	Contract *FillBatcherCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// FillBatcherTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type FillBatcherTransactorRaw struct {
+	Contract *FillBatcherTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewFillBatcher creates a new instance of FillBatcher, bound to a specific deployed contract.
+func NewFillBatcher(address common.Address, backend bind.ContractBackend) (*FillBatcher, error) {
+	contract, err := bindFillBatcher(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &FillBatcher{FillBatcherCaller: FillBatcherCaller{contract: contract}, FillBatcherTransactor: FillBatcherTransactor{contract: contract}, FillBatcherFilterer: FillBatcherFilterer{contract: contract}}, nil
+}
+
+// NewFillBatcherCaller creates a new read-only instance of FillBatcher, bound to a specific deployed contract.
+func NewFillBatcherCaller(address common.Address, caller bind.ContractCaller) (*FillBatcherCaller, error) {
+	contract, err := bindFillBatcher(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FillBatcherCaller{contract: contract}, nil
+}
+
+// NewFillBatcherTransactor creates a new write-only instance of FillBatcher, bound to a specific deployed contract.
+func NewFillBatcherTransactor(address common.Address, transactor bind.ContractTransactor) (*FillBatcherTransactor, error) {
+	contract, err := bindFillBatcher(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FillBatcherTransactor{contract: contract}, nil
+}
+
+// NewFillBatcherFilterer creates a new log filterer instance of FillBatcher, bound to a specific deployed contract.
+func NewFillBatcherFilterer(address common.Address, filterer bind.ContractFilterer) (*FillBatcherFilterer, error) {
+	contract, err := bindFillBatcher(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &FillBatcherFilterer{contract: contract}, nil
+}
+
+// bindFillBatcher binds a generic wrapper to an already deployed contract.
+func bindFillBatcher(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(FillBatcherABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FillBatcher *FillBatcherRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FillBatcher.Contract.FillBatcherCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FillBatcher *FillBatcherRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FillBatcher.Contract.FillBatcherTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FillBatcher *FillBatcherRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FillBatcher.Contract.FillBatcherTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FillBatcher *FillBatcherCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FillBatcher.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FillBatcher *FillBatcherTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FillBatcher.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FillBatcher *FillBatcherTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FillBatcher.Contract.contract.Transact(opts, method, params...)
+}
+
+// FillBatch is a paid mutator transaction binding the contract method 0xf4e540c4.
+//
+// Solidity: function fillBatch(bytes[] fills) returns(bool[] filled)
+func (_FillBatcher *FillBatcherTransactor) FillBatch(opts *bind.TransactOpts, fills [][]byte) (*types.Transaction, error) {
+	return _FillBatcher.contract.Transact(opts, "fillBatch", fills)
+}
+
+// FillBatch is a paid mutator transaction binding the contract method 0xf4e540c4.
+//
+// Solidity: function fillBatch(bytes[] fills) returns(bool[] filled)
+func (_FillBatcher *FillBatcherSession) FillBatch(fills [][]byte) (*types.Transaction, error) {
+	return _FillBatcher.Contract.FillBatch(&_FillBatcher.TransactOpts, fills)
+}
+
+// FillBatch is a paid mutator transaction binding the contract method 0xf4e540c4.
+//
+// Solidity: function fillBatch(bytes[] fills) returns(bool[] filled)
+func (_FillBatcher *FillBatcherTransactorSession) FillBatch(fills [][]byte) (*types.Transaction, error) {
+	return _FillBatcher.Contract.FillBatch(&_FillBatcher.TransactOpts, fills)
+}
@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+type Config struct {
+	ListenAddr string
+	// APIKey must be sent as a bearer token by every request
+	APIKey string
+}
+
+type Dependencies struct {
+	DB *gorm.DB
+}
+
+// Server serves the admin api
+type Server struct {
+	conf *Config
+	deps *Dependencies
+
+	wg sync.WaitGroup
+}
+
+// NewServer returns the admin server instance
+func NewServer(c *Config, d *Dependencies) *Server {
+	return &Server{
+		conf: c,
+		deps: d,
+	}
+}
+
+// Start serves the admin api on the configured listen address
+func (s *Server) Start(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/webhooks/subscriptions", s.handleSubscriptions)
+	mux.HandleFunc("/webhooks/subscriptions/", s.handleSubscription)
+	mux.HandleFunc("/webhooks/deliveries", s.handleDeliveries)
+	mux.HandleFunc("/webhooks/deliveries/", s.handleDelivery)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		util.Logger.Infof("[Server.Start]: serving admin api on %s", s.conf.ListenAddr)
+		if err := util.ListenAndServe(ctx, s.conf.ListenAddr, s.authenticate(mux)); err != nil {
+			util.Logger.Errorf("[Server.Start]: admin server stopped, err=%s", err.Error())
+		}
+	}()
+}
+
+// Wait blocks until the server started by Start has answered its in-flight requests and stopped
+func (s *Server) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	expected := []byte("Bearer " + s.conf.APIKey)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, &errorResponse{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		util.Logger.Errorf("[writeJSON]: failed to encode response, err=%s", err.Error())
+	}
+}
@@ -0,0 +1,339 @@
+package admin
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/webhook"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+	webhookdispatcher "github.com/synycboom/bsc-evm-compatible-bridge-core/webhook"
+)
+
+const (
+	defaultDeliveryPageSize = 20
+	maxDeliveryPageSize     = 100
+)
+
+type subscriptionRequest struct {
+	URL string `json:"url"`
+	// Secret is generated when it is empty
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+	ChainIDs   []string `json:"chain_ids"`
+	TokenAddrs []string `json:"token_addrs"`
+}
+
+type subscriptionPatch struct {
+	Enabled *bool `json:"enabled"`
+}
+
+type subscriptionResponse struct {
+	ID string `json:"id"`
+	// Secret is only returned when the subscription is created
+	Secret     string          `json:"secret,omitempty"`
+	URL        string          `json:"url"`
+	EventTypes json.RawMessage `json:"event_types,omitempty"`
+	ChainIDs   json.RawMessage `json:"chain_ids,omitempty"`
+	TokenAddrs json.RawMessage `json:"token_addrs,omitempty"`
+	Enabled    bool            `json:"enabled"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+type deliveryResponse struct {
+	ID             string     `json:"id"`
+	SubscriptionID string     `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	State          string     `json:"state"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type listDeliveriesResponse struct {
+	Deliveries []*deliveryResponse `json:"deliveries"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+type replayResponse struct {
+	Replayed int64 `json:"replayed"`
+}
+
+func newSubscriptionResponse(s *webhook.Subscription) *subscriptionResponse {
+	return &subscriptionResponse{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: json.RawMessage(s.EventTypes),
+		ChainIDs:   json.RawMessage(s.ChainIDs),
+		TokenAddrs: json.RawMessage(s.TokenAddrs),
+		Enabled:    s.Enabled,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+func newDeliveryResponse(d *webhook.Delivery) *deliveryResponse {
+	return &deliveryResponse{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		State:          string(d.State),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+// handleSubscriptions serves GET and POST /webhooks/subscriptions
+func (s *Server) handleSubscriptions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var ss []webhook.Subscription
+		if err := s.deps.DB.Order("id asc").Find(&ss).Error; err != nil {
+			util.Logger.Error(errors.Wrap(err, "[Server.handleSubscriptions]: failed to query subscriptions"))
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+
+		resp := make([]*subscriptionResponse, 0, len(ss))
+		for i := range ss {
+			resp = append(resp, newSubscriptionResponse(&ss[i]))
+		}
+		writeJSON(w, http.StatusOK, resp)
+	case http.MethodPost:
+		var req subscriptionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		sub, err := newSubscription(&req)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := s.deps.DB.Create(sub).Error; err != nil {
+			util.Logger.Error(errors.Wrap(err, "[Server.handleSubscriptions]: failed to create subscription"))
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+
+		resp := newSubscriptionResponse(sub)
+		resp.Secret = sub.Secret
+		writeJSON(w, http.StatusCreated, resp)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func newSubscription(req *subscriptionRequest) (*webhook.Subscription, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("invalid url")
+	}
+
+	for i, addr := range req.TokenAddrs {
+		if !common.IsHexAddress(addr) {
+			return nil, errors.Errorf("invalid token address %s", addr)
+		}
+		req.TokenAddrs[i] = common.HexToAddress(addr).String()
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, errors.Wrap(err, "failed to generate secret")
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	sub := webhook.Subscription{
+		URL:     req.URL,
+		Secret:  secret,
+		Enabled: true,
+	}
+	for _, f := range []struct {
+		values []string
+		dst    *datatypes.JSON
+	}{
+		{req.EventTypes, &sub.EventTypes},
+		{req.ChainIDs, &sub.ChainIDs},
+		{req.TokenAddrs, &sub.TokenAddrs},
+	} {
+		if len(f.values) == 0 {
+			continue
+		}
+
+		j, err := json.Marshal(f.values)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode filter")
+		}
+		*f.dst = datatypes.JSON(j)
+	}
+
+	return &sub, nil
+}
+
+// handleSubscription serves GET, PATCH and DELETE /webhooks/subscriptions/{id}
+// and POST /webhooks/subscriptions/{id}/replay, which replays the dead deliveries of the subscription
+func (s *Server) handleSubscription(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/webhooks/subscriptions/"), "/")
+	id := parts[0]
+	if len(parts) == 2 && parts[1] == "replay" {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		n, err := webhookdispatcher.ReplayDeadDeliveries(s.deps.DB, id)
+		if err != nil {
+			util.Logger.Error(errors.Wrap(err, "[Server.handleSubscription]: failed to replay deliveries"))
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, &replayResponse{Replayed: n})
+		return
+	}
+	if len(parts) != 1 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	var sub webhook.Subscription
+	err := s.deps.DB.Where("id = ?", id).First(&sub).Error
+	if err == gorm.ErrRecordNotFound {
+		writeError(w, http.StatusNotFound, "subscription not found")
+		return
+	}
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Server.handleSubscription]: failed to query subscription"))
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, newSubscriptionResponse(&sub))
+	case http.MethodPatch:
+		var patch subscriptionPatch
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil || patch.Enabled == nil {
+			writeError(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		sub.Enabled = *patch.Enabled
+		if err := s.deps.DB.Save(&sub).Error; err != nil {
+			util.Logger.Error(errors.Wrap(err, "[Server.handleSubscription]: failed to update subscription"))
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newSubscriptionResponse(&sub))
+	case http.MethodDelete:
+		if err := s.deps.DB.Delete(&sub).Error; err != nil {
+			util.Logger.Error(errors.Wrap(err, "[Server.handleSubscription]: failed to delete subscription"))
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// handleDeliveries serves GET /webhooks/deliveries, filtered by the state and subscription_id query parameters
+// and paginated with the limit and cursor ones. Dead-lettered deliveries are listed with state=dead.
+func (s *Server) handleDeliveries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	limit := defaultDeliveryPageSize
+	if l := q.Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 || n > maxDeliveryPageSize {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = n
+	}
+
+	query := s.deps.DB.Order("id desc").Limit(limit + 1)
+	if state := q.Get("state"); state != "" {
+		query = query.Where("state = ?", state)
+	}
+	if subscriptionID := q.Get("subscription_id"); subscriptionID != "" {
+		query = query.Where("subscription_id = ?", subscriptionID)
+	}
+	if cursor := q.Get("cursor"); cursor != "" {
+		query = query.Where("id < ?", cursor)
+	}
+
+	var dd []webhook.Delivery
+	if err := query.Find(&dd).Error; err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Server.handleDeliveries]: failed to query deliveries"))
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	resp := listDeliveriesResponse{
+		Deliveries: make([]*deliveryResponse, 0, len(dd)),
+	}
+	if len(dd) > limit {
+		dd = dd[:limit]
+		resp.NextCursor = dd[limit-1].ID
+	}
+	for i := range dd {
+		resp.Deliveries = append(resp.Deliveries, newDeliveryResponse(&dd[i]))
+	}
+
+	writeJSON(w, http.StatusOK, &resp)
+}
+
+// handleDelivery serves POST /webhooks/deliveries/{id}/replay
+func (s *Server) handleDelivery(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/webhooks/deliveries/"), "/")
+	if len(parts) != 2 || parts[1] != "replay" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	err := webhookdispatcher.ReplayDelivery(s.deps.DB, parts[0])
+	if err == gorm.ErrRecordNotFound {
+		writeError(w, http.StatusNotFound, "delivery not found")
+		return
+	}
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Server.handleDelivery]: failed to replay delivery"))
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &replayResponse{Replayed: 1})
+}
@@ -70,3 +70,44 @@ type SwapAgent interface {
 		recipient []common.Address,
 	) (*contractabi.ERC721SwapAgentBackwardSwapFilledIterator, error)
 }
+
+// BatchSwapAgent swaps several tokens of a collection at once, see contracts/ERC721BatchSwapAgent.sol
+type BatchSwapAgent interface {
+	FilterBatchSwapStarted(
+		opts *bind.FilterOpts,
+		tokenAddr []common.Address,
+		sender []common.Address,
+		recipient []common.Address,
+	) (*contractabi.ERC721BatchSwapAgentBatchSwapStartedIterator, error)
+
+	FilterBatchSwapFilled(
+		opts *bind.FilterOpts,
+		swapTxHash [][32]byte,
+		fromTokenAddr []common.Address,
+		recipient []common.Address,
+	) (*contractabi.ERC721BatchSwapAgentBatchSwapFilledIterator, error)
+
+	BatchFill(
+		opts *bind.TransactOpts,
+		swapTxHash [32]byte,
+		fromTokenAddr common.Address,
+		recipient common.Address,
+		fromChainId *big.Int,
+		tokenIds []*big.Int,
+		tokenURIs []string,
+	) (*types.Transaction, error)
+
+	FilterBatchBackwardSwapStarted(
+		opts *bind.FilterOpts,
+		mirroredTokenAddr []common.Address,
+		sender []common.Address,
+		recipient []common.Address,
+	) (*contractabi.ERC721BatchSwapAgentBatchBackwardSwapStartedIterator, error)
+
+	FilterBatchBackwardSwapFilled(
+		opts *bind.FilterOpts,
+		swapTxHash [][32]byte,
+		tokenAddr []common.Address,
+		recipient []common.Address,
+	) (*contractabi.ERC721BatchSwapAgentBatchBackwardSwapFilledIterator, error)
+}
@@ -0,0 +1,14 @@
+package agent
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// FillBatcher sends several fills of a swap agent in a single tx, see contracts/lib/FillBatcher.sol
+type FillBatcher interface {
+	FillBatch(
+		opts *bind.TransactOpts,
+		fills [][]byte,
+	) (*types.Transaction, error)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+
+	contractabi "github.com/synycboom/bsc-evm-compatible-bridge-core/abi"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// erc1155ReceiverInterfaceID is the ERC165 id of IERC1155Receiver, which the ERC1155 swap agent implements
+var erc1155ReceiverInterfaceID = [4]byte{0x4e, 0x23, 0x12, 0xe0}
+
+// relayers returns the addresses sending transactions to the swap agents of a chain. The engines of every other
+// chain fill the swaps and create the swap pairs requested on their own chain with their own private key.
+func relayers(config *util.Config, chainID string) ([]common.Address, error) {
+	var addrs []common.Address
+	for _, c := range config.ChainConfigs {
+		if c.ID == chainID {
+			continue
+		}
+
+		_, pubKey, err := util.BuildKeys(c.PrivateKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[relayers]: invalid private_key of chain %s", c.Name)
+		}
+
+		addrs = append(addrs, crypto.PubkeyToAddress(*pubKey))
+	}
+
+	return addrs, nil
+}
+
+// verifyAgents checks that the swap agents of every chain are deployed, answer the views of their ABI and are owned
+// by the relayers of the chain. It returns a single error listing every misconfiguration found.
+func (c *chains) verifyAgents(ctx context.Context, config *util.Config) error {
+	var problems []string
+	for i, cc := range config.ChainConfigs {
+		relayerAddrs, err := relayers(config, cc.ID)
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+
+		ec := c.ethClients[i]
+		problems = append(problems, verifyAgent(ctx, ec, cc.Name, "erc_721_swap_agent_addr", cc.ERC721SwapAgentAddr, relayerAddrs, func(opts *bind.CallOpts, addr common.Address) (common.Address, error) {
+			caller, err := contractabi.NewERC721SwapAgentCaller(addr, ec)
+			if err != nil {
+				return common.Address{}, err
+			}
+			if _, err := caller.FilledSwap(opts, [32]byte{}); err != nil {
+				return common.Address{}, errors.Wrap(err, "filledSwap")
+			}
+			if _, err := caller.RegisteredToken(opts, big.NewInt(0), common.Address{}); err != nil {
+				return common.Address{}, errors.Wrap(err, "registeredToken")
+			}
+
+			owner, err := caller.Owner(opts)
+			return owner, errors.Wrap(err, "owner")
+		})...)
+		if cc.ERC721BatchSwapAgentAddr != "" {
+			problems = append(problems, verifyAgent(ctx, ec, cc.Name, "erc_721_batch_swap_agent_addr", cc.ERC721BatchSwapAgentAddr, relayerAddrs, func(opts *bind.CallOpts, addr common.Address) (common.Address, error) {
+				caller, err := contractabi.NewERC721BatchSwapAgentCaller(addr, ec)
+				if err != nil {
+					return common.Address{}, err
+				}
+				if _, err := caller.FilledToken(opts, [32]byte{}, big.NewInt(0)); err != nil {
+					return common.Address{}, errors.Wrap(err, "filledToken")
+				}
+
+				owner, err := caller.Owner(opts)
+				return owner, errors.Wrap(err, "owner")
+			})...)
+		}
+		problems = append(problems, verifyAgent(ctx, ec, cc.Name, "erc_1155_swap_agent_addr", cc.ERC1155SwapAgentAddr, relayerAddrs, func(opts *bind.CallOpts, addr common.Address) (common.Address, error) {
+			caller, err := contractabi.NewERC1155SwapAgentCaller(addr, ec)
+			if err != nil {
+				return common.Address{}, err
+			}
+			supported, err := caller.SupportsInterface(opts, erc1155ReceiverInterfaceID)
+			if err != nil {
+				return common.Address{}, errors.Wrap(err, "supportsInterface")
+			}
+			if !supported {
+				return common.Address{}, errors.New("supportsInterface: IERC1155Receiver is not supported")
+			}
+			if _, err := caller.FilledSwap(opts, [32]byte{}); err != nil {
+				return common.Address{}, errors.Wrap(err, "filledSwap")
+			}
+
+			owner, err := caller.Owner(opts)
+			return owner, errors.Wrap(err, "owner")
+		})...)
+		if cc.ERC20SwapAgentAddr != "" {
+			problems = append(problems, verifyAgent(ctx, ec, cc.Name, "erc_20_swap_agent_addr", cc.ERC20SwapAgentAddr, relayerAddrs, func(opts *bind.CallOpts, addr common.Address) (common.Address, error) {
+				caller, err := contractabi.NewERC20SwapAgentCaller(addr, ec)
+				if err != nil {
+					return common.Address{}, err
+				}
+				if _, err := caller.FilledSwap(opts, [32]byte{}); err != nil {
+					return common.Address{}, errors.Wrap(err, "filledSwap")
+				}
+				if _, err := caller.RegisteredToken(opts, big.NewInt(0), common.Address{}); err != nil {
+					return common.Address{}, errors.Wrap(err, "registeredToken")
+				}
+
+				owner, err := caller.Owner(opts)
+				return owner, errors.Wrap(err, "owner")
+			})...)
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.Errorf("[chains.verifyAgents]: %d swap agent misconfigurations:\n  - %s", len(problems), strings.Join(problems, "\n  - "))
+	}
+
+	return nil
+}
+
+// verifyAgent checks a single swap agent, probe calls views of the agent ABI and returns its owner
+func verifyAgent(
+	ctx context.Context,
+	ec *ethclient.Client,
+	chainName string,
+	field string,
+	raw string,
+	relayerAddrs []common.Address,
+	probe func(opts *bind.CallOpts, addr common.Address) (common.Address, error),
+) []string {
+	ctx, cancel := context.WithTimeout(ctx, verifyTimeout)
+	defer cancel()
+
+	addr := common.HexToAddress(raw)
+
+	code, err := ec.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return []string{fmt.Sprintf("chain %s: failed to get the code of %s %s: %s", chainName, field, raw, err.Error())}
+	}
+	if len(code) == 0 {
+		return []string{fmt.Sprintf("chain %s: %s %s has no code, the contract is not deployed on this chain", chainName, field, raw)}
+	}
+
+	owner, err := probe(&bind.CallOpts{Context: ctx}, addr)
+	if err != nil {
+		return []string{fmt.Sprintf("chain %s: %s %s does not answer the views of its swap agent ABI (%s), check that the address is the right agent", chainName, field, raw, err.Error())}
+	}
+
+	var problems []string
+	for _, r := range relayerAddrs {
+		if r != owner {
+			problems = append(problems, fmt.Sprintf("chain %s: %s %s is owned by %s, not by the relayer %s, fills and swap pair creations will fail until ownership is transferred to it", chainName, field, raw, owner.String(), r.String()))
+		}
+	}
+
+	return problems
+}
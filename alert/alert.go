@@ -0,0 +1,61 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+
+	// messagePrefix is prepended to every human readable alert
+	messagePrefix = "bsc-evm-compatible-bridge-core alert"
+)
+
+// Alert is a notification sent to alerting backends
+type Alert struct {
+	// Key identifies the condition this alert is about, alerts with the same key are deduplicated
+	Key      string    `json:"key"`
+	Severity Severity  `json:"severity"`
+	ChainID  string    `json:"chain_id,omitempty"`
+	Title    string    `json:"title"`
+	Message  string    `json:"message"`
+	Resolved bool      `json:"resolved"`
+	Time     time.Time `json:"time"`
+}
+
+// Text returns a single line human readable representation of the alert
+func (a *Alert) Text() string {
+	status := strings.ToUpper(string(a.Severity))
+	if a.Resolved {
+		status = "RESOLVED"
+	}
+
+	var chain string
+	if a.ChainID != "" {
+		chain = fmt.Sprintf(" chain=%s", a.ChainID)
+	}
+
+	return fmt.Sprintf("%s: [%s]%s %s: %s", messagePrefix, status, chain, a.Title, a.Message)
+}
+
+// Alerter delivers alerts to a single backend
+type Alerter interface {
+	Send(ctx context.Context, a *Alert) error
+}
+
+// Dispatcher routes alerts to alerting backends
+type Dispatcher interface {
+	// Fire sends an alert unless an alert with the same key was sent within the cooldown
+	Fire(a *Alert)
+	// Resolve sends a resolved notification if an alert with the key is active
+	Resolve(key, message string)
+	// Notify sends a one-off alert about an event, which is neither deduplicated nor resolved
+	Notify(a *Alert)
+}
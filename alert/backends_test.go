@@ -0,0 +1,293 @@
+package alert
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+type capturedRequest struct {
+	Method      string
+	Path        string
+	ContentType string
+	Body        []byte
+}
+
+type captured struct {
+	mutex    sync.Mutex
+	requests []capturedRequest
+}
+
+func (c *captured) list() []capturedRequest {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]capturedRequest(nil), c.requests...)
+}
+
+// newCaptureServer records every request and answers them with the status
+func newCaptureServer(t *testing.T, status int) (*httptest.Server, *captured) {
+	c := &captured{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			t.Errorf("failed to read body: %v", err)
+		}
+
+		c.mutex.Lock()
+		c.requests = append(c.requests, capturedRequest{
+			Method:      req.Method,
+			Path:        req.URL.Path,
+			ContentType: req.Header.Get("Content-Type"),
+			Body:        body,
+		})
+		c.mutex.Unlock()
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"ok":false,"description":"rejected"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, c
+}
+
+func TestTelegramAlerterSendsMessage(t *testing.T) {
+	srv, got := newCaptureServer(t, http.StatusOK)
+	a := &Alert{Key: "stuck", Severity: SeverityWarning, ChainID: "97", Title: "stuck swaps", Message: "2 swaps"}
+
+	if err := NewTelegramAlerter(srv.URL+"/", "bot-id", "chat-id").Send(testContext(t), a); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+
+	requests := got.list()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	req := requests[0]
+	if req.Method != http.MethodPost || req.Path != "/botbot-id/sendMessage" {
+		t.Errorf("unexpected request %s %s", req.Method, req.Path)
+	}
+	if req.ContentType != "application/x-www-form-urlencoded" {
+		t.Errorf("unexpected content type %s", req.ContentType)
+	}
+	form, err := url.ParseQuery(string(req.Body))
+	if err != nil {
+		t.Fatalf("failed to parse form: %v", err)
+	}
+	if form.Get("chat_id") != "chat-id" || form.Get("parse_mode") != "html" {
+		t.Errorf("unexpected form %v", form)
+	}
+	if text := form.Get("text"); text != a.Text() || !strings.Contains(text, "[WARNING] chain=97 stuck swaps: 2 swaps") {
+		t.Errorf("unexpected text %q", text)
+	}
+}
+
+func TestSlackAlerterPostsText(t *testing.T) {
+	srv, got := newCaptureServer(t, http.StatusOK)
+	a := &Alert{Key: "stuck", Severity: SeverityCritical, Title: "stuck swaps", Message: "2 swaps", Resolved: true}
+
+	if err := NewSlackAlerter(srv.URL).Send(testContext(t), a); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+
+	requests := got.list()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	if requests[0].ContentType != "application/json" {
+		t.Errorf("unexpected content type %s", requests[0].ContentType)
+	}
+	var payload map[string]string
+	if err := json.Unmarshal(requests[0].Body, &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if len(payload) != 1 || !strings.Contains(payload["text"], "[RESOLVED] stuck swaps: 2 swaps") {
+		t.Errorf("unexpected payload %v", payload)
+	}
+}
+
+func TestPagerDutyAlerterSendsEvents(t *testing.T) {
+	at := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		alert        Alert
+		wantAction   string
+		wantSeverity string
+	}{
+		{
+			name:         "critical",
+			alert:        Alert{Key: "k", Severity: SeverityCritical, ChainID: "4", Title: "t", Message: "m", Time: at},
+			wantAction:   "trigger",
+			wantSeverity: "critical",
+		},
+		{
+			name:         "warning",
+			alert:        Alert{Key: "k", Severity: SeverityWarning, Title: "t", Message: "m", Time: at},
+			wantAction:   "trigger",
+			wantSeverity: "warning",
+		},
+		{
+			name:         "info",
+			alert:        Alert{Key: "k", Severity: SeverityInfo, Title: "t", Message: "m", Time: at},
+			wantAction:   "trigger",
+			wantSeverity: "info",
+		},
+		{
+			name:       "resolved",
+			alert:      Alert{Key: "k", Severity: SeverityCritical, Title: "t", Message: "m", Time: at, Resolved: true},
+			wantAction: "resolve",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, got := newCaptureServer(t, http.StatusAccepted)
+			if err := NewPagerDutyAlerter(srv.URL, "routing-key").Send(testContext(t), &tt.alert); err != nil {
+				t.Fatalf("failed to send: %v", err)
+			}
+
+			requests := got.list()
+			if len(requests) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(requests))
+			}
+			var event pagerDutyEvent
+			if err := json.Unmarshal(requests[0].Body, &event); err != nil {
+				t.Fatalf("failed to decode event: %v", err)
+			}
+			if event.RoutingKey != "routing-key" || event.DedupKey != "k" || event.EventAction != tt.wantAction {
+				t.Errorf("unexpected event %+v", event)
+			}
+			if tt.alert.Resolved {
+				if event.Payload != nil {
+					t.Errorf("expected no payload on resolve, got %+v", event.Payload)
+				}
+
+				return
+			}
+			if event.Payload == nil {
+				t.Fatal("expected a payload")
+			}
+			if event.Payload.Severity != tt.wantSeverity || event.Payload.Source != pagerDutySource {
+				t.Errorf("unexpected payload %+v", event.Payload)
+			}
+			if event.Payload.Timestamp != "2021-10-01T12:00:00Z" || event.Payload.Summary != tt.alert.Text() {
+				t.Errorf("unexpected payload %+v", event.Payload)
+			}
+			if event.Payload.CustomDetails["chain_id"] != tt.alert.ChainID || event.Payload.CustomDetails["message"] != "m" {
+				t.Errorf("unexpected custom details %v", event.Payload.CustomDetails)
+			}
+		})
+	}
+}
+
+func TestBackendsFailOnErrorStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		send   func(url string) error
+	}{
+		{
+			name:   "telegram",
+			status: http.StatusBadRequest,
+			send: func(url string) error {
+				return NewTelegramAlerter(url, "bot", "chat").Send(testContext(t), &Alert{Key: "a"})
+			},
+		},
+		{
+			name:   "slack",
+			status: http.StatusForbidden,
+			send: func(url string) error {
+				return NewSlackAlerter(url).Send(testContext(t), &Alert{Key: "a"})
+			},
+		},
+		{
+			name:   "pagerduty",
+			status: http.StatusTooManyRequests,
+			send: func(url string) error {
+				return NewPagerDutyAlerter(url, "key").Send(testContext(t), &Alert{Key: "a"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := newCaptureServer(t, tt.status)
+
+			err := tt.send(srv.URL)
+			if err == nil {
+				t.Fatalf("expected an error on a %d response", tt.status)
+			}
+			if !strings.Contains(err.Error(), "rejected") {
+				t.Errorf("expected the response body in the error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBackendsFailWhenUnreachable(t *testing.T) {
+	srv, _ := newCaptureServer(t, http.StatusOK)
+	srv.Close()
+
+	backends := map[string]Alerter{
+		BackendTelegram:  NewTelegramAlerter(srv.URL, "bot", "chat"),
+		BackendSlack:     NewSlackAlerter(srv.URL),
+		BackendPagerDuty: NewPagerDutyAlerter(srv.URL, "key"),
+	}
+	for name, backend := range backends {
+		if err := backend.Send(testContext(t), &Alert{Key: "a"}); err == nil {
+			t.Errorf("expected %s to fail on an unreachable endpoint", name)
+		}
+	}
+}
+
+func TestManagerRoutesSeveritiesToBackends(t *testing.T) {
+	telegram, gotTelegram := newCaptureServer(t, http.StatusOK)
+	slack, gotSlack := newCaptureServer(t, http.StatusOK)
+	pagerDuty, gotPagerDuty := newCaptureServer(t, http.StatusAccepted)
+	// send_timeout is left out, the default applies
+	m := NewManagerFromConfig(util.AlertConfig{
+		Backends: []util.AlertBackendConfig{
+			{Name: "chat", Type: BackendTelegram, Endpoint: telegram.URL, BotID: "bot", ChatID: "chat"},
+			{Name: "ops", Type: BackendSlack, URL: slack.URL},
+			{Name: "oncall", Type: BackendPagerDuty, Endpoint: pagerDuty.URL, RoutingKey: "key"},
+		},
+		Routes: []util.AlertRouteConfig{
+			{Severities: []string{"critical"}, Backends: []string{"oncall", "ops"}},
+			{Severities: []string{"warning"}, Backends: []string{"ops"}},
+			{Severities: []string{"info"}, Backends: []string{"chat"}},
+		},
+	})
+	if m.conf.SendTimeout != 10*time.Second {
+		t.Errorf("expected the default send timeout, got %s", m.conf.SendTimeout)
+	}
+
+	m.Notify(&Alert{Key: "info", Severity: SeverityInfo})
+	m.Notify(&Alert{Key: "warning", Severity: SeverityWarning})
+	m.Notify(&Alert{Key: "critical", Severity: SeverityCritical})
+
+	if n := len(gotTelegram.list()); n != 1 {
+		t.Errorf("expected 1 message on telegram, got %d", n)
+	}
+	if n := len(gotSlack.list()); n != 2 {
+		t.Errorf("expected 2 messages on slack, got %d", n)
+	}
+	requests := gotPagerDuty.list()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 event on pagerduty, got %d", len(requests))
+	}
+	var event pagerDutyEvent
+	if err := json.Unmarshal(requests[0].Body, &event); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	if event.DedupKey != "critical" {
+		t.Errorf("expected the critical alert on pagerduty, got %+v", event)
+	}
+}
@@ -0,0 +1,60 @@
+package alert
+
+import (
+	"time"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+const (
+	BackendTelegram  = "telegram"
+	BackendSlack     = "slack"
+	BackendWebhook   = "webhook"
+	BackendEmail     = "email"
+	BackendPagerDuty = "pagerduty"
+)
+
+// NewManagerFromConfig builds the alert manager and its backends from the alert config
+func NewManagerFromConfig(cfg util.AlertConfig) *Manager {
+	backends := make(map[string]Alerter)
+	if cfg.TelegramBotId != "" && cfg.TelegramChatId != "" {
+		backends[BackendTelegram] = NewTelegramAlerter("", cfg.TelegramBotId, cfg.TelegramChatId)
+	}
+
+	for _, b := range cfg.Backends {
+		switch b.Type {
+		case BackendTelegram:
+			backends[b.Name] = NewTelegramAlerter(b.Endpoint, b.BotID, b.ChatID)
+		case BackendSlack:
+			backends[b.Name] = NewSlackAlerter(b.URL)
+		case BackendWebhook:
+			backends[b.Name] = NewWebhookAlerter(b.URL, b.Headers)
+		case BackendEmail:
+			backends[b.Name] = NewEmailAlerter(b.SMTPAddr, b.Username, b.Password, b.From, b.To)
+		case BackendPagerDuty:
+			backends[b.Name] = NewPagerDutyAlerter(b.Endpoint, b.RoutingKey)
+		}
+	}
+
+	routes := make([]Route, len(cfg.Routes))
+	for idx, r := range cfg.Routes {
+		severities := make([]Severity, len(r.Severities))
+		for i, s := range r.Severities {
+			severities[i] = Severity(s)
+		}
+
+		routes[idx] = Route{
+			Severities: severities,
+			Chains:     r.Chains,
+			Backends:   r.Backends,
+		}
+	}
+
+	return NewManager(&Config{
+		Cooldown:    time.Duration(cfg.Cooldown) * time.Second,
+		SendTimeout: time.Duration(cfg.SendTimeoutOrDefault()) * time.Second,
+		Routes:      routes,
+	}, &Dependencies{
+		Backends: backends,
+	})
+}
@@ -0,0 +1,128 @@
+package alert
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type EmailAlerter struct {
+	Addr     string
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// NewEmailAlerter returns an alerter sending alerts through a SMTP server
+func NewEmailAlerter(addr, username, password, from string, to []string) *EmailAlerter {
+	return &EmailAlerter{
+		Addr:     addr,
+		Username: username,
+		Password: password,
+		From:     from,
+		To:       to,
+	}
+}
+
+func (e *EmailAlerter) Send(ctx context.Context, a *Alert) error {
+	var auth smtp.Auth
+	if e.Username != "" {
+		host, _, err := net.SplitHostPort(e.Addr)
+		if err != nil {
+			return errors.Wrap(err, "[EmailAlerter.Send]: invalid smtp address")
+		}
+
+		auth = smtp.PlainAuth("", e.Username, e.Password, host)
+	}
+
+	status := strings.ToUpper(string(a.Severity))
+	if a.Resolved {
+		status = "RESOLVED"
+	}
+
+	msg := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: [%s] %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		e.From,
+		strings.Join(e.To, ", "),
+		status,
+		a.Title,
+		a.Text(),
+	)
+
+	if err := e.sendMail(ctx, auth, []byte(msg)); err != nil {
+		return errors.Wrap(err, "[EmailAlerter.Send]: failed to send email")
+	}
+
+	return nil
+}
+
+// sendMail does what smtp.SendMail does over a connection bound to the context deadline,
+// so that a stalled server cannot outlive the send timeout
+func (e *EmailAlerter) sendMail(ctx context.Context, auth smtp.Auth, msg []byte) error {
+	host, _, err := net.SplitHostPort(e.Addr)
+	if err != nil {
+		return errors.Wrap(err, "[EmailAlerter.sendMail]: invalid smtp address")
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", e.Addr)
+	if err != nil {
+		return errors.Wrap(err, "[EmailAlerter.sendMail]: failed to dial smtp server")
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return errors.Wrap(err, "[EmailAlerter.sendMail]: failed to set deadline")
+		}
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return errors.Wrap(err, "[EmailAlerter.sendMail]: failed to create smtp client")
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return errors.Wrap(err, "[EmailAlerter.sendMail]: failed to start tls")
+		}
+	}
+
+	if auth != nil {
+		if err := c.Auth(auth); err != nil {
+			return errors.Wrap(err, "[EmailAlerter.sendMail]: failed to authenticate")
+		}
+	}
+
+	if err := c.Mail(e.From); err != nil {
+		return errors.Wrap(err, "[EmailAlerter.sendMail]: failed to set sender")
+	}
+
+	for _, to := range e.To {
+		if err := c.Rcpt(to); err != nil {
+			return errors.Wrapf(err, "[EmailAlerter.sendMail]: failed to add recipient %s", to)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return errors.Wrap(err, "[EmailAlerter.sendMail]: failed to start data")
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return errors.Wrap(err, "[EmailAlerter.sendMail]: failed to write message")
+	}
+
+	if err := w.Close(); err != nil {
+		return errors.Wrap(err, "[EmailAlerter.sendMail]: failed to end data")
+	}
+
+	return c.Quit()
+}
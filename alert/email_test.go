@@ -0,0 +1,141 @@
+package alert
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	return ctx
+}
+
+// smtpServer is a minimal SMTP stand-in recording the messages it accepts
+type smtpServer struct {
+	addr   string
+	stall  bool
+	mutex  sync.Mutex
+	rcpts  []string
+	bodies []string
+}
+
+func newSMTPServer(t *testing.T, stall bool) *smtpServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	s := &smtpServer{addr: l.Addr().String(), stall: stall}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	if s.stall {
+		// never greet, the client has to give up on its own
+		_, _ = bufio.NewReader(conn).ReadString('\n')
+		return
+	}
+
+	r := bufio.NewReader(conn)
+	write := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+	write("220 localhost ESMTP")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			write("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM"):
+			write("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO"):
+			s.mutex.Lock()
+			s.rcpts = append(s.rcpts, strings.TrimSpace(line[len("RCPT TO:"):]))
+			s.mutex.Unlock()
+			write("250 OK")
+		case cmd == "DATA":
+			write("354 go ahead")
+			var body strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				body.WriteString(l)
+			}
+			s.mutex.Lock()
+			s.bodies = append(s.bodies, body.String())
+			s.mutex.Unlock()
+			write("250 OK")
+		case cmd == "QUIT":
+			write("221 bye")
+			return
+		default:
+			write("250 OK")
+		}
+	}
+}
+
+func TestEmailAlerterSends(t *testing.T) {
+	srv := newSMTPServer(t, false)
+	e := NewEmailAlerter(srv.addr, "", "", "bridge@example.com", []string{"ops@example.com", "oncall@example.com"})
+
+	err := e.Send(testContext(t), &Alert{Key: "a", Severity: SeverityCritical, Title: "stuck swaps", Message: "3 swaps"})
+	if err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+
+	if len(srv.rcpts) != 2 {
+		t.Fatalf("expected 2 recipients, got %v", srv.rcpts)
+	}
+	if len(srv.bodies) != 1 || !strings.Contains(srv.bodies[0], "Subject: [CRITICAL] stuck swaps") {
+		t.Fatalf("unexpected message %v", srv.bodies)
+	}
+}
+
+func TestEmailAlerterHonoursTimeout(t *testing.T) {
+	srv := newSMTPServer(t, true)
+	e := NewEmailAlerter(srv.addr, "", "", "bridge@example.com", []string{"ops@example.com"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := e.Send(ctx, &Alert{Key: "a", Severity: SeverityWarning}); err == nil {
+		t.Fatal("expected a timeout error from a stalled server")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("send returned after %s, the deadline was not applied", elapsed)
+	}
+}
@@ -0,0 +1,46 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// postJSON posts a JSON payload and fails on any non 2xx response
+func postJSON(ctx context.Context, url string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "[postJSON]: failed to marshal payload")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "[postJSON]: failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	return do(req)
+}
+
+func do(req *http.Request) error {
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "[do]: failed to send request")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		bodyBytes, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		return errors.Errorf("[do]: unexpected status %d, body=%s", res.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package alert
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// Route sends alerts matching severities and chains to backends, empty matchers match everything
+type Route struct {
+	Severities []Severity
+	Chains     []string
+	Backends   []string
+}
+
+func (r *Route) matches(a *Alert) bool {
+	if len(r.Severities) > 0 {
+		var ok bool
+		for _, s := range r.Severities {
+			if s == a.Severity {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	if len(r.Chains) > 0 {
+		var ok bool
+		for _, c := range r.Chains {
+			if c == a.ChainID {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+type Config struct {
+	Cooldown    time.Duration
+	SendTimeout time.Duration
+	Routes      []Route
+}
+
+type Dependencies struct {
+	Backends map[string]Alerter
+}
+
+type activeAlert struct {
+	alert    Alert
+	lastSent time.Time
+	backends []string
+}
+
+// Manager deduplicates and routes alerts. The cooldown state lives in memory, so every process keeps its own
+// and a replica taking over the lease sends the alerts that are still active once more
+type Manager struct {
+	conf *Config
+	deps *Dependencies
+
+	active map[string]*activeAlert
+	mutex  sync.Mutex
+}
+
+// NewManager returns the alert manager instance
+func NewManager(c *Config, d *Dependencies) *Manager {
+	return &Manager{
+		conf:   c,
+		deps:   d,
+		active: make(map[string]*activeAlert),
+	}
+}
+
+func (m *Manager) Fire(a *Alert) {
+	if a.Time.IsZero() {
+		a.Time = time.Now()
+	}
+
+	m.mutex.Lock()
+	state, ok := m.active[a.Key]
+	if ok && a.Time.Sub(state.lastSent) < m.conf.Cooldown {
+		m.mutex.Unlock()
+		util.Logger.Debugf("[Manager.Fire]: suppressed duplicated alert %s", a.Key)
+
+		return
+	}
+
+	backends := m.route(a)
+	m.active[a.Key] = &activeAlert{
+		alert:    *a,
+		lastSent: a.Time,
+		backends: backends,
+	}
+	m.mutex.Unlock()
+
+	m.send(a, backends)
+}
+
+func (m *Manager) Resolve(key, message string) {
+	m.mutex.Lock()
+	state, ok := m.active[key]
+	if !ok {
+		m.mutex.Unlock()
+
+		return
+	}
+	delete(m.active, key)
+	m.mutex.Unlock()
+
+	resolved := state.alert
+	resolved.Resolved = true
+	resolved.Message = message
+	resolved.Time = time.Now()
+	m.send(&resolved, state.backends)
+}
+
+func (m *Manager) Notify(a *Alert) {
+	if a.Time.IsZero() {
+		a.Time = time.Now()
+	}
+
+	m.send(a, m.route(a))
+}
+
+// route returns the names of backends the alert should be sent to
+func (m *Manager) route(a *Alert) []string {
+	if len(m.conf.Routes) == 0 {
+		names := make([]string, 0, len(m.deps.Backends))
+		for name := range m.deps.Backends {
+			names = append(names, name)
+		}
+
+		return names
+	}
+
+	var names []string
+	seen := make(map[string]struct{})
+	for _, r := range m.conf.Routes {
+		if !r.matches(a) {
+			continue
+		}
+
+		for _, name := range r.Backends {
+			if _, ok := seen[name]; ok {
+				continue
+			}
+
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func (m *Manager) send(a *Alert, backends []string) {
+	for _, name := range backends {
+		backend, ok := m.deps.Backends[name]
+		if !ok {
+			util.Logger.Errorf("[Manager.send]: alert backend %s is not configured", name)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), m.conf.SendTimeout)
+		err := backend.Send(ctx, a)
+		cancel()
+		if err != nil {
+			util.Logger.Error(errors.Wrapf(err, "[Manager.send]: failed to send alert %s to %s", a.Key, name))
+			continue
+		}
+
+		util.Logger.Infof("[Manager.send]: sent alert %s to %s, resolved=%t", a.Key, name, a.Resolved)
+	}
+}
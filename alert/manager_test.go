@@ -0,0 +1,103 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type received struct {
+	mutex  sync.Mutex
+	alerts []Alert
+}
+
+func (r *received) list() []Alert {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]Alert(nil), r.alerts...)
+}
+
+func newWebhookServer(t *testing.T, status int) (*httptest.Server, *received) {
+	r := &received{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var a Alert
+		if err := json.NewDecoder(req.Body).Decode(&a); err != nil {
+			t.Errorf("failed to decode alert: %v", err)
+		}
+
+		r.mutex.Lock()
+		r.alerts = append(r.alerts, a)
+		r.mutex.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, r
+}
+
+func TestManagerDeduplicatesAndResolves(t *testing.T) {
+	srv, got := newWebhookServer(t, http.StatusOK)
+	m := NewManager(&Config{
+		Cooldown:    time.Hour,
+		SendTimeout: time.Second,
+	}, &Dependencies{
+		Backends: map[string]Alerter{"hook": NewWebhookAlerter(srv.URL, nil)},
+	})
+
+	m.Fire(&Alert{Key: "stuck", Severity: SeverityWarning, Title: "stuck", Message: "first"})
+	m.Fire(&Alert{Key: "stuck", Severity: SeverityWarning, Title: "stuck", Message: "second"})
+	m.Resolve("stuck", "cleared")
+	m.Resolve("stuck", "cleared again")
+
+	alerts := got.list()
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 deliveries, got %d: %+v", len(alerts), alerts)
+	}
+	if alerts[0].Message != "first" || alerts[0].Resolved {
+		t.Errorf("unexpected first delivery %+v", alerts[0])
+	}
+	if alerts[1].Message != "cleared" || !alerts[1].Resolved {
+		t.Errorf("unexpected resolved delivery %+v", alerts[1])
+	}
+}
+
+func TestManagerRoutesBySeverityAndChain(t *testing.T) {
+	oncall, gotOncall := newWebhookServer(t, http.StatusOK)
+	ops, gotOps := newWebhookServer(t, http.StatusOK)
+	m := NewManager(&Config{
+		SendTimeout: time.Second,
+		Routes: []Route{
+			{Severities: []Severity{SeverityCritical}, Backends: []string{"oncall", "ops"}},
+			{Severities: []Severity{SeverityWarning}, Chains: []string{"97"}, Backends: []string{"ops"}},
+		},
+	}, &Dependencies{
+		Backends: map[string]Alerter{
+			"oncall": NewWebhookAlerter(oncall.URL, nil),
+			"ops":    NewWebhookAlerter(ops.URL, nil),
+		},
+	})
+
+	m.Notify(&Alert{Key: "a", Severity: SeverityCritical, ChainID: "4"})
+	m.Notify(&Alert{Key: "b", Severity: SeverityWarning, ChainID: "97"})
+	m.Notify(&Alert{Key: "c", Severity: SeverityWarning, ChainID: "4"})
+
+	if n := len(gotOncall.list()); n != 1 {
+		t.Errorf("expected 1 alert on oncall, got %d", n)
+	}
+	if n := len(gotOps.list()); n != 2 {
+		t.Errorf("expected 2 alerts on ops, got %d", n)
+	}
+}
+
+func TestWebhookAlerterFailsOnErrorStatus(t *testing.T) {
+	srv, _ := newWebhookServer(t, http.StatusInternalServerError)
+
+	if err := NewWebhookAlerter(srv.URL, nil).Send(testContext(t), &Alert{Key: "a"}); err == nil {
+		t.Fatal("expected an error on a 500 response")
+	}
+}
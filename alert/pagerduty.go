@@ -0,0 +1,84 @@
+package alert
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultPagerDutyEndpoint = "https://events.pagerduty.com/v2/enqueue"
+	pagerDutySource          = "bsc-evm-compatible-bridge-core"
+)
+
+type PagerDutyAlerter struct {
+	Endpoint   string
+	RoutingKey string
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// NewPagerDutyAlerter returns an alerter sending PagerDuty Events API v2 events
+func NewPagerDutyAlerter(endpoint, routingKey string) *PagerDutyAlerter {
+	if endpoint == "" {
+		endpoint = defaultPagerDutyEndpoint
+	}
+
+	return &PagerDutyAlerter{
+		Endpoint:   endpoint,
+		RoutingKey: routingKey,
+	}
+}
+
+func (p *PagerDutyAlerter) Send(ctx context.Context, a *Alert) error {
+	event := pagerDutyEvent{
+		RoutingKey:  p.RoutingKey,
+		EventAction: "trigger",
+		DedupKey:    a.Key,
+	}
+
+	if a.Resolved {
+		event.EventAction = "resolve"
+	} else {
+		event.Payload = &pagerDutyPayload{
+			Summary:   a.Text(),
+			Source:    pagerDutySource,
+			Severity:  pagerDutySeverity(a.Severity),
+			Timestamp: a.Time.UTC().Format("2006-01-02T15:04:05Z"),
+			CustomDetails: map[string]string{
+				"chain_id": a.ChainID,
+				"message":  a.Message,
+			},
+		}
+	}
+
+	if err := postJSON(ctx, p.Endpoint, nil, &event); err != nil {
+		return errors.Wrap(err, "[PagerDutyAlerter.Send]: failed to enqueue event")
+	}
+
+	return nil
+}
+
+func pagerDutySeverity(s Severity) string {
+	switch s {
+	case SeverityCritical:
+		return "critical"
+	case SeverityWarning:
+		return "warning"
+	}
+
+	return "info"
+}
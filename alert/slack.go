@@ -0,0 +1,29 @@
+package alert
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+type SlackAlerter struct {
+	WebhookURL string
+}
+
+// NewSlackAlerter returns an alerter posting messages to a slack incoming webhook
+func NewSlackAlerter(webhookURL string) *SlackAlerter {
+	return &SlackAlerter{
+		WebhookURL: webhookURL,
+	}
+}
+
+func (s *SlackAlerter) Send(ctx context.Context, a *Alert) error {
+	payload := map[string]string{
+		"text": a.Text(),
+	}
+	if err := postJSON(ctx, s.WebhookURL, nil, payload); err != nil {
+		return errors.Wrap(err, "[SlackAlerter.Send]: failed to post slack message")
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const defaultTelegramEndpoint = "https://api.telegram.org"
+
+type TelegramAlerter struct {
+	Endpoint string
+	BotID    string
+	ChatID   string
+}
+
+// NewTelegramAlerter returns an alerter sending messages through a telegram bot
+func NewTelegramAlerter(endpoint, botID, chatID string) *TelegramAlerter {
+	if endpoint == "" {
+		endpoint = defaultTelegramEndpoint
+	}
+
+	return &TelegramAlerter{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		BotID:    botID,
+		ChatID:   chatID,
+	}
+}
+
+func (t *TelegramAlerter) Send(ctx context.Context, a *Alert) error {
+	formData := url.Values{
+		"chat_id":    {t.ChatID},
+		"parse_mode": {"html"},
+		"text":       {a.Text()},
+	}
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", t.Endpoint, t.BotID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(formData.Encode()))
+	if err != nil {
+		return errors.Wrap(err, "[TelegramAlerter.Send]: failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if err := do(req); err != nil {
+		return errors.Wrap(err, "[TelegramAlerter.Send]: failed to send telegram message")
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package alert
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+type WebhookAlerter struct {
+	URL     string
+	Headers map[string]string
+}
+
+// NewWebhookAlerter returns an alerter posting alerts as JSON to a generic webhook
+func NewWebhookAlerter(url string, headers map[string]string) *WebhookAlerter {
+	return &WebhookAlerter{
+		URL:     url,
+		Headers: headers,
+	}
+}
+
+func (w *WebhookAlerter) Send(ctx context.Context, a *Alert) error {
+	if err := postJSON(ctx, w.URL, w.Headers, a); err != nil {
+		return errors.Wrap(err, "[WebhookAlerter.Send]: failed to post alert")
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+type ChainConfig struct {
+	ExplorerURL string
+	// ConfirmNum is the number of blocks the engines of this chain wait for before confirming a transaction
+	ConfirmNum int64
+}
+
+type Config struct {
+	ListenAddr      string
+	DefaultPageSize int
+	MaxPageSize     int
+	// Chains is keyed by chain id
+	Chains map[string]ChainConfig
+}
+
+type Dependencies struct {
+	DB *gorm.DB
+}
+
+// Server serves the read-only public API, it only reads from the database
+type Server struct {
+	conf *Config
+	deps *Dependencies
+
+	wg sync.WaitGroup
+}
+
+// NewServer returns the public API server instance
+func NewServer(c *Config, d *Dependencies) *Server {
+	return &Server{
+		conf: c,
+		deps: d,
+	}
+}
+
+// Handler returns the handler of the public API routes
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/chains/", s.handleGetSwaps)
+	mux.HandleFunc("/v1/senders/", s.handleListSwaps("sender"))
+	mux.HandleFunc("/v1/recipients/", s.handleListSwaps("recipient"))
+	mux.HandleFunc("/v1/swap-pairs", s.handleListSwapPairs)
+	mux.HandleFunc("/v1/swap-pairs/resolve", s.handleResolve)
+
+	return mux
+}
+
+// Start serves the public API on the configured listen address
+func (s *Server) Start(ctx context.Context) {
+	mux := s.Handler()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		util.Logger.Infof("[Server.Start]: serving public api on %s", s.conf.ListenAddr)
+		if err := util.ListenAndServe(ctx, s.conf.ListenAddr, mux); err != nil {
+			util.Logger.Errorf("[Server.Start]: public api server stopped, err=%s", err.Error())
+		}
+	}()
+}
+
+// Wait blocks until the server started by Start has answered its in-flight requests and stopped
+func (s *Server) Wait() {
+	s.wg.Wait()
+}
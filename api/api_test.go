@@ -0,0 +1,396 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/testutil"
+)
+
+var (
+	sender    = common.HexToAddress("0x1000000000000000000000000000000000000001").String()
+	recipient = common.HexToAddress("0x2000000000000000000000000000000000000002").String()
+	tokenA    = common.HexToAddress("0xa000000000000000000000000000000000000001").String()
+	tokenB    = common.HexToAddress("0xb000000000000000000000000000000000000002").String()
+	mirrorA   = common.HexToAddress("0xa000000000000000000000000000000000000004").String()
+)
+
+func newTestServer(db *gorm.DB) http.Handler {
+	return NewServer(&Config{
+		DefaultPageSize: 10,
+		MaxPageSize:     100,
+		Chains: map[string]ChainConfig{
+			"97": {ExplorerURL: "https://testnet.bscscan.com/tx", ConfirmNum: 3},
+			"4":  {ExplorerURL: "https://rinkeby.etherscan.io/tx", ConfirmNum: 3},
+		},
+	}, &Dependencies{
+		DB: db,
+	}).Handler()
+}
+
+func txHash(n int) string {
+	return common.BigToHash(big.NewInt(int64(n))).String()
+}
+
+func createERC721Swap(t *testing.T, db *gorm.DB, n int) *erc721.Swap {
+	t.Helper()
+
+	s := erc721.Swap{
+		SrcChainID:    "97",
+		DstChainID:    "4",
+		SrcTokenAddr:  tokenA,
+		Sender:        sender,
+		Recipient:     recipient,
+		TokenID:       fmt.Sprint(n),
+		State:         erc721.SwapStateRequestConfirmed,
+		SwapDirection: erc721.SwapDirectionForward,
+		RequestTxHash: txHash(n),
+	}
+	if err := db.Create(&s).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return &s
+}
+
+func createERC20Swap(t *testing.T, db *gorm.DB, n int) *erc20.Swap {
+	t.Helper()
+
+	s := erc20.Swap{
+		SrcChainID:    "97",
+		DstChainID:    "4",
+		SrcTokenAddr:  tokenB,
+		Sender:        sender,
+		Recipient:     recipient,
+		Amount:        "1000",
+		State:         erc20.SwapStateRequestConfirmed,
+		SwapDirection: erc20.SwapDirectionForward,
+		RequestTxHash: txHash(n),
+	}
+	if err := db.Create(&s).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return &s
+}
+
+func createSwapPairs(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	pp721 := []erc721.SwapPair{
+		{SrcChainID: "97", DstChainID: "4", SrcTokenAddr: tokenA, DstTokenAddr: mirrorA, Available: true, RegisterTxHash: txHash(101)},
+		{SrcChainID: "4", DstChainID: "97", SrcTokenAddr: tokenA, Available: false, RegisterTxHash: txHash(102)},
+	}
+	for i := range pp721 {
+		if err := db.Create(&pp721[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p20 := erc20.SwapPair{SrcChainID: "4", DstChainID: "97", SrcTokenAddr: tokenB, Available: true, Decimals: 18, RegisterTxHash: txHash(103)}
+	if err := db.Create(&p20).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// get serves a GET request and decodes its body into v unless the body is empty
+func get(t *testing.T, h http.Handler, target string, header http.Header, v interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, vv := range header {
+		r.Header[k] = vv
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if v != nil && w.Body.Len() > 0 {
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+			t.Fatalf("failed to decode %s: %s", w.Body.String(), err)
+		}
+	}
+
+	return w
+}
+
+func expectStatus(t *testing.T, w *httptest.ResponseRecorder, status int, message string) {
+	t.Helper()
+
+	if w.Code != status {
+		t.Fatalf("expected status %d, got %d: %s", status, w.Code, w.Body.String())
+	}
+	if message == "" {
+		return
+	}
+
+	var res errorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Error != message {
+		t.Fatalf("expected error %q, got %q", message, res.Error)
+	}
+}
+
+func TestGetSwaps(t *testing.T) {
+	db := testutil.NewDB()
+	h := newTestServer(db)
+	s := createERC721Swap(t, db, 1)
+
+	var res getSwapsResponse
+	w := get(t, h, "/v1/chains/97/swaps/"+s.RequestTxHash, nil, &res)
+	expectStatus(t, w, http.StatusOK, "")
+	if len(res.Swaps) != 1 || res.Swaps[0].ID != s.ID || res.Swaps[0].Standard != StandardERC721 {
+		t.Fatalf("expected swap %s, got %+v", s.ID, res.Swaps)
+	}
+	if got := res.Swaps[0].RequestTxURL; got != "https://testnet.bscscan.com/tx/"+s.RequestTxHash {
+		t.Fatalf("unexpected request tx url %s", got)
+	}
+
+	cases := []struct {
+		target  string
+		status  int
+		message string
+	}{
+		{"/v1/chains/56/swaps/" + s.RequestTxHash, http.StatusNotFound, "unknown chain"},
+		{"/v1/chains/97/swaps/" + txHash(2), http.StatusNotFound, "swap not found"},
+		// the swap was requested on chain 97
+		{"/v1/chains/4/swaps/" + s.RequestTxHash, http.StatusNotFound, "swap not found"},
+		{"/v1/chains/97/swap/" + s.RequestTxHash, http.StatusNotFound, "not found"},
+		{"/v1/chains/97/swaps/" + s.RequestTxHash + "/fill", http.StatusNotFound, "not found"},
+		{"/v1/chains/97/swaps/0x1234", http.StatusBadRequest, "invalid request tx hash"},
+	}
+	for _, c := range cases {
+		w := get(t, h, c.target, nil, nil)
+		expectStatus(t, w, c.status, c.message)
+	}
+}
+
+func TestListSwapsPaginates(t *testing.T) {
+	db := testutil.NewDB()
+	h := newTestServer(db)
+
+	ids := make(map[string]bool)
+	for i := 1; i <= 3; i++ {
+		ids[createERC721Swap(t, db, i).ID] = true
+	}
+	for i := 4; i <= 5; i++ {
+		ids[createERC20Swap(t, db, i).ID] = true
+	}
+
+	var seen []string
+	cursor := ""
+	for page := 0; ; page++ {
+		if page > len(ids) {
+			t.Fatal("pagination did not end")
+		}
+
+		target := "/v1/senders/" + strings.ToLower(sender) + "/swaps?limit=2"
+		if cursor != "" {
+			target += "&cursor=" + cursor
+		}
+		var res listSwapsResponse
+		expectStatus(t, get(t, h, target, nil, &res), http.StatusOK, "")
+		if len(res.Swaps) > 2 {
+			t.Fatalf("expected at most 2 swaps, got %d", len(res.Swaps))
+		}
+		for _, v := range res.Swaps {
+			seen = append(seen, v.ID)
+		}
+
+		if res.NextCursor == "" {
+			break
+		}
+		if res.NextCursor != res.Swaps[len(res.Swaps)-1].ID {
+			t.Fatalf("expected the cursor to be the last id of the page, got %s", res.NextCursor)
+		}
+		cursor = res.NextCursor
+	}
+
+	if len(seen) != len(ids) {
+		t.Fatalf("expected %d swaps, got %d: %v", len(ids), len(seen), seen)
+	}
+	for i, id := range seen {
+		if !ids[id] {
+			t.Fatalf("unexpected swap %s", id)
+		}
+		if i > 0 && seen[i-1] <= id {
+			t.Fatalf("expected newest first, got %v", seen)
+		}
+	}
+
+	var res listSwapsResponse
+	expectStatus(t, get(t, h, "/v1/recipients/"+recipient+"/swaps", nil, &res), http.StatusOK, "")
+	if len(res.Swaps) != len(ids) || res.NextCursor != "" {
+		t.Fatalf("expected %d swaps on a single page, got %d, next cursor %q", len(ids), len(res.Swaps), res.NextCursor)
+	}
+
+	cases := []struct {
+		target  string
+		status  int
+		message string
+	}{
+		{"/v1/senders/" + sender + "/swaps?limit=0", http.StatusBadRequest, "invalid limit"},
+		{"/v1/senders/" + sender + "/swaps?limit=101", http.StatusBadRequest, "invalid limit"},
+		{"/v1/senders/0x1234/swaps", http.StatusBadRequest, "invalid address"},
+		{"/v1/senders/" + sender, http.StatusNotFound, "not found"},
+	}
+	for _, c := range cases {
+		w := get(t, h, c.target, nil, nil)
+		expectStatus(t, w, c.status, c.message)
+	}
+}
+
+func TestListSwapPairsFilters(t *testing.T) {
+	db := testutil.NewDB()
+	h := newTestServer(db)
+	createSwapPairs(t, db)
+
+	cases := []struct {
+		query     string
+		standards []string
+	}{
+		// the unavailable pair is never listed
+		{"", []string{StandardERC721, StandardERC20}},
+		{"?standard=erc721", []string{StandardERC721}},
+		{"?standard=erc1155", nil},
+		{"?src_chain_id=4", []string{StandardERC20}},
+		{"?dst_chain_id=4", []string{StandardERC721}},
+		{"?src_token_addr=" + strings.ToLower(tokenB), []string{StandardERC20}},
+		{"?standard=erc20&src_chain_id=97", nil},
+	}
+	for _, c := range cases {
+		var res listSwapPairsResponse
+		expectStatus(t, get(t, h, "/v1/swap-pairs"+c.query, nil, &res), http.StatusOK, "")
+
+		var standards []string
+		for _, p := range res.SwapPairs {
+			standards = append(standards, p.Standard)
+		}
+		if fmt.Sprint(standards) != fmt.Sprint(c.standards) {
+			t.Fatalf("query %q: expected %v, got %v", c.query, c.standards, standards)
+		}
+	}
+
+	var first listSwapPairsResponse
+	expectStatus(t, get(t, h, "/v1/swap-pairs?limit=1", nil, &first), http.StatusOK, "")
+	if len(first.SwapPairs) != 1 || first.NextCursor != first.SwapPairs[0].ID {
+		t.Fatalf("expected 1 pair and its id as the cursor, got %+v", first)
+	}
+	var last listSwapPairsResponse
+	expectStatus(t, get(t, h, "/v1/swap-pairs?limit=1&cursor="+first.NextCursor, nil, &last), http.StatusOK, "")
+	if len(last.SwapPairs) != 1 || last.NextCursor != "" || last.SwapPairs[0].ID == first.SwapPairs[0].ID {
+		t.Fatalf("expected the other pair on the last page, got %+v", last)
+	}
+
+	expectStatus(t, get(t, h, "/v1/swap-pairs?standard=erc777", nil, nil), http.StatusBadRequest, "invalid standard")
+	expectStatus(t, get(t, h, "/v1/swap-pairs?src_token_addr=0x1234", nil, nil), http.StatusBadRequest, "invalid src_token_addr")
+}
+
+func TestResolve(t *testing.T) {
+	db := testutil.NewDB()
+	h := newTestServer(db)
+	createSwapPairs(t, db)
+
+	var res resolveResponse
+	w := get(t, h, "/v1/swap-pairs/resolve?chain_id=97&token_addr="+tokenA+"&target_chain_id=4", nil, &res)
+	expectStatus(t, w, http.StatusOK, "")
+	if res.MirrorAddr != mirrorA {
+		t.Fatalf("expected mirror %s, got %s", mirrorA, res.MirrorAddr)
+	}
+
+	// the mirror resolves back to the original token
+	res = resolveResponse{}
+	w = get(t, h, "/v1/swap-pairs/resolve?chain_id=4&token_addr="+mirrorA+"&target_chain_id=97", nil, &res)
+	expectStatus(t, w, http.StatusOK, "")
+	if res.MirrorAddr != tokenA {
+		t.Fatalf("expected original token %s, got %s", tokenA, res.MirrorAddr)
+	}
+
+	// only the unavailable pair registers token A of chain 4
+	w = get(t, h, "/v1/swap-pairs/resolve?chain_id=4&token_addr="+tokenA+"&target_chain_id=97", nil, nil)
+	expectStatus(t, w, http.StatusNotFound, "swap pair not found")
+
+	w = get(t, h, "/v1/swap-pairs/resolve?chain_id=97&token_addr="+tokenA+"&target_chain_id=97", nil, nil)
+	expectStatus(t, w, http.StatusBadRequest, "invalid target_chain_id")
+}
+
+func TestETag(t *testing.T) {
+	db := testutil.NewDB()
+	h := newTestServer(db)
+	s := createERC721Swap(t, db, 1)
+	target := "/v1/chains/97/swaps/" + s.RequestTxHash
+
+	w := get(t, h, target, nil, nil)
+	expectStatus(t, w, http.StatusOK, "")
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+
+	w = get(t, h, target, http.Header{"If-None-Match": {etag}}, nil)
+	expectStatus(t, w, http.StatusNotModified, "")
+	if w.Body.Len() != 0 || w.Header().Get("ETag") != etag {
+		t.Fatalf("expected an empty 304 tagged %s, got %q tagged %s", etag, w.Body.String(), w.Header().Get("ETag"))
+	}
+
+	var loaded erc721.Swap
+	if err := db.First(&loaded, "id = ?", s.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	loaded.State = erc721.SwapStateFillTxCreated
+	if err := model.CompareAndSave(db, &loaded, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	w = get(t, h, target, http.Header{"If-None-Match": {etag}}, nil)
+	expectStatus(t, w, http.StatusOK, "")
+	saved := w.Header().Get("ETag")
+	if saved == etag {
+		t.Fatal("expected a new ETag once the swap is saved")
+	}
+
+	// a save which leaves the public fields as they are still changes the version
+	if err := db.Model(&loaded).UpdateColumn("version", loaded.Version+1).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	w = get(t, h, target, http.Header{"If-None-Match": {saved}}, nil)
+	expectStatus(t, w, http.StatusOK, "")
+	if w.Header().Get("ETag") == saved {
+		t.Fatal("expected a new ETag once the version changes")
+	}
+}
+
+func TestETagOfSwapPairs(t *testing.T) {
+	db := testutil.NewDB()
+	h := newTestServer(db)
+	createSwapPairs(t, db)
+	target := "/v1/swap-pairs?standard=erc20"
+
+	w := get(t, h, target, nil, nil)
+	expectStatus(t, w, http.StatusOK, "")
+	etag := w.Header().Get("ETag")
+	expectStatus(t, get(t, h, target, http.Header{"If-None-Match": {etag}}, nil), http.StatusNotModified, "")
+
+	if err := db.Model(&erc20.SwapPair{}).Where("available = ?", true).UpdateColumn("version", 2).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	w = get(t, h, target, http.Header{"If-None-Match": {etag}}, nil)
+	expectStatus(t, w, http.StatusOK, "")
+	if w.Header().Get("ETag") == etag {
+		t.Fatal("expected a new ETag once the version changes")
+	}
+}
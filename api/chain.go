@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+)
+
+// blockTimeSampleSize is the number of latest block logs the average block time is measured on
+const blockTimeSampleSize = 20
+
+type chainHead struct {
+	Height int64
+	// BlockTime is the average number of seconds between blocks, 0 when it is unknown
+	BlockTime float64
+}
+
+// chainHeads caches the heads of the chains read while serving a single request
+type chainHeads struct {
+	s     *Server
+	ctx   context.Context
+	heads map[string]*chainHead
+}
+
+func (s *Server) newChainHeads(ctx context.Context) *chainHeads {
+	return &chainHeads{
+		s:     s,
+		ctx:   ctx,
+		heads: make(map[string]*chainHead),
+	}
+}
+
+// get returns the highest block recorded by the observer of the chain
+func (c *chainHeads) get(chainID string) (*chainHead, error) {
+	if h, ok := c.heads[chainID]; ok {
+		return h, nil
+	}
+
+	var ll []block.Log
+	err := c.s.deps.DB.WithContext(c.ctx).Where(
+		"chain_id = ?",
+		chainID,
+	).Order(
+		"height desc",
+	).Limit(
+		blockTimeSampleSize,
+	).Find(
+		&ll,
+	).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "[chainHeads.get]: failed to query block logs of chain %s", chainID)
+	}
+
+	h := &chainHead{}
+	if len(ll) > 0 {
+		latest, oldest := ll[0], ll[len(ll)-1]
+		h.Height = latest.Height
+		if blocks := latest.Height - oldest.Height; blocks > 0 && latest.BlockTime > oldest.BlockTime {
+			h.BlockTime = float64(latest.BlockTime-oldest.BlockTime) / float64(blocks)
+		}
+	}
+
+	c.heads[chainID] = h
+	return h, nil
+}
@@ -0,0 +1,319 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type getSwapsResponse struct {
+	Swaps []*Swap `json:"swaps"`
+}
+
+type listSwapsResponse struct {
+	Swaps      []*Swap `json:"swaps"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// handleGetSwaps serves GET /v1/chains/{src chain id}/swaps/{request tx hash}
+func (s *Server) handleGetSwaps(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/chains/"), "/")
+	if len(parts) != 3 || parts[1] != "swaps" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	srcChainID := parts[0]
+	if _, ok := s.conf.Chains[srcChainID]; !ok {
+		writeError(w, http.StatusNotFound, "unknown chain")
+		return
+	}
+
+	txHash, err := hexutil.Decode(parts[2])
+	if err != nil || len(txHash) != common.HashLength {
+		writeError(w, http.StatusBadRequest, "invalid request tx hash")
+		return
+	}
+
+	vv, err := s.findSwaps(r.Context(), srcChainID, common.BytesToHash(txHash).String())
+	if err != nil {
+		util.Logger.Error(err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if len(vv) == 0 {
+		writeError(w, http.StatusNotFound, "swap not found")
+		return
+	}
+
+	if !s.trackAll(r.Context(), w, vv) {
+		return
+	}
+
+	writeOK(w, r, &getSwapsResponse{Swaps: vv}, swapVersions(vv)...)
+}
+
+// handleListSwaps serves GET /v1/senders/{address}/swaps and GET /v1/recipients/{address}/swaps,
+// paginated with the limit and cursor query parameters
+func (s *Server) handleListSwaps(column string) http.HandlerFunc {
+	prefix := "/v1/" + column + "s/"
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, prefix), "/")
+		if len(parts) != 2 || parts[1] != "swaps" {
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+		if !common.IsHexAddress(parts[0]) {
+			writeError(w, http.StatusBadRequest, "invalid address")
+			return
+		}
+
+		limit, ok := s.limit(r)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+
+		vv, next, err := s.listSwaps(r.Context(), column, common.HexToAddress(parts[0]).String(), r.URL.Query().Get("cursor"), limit)
+		if err != nil {
+			util.Logger.Error(err)
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+
+		if !s.trackAll(r.Context(), w, vv) {
+			return
+		}
+
+		writeOK(w, r, &listSwapsResponse{Swaps: vv, NextCursor: next}, swapVersions(vv)...)
+	}
+}
+
+type listSwapPairsResponse struct {
+	SwapPairs  []*SwapPair `json:"swap_pairs"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+type resolveResponse struct {
+	ChainID       string    `json:"chain_id"`
+	TokenAddr     string    `json:"token_addr"`
+	TargetChainID string    `json:"target_chain_id"`
+	MirrorAddr    string    `json:"mirror_addr"`
+	SwapPair      *SwapPair `json:"swap_pair"`
+}
+
+// handleListSwapPairs serves GET /v1/swap-pairs, filtered by the standard, src_chain_id, dst_chain_id and
+// src_token_addr query parameters and paginated with the limit and cursor ones
+func (s *Server) handleListSwapPairs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	f := SwapPairFilter{
+		Standard:   q.Get("standard"),
+		SrcChainID: q.Get("src_chain_id"),
+		DstChainID: q.Get("dst_chain_id"),
+	}
+	if f.Standard != "" && f.Standard != StandardERC721 && f.Standard != StandardERC1155 && f.Standard != StandardERC20 {
+		writeError(w, http.StatusBadRequest, "invalid standard")
+		return
+	}
+	if addr := q.Get("src_token_addr"); addr != "" {
+		if !common.IsHexAddress(addr) {
+			writeError(w, http.StatusBadRequest, "invalid src_token_addr")
+			return
+		}
+		f.SrcTokenAddr = common.HexToAddress(addr).String()
+	}
+
+	limit, ok := s.limit(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	pp, next, err := s.listSwapPairs(r.Context(), &f, q.Get("cursor"), limit)
+	if err != nil {
+		util.Logger.Error(err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeOK(w, r, &listSwapPairsResponse{SwapPairs: pp, NextCursor: next}, swapPairVersions(pp)...)
+}
+
+// handleResolve serves GET /v1/swap-pairs/resolve?chain_id=A&token_addr=X&target_chain_id=B, which answers
+// the address of the counterpart of token X of chain A on chain B
+func (s *Server) handleResolve(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	chainID, targetChainID := q.Get("chain_id"), q.Get("target_chain_id")
+	if _, ok := s.conf.Chains[chainID]; !ok {
+		writeError(w, http.StatusBadRequest, "invalid chain_id")
+		return
+	}
+	if _, ok := s.conf.Chains[targetChainID]; !ok || targetChainID == chainID {
+		writeError(w, http.StatusBadRequest, "invalid target_chain_id")
+		return
+	}
+	if !common.IsHexAddress(q.Get("token_addr")) {
+		writeError(w, http.StatusBadRequest, "invalid token_addr")
+		return
+	}
+	tokenAddr := common.HexToAddress(q.Get("token_addr")).String()
+
+	p, mirrorAddr, err := s.resolveMirror(r.Context(), chainID, tokenAddr, targetChainID)
+	if err != nil {
+		util.Logger.Error(err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if p == nil {
+		writeError(w, http.StatusNotFound, "swap pair not found")
+		return
+	}
+
+	writeOK(w, r, &resolveResponse{
+		ChainID:       chainID,
+		TokenAddr:     tokenAddr,
+		TargetChainID: targetChainID,
+		MirrorAddr:    mirrorAddr,
+		SwapPair:      p,
+	}, p.version)
+}
+
+// limit returns the page size requested by the limit query parameter, or false if it is out of range
+func (s *Server) limit(r *http.Request) (int, bool) {
+	l := r.URL.Query().Get("limit")
+	if l == "" {
+		return s.conf.DefaultPageSize, true
+	}
+
+	n, err := strconv.Atoi(l)
+	if err != nil || n < 1 || n > s.conf.MaxPageSize {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// trackAll tracks the swaps of a response, it writes the error response and returns false if it fails
+func (s *Server) trackAll(ctx context.Context, w http.ResponseWriter, vv []*Swap) bool {
+	heads := s.newChainHeads(ctx)
+	for _, v := range vv {
+		if err := s.track(v, heads); err != nil {
+			util.Logger.Error(err)
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	body, err := json.Marshal(&errorResponse{Error: message})
+	if err != nil {
+		util.Logger.Errorf("[writeError]: failed to encode response, err=%s", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writeBody(w, status, body)
+}
+
+// writeOK writes a successful response tagged with the hash of its body and the versions of the rows it shows, the
+// body is left out if the client already has it. A row saved again gets a new tag even if its public view is the same.
+func writeOK(w http.ResponseWriter, r *http.Request, v interface{}, versions ...int64) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		util.Logger.Errorf("[writeOK]: failed to encode response, err=%s", err.Error())
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	h := sha256.New()
+	h.Write(body)
+	for _, version := range versions {
+		h.Write([]byte(strconv.FormatInt(version, 10) + ","))
+	}
+	etag := `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	// clients may cache responses but revalidate them every time
+	w.Header().Set("Cache-Control", "no-cache")
+	if matchETag(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	writeBody(w, http.StatusOK, body)
+}
+
+func swapVersions(vv []*Swap) []int64 {
+	versions := make([]int64, len(vv))
+	for i, v := range vv {
+		versions[i] = v.version
+	}
+
+	return versions
+}
+
+func swapPairVersions(pp []*SwapPair) []int64 {
+	versions := make([]int64, len(pp))
+	for i, p := range pp {
+		versions[i] = p.version
+	}
+
+	return versions
+}
+
+func matchETag(ifNoneMatch, etag string) bool {
+	for _, t := range strings.Split(ifNoneMatch, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == etag || t == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeBody(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	// the api is public and read-only, so browsers may call it from any origin
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		util.Logger.Errorf("[writeBody]: failed to write response, err=%s", err.Error())
+	}
+}
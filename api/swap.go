@@ -0,0 +1,397 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+)
+
+const (
+	StandardERC721  = "erc721"
+	StandardERC1155 = "erc1155"
+	StandardERC20   = "erc20"
+)
+
+// Swap is the public view of an ERC721, ERC1155 or ERC20 swap
+type Swap struct {
+	ID           string   `json:"id"`
+	Standard     string   `json:"standard"`
+	State        string   `json:"state"`
+	Direction    string   `json:"direction"`
+	SrcChainID   string   `json:"src_chain_id"`
+	DstChainID   string   `json:"dst_chain_id"`
+	SrcTokenAddr string   `json:"src_token_addr"`
+	DstTokenAddr string   `json:"dst_token_addr"`
+	Sender       string   `json:"sender"`
+	Recipient    string   `json:"recipient"`
+	TokenID      string   `json:"token_id,omitempty"`
+	TokenIDs     []string `json:"token_ids,omitempty"`
+	Amounts      []string `json:"amounts,omitempty"`
+	Amount       string   `json:"amount,omitempty"`
+	// Tokens holds the fill status of every token of an ERC721 batch swap
+	Tokens []erc721.BatchToken `json:"tokens,omitempty"`
+
+	RequestTxHash string `json:"request_tx_hash"`
+	RequestTxURL  string `json:"request_tx_url,omitempty"`
+	FillTxHash    string `json:"fill_tx_hash,omitempty"`
+	FillTxURL     string `json:"fill_tx_url,omitempty"`
+
+	// Confirmations counts the blocks on top of the request tx until it is confirmed, then the ones on top of the fill tx
+	Confirmations         int64 `json:"confirmations"`
+	RequiredConfirmations int64 `json:"required_confirmations"`
+	// EstimatedSecondsRemaining is null when the swap failed or the block times are unknown yet
+	EstimatedSecondsRemaining *int64 `json:"estimated_seconds_remaining"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	requestHeight int64
+	fillHeight    int64
+	version       int64
+}
+
+func fromERC721Swap(s *erc721.Swap) *Swap {
+	return &Swap{
+		ID:            s.ID,
+		Standard:      StandardERC721,
+		State:         string(s.State),
+		Direction:     string(s.SwapDirection),
+		SrcChainID:    s.SrcChainID,
+		DstChainID:    s.DstChainID,
+		SrcTokenAddr:  s.SrcTokenAddr,
+		DstTokenAddr:  s.DstTokenAddr,
+		Sender:        s.Sender,
+		Recipient:     s.Recipient,
+		TokenID:       s.TokenID,
+		RequestTxHash: s.RequestTxHash,
+		FillTxHash:    s.FillTxHash,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+		requestHeight: s.RequestHeight,
+		fillHeight:    s.FillHeight,
+		version:       s.Version,
+	}
+}
+
+func fromERC721BatchSwap(s *erc721.BatchSwap) (*Swap, error) {
+	v := &Swap{
+		ID:            s.ID,
+		Standard:      StandardERC721,
+		State:         string(s.State),
+		Direction:     string(s.SwapDirection),
+		SrcChainID:    s.SrcChainID,
+		DstChainID:    s.DstChainID,
+		SrcTokenAddr:  s.SrcTokenAddr,
+		DstTokenAddr:  s.DstTokenAddr,
+		Sender:        s.Sender,
+		Recipient:     s.Recipient,
+		RequestTxHash: s.RequestTxHash,
+		FillTxHash:    s.FillTxHash,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+		requestHeight: s.RequestHeight,
+		fillHeight:    s.FillHeight,
+		version:       s.Version,
+	}
+
+	var err error
+	if v.TokenIDs, err = s.TokenIDs(); err != nil {
+		return nil, errors.Wrapf(err, "[fromERC721BatchSwap]: failed to decode ids of BatchSwap %s", s.ID)
+	}
+	if v.Tokens, err = s.Tokens(); err != nil {
+		return nil, errors.Wrapf(err, "[fromERC721BatchSwap]: failed to decode tokens of BatchSwap %s", s.ID)
+	}
+
+	return v, nil
+}
+
+func fromERC1155Swap(s *erc1155.Swap) (*Swap, error) {
+	v := &Swap{
+		ID:            s.ID,
+		Standard:      StandardERC1155,
+		State:         string(s.State),
+		Direction:     string(s.SwapDirection),
+		SrcChainID:    s.SrcChainID,
+		DstChainID:    s.DstChainID,
+		SrcTokenAddr:  s.SrcTokenAddr,
+		DstTokenAddr:  s.DstTokenAddr,
+		Sender:        s.Sender,
+		Recipient:     s.Recipient,
+		RequestTxHash: s.RequestTxHash,
+		FillTxHash:    s.FillTxHash,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+		requestHeight: s.RequestHeight,
+		fillHeight:    s.FillHeight,
+		version:       s.Version,
+	}
+	if err := json.Unmarshal(s.IDs, &v.TokenIDs); err != nil {
+		return nil, errors.Wrapf(err, "[fromERC1155Swap]: failed to decode ids of Swap %s", s.ID)
+	}
+	if err := json.Unmarshal(s.Amounts, &v.Amounts); err != nil {
+		return nil, errors.Wrapf(err, "[fromERC1155Swap]: failed to decode amounts of Swap %s", s.ID)
+	}
+
+	return v, nil
+}
+
+func fromERC20Swap(s *erc20.Swap) *Swap {
+	return &Swap{
+		ID:            s.ID,
+		Standard:      StandardERC20,
+		State:         string(s.State),
+		Direction:     string(s.SwapDirection),
+		SrcChainID:    s.SrcChainID,
+		DstChainID:    s.DstChainID,
+		SrcTokenAddr:  s.SrcTokenAddr,
+		DstTokenAddr:  s.DstTokenAddr,
+		Sender:        s.Sender,
+		Recipient:     s.Recipient,
+		Amount:        s.Amount,
+		RequestTxHash: s.RequestTxHash,
+		FillTxHash:    s.FillTxHash,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+		requestHeight: s.RequestHeight,
+		fillHeight:    s.FillHeight,
+		version:       s.Version,
+	}
+}
+
+// findSwaps returns the swaps requested by a transaction, ordered by log index
+func (s *Server) findSwaps(ctx context.Context, srcChainID, requestTxHash string) ([]*Swap, error) {
+	var ss721 []erc721.Swap
+	err := s.deps.DB.WithContext(ctx).Where(
+		"src_chain_id = ? and request_tx_hash = ?",
+		srcChainID,
+		requestTxHash,
+	).Order(
+		"request_log_index asc",
+	).Find(
+		&ss721,
+	).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "[Server.findSwaps]: failed to query ERC721 Swaps")
+	}
+
+	var bb721 []erc721.BatchSwap
+	err = s.deps.DB.WithContext(ctx).Where(
+		"src_chain_id = ? and request_tx_hash = ?",
+		srcChainID,
+		requestTxHash,
+	).Order(
+		"request_log_index asc",
+	).Find(
+		&bb721,
+	).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "[Server.findSwaps]: failed to query ERC721 BatchSwaps")
+	}
+
+	var ss1155 []erc1155.Swap
+	err = s.deps.DB.WithContext(ctx).Where(
+		"src_chain_id = ? and request_tx_hash = ?",
+		srcChainID,
+		requestTxHash,
+	).Order(
+		"request_log_index asc",
+	).Find(
+		&ss1155,
+	).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "[Server.findSwaps]: failed to query ERC1155 Swaps")
+	}
+
+	var ss20 []erc20.Swap
+	err = s.deps.DB.WithContext(ctx).Where(
+		"src_chain_id = ? and request_tx_hash = ?",
+		srcChainID,
+		requestTxHash,
+	).Order(
+		"request_log_index asc",
+	).Find(
+		&ss20,
+	).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "[Server.findSwaps]: failed to query ERC20 Swaps")
+	}
+
+	vv := make([]*Swap, 0, len(ss721)+len(bb721)+len(ss1155)+len(ss20))
+	for i := range ss721 {
+		vv = append(vv, fromERC721Swap(&ss721[i]))
+	}
+	for i := range bb721 {
+		v, err := fromERC721BatchSwap(&bb721[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "[Server.findSwaps]: failed to convert ERC721 BatchSwap")
+		}
+		vv = append(vv, v)
+	}
+	for i := range ss1155 {
+		v, err := fromERC1155Swap(&ss1155[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "[Server.findSwaps]: failed to convert ERC1155 Swap")
+		}
+		vv = append(vv, v)
+	}
+	for i := range ss20 {
+		vv = append(vv, fromERC20Swap(&ss20[i]))
+	}
+
+	return vv, nil
+}
+
+// listSwaps returns the swaps of an address, newest first. Swap ids are ULIDs, so the id of the last swap of a
+// page is the cursor of the next one; next is empty on the last page.
+func (s *Server) listSwaps(ctx context.Context, column, address, cursor string, limit int) (vv []*Swap, next string, err error) {
+	query := func() *gorm.DB {
+		q := s.deps.DB.WithContext(ctx).Where(column+" = ?", address)
+		if cursor != "" {
+			q = q.Where("id < ?", cursor)
+		}
+
+		return q.Order("id desc").Limit(limit + 1)
+	}
+
+	var ss721 []erc721.Swap
+	if err := query().Find(&ss721).Error; err != nil {
+		return nil, "", errors.Wrap(err, "[Server.listSwaps]: failed to query ERC721 Swaps")
+	}
+
+	var bb721 []erc721.BatchSwap
+	if err := query().Find(&bb721).Error; err != nil {
+		return nil, "", errors.Wrap(err, "[Server.listSwaps]: failed to query ERC721 BatchSwaps")
+	}
+
+	var ss1155 []erc1155.Swap
+	if err := query().Find(&ss1155).Error; err != nil {
+		return nil, "", errors.Wrap(err, "[Server.listSwaps]: failed to query ERC1155 Swaps")
+	}
+
+	var ss20 []erc20.Swap
+	if err := query().Find(&ss20).Error; err != nil {
+		return nil, "", errors.Wrap(err, "[Server.listSwaps]: failed to query ERC20 Swaps")
+	}
+
+	vv = make([]*Swap, 0, len(ss721)+len(bb721)+len(ss1155)+len(ss20))
+	for i := range ss721 {
+		vv = append(vv, fromERC721Swap(&ss721[i]))
+	}
+	for i := range bb721 {
+		v, err := fromERC721BatchSwap(&bb721[i])
+		if err != nil {
+			return nil, "", errors.Wrap(err, "[Server.listSwaps]: failed to convert ERC721 BatchSwap")
+		}
+		vv = append(vv, v)
+	}
+	for i := range ss1155 {
+		v, err := fromERC1155Swap(&ss1155[i])
+		if err != nil {
+			return nil, "", errors.Wrap(err, "[Server.listSwaps]: failed to convert ERC1155 Swap")
+		}
+		vv = append(vv, v)
+	}
+	for i := range ss20 {
+		vv = append(vv, fromERC20Swap(&ss20[i]))
+	}
+
+	sort.Slice(vv, func(i, j int) bool {
+		return vv[i].ID > vv[j].ID
+	})
+	if len(vv) > limit {
+		vv = vv[:limit]
+		next = vv[limit-1].ID
+	}
+
+	return vv, next, nil
+}
+
+// track fills the explorer links, the confirmations and the estimated time remaining of a swap. The states of
+// ERC721, ERC1155 and ERC20 swaps and ERC721 batch swaps share their values, an ERC721 batch swap is tracked by the
+// chunk in flight.
+func (s *Server) track(v *Swap, heads *chainHeads) error {
+	v.RequestTxURL = s.txURL(v.SrcChainID, v.RequestTxHash)
+	v.FillTxURL = s.txURL(v.DstChainID, v.FillTxHash)
+
+	// the engines of the source chain confirm both the request and the fill tx
+	required := s.conf.Chains[v.SrcChainID].ConfirmNum
+	v.RequiredConfirmations = required
+
+	src, err := heads.get(v.SrcChainID)
+	if err != nil {
+		return errors.Wrap(err, "[Server.track]: failed to get source chain head")
+	}
+	dst, err := heads.get(v.DstChainID)
+	if err != nil {
+		return errors.Wrap(err, "[Server.track]: failed to get destination chain head")
+	}
+
+	hasFillHeight := v.FillTxHash != "" && v.fillHeight > 0 && v.fillHeight != math.MaxInt64
+	switch v.State {
+	case string(erc721.SwapStateRequestOngoing):
+		v.Confirmations = confirmations(src.Height, v.requestHeight)
+		if src.BlockTime > 0 && dst.BlockTime > 0 {
+			v.EstimatedSecondsRemaining = seconds(
+				float64(remaining(required, v.Confirmations))*src.BlockTime + float64(required)*dst.BlockTime,
+			)
+		}
+	case string(erc721.SwapStateRequestConfirmed),
+		string(erc721.SwapStateFillTxCreated),
+		string(erc721.SwapStateFillTxSent):
+		if hasFillHeight {
+			v.Confirmations = confirmations(dst.Height, v.fillHeight)
+		}
+		if dst.BlockTime > 0 {
+			v.EstimatedSecondsRemaining = seconds(float64(remaining(required, v.Confirmations)) * dst.BlockTime)
+		}
+	case string(erc721.SwapStateFillTxConfirmed):
+		v.Confirmations = required
+		if hasFillHeight {
+			v.Confirmations = confirmations(dst.Height, v.fillHeight)
+		}
+		v.EstimatedSecondsRemaining = seconds(0)
+	}
+
+	return nil
+}
+
+func (s *Server) txURL(chainID, txHash string) string {
+	explorerURL := s.conf.Chains[chainID].ExplorerURL
+	if txHash == "" || explorerURL == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(explorerURL, "/"), txHash)
+}
+
+func confirmations(head, height int64) int64 {
+	if head < height {
+		return 0
+	}
+
+	return head - height
+}
+
+func remaining(required, confirmations int64) int64 {
+	if confirmations >= required {
+		return 0
+	}
+
+	return required - confirmations
+}
+
+func seconds(s float64) *int64 {
+	n := int64(math.Ceil(s))
+	return &n
+}
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+)
+
+// SwapPair is the public view of an available ERC721, ERC1155 or ERC20 swap pair
+type SwapPair struct {
+	ID           string `json:"id"`
+	Standard     string `json:"standard"`
+	SrcChainID   string `json:"src_chain_id"`
+	DstChainID   string `json:"dst_chain_id"`
+	SrcTokenAddr string `json:"src_token_addr"`
+	DstTokenAddr string `json:"dst_token_addr"`
+	SrcTokenName string `json:"src_token_name,omitempty"`
+	DstTokenName string `json:"dst_token_name,omitempty"`
+	Symbol       string `json:"symbol,omitempty"`
+	BaseURI      string `json:"base_uri,omitempty"`
+	URI          string `json:"uri,omitempty"`
+	Decimals     *uint8 `json:"decimals,omitempty"`
+	MinAmount    string `json:"min_amount,omitempty"`
+	MaxAmount    string `json:"max_amount,omitempty"`
+
+	RegisterTxHash string `json:"register_tx_hash"`
+	RegisterTxURL  string `json:"register_tx_url,omitempty"`
+	CreateTxHash   string `json:"create_tx_hash"`
+	CreateTxURL    string `json:"create_tx_url,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	version int64
+}
+
+// SwapPairFilter narrows the listed swap pairs, empty fields match everything
+type SwapPairFilter struct {
+	Standard     string
+	SrcChainID   string
+	DstChainID   string
+	SrcTokenAddr string
+}
+
+func (s *Server) fromERC721SwapPair(p *erc721.SwapPair) *SwapPair {
+	return &SwapPair{
+		ID:             p.ID,
+		Standard:       StandardERC721,
+		SrcChainID:     p.SrcChainID,
+		DstChainID:     p.DstChainID,
+		SrcTokenAddr:   p.SrcTokenAddr,
+		DstTokenAddr:   p.DstTokenAddr,
+		SrcTokenName:   p.SrcTokenName,
+		DstTokenName:   p.DstTokenName,
+		Symbol:         p.Symbol,
+		BaseURI:        p.BaseURI,
+		RegisterTxHash: p.RegisterTxHash,
+		RegisterTxURL:  s.txURL(p.SrcChainID, p.RegisterTxHash),
+		CreateTxHash:   p.CreateTxHash,
+		CreateTxURL:    s.txURL(p.DstChainID, p.CreateTxHash),
+		CreatedAt:      p.CreatedAt,
+		version:        p.Version,
+	}
+}
+
+func (s *Server) fromERC1155SwapPair(p *erc1155.SwapPair) *SwapPair {
+	return &SwapPair{
+		ID:             p.ID,
+		Standard:       StandardERC1155,
+		SrcChainID:     p.SrcChainID,
+		DstChainID:     p.DstChainID,
+		SrcTokenAddr:   p.SrcTokenAddr,
+		DstTokenAddr:   p.DstTokenAddr,
+		URI:            p.URI,
+		RegisterTxHash: p.RegisterTxHash,
+		RegisterTxURL:  s.txURL(p.SrcChainID, p.RegisterTxHash),
+		CreateTxHash:   p.CreateTxHash,
+		CreateTxURL:    s.txURL(p.DstChainID, p.CreateTxHash),
+		CreatedAt:      p.CreatedAt,
+		version:        p.Version,
+	}
+}
+
+func (s *Server) fromERC20SwapPair(p *erc20.SwapPair) *SwapPair {
+	decimals := p.Decimals
+
+	return &SwapPair{
+		ID:             p.ID,
+		Standard:       StandardERC20,
+		SrcChainID:     p.SrcChainID,
+		DstChainID:     p.DstChainID,
+		SrcTokenAddr:   p.SrcTokenAddr,
+		DstTokenAddr:   p.DstTokenAddr,
+		SrcTokenName:   p.SrcTokenName,
+		DstTokenName:   p.DstTokenName,
+		Symbol:         p.Symbol,
+		Decimals:       &decimals,
+		MinAmount:      p.MinAmount,
+		MaxAmount:      p.MaxAmount,
+		RegisterTxHash: p.RegisterTxHash,
+		RegisterTxURL:  s.txURL(p.SrcChainID, p.RegisterTxHash),
+		CreateTxHash:   p.CreateTxHash,
+		CreateTxURL:    s.txURL(p.DstChainID, p.CreateTxHash),
+		CreatedAt:      p.CreatedAt,
+		version:        p.Version,
+	}
+}
+
+// listSwapPairs returns the available swap pairs matching the filter, oldest first. The id of the last pair of a
+// page is the cursor of the next one; next is empty on the last page.
+func (s *Server) listSwapPairs(ctx context.Context, f *SwapPairFilter, cursor string, limit int) (pp []*SwapPair, next string, err error) {
+	query := func() *gorm.DB {
+		q := s.deps.DB.WithContext(ctx).Where("available = ?", true)
+		if f.SrcChainID != "" {
+			q = q.Where("src_chain_id = ?", f.SrcChainID)
+		}
+		if f.DstChainID != "" {
+			q = q.Where("dst_chain_id = ?", f.DstChainID)
+		}
+		if f.SrcTokenAddr != "" {
+			q = q.Where("src_token_addr = ?", f.SrcTokenAddr)
+		}
+		if cursor != "" {
+			q = q.Where("id > ?", cursor)
+		}
+
+		return q.Order("id asc").Limit(limit + 1)
+	}
+
+	var pp721 []erc721.SwapPair
+	if f.Standard == "" || f.Standard == StandardERC721 {
+		if err := query().Find(&pp721).Error; err != nil {
+			return nil, "", errors.Wrap(err, "[Server.listSwapPairs]: failed to query ERC721 SwapPairs")
+		}
+	}
+
+	var pp1155 []erc1155.SwapPair
+	if f.Standard == "" || f.Standard == StandardERC1155 {
+		if err := query().Find(&pp1155).Error; err != nil {
+			return nil, "", errors.Wrap(err, "[Server.listSwapPairs]: failed to query ERC1155 SwapPairs")
+		}
+	}
+
+	var pp20 []erc20.SwapPair
+	if f.Standard == "" || f.Standard == StandardERC20 {
+		if err := query().Find(&pp20).Error; err != nil {
+			return nil, "", errors.Wrap(err, "[Server.listSwapPairs]: failed to query ERC20 SwapPairs")
+		}
+	}
+
+	pp = make([]*SwapPair, 0, len(pp721)+len(pp1155)+len(pp20))
+	for i := range pp721 {
+		pp = append(pp, s.fromERC721SwapPair(&pp721[i]))
+	}
+	for i := range pp1155 {
+		pp = append(pp, s.fromERC1155SwapPair(&pp1155[i]))
+	}
+	for i := range pp20 {
+		pp = append(pp, s.fromERC20SwapPair(&pp20[i]))
+	}
+
+	sort.Slice(pp, func(i, j int) bool {
+		return pp[i].ID < pp[j].ID
+	})
+	if len(pp) > limit {
+		pp = pp[:limit]
+		next = pp[limit-1].ID
+	}
+
+	return pp, next, nil
+}
+
+// resolveMirror returns the available swap pair connecting a token of one chain to another chain, with the address
+// of its counterpart. The token is either an original token of the chain or a mirror of an original token of the
+// other chain.
+func (s *Server) resolveMirror(ctx context.Context, chainID, tokenAddr, otherChainID string) (p *SwapPair, counterpartAddr string, err error) {
+	var p721 []erc721.SwapPair
+	err = s.deps.DB.WithContext(ctx).Where(
+		"available = ? and ((src_chain_id = ? and src_token_addr = ? and dst_chain_id = ?) or (dst_chain_id = ? and dst_token_addr = ? and src_chain_id = ?))",
+		true,
+		chainID, tokenAddr, otherChainID,
+		chainID, tokenAddr, otherChainID,
+	).Limit(
+		1,
+	).Find(
+		&p721,
+	).Error
+	if err != nil {
+		return nil, "", errors.Wrap(err, "[Server.resolveMirror]: failed to query ERC721 SwapPairs")
+	}
+	if len(p721) > 0 {
+		p = s.fromERC721SwapPair(&p721[0])
+	}
+
+	if p == nil {
+		var p1155 []erc1155.SwapPair
+		err = s.deps.DB.WithContext(ctx).Where(
+			"available = ? and ((src_chain_id = ? and src_token_addr = ? and dst_chain_id = ?) or (dst_chain_id = ? and dst_token_addr = ? and src_chain_id = ?))",
+			true,
+			chainID, tokenAddr, otherChainID,
+			chainID, tokenAddr, otherChainID,
+		).Limit(
+			1,
+		).Find(
+			&p1155,
+		).Error
+		if err != nil {
+			return nil, "", errors.Wrap(err, "[Server.resolveMirror]: failed to query ERC1155 SwapPairs")
+		}
+		if len(p1155) > 0 {
+			p = s.fromERC1155SwapPair(&p1155[0])
+		}
+	}
+
+	if p == nil {
+		var p20 []erc20.SwapPair
+		err = s.deps.DB.WithContext(ctx).Where(
+			"available = ? and ((src_chain_id = ? and src_token_addr = ? and dst_chain_id = ?) or (dst_chain_id = ? and dst_token_addr = ? and src_chain_id = ?))",
+			true,
+			chainID, tokenAddr, otherChainID,
+			chainID, tokenAddr, otherChainID,
+		).Limit(
+			1,
+		).Find(
+			&p20,
+		).Error
+		if err != nil {
+			return nil, "", errors.Wrap(err, "[Server.resolveMirror]: failed to query ERC20 SwapPairs")
+		}
+		if len(p20) > 0 {
+			p = s.fromERC20SwapPair(&p20[0])
+		}
+	}
+
+	if p == nil {
+		return nil, "", nil
+	}
+	if p.SrcChainID == chainID {
+		return p, p.DstTokenAddr, nil
+	}
+
+	return p, p.SrcTokenAddr, nil
+}
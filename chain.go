@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/health"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/lease"
+	observer "github.com/synycboom/bsc-evm-compatible-bridge-core/observer"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// runChain prints the observer progress, the RPC head and the lease holders of the given chains, every configured
+// chain by default
+func runChain(args []string) error {
+	if len(args) == 0 || args[0] != "status" {
+		return errUsage
+	}
+
+	config, db, err := setup(false)
+	if err != nil {
+		return err
+	}
+
+	cc, err := newChains(config, db)
+	if err != nil {
+		return err
+	}
+	defer cc.close()
+
+	ids := args[1:]
+	if len(ids) == 0 {
+		for _, c := range config.ChainConfigs {
+			ids = append(ids, c.ID)
+		}
+	}
+
+	var chainConfigs []health.ChainConfig
+	healthChains := make(map[string]*health.ChainDependencies)
+	for _, id := range ids {
+		var c *util.ChainConfig
+		for i := range config.ChainConfigs {
+			if config.ChainConfigs[i].ID == id {
+				c = &config.ChainConfigs[i]
+			}
+		}
+		if c == nil {
+			return errors.Errorf("chain %s is not configured", id)
+		}
+
+		chainConfigs = append(chainConfigs, health.ChainConfig{
+			ChainID:            c.ID,
+			ChainName:          c.Name,
+			BlockUpdateTimeout: time.Duration(config.AlertConfig.BlockUpdateTimeout) * time.Second,
+		})
+		healthChains[c.ID] = &health.ChainDependencies{
+			Client: cc.clients[c.ID],
+			// the observer is not started, it only reads the block logs
+			Observer: observer.NewObserver(&observer.Config{}, &observer.Dependencies{
+				DB:       db,
+				Recorder: cc.recorders[c.ID],
+			}),
+		}
+	}
+
+	checker := health.NewChecker(&health.Config{
+		MaxObserverLag: config.HealthConfig.MaxObserverLag,
+		CheckTimeout:   10 * time.Second,
+		Chains:         chainConfigs,
+	}, &health.Dependencies{
+		DB:     db,
+		Chains: healthChains,
+	})
+	report := checker.Check(context.Background())
+
+	var ll []lease.Lease
+	if err := db.Order("name asc").Find(&ll).Error; err != nil {
+		return errors.Wrap(err, "failed to query leases")
+	}
+
+	for _, s := range report.Chains {
+		printChainStatus(s, ll)
+	}
+
+	return nil
+}
+
+func printChainStatus(s *health.ChainStatus, ll []lease.Lease) {
+	status := "ok"
+	if !s.Healthy {
+		status = "degraded"
+	}
+	fmt.Printf("%s (%s): %s\n", s.ChainID, s.ChainName, status)
+
+	if s.RPCReachable {
+		fmt.Printf("  chain head:     %d\n", s.ChainHead)
+	} else {
+		fmt.Print("  chain head:     unreachable\n")
+	}
+	fmt.Printf("  recorded:       %d\n", s.RecordedHeight)
+	if s.RPCReachable && s.RecordedHeight > 0 {
+		fmt.Printf("  observer lag:   %d blocks\n", s.ObserverLag)
+	}
+	if s.RecordedHeight > 0 {
+		fmt.Printf("  last block log: %ds ago\n", s.SecondsSinceLastLog)
+	}
+
+	for _, l := range ll {
+		if !strings.HasSuffix(l.Name, "/"+s.ChainID) {
+			continue
+		}
+
+		state := "held"
+		if l.ExpiresAt.Before(time.Now()) {
+			state = "expired"
+		}
+		fmt.Printf("  lease %s: %s by %s until %s\n", l.Name, state, l.Holder, l.ExpiresAt.Local().Format(time.RFC3339))
+	}
+
+	for _, p := range s.Problems {
+		fmt.Printf("  problem: %s\n", p)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	contractabi "github.com/synycboom/bsc-evm-compatible-bridge-core/abi"
+	erc1155agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc1155"
+	erc20agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc20"
+	erc721agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc721"
+	fillbatchagent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/fillbatch"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/client"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/head"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/recorder"
+	sengine "github.com/synycboom/bsc-evm-compatible-bridge-core/swap-engine"
+	erc1155token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc1155"
+	erc721token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// verifyTimeout bounds the requests of the startup checks of a chain
+const verifyTimeout = 10 * time.Second
+
+// chains holds the clients, swap agents, tokens, head trackers and recorders of the configured chains, keyed by chain
+// id
+type chains struct {
+	chainIDs                  map[string]*big.Int
+	ethClients                []*ethclient.Client
+	clients                   map[string]client.ETHClient
+	erc721SwapAgents          map[string]erc721agent.SwapAgent
+	erc721SwapAgentAddresses  map[string]common.Address
+	erc721BatchSwapAgents     map[string]erc721agent.BatchSwapAgent
+	erc721Tokens              map[string]erc721token.IToken
+	erc1155SwapAgents         map[string]erc1155agent.SwapAgent
+	erc1155SwapAgentAddresses map[string]common.Address
+	erc1155Tokens             map[string]erc1155token.IToken
+	erc20SwapAgents           map[string]erc20agent.SwapAgent
+	erc20SwapAgentAddresses   map[string]common.Address
+	fillBatchers              map[string]map[common.Address]fillbatchagent.FillBatcher
+	fillBatches               map[string]*sengine.FillBatch
+	heads                     map[string]*head.Tracker
+	recorders                 map[string]recorder.IRecorder
+}
+
+// newChains dials the configured chains and creates their recorders
+func newChains(config *util.Config, db *gorm.DB) (*chains, error) {
+	c := chains{
+		chainIDs:                  make(map[string]*big.Int),
+		clients:                   make(map[string]client.ETHClient),
+		erc721SwapAgents:          make(map[string]erc721agent.SwapAgent),
+		erc721SwapAgentAddresses:  make(map[string]common.Address),
+		erc721BatchSwapAgents:     make(map[string]erc721agent.BatchSwapAgent),
+		erc721Tokens:              make(map[string]erc721token.IToken),
+		erc1155SwapAgents:         make(map[string]erc1155agent.SwapAgent),
+		erc1155SwapAgentAddresses: make(map[string]common.Address),
+		erc1155Tokens:             make(map[string]erc1155token.IToken),
+		erc20SwapAgents:           make(map[string]erc20agent.SwapAgent),
+		erc20SwapAgentAddresses:   make(map[string]common.Address),
+		fillBatchers:              make(map[string]map[common.Address]fillbatchagent.FillBatcher),
+		fillBatches:               make(map[string]*sengine.FillBatch),
+		heads:                     make(map[string]*head.Tracker),
+		recorders:                 make(map[string]recorder.IRecorder),
+	}
+
+	for _, cc := range config.ChainConfigs {
+		ec, err := ethclient.Dial(cc.Provider)
+		if err != nil {
+			return nil, errors.Wrap(err, "[newChains]: new eth client error")
+		}
+
+		erc721SwapAgentAddr := common.HexToAddress(cc.ERC721SwapAgentAddr)
+		erc721SwapAgent, err := contractabi.NewERC721SwapAgent(erc721SwapAgentAddr, ec)
+		if err != nil {
+			return nil, errors.Wrap(err, "[newChains]: failed to create ERC721 swap agent")
+		}
+
+		erc1155SwapAgentAddr := common.HexToAddress(cc.ERC1155SwapAgentAddr)
+		erc1155SwapAgent, err := contractabi.NewERC1155SwapAgent(erc1155SwapAgentAddr, ec)
+		if err != nil {
+			return nil, errors.Wrap(err, "[newChains]: failed to create ERC1155 swap agent")
+		}
+
+		c.ethClients = append(c.ethClients, ec)
+		c.clients[cc.ID] = client.NewClient(ec)
+		c.erc721Tokens[cc.ID] = erc721token.NewToken(ec)
+		c.erc721SwapAgents[cc.ID] = erc721SwapAgent
+		c.erc721SwapAgentAddresses[cc.ID] = erc721SwapAgentAddr
+
+		if cc.ERC721BatchSwapAgentAddr != "" {
+			erc721BatchSwapAgent, err := contractabi.NewERC721BatchSwapAgent(common.HexToAddress(cc.ERC721BatchSwapAgentAddr), ec)
+			if err != nil {
+				return nil, errors.Wrap(err, "[newChains]: failed to create ERC721 batch swap agent")
+			}
+
+			c.erc721BatchSwapAgents[cc.ID] = erc721BatchSwapAgent
+		}
+
+		c.erc1155Tokens[cc.ID] = erc1155token.NewToken(ec)
+		c.erc1155SwapAgents[cc.ID] = erc1155SwapAgent
+		c.erc1155SwapAgentAddresses[cc.ID] = erc1155SwapAgentAddr
+
+		if cc.ERC20SwapAgentAddr != "" {
+			erc20SwapAgentAddr := common.HexToAddress(cc.ERC20SwapAgentAddr)
+			erc20SwapAgent, err := contractabi.NewERC20SwapAgent(erc20SwapAgentAddr, ec)
+			if err != nil {
+				return nil, errors.Wrap(err, "[newChains]: failed to create ERC20 swap agent")
+			}
+
+			c.erc20SwapAgents[cc.ID] = erc20SwapAgent
+			c.erc20SwapAgentAddresses[cc.ID] = erc20SwapAgentAddr
+		}
+
+		if cc.FillBatchSize > 1 {
+			// the fills of a batch are sent to the agent they fill with
+			agentAddrs := []common.Address{erc721SwapAgentAddr, erc1155SwapAgentAddr}
+			if addr, ok := c.erc20SwapAgentAddresses[cc.ID]; ok {
+				agentAddrs = append(agentAddrs, addr)
+			}
+
+			c.fillBatchers[cc.ID] = make(map[common.Address]fillbatchagent.FillBatcher)
+			for _, addr := range agentAddrs {
+				fillBatcher, err := contractabi.NewFillBatcher(addr, ec)
+				if err != nil {
+					return nil, errors.Wrap(err, "[newChains]: failed to create fill batcher")
+				}
+
+				c.fillBatchers[cc.ID][addr] = fillBatcher
+			}
+			c.fillBatches[cc.ID] = &sengine.FillBatch{
+				Window: time.Duration(cc.FillBatchWindow) * time.Second,
+				Size:   cc.FillBatchSize,
+			}
+		}
+
+		c.heads[cc.ID] = head.NewTracker(&head.Config{
+			ChainID:      cc.ID,
+			PollInterval: time.Duration(cc.ObserverFetchInterval) * time.Second,
+			StaleAfter:   time.Duration(config.AlertConfig.BlockUpdateTimeout) * time.Second,
+		}, &head.Dependencies{
+			Client: c.clients[cc.ID],
+		})
+	}
+
+	for _, cc := range config.ChainConfigs {
+		chainID, err := util.ParseBigInt(cc.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[newChains]: invalid id of chain %s", cc.Name)
+		}
+		c.chainIDs[cc.ID] = chainID
+
+		c.recorders[cc.ID] = recorder.NewRecorder(&recorder.Config{
+			ChainID:   chainID,
+			ChainName: cc.Name,
+			HMACKey:   config.KeyManagerConfig.HMACKey,
+		}, &recorder.Dependencies{
+			Client:               c.clients,
+			DB:                   db.Session(&gorm.Session{}),
+			ERC721SwapAgent:      c.erc721SwapAgents,
+			ERC721BatchSwapAgent: c.erc721BatchSwapAgents,
+			ERC721Token:          c.erc721Tokens,
+			ERC1155SwapAgent:     c.erc1155SwapAgents,
+			ERC1155Token:         c.erc1155Tokens,
+			ERC20SwapAgent:       c.erc20SwapAgents,
+			Head:                 c.heads[cc.ID],
+		})
+	}
+
+	return &c, nil
+}
+
+// verifyChainIDs checks that every provider serves the chain it is configured for, so that transactions are never
+// signed for, or events recorded from, another chain
+func (c *chains) verifyChainIDs(ctx context.Context, config *util.Config) error {
+	for i, cc := range config.ChainConfigs {
+		reqCtx, cancel := context.WithTimeout(ctx, verifyTimeout)
+		id, err := c.ethClients[i].ChainID(reqCtx)
+		cancel()
+		if err != nil {
+			return errors.Wrapf(err, "[chains.verifyChainIDs]: failed to get the chain id of chain %s", cc.Name)
+		}
+		if id.String() != cc.ID {
+			return errors.Errorf("[chains.verifyChainIDs]: chain %s is configured with id %s but its provider serves chain %s", cc.Name, cc.ID, id.String())
+		}
+	}
+
+	return nil
+}
+
+// close closes the connections to the chains
+func (c *chains) close() {
+	for _, ec := range c.ethClients {
+		ec.Close()
+	}
+}
@@ -10,8 +10,13 @@ const (
 	ObserverPruneInterval  = 10 * time.Second
 	ObserverAlertInterval  = 5 * time.Second
 
-	DBDialectMysql   = "mysql"
-	DBDialectSqlite3 = "sqlite3"
+	DBDialectMysql    = "mysql"
+	DBDialectSqlite3  = "sqlite3"
+	DBDialectPostgres = "postgres"
+
+	BrokerNATS  = "nats"
+	BrokerKafka = "kafka"
+	BrokerFile  = "file"
 
 	LocalPrivateKey = "local_private_key"
 	AWSPrivateKey   = "aws_private_key"
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./ERC1155Token.sol";
+import "./lib/Interfaces.sol";
+import "./lib/FillBatcher.sol";
+
+/// @notice ERC1155 token deployed by the swap agent for a token of another chain, only the agent mints and burns it
+contract MirroredERC1155 is ERC1155Token {
+    constructor(string memory uri) ERC1155Token(uri) {}
+
+    function burnBatch(uint256[] calldata ids, uint256[] calldata amounts) external onlyOwner {
+        _burnBatch(msg.sender, ids, amounts);
+    }
+}
+
+/// @notice ERC1155 swap agent of abi/ERC1155SwapAgent.json and abi/FillBatcher.json. It locks the tokens of this
+/// chain swapped to another chain and mints their mirrors filled from another chain, the owner is the relayer of the
+/// bridge.
+contract ERC1155SwapAgent is FillBatcher, IERC1155Receiver {
+    /// @dev registeredToken[dstChainId][tokenAddr] is set once a token of this chain is registered towards a chain
+    mapping(uint256 => mapping(address => bool)) public registeredToken;
+    /// @dev swapMappingIncoming[fromChainId][fromTokenAddr] is the mirror of a token of another chain
+    mapping(uint256 => mapping(address => address)) public swapMappingIncoming;
+    /// @dev swapMappingOutgoing[fromChainId][mirroredTokenAddr] is the token of another chain a mirror stands for
+    mapping(uint256 => mapping(address => address)) public swapMappingOutgoing;
+    mapping(bytes32 => bool) public filledSwap;
+
+    bool private _initialized;
+
+    event SwapPairRegister(address indexed sponsor, address indexed tokenAddress, uint256 toChainId, uint256 feeAmount);
+    event SwapPairCreated(
+        bytes32 indexed registerTxHash,
+        address indexed fromTokenAddr,
+        address indexed mirroredTokenAddr,
+        uint256 fromChainId
+    );
+    event SwapStarted(
+        address indexed tokenAddr,
+        address indexed sender,
+        address indexed recipient,
+        uint256 dstChainId,
+        uint256[] ids,
+        uint256[] amounts,
+        uint256 feeAmount
+    );
+    event BackwardSwapStarted(
+        address indexed mirroredTokenAddr,
+        address indexed sender,
+        address indexed recipient,
+        uint256 dstChainId,
+        uint256[] ids,
+        uint256[] amounts,
+        uint256 feeAmount
+    );
+    event SwapFilled(
+        bytes32 indexed swapTxHash,
+        address indexed fromTokenAddr,
+        address indexed recipient,
+        address mirroredTokenAddr,
+        uint256 fromChainId,
+        uint256[] ids,
+        uint256[] amounts
+    );
+    event BackwardSwapFilled(
+        bytes32 indexed swapTxHash,
+        address indexed tokenAddr,
+        address indexed recipient,
+        uint256 fromChainId,
+        uint256[] ids,
+        uint256[] amounts
+    );
+
+    /// @notice makes the caller the owner, it can be called once
+    function initialize() public {
+        require(!_initialized, "ERC1155SwapAgent: already initialized");
+
+        _initialized = true;
+        _setOwner(msg.sender);
+    }
+
+    function supportsInterface(bytes4 interfaceId) external view returns (bool) {
+        return interfaceId == type(IERC1155Receiver).interfaceId || interfaceId == type(IERC165).interfaceId;
+    }
+
+    function registerSwapPair(address tokenAddr, uint256 chainId) external payable {
+        require(!registeredToken[chainId][tokenAddr], "ERC1155SwapAgent: token is already registered");
+
+        registeredToken[chainId][tokenAddr] = true;
+        emit SwapPairRegister(msg.sender, tokenAddr, chainId, msg.value);
+    }
+
+    function createSwapPair(
+        bytes32 registerTxHash,
+        address fromTokenAddr,
+        uint256 fromChainId,
+        string calldata uri
+    ) external onlyOwner {
+        require(
+            swapMappingIncoming[fromChainId][fromTokenAddr] == address(0),
+            "ERC1155SwapAgent: mirrored token is already deployed"
+        );
+
+        MirroredERC1155 mirrored = new MirroredERC1155(uri);
+
+        swapMappingIncoming[fromChainId][fromTokenAddr] = address(mirrored);
+        swapMappingOutgoing[fromChainId][address(mirrored)] = fromTokenAddr;
+        emit SwapPairCreated(registerTxHash, fromTokenAddr, address(mirrored), fromChainId);
+    }
+
+    /// @notice locks registered tokens of this chain, or burns mirrored tokens to release them on their own chain
+    function swap(
+        address tokenAddr,
+        address recipient,
+        uint256[] calldata ids,
+        uint256[] calldata amounts,
+        uint256 dstChainId
+    ) external payable {
+        address dstTokenAddr = swapMappingOutgoing[dstChainId][tokenAddr];
+        if (dstTokenAddr != address(0)) {
+            IERC1155(tokenAddr).safeBatchTransferFrom(msg.sender, address(this), ids, amounts, "");
+            MirroredERC1155(tokenAddr).burnBatch(ids, amounts);
+            emit BackwardSwapStarted(tokenAddr, msg.sender, recipient, dstChainId, ids, amounts, msg.value);
+
+            return;
+        }
+
+        require(registeredToken[dstChainId][tokenAddr], "ERC1155SwapAgent: token is not registered");
+
+        IERC1155(tokenAddr).safeBatchTransferFrom(msg.sender, address(this), ids, amounts, "");
+        emit SwapStarted(tokenAddr, msg.sender, recipient, dstChainId, ids, amounts, msg.value);
+    }
+
+    /// @notice mints the mirrors of tokens swapped from another chain, or releases tokens of this chain swapped back
+    function fill(
+        bytes32 swapTxHash,
+        address fromTokenAddr,
+        address recipient,
+        uint256 fromChainId,
+        uint256[] calldata ids,
+        uint256[] calldata amounts
+    ) external onlyOwner {
+        require(!filledSwap[swapTxHash], "ERC1155SwapAgent: swap is already filled");
+        filledSwap[swapTxHash] = true;
+
+        address mirroredTokenAddr = swapMappingIncoming[fromChainId][fromTokenAddr];
+        if (mirroredTokenAddr != address(0)) {
+            MirroredERC1155(mirroredTokenAddr).mintBatch(recipient, ids, amounts, "");
+            emit SwapFilled(swapTxHash, fromTokenAddr, recipient, mirroredTokenAddr, fromChainId, ids, amounts);
+
+            return;
+        }
+
+        require(registeredToken[fromChainId][fromTokenAddr], "ERC1155SwapAgent: token is not registered");
+
+        IERC1155(fromTokenAddr).safeBatchTransferFrom(address(this), recipient, ids, amounts, "");
+        emit BackwardSwapFilled(swapTxHash, fromTokenAddr, recipient, fromChainId, ids, amounts);
+    }
+
+    function onERC1155Received(address, address, uint256, uint256, bytes calldata)
+        external
+        override
+        returns (bytes4)
+    {
+        return this.onERC1155Received.selector;
+    }
+
+    function onERC1155BatchReceived(address, address, uint256[] calldata, uint256[] calldata, bytes calldata)
+        external
+        override
+        returns (bytes4)
+    {
+        return this.onERC1155BatchReceived.selector;
+    }
+}
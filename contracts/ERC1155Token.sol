@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./lib/ERC1155.sol";
+import "./lib/Ownable.sol";
+
+/// @notice ERC1155 token of abi/ERC1155Token.json, the owner mints the tokens and sets their uri
+contract ERC1155Token is ERC1155, Ownable {
+    constructor(string memory uri) ERC1155(uri) {
+        _setOwner(msg.sender);
+    }
+
+    function setURI(string memory newuri) public onlyOwner {
+        _setURI(newuri);
+    }
+
+    function mint(address account, uint256 id, uint256 amount, bytes memory data) public onlyOwner {
+        _mint(account, id, amount, data);
+    }
+
+    function mintBatch(address to, uint256[] memory ids, uint256[] memory amounts, bytes memory data) public onlyOwner {
+        _mintBatch(to, ids, amounts, data);
+    }
+}
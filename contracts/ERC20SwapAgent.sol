@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./ERC20Token.sol";
+import "./lib/Interfaces.sol";
+import "./lib/FillBatcher.sol";
+
+/// @notice ERC20 token deployed by the swap agent for a token of another chain, only the agent mints and burns it
+contract MirroredERC20 is ERC20Token {
+    constructor(string memory name, string memory symbol, uint8 decimals) ERC20Token(name, symbol, decimals) {}
+
+    function burn(uint256 amount) external onlyOwner {
+        _burn(msg.sender, amount);
+    }
+}
+
+/// @notice ERC20 swap agent of abi/ERC20SwapAgent.json and abi/FillBatcher.json, modelled on the ERC721 and ERC1155
+/// agents. It locks the amounts of the tokens of this chain swapped to another chain and mints their mirrors filled
+/// from another chain, the owner is the relayer of the bridge.
+contract ERC20SwapAgent is FillBatcher {
+    /// @dev registeredToken[dstChainId][tokenAddr] is set once a token of this chain is registered towards a chain
+    mapping(uint256 => mapping(address => bool)) public registeredToken;
+    /// @dev swapMappingIncoming[fromChainId][fromTokenAddr] is the mirror of a token of another chain
+    mapping(uint256 => mapping(address => address)) public swapMappingIncoming;
+    /// @dev swapMappingOutgoing[fromChainId][mirroredTokenAddr] is the token of another chain a mirror stands for
+    mapping(uint256 => mapping(address => address)) public swapMappingOutgoing;
+    mapping(bytes32 => bool) public filledSwap;
+
+    bool private _initialized;
+
+    event SwapPairRegister(
+        address indexed sponsor,
+        address indexed tokenAddress,
+        string tokenName,
+        string tokenSymbol,
+        uint8 tokenDecimals,
+        uint256 toChainId,
+        uint256 feeAmount
+    );
+    event SwapPairCreated(
+        bytes32 indexed registerTxHash,
+        address indexed fromTokenAddr,
+        address indexed mirroredTokenAddr,
+        uint256 fromChainId,
+        string tokenSymbol,
+        string tokenName,
+        uint8 tokenDecimals
+    );
+    event SwapStarted(
+        address indexed tokenAddr,
+        address indexed sender,
+        address indexed recipient,
+        uint256 dstChainId,
+        uint256 amount,
+        uint256 feeAmount
+    );
+    event BackwardSwapStarted(
+        address indexed mirroredTokenAddr,
+        address indexed sender,
+        address indexed recipient,
+        uint256 dstChainId,
+        uint256 amount,
+        uint256 feeAmount
+    );
+    event SwapFilled(
+        bytes32 indexed swapTxHash,
+        address indexed fromTokenAddr,
+        address indexed recipient,
+        address mirroredTokenAddr,
+        uint256 fromChainId,
+        uint256 amount
+    );
+    event BackwardSwapFilled(
+        bytes32 indexed swapTxHash,
+        address indexed tokenAddr,
+        address indexed recipient,
+        uint256 fromChainId,
+        uint256 amount
+    );
+
+    /// @notice makes the caller the owner, it can be called once
+    function initialize() public {
+        require(!_initialized, "ERC20SwapAgent: already initialized");
+
+        _initialized = true;
+        _setOwner(msg.sender);
+    }
+
+    function registerSwapPair(address tokenAddr, uint256 chainId) external payable {
+        require(!registeredToken[chainId][tokenAddr], "ERC20SwapAgent: token is already registered");
+
+        registeredToken[chainId][tokenAddr] = true;
+        emit SwapPairRegister(
+            msg.sender,
+            tokenAddr,
+            IERC20Metadata(tokenAddr).name(),
+            IERC20Metadata(tokenAddr).symbol(),
+            IERC20Metadata(tokenAddr).decimals(),
+            chainId,
+            msg.value
+        );
+    }
+
+    function createSwapPair(
+        bytes32 registerTxHash,
+        address fromTokenAddr,
+        uint256 fromChainId,
+        string calldata tokenName,
+        string calldata tokenSymbol,
+        uint8 tokenDecimals
+    ) external onlyOwner {
+        require(
+            swapMappingIncoming[fromChainId][fromTokenAddr] == address(0),
+            "ERC20SwapAgent: mirrored token is already deployed"
+        );
+
+        MirroredERC20 mirrored = new MirroredERC20(tokenName, tokenSymbol, tokenDecimals);
+
+        swapMappingIncoming[fromChainId][fromTokenAddr] = address(mirrored);
+        swapMappingOutgoing[fromChainId][address(mirrored)] = fromTokenAddr;
+        emit SwapPairCreated(
+            registerTxHash,
+            fromTokenAddr,
+            address(mirrored),
+            fromChainId,
+            tokenSymbol,
+            tokenName,
+            tokenDecimals
+        );
+    }
+
+    /// @notice locks an amount of a registered token of this chain, or burns an amount of a mirrored token to release
+    /// it on its own chain
+    function swap(address tokenAddr, address recipient, uint256 amount, uint256 dstChainId) external payable {
+        require(amount > 0, "ERC20SwapAgent: amount is zero");
+
+        address dstTokenAddr = swapMappingOutgoing[dstChainId][tokenAddr];
+        if (dstTokenAddr != address(0)) {
+            require(IERC20(tokenAddr).transferFrom(msg.sender, address(this), amount), "ERC20SwapAgent: transfer failed");
+            MirroredERC20(tokenAddr).burn(amount);
+            emit BackwardSwapStarted(tokenAddr, msg.sender, recipient, dstChainId, amount, msg.value);
+
+            return;
+        }
+
+        require(registeredToken[dstChainId][tokenAddr], "ERC20SwapAgent: token is not registered");
+
+        require(IERC20(tokenAddr).transferFrom(msg.sender, address(this), amount), "ERC20SwapAgent: transfer failed");
+        emit SwapStarted(tokenAddr, msg.sender, recipient, dstChainId, amount, msg.value);
+    }
+
+    /// @notice mints the mirror of an amount swapped from another chain, or releases an amount of a token of this
+    /// chain swapped back
+    function fill(
+        bytes32 swapTxHash,
+        address fromTokenAddr,
+        address recipient,
+        uint256 fromChainId,
+        uint256 amount
+    ) external onlyOwner {
+        require(!filledSwap[swapTxHash], "ERC20SwapAgent: swap is already filled");
+        filledSwap[swapTxHash] = true;
+
+        address mirroredTokenAddr = swapMappingIncoming[fromChainId][fromTokenAddr];
+        if (mirroredTokenAddr != address(0)) {
+            MirroredERC20(mirroredTokenAddr).mint(recipient, amount);
+            emit SwapFilled(swapTxHash, fromTokenAddr, recipient, mirroredTokenAddr, fromChainId, amount);
+
+            return;
+        }
+
+        require(registeredToken[fromChainId][fromTokenAddr], "ERC20SwapAgent: token is not registered");
+
+        require(IERC20(fromTokenAddr).transfer(recipient, amount), "ERC20SwapAgent: transfer failed");
+        emit BackwardSwapFilled(swapTxHash, fromTokenAddr, recipient, fromChainId, amount);
+    }
+}
@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./lib/ERC20.sol";
+import "./lib/Ownable.sol";
+
+/// @notice ERC20 token of abi/ERC20Token.json, the owner mints the tokens
+contract ERC20Token is ERC20, Ownable {
+    constructor(string memory name, string memory symbol, uint8 decimals) ERC20(name, symbol, decimals) {
+        _setOwner(msg.sender);
+    }
+
+    function mint(address to, uint256 amount) public onlyOwner {
+        _mint(to, amount);
+    }
+}
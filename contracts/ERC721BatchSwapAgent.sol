@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./ERC721SwapAgent.sol";
+
+/// @notice ERC721 swap agent with the batch swaps of abi/ERC721BatchSwapAgent.json on top of the ones of
+/// abi/ERC721SwapAgent.json, an upgrade of the ERC721 agent as only the agent which deployed a mirrored token mints it.
+/// A batch swap is filled in chunks of its tokens, so a fill is keyed by the swap tx hash and the token id.
+contract ERC721BatchSwapAgent is ERC721SwapAgent {
+    /// @dev filledToken[swapTxHash][tokenId] is set once the token of a batch swap is filled
+    mapping(bytes32 => mapping(uint256 => bool)) public filledToken;
+
+    event BatchSwapStarted(
+        address indexed tokenAddr,
+        address indexed sender,
+        address indexed recipient,
+        uint256 dstChainId,
+        uint256[] tokenIds,
+        uint256 feeAmount
+    );
+    event BatchBackwardSwapStarted(
+        address indexed mirroredTokenAddr,
+        address indexed sender,
+        address indexed recipient,
+        uint256 dstChainId,
+        uint256[] tokenIds,
+        uint256 feeAmount
+    );
+    event BatchSwapFilled(
+        bytes32 indexed swapTxHash,
+        address indexed fromTokenAddr,
+        address indexed recipient,
+        address mirroredTokenAddr,
+        uint256 fromChainId,
+        uint256[] tokenIds
+    );
+    event BatchBackwardSwapFilled(
+        bytes32 indexed swapTxHash,
+        address indexed tokenAddr,
+        address indexed recipient,
+        uint256 fromChainId,
+        uint256[] tokenIds
+    );
+
+    /// @notice locks several registered tokens of this chain, or burns several mirrored tokens to release them on
+    /// their own chain
+    function batchSwap(address tokenAddr, address recipient, uint256[] calldata tokenIds, uint256 dstChainId)
+        external
+        payable
+    {
+        require(tokenIds.length > 0, "ERC721BatchSwapAgent: no token");
+
+        address dstTokenAddr = swapMappingOutgoing[dstChainId][tokenAddr];
+        if (dstTokenAddr != address(0)) {
+            for (uint256 i = 0; i < tokenIds.length; i++) {
+                IERC721(tokenAddr).safeTransferFrom(msg.sender, address(this), tokenIds[i]);
+                MirroredERC721(tokenAddr).burn(tokenIds[i]);
+            }
+            emit BatchBackwardSwapStarted(tokenAddr, msg.sender, recipient, dstChainId, tokenIds, msg.value);
+
+            return;
+        }
+
+        require(registeredToken[dstChainId][tokenAddr], "ERC721BatchSwapAgent: token is not registered");
+
+        for (uint256 i = 0; i < tokenIds.length; i++) {
+            IERC721(tokenAddr).safeTransferFrom(msg.sender, address(this), tokenIds[i]);
+        }
+        emit BatchSwapStarted(tokenAddr, msg.sender, recipient, dstChainId, tokenIds, msg.value);
+    }
+
+    /// @notice mints the mirrors of a chunk of the tokens of a batch swap from another chain, or releases a chunk of
+    /// the tokens of this chain swapped back. tokenURIs holds the uri of every token and is ignored backward, it reverts
+    /// when a token of the chunk is already filled.
+    function batchFill(
+        bytes32 swapTxHash,
+        address fromTokenAddr,
+        address recipient,
+        uint256 fromChainId,
+        uint256[] calldata tokenIds,
+        string[] calldata tokenURIs
+    ) external onlyOwner {
+        require(tokenIds.length > 0, "ERC721BatchSwapAgent: no token");
+        for (uint256 i = 0; i < tokenIds.length; i++) {
+            require(!filledToken[swapTxHash][tokenIds[i]], "ERC721BatchSwapAgent: token is already filled");
+            filledToken[swapTxHash][tokenIds[i]] = true;
+        }
+
+        address mirroredTokenAddr = swapMappingIncoming[fromChainId][fromTokenAddr];
+        if (mirroredTokenAddr != address(0)) {
+            require(tokenURIs.length == tokenIds.length, "ERC721BatchSwapAgent: token uris do not match token ids");
+            for (uint256 i = 0; i < tokenIds.length; i++) {
+                MirroredERC721(mirroredTokenAddr).safeMint(recipient, tokenIds[i]);
+                MirroredERC721(mirroredTokenAddr).setTokenURI(tokenIds[i], tokenURIs[i]);
+            }
+            emit BatchSwapFilled(swapTxHash, fromTokenAddr, recipient, mirroredTokenAddr, fromChainId, tokenIds);
+
+            return;
+        }
+
+        require(registeredToken[fromChainId][fromTokenAddr], "ERC721BatchSwapAgent: token is not registered");
+
+        for (uint256 i = 0; i < tokenIds.length; i++) {
+            IERC721(fromTokenAddr).safeTransferFrom(address(this), recipient, tokenIds[i]);
+        }
+        emit BatchBackwardSwapFilled(swapTxHash, fromTokenAddr, recipient, fromChainId, tokenIds);
+    }
+}
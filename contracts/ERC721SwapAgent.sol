@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./ERC721Token.sol";
+import "./lib/Interfaces.sol";
+import "./lib/FillBatcher.sol";
+
+/// @notice ERC721 token deployed by the swap agent for a token of another chain, only the agent mints and burns it
+contract MirroredERC721 is ERC721Token {
+    constructor(string memory name, string memory symbol) ERC721Token(name, symbol) {}
+
+    function burn(uint256 tokenId) external onlyOwner {
+        _burn(tokenId);
+    }
+}
+
+/// @notice ERC721 swap agent of abi/ERC721SwapAgent.json and abi/FillBatcher.json. It locks the tokens of this chain
+/// swapped to another chain and mints their mirrors filled from another chain, the owner is the relayer of the bridge.
+contract ERC721SwapAgent is FillBatcher, IERC721Receiver {
+    /// @dev registeredToken[dstChainId][tokenAddr] is set once a token of this chain is registered towards a chain
+    mapping(uint256 => mapping(address => bool)) public registeredToken;
+    /// @dev swapMappingIncoming[fromChainId][fromTokenAddr] is the mirror of a token of another chain
+    mapping(uint256 => mapping(address => address)) public swapMappingIncoming;
+    /// @dev swapMappingOutgoing[fromChainId][mirroredTokenAddr] is the token of another chain a mirror stands for
+    mapping(uint256 => mapping(address => address)) public swapMappingOutgoing;
+    mapping(bytes32 => bool) public filledSwap;
+
+    bool private _initialized;
+
+    event SwapPairRegister(
+        address indexed sponsor,
+        address indexed tokenAddress,
+        string tokenName,
+        string tokenSymbol,
+        uint256 toChainId,
+        uint256 feeAmount
+    );
+    event SwapPairCreated(
+        bytes32 indexed registerTxHash,
+        address indexed fromTokenAddr,
+        address indexed mirroredTokenAddr,
+        uint256 fromChainId,
+        string tokenSymbol,
+        string tokenName
+    );
+    event SwapStarted(
+        address indexed tokenAddr,
+        address indexed sender,
+        address indexed recipient,
+        uint256 dstChainId,
+        uint256 tokenId,
+        uint256 feeAmount
+    );
+    event BackwardSwapStarted(
+        address indexed mirroredTokenAddr,
+        address indexed sender,
+        address indexed recipient,
+        uint256 dstChainId,
+        uint256 tokenId,
+        uint256 feeAmount
+    );
+    event SwapFilled(
+        bytes32 indexed swapTxHash,
+        address indexed fromTokenAddr,
+        address indexed recipient,
+        address mirroredTokenAddr,
+        uint256 fromChainId,
+        uint256 tokenId
+    );
+    event BackwardSwapFilled(
+        bytes32 indexed swapTxHash,
+        address indexed tokenAddr,
+        address indexed recipient,
+        uint256 fromChainId,
+        uint256 tokenId
+    );
+
+    /// @notice makes the caller the owner, it can be called once
+    function initialize() public {
+        require(!_initialized, "ERC721SwapAgent: already initialized");
+
+        _initialized = true;
+        _setOwner(msg.sender);
+    }
+
+    function registerSwapPair(address tokenAddr, uint256 chainId) external payable {
+        require(!registeredToken[chainId][tokenAddr], "ERC721SwapAgent: token is already registered");
+
+        registeredToken[chainId][tokenAddr] = true;
+        emit SwapPairRegister(
+            msg.sender,
+            tokenAddr,
+            IERC721Metadata(tokenAddr).name(),
+            IERC721Metadata(tokenAddr).symbol(),
+            chainId,
+            msg.value
+        );
+    }
+
+    function createSwapPair(
+        bytes32 registerTxHash,
+        address fromTokenAddr,
+        uint256 fromChainId,
+        string calldata baseURI_,
+        string calldata tokenName,
+        string calldata tokenSymbol
+    ) external onlyOwner {
+        require(
+            swapMappingIncoming[fromChainId][fromTokenAddr] == address(0),
+            "ERC721SwapAgent: mirrored token is already deployed"
+        );
+
+        MirroredERC721 mirrored = new MirroredERC721(tokenName, tokenSymbol);
+        if (bytes(baseURI_).length > 0) {
+            mirrored.setBaseURI(baseURI_);
+        }
+
+        swapMappingIncoming[fromChainId][fromTokenAddr] = address(mirrored);
+        swapMappingOutgoing[fromChainId][address(mirrored)] = fromTokenAddr;
+        emit SwapPairCreated(registerTxHash, fromTokenAddr, address(mirrored), fromChainId, tokenSymbol, tokenName);
+    }
+
+    /// @notice locks a registered token of this chain, or burns a mirrored token to release it on its own chain
+    function swap(address tokenAddr, address recipient, uint256 tokenId, uint256 dstChainId) external payable {
+        address dstTokenAddr = swapMappingOutgoing[dstChainId][tokenAddr];
+        if (dstTokenAddr != address(0)) {
+            IERC721(tokenAddr).safeTransferFrom(msg.sender, address(this), tokenId);
+            MirroredERC721(tokenAddr).burn(tokenId);
+            emit BackwardSwapStarted(tokenAddr, msg.sender, recipient, dstChainId, tokenId, msg.value);
+
+            return;
+        }
+
+        require(registeredToken[dstChainId][tokenAddr], "ERC721SwapAgent: token is not registered");
+
+        IERC721(tokenAddr).safeTransferFrom(msg.sender, address(this), tokenId);
+        emit SwapStarted(tokenAddr, msg.sender, recipient, dstChainId, tokenId, msg.value);
+    }
+
+    /// @notice mints the mirror of a token swapped from another chain, or releases a token of this chain swapped back
+    function fill(
+        bytes32 swapTxHash,
+        address fromTokenAddr,
+        address recipient,
+        uint256 fromChainId,
+        uint256 tokenId,
+        string calldata tokenURI
+    ) external onlyOwner {
+        require(!filledSwap[swapTxHash], "ERC721SwapAgent: swap is already filled");
+        filledSwap[swapTxHash] = true;
+
+        address mirroredTokenAddr = swapMappingIncoming[fromChainId][fromTokenAddr];
+        if (mirroredTokenAddr != address(0)) {
+            MirroredERC721(mirroredTokenAddr).safeMint(recipient, tokenId);
+            MirroredERC721(mirroredTokenAddr).setTokenURI(tokenId, tokenURI);
+            emit SwapFilled(swapTxHash, fromTokenAddr, recipient, mirroredTokenAddr, fromChainId, tokenId);
+
+            return;
+        }
+
+        require(registeredToken[fromChainId][fromTokenAddr], "ERC721SwapAgent: token is not registered");
+
+        IERC721(fromTokenAddr).safeTransferFrom(address(this), recipient, tokenId);
+        emit BackwardSwapFilled(swapTxHash, fromTokenAddr, recipient, fromChainId, tokenId);
+    }
+
+    function onERC721Received(address, address, uint256, bytes calldata) external override returns (bytes4) {
+        return this.onERC721Received.selector;
+    }
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./lib/ERC721.sol";
+import "./lib/Ownable.sol";
+
+/// @notice ERC721 token of abi/ERC721Token.json, the owner mints the tokens and sets their uris
+contract ERC721Token is ERC721, Ownable {
+    constructor(string memory name, string memory symbol) ERC721(name, symbol) {
+        _setOwner(msg.sender);
+    }
+
+    function safeMint(address to, uint256 tokenId) public onlyOwner {
+        _safeMint(to, tokenId);
+    }
+
+    function setTokenURI(uint256 tokenId, string memory _tokenURI) public onlyOwner {
+        _setTokenURI(tokenId, _tokenURI);
+    }
+
+    function setBaseURI(string memory baseURI_) public onlyOwner {
+        _setBaseURI(baseURI_);
+    }
+}
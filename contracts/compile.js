@@ -0,0 +1,111 @@
+// Compiles the contracts with the emscripten build of solc and writes the creation bytecode of the ones deployed by
+// the simulated chain tests to testutil/simchain/testdata. The ABI of every contract with a bundled ABI in abi/ is
+// checked against it, so the contracts implement exactly what the bindings expect.
+//
+// usage: node contracts/compile.js <path of soljson-v0.8.21+commit.d9974bed.js>
+const fs = require('fs');
+const path = require('path');
+
+const root = path.join(__dirname, '..');
+const outDir = path.join(root, 'testutil', 'simchain', 'testdata');
+
+// contracts maps the contract name to its source file, bundled is the name of its ABI in abi/ or the names of the ABIs
+// it implements together
+const contracts = {
+  ERC721Token: { source: 'ERC721Token.sol', bundled: 'ERC721Token' },
+  ERC1155Token: { source: 'ERC1155Token.sol', bundled: 'ERC1155Token' },
+  ERC721SwapAgent: { source: 'ERC721SwapAgent.sol', bundled: ['ERC721SwapAgent', 'FillBatcher'] },
+  ERC1155SwapAgent: { source: 'ERC1155SwapAgent.sol', bundled: ['ERC1155SwapAgent', 'FillBatcher'] },
+  ERC20Token: { source: 'ERC20Token.sol', bundled: 'ERC20Token' },
+  ERC20SwapAgent: { source: 'ERC20SwapAgent.sol', bundled: ['ERC20SwapAgent', 'FillBatcher'] },
+  ERC721BatchSwapAgent: {
+    source: 'ERC721BatchSwapAgent.sol',
+    bundled: ['ERC721SwapAgent', 'ERC721BatchSwapAgent', 'FillBatcher'],
+  },
+};
+
+function readSources(dir, sources) {
+  for (const entry of fs.readdirSync(dir, { withFileTypes: true })) {
+    const file = path.join(dir, entry.name);
+    if (entry.isDirectory()) {
+      readSources(file, sources);
+    } else if (entry.name.endsWith('.sol')) {
+      sources[path.relative(__dirname, file)] = { content: fs.readFileSync(file, 'utf8') };
+    }
+  }
+
+  return sources;
+}
+
+function params(pp) {
+  return (pp || []).map((p) => `${p.type}${p.indexed ? ' indexed' : ''} ${p.name}`).join(', ');
+}
+
+// signatures describes every entry of an ABI including names, so that the generated bindings are the same
+function signatures(abi) {
+  return abi
+    .map((e) => {
+      const outputs = (e.outputs || []).map((o) => o.type).join(', ');
+      return `${e.type} ${e.name || ''}(${params(e.inputs)}) ${e.stateMutability || ''} ${e.anonymous ? 'anonymous' : ''} -> (${outputs})`;
+    })
+    .sort();
+}
+
+function main() {
+  if (process.argv.length < 3) {
+    console.error('usage: node contracts/compile.js <soljson path>');
+    process.exit(2);
+  }
+
+  const soljson = require(path.resolve(process.argv[2]));
+  const compile = soljson.cwrap('solidity_compile', 'string', ['string', 'number', 'number']);
+  const input = {
+    language: 'Solidity',
+    sources: readSources(__dirname, {}),
+    settings: {
+      // go-ethereum v1.10.10 of the simulated backend runs london
+      evmVersion: 'london',
+      optimizer: { enabled: true, runs: 200 },
+      outputSelection: { '*': { '*': ['abi', 'evm.bytecode.object'] } },
+    },
+  };
+
+  const output = JSON.parse(compile(JSON.stringify(input), 0, 0));
+  const errors = (output.errors || []).filter((e) => e.severity === 'error');
+  for (const e of output.errors || []) {
+    console.error(e.formattedMessage);
+  }
+  if (errors.length > 0) {
+    process.exit(1);
+  }
+
+  fs.mkdirSync(outDir, { recursive: true });
+  let failed = false;
+  for (const [name, c] of Object.entries(contracts)) {
+    const compiled = output.contracts[c.source][name];
+    if (c.bundled) {
+      const names = [].concat(c.bundled);
+      const want = [];
+      for (const n of names) {
+        const bundled = JSON.parse(fs.readFileSync(path.join(root, 'abi', `${n}.json`), 'utf8'));
+        want.push(...signatures(bundled).filter((s) => !want.includes(s)));
+      }
+      const got = signatures(compiled.abi);
+      const missing = want.filter((s) => !got.includes(s));
+      const extra = got.filter((s) => !want.includes(s));
+      if (missing.length > 0 || extra.length > 0) {
+        failed = true;
+        console.error(`${name} does not match ${names.map((n) => `abi/${n}.json`).join(' + ')}`);
+        missing.forEach((s) => console.error(`  missing: ${s}`));
+        extra.forEach((s) => console.error(`  extra:   ${s}`));
+      }
+    }
+
+    fs.writeFileSync(path.join(outDir, `${name}.bin`), compiled.evm.bytecode.object + '\n');
+  }
+  if (failed) {
+    process.exit(1);
+  }
+}
+
+main();
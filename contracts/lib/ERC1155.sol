@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./Interfaces.sol";
+
+/// @notice ERC1155 with a single uri shared by every token id
+abstract contract ERC1155 is IERC165 {
+    mapping(uint256 => mapping(address => uint256)) private _balances;
+    mapping(address => mapping(address => bool)) private _operatorApprovals;
+    string private _uri;
+
+    event TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value);
+    event TransferBatch(
+        address indexed operator,
+        address indexed from,
+        address indexed to,
+        uint256[] ids,
+        uint256[] values
+    );
+    event ApprovalForAll(address indexed account, address indexed operator, bool approved);
+    event URI(string value, uint256 indexed id);
+
+    constructor(string memory uri_) {
+        _uri = uri_;
+    }
+
+    function supportsInterface(bytes4 interfaceId) public view virtual override returns (bool) {
+        return
+            interfaceId == 0xd9b67a26 || // ERC1155
+            interfaceId == 0x0e89341c || // ERC1155MetadataURI
+            interfaceId == type(IERC165).interfaceId;
+    }
+
+    function uri(uint256) public view returns (string memory) {
+        return _uri;
+    }
+
+    function balanceOf(address account, uint256 id) public view returns (uint256) {
+        require(account != address(0), "ERC1155: balance query for the zero address");
+        return _balances[id][account];
+    }
+
+    function balanceOfBatch(address[] memory accounts, uint256[] memory ids) public view returns (uint256[] memory) {
+        require(accounts.length == ids.length, "ERC1155: accounts and ids length mismatch");
+
+        uint256[] memory batchBalances = new uint256[](accounts.length);
+        for (uint256 i = 0; i < accounts.length; ++i) {
+            batchBalances[i] = balanceOf(accounts[i], ids[i]);
+        }
+
+        return batchBalances;
+    }
+
+    function setApprovalForAll(address operator, bool approved) public {
+        require(msg.sender != operator, "ERC1155: setting approval status for self");
+
+        _operatorApprovals[msg.sender][operator] = approved;
+        emit ApprovalForAll(msg.sender, operator, approved);
+    }
+
+    function isApprovedForAll(address account, address operator) public view returns (bool) {
+        return _operatorApprovals[account][operator];
+    }
+
+    function safeTransferFrom(address from, address to, uint256 id, uint256 amount, bytes memory data) public {
+        require(from == msg.sender || isApprovedForAll(from, msg.sender), "ERC1155: caller is not owner nor approved");
+        require(to != address(0), "ERC1155: transfer to the zero address");
+
+        _move(from, to, id, amount);
+        emit TransferSingle(msg.sender, from, to, id, amount);
+        _checkOnERC1155Received(from, to, id, amount, data);
+    }
+
+    function safeBatchTransferFrom(
+        address from,
+        address to,
+        uint256[] memory ids,
+        uint256[] memory amounts,
+        bytes memory data
+    ) public {
+        require(from == msg.sender || isApprovedForAll(from, msg.sender), "ERC1155: caller is not owner nor approved");
+        require(ids.length == amounts.length, "ERC1155: ids and amounts length mismatch");
+        require(to != address(0), "ERC1155: transfer to the zero address");
+
+        for (uint256 i = 0; i < ids.length; ++i) {
+            _move(from, to, ids[i], amounts[i]);
+        }
+        emit TransferBatch(msg.sender, from, to, ids, amounts);
+        _checkOnERC1155BatchReceived(from, to, ids, amounts, data);
+    }
+
+    function _setURI(string memory newuri) internal {
+        _uri = newuri;
+    }
+
+    function _mint(address to, uint256 id, uint256 amount, bytes memory data) internal {
+        require(to != address(0), "ERC1155: mint to the zero address");
+
+        _balances[id][to] += amount;
+        emit TransferSingle(msg.sender, address(0), to, id, amount);
+        _checkOnERC1155Received(address(0), to, id, amount, data);
+    }
+
+    function _mintBatch(address to, uint256[] memory ids, uint256[] memory amounts, bytes memory data) internal {
+        require(to != address(0), "ERC1155: mint to the zero address");
+        require(ids.length == amounts.length, "ERC1155: ids and amounts length mismatch");
+
+        for (uint256 i = 0; i < ids.length; i++) {
+            _balances[ids[i]][to] += amounts[i];
+        }
+        emit TransferBatch(msg.sender, address(0), to, ids, amounts);
+        _checkOnERC1155BatchReceived(address(0), to, ids, amounts, data);
+    }
+
+    function _burnBatch(address from, uint256[] memory ids, uint256[] memory amounts) internal {
+        require(ids.length == amounts.length, "ERC1155: ids and amounts length mismatch");
+
+        for (uint256 i = 0; i < ids.length; i++) {
+            uint256 balance = _balances[ids[i]][from];
+            require(balance >= amounts[i], "ERC1155: burn amount exceeds balance");
+            _balances[ids[i]][from] = balance - amounts[i];
+        }
+        emit TransferBatch(msg.sender, from, address(0), ids, amounts);
+    }
+
+    function _move(address from, address to, uint256 id, uint256 amount) private {
+        uint256 balance = _balances[id][from];
+        require(balance >= amount, "ERC1155: insufficient balance for transfer");
+
+        _balances[id][from] = balance - amount;
+        _balances[id][to] += amount;
+    }
+
+    function _checkOnERC1155Received(address from, address to, uint256 id, uint256 amount, bytes memory data)
+        private
+    {
+        if (to.code.length == 0) {
+            return;
+        }
+
+        bytes4 retval = IERC1155Receiver(to).onERC1155Received(msg.sender, from, id, amount, data);
+        require(retval == IERC1155Receiver.onERC1155Received.selector, "ERC1155: ERC1155Receiver rejected tokens");
+    }
+
+    function _checkOnERC1155BatchReceived(
+        address from,
+        address to,
+        uint256[] memory ids,
+        uint256[] memory amounts,
+        bytes memory data
+    ) private {
+        if (to.code.length == 0) {
+            return;
+        }
+
+        bytes4 retval = IERC1155Receiver(to).onERC1155BatchReceived(msg.sender, from, ids, amounts, data);
+        require(
+            retval == IERC1155Receiver.onERC1155BatchReceived.selector,
+            "ERC1155: ERC1155Receiver rejected tokens"
+        );
+    }
+}
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./Ownable.sol";
+
+/// @notice Implements abi/FillBatcher.json, it lets the owner of a swap agent send several fills in a single tx. Every
+/// fill is a call of the agent to itself, so it runs as sent by the owner and emits its events from the agent, and a
+/// reverting fill does not revert the others.
+abstract contract FillBatcher is Ownable {
+    /// @notice runs every call of fills on this agent, filled tells which ones did not revert
+    function fillBatch(bytes[] calldata fills) external onlyOwner returns (bool[] memory filled) {
+        filled = new bool[](fills.length);
+        for (uint256 i = 0; i < fills.length; i++) {
+            (filled[i], ) = address(this).delegatecall(fills[i]);
+        }
+    }
+}
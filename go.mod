@@ -6,18 +6,20 @@ require (
 	github.com/aws/aws-sdk-go v1.41.9
 	github.com/btcsuite/btcd v0.22.0-beta // indirect
 	github.com/ethereum/go-ethereum v1.10.10
-	github.com/oklog/ulid/v2 v2.0.2 // indirect
+	github.com/nats-io/nats.go v1.13.0
+	github.com/oklog/ulid/v2 v2.0.2
 	github.com/op/go-logging v0.0.0-20160315200505-970db520ece7
 	github.com/pkg/errors v0.9.1
+	github.com/segmentio/kafka-go v0.4.25
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.9.0
 	github.com/tendermint/tendermint v0.34.14
-	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519 // indirect
-	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c // indirect
+	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
 	golang.org/x/sys v0.0.0-20211023085530-d6a326fbbf70 // indirect
-	golang.org/x/text v0.3.7 // indirect
 	gopkg.in/natefinch/lumberjack.v2 v2.0.0
-	gorm.io/datatypes v1.0.3 // indirect
-	gorm.io/driver/mysql v1.1.3 // indirect
-	gorm.io/gorm v1.22.2 // indirect
+	gorm.io/datatypes v1.0.3
+	gorm.io/driver/mysql v1.1.3
+	gorm.io/driver/postgres v1.2.2
+	gorm.io/driver/sqlite v1.2.4
+	gorm.io/gorm v1.22.2
 )
@@ -0,0 +1,198 @@
+package head
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/client"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/common"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+var (
+	// ErrUnknown is returned until the chain head is polled or a block is recorded
+	ErrUnknown = errors.New("head is unknown")
+	// ErrStale is returned when neither the chain head nor the recorded block moved for too long
+	ErrStale = errors.New("head is stale")
+)
+
+type Config struct {
+	ChainID string
+	// PollInterval is the delay between two polls of the chain head
+	PollInterval time.Duration
+	// StaleAfter is how long the head may stay still before it is stale
+	StaleAfter time.Duration
+}
+
+type Dependencies struct {
+	Client client.ETHClient
+}
+
+// Head is the chain head and the highest recorded block of a chain at some point in time
+type Head struct {
+	// ChainHeight is the latest block number of the chain, 0 until it is polled
+	ChainHeight    int64
+	ChainUpdatedAt time.Time
+	// Recorded is the block last fetched by the observer or its follower, nil until there is one
+	Recorded   *common.Block
+	RecordedAt time.Time
+}
+
+// Height returns the highest height known to be on the chain
+func (h *Head) Height() int64 {
+	height := h.ChainHeight
+	if h.Recorded != nil && h.Recorded.Height > height {
+		height = h.Recorded.Height
+	}
+
+	return height
+}
+
+// UpdatedAt returns when the head last moved
+func (h *Head) UpdatedAt() time.Time {
+	if h.RecordedAt.After(h.ChainUpdatedAt) {
+		return h.RecordedAt
+	}
+
+	return h.ChainUpdatedAt
+}
+
+// Tracker follows the head of a chain. It is updated by its own poll of the chain head and by the recorder, and
+// read concurrently by the engines.
+type Tracker struct {
+	conf *Config
+	deps *Dependencies
+
+	mutex sync.RWMutex
+	head  Head
+	subs  []chan Head
+
+	wg sync.WaitGroup
+}
+
+// NewTracker returns the head tracker instance of a chain
+func NewTracker(c *Config, d *Dependencies) *Tracker {
+	return &Tracker{
+		conf: c,
+		deps: d,
+	}
+}
+
+// Start starts polling the chain head, it stops once ctx is done
+func (t *Tracker) Start(ctx context.Context) {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+
+		for {
+			t.poll(ctx)
+
+			if !util.Sleep(ctx, t.conf.PollInterval) {
+				return
+			}
+		}
+	}()
+}
+
+// Wait blocks until the routine started by Start has returned
+func (t *Tracker) Wait() {
+	t.wg.Wait()
+}
+
+func (t *Tracker) poll(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	header, err := t.deps.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		util.Logger.Debugf("[Tracker.poll]: failed to get the head of chain id %s, err=%s", t.conf.ChainID, err.Error())
+		return
+	}
+
+	t.update(func(h *Head) bool {
+		height := header.Number.Int64()
+		if height <= h.ChainHeight {
+			return false
+		}
+
+		h.ChainHeight = height
+		h.ChainUpdatedAt = time.Now()
+		return true
+	})
+}
+
+// SetRecorded sets the block last fetched by the observer, which may be lower than the previous one after a reorg
+func (t *Tracker) SetRecorded(b *common.Block) {
+	t.update(func(h *Head) bool {
+		recorded := *b
+		h.Recorded = &recorded
+		h.RecordedAt = time.Now()
+		return true
+	})
+}
+
+func (t *Tracker) update(fn func(h *Head) bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !fn(&t.head) {
+		return
+	}
+
+	for _, c := range t.subs {
+		// replace the pending head that was not received yet, subscribers only care about the latest
+		select {
+		case <-c:
+		default:
+		}
+		c <- t.copyHead()
+	}
+}
+
+// copyHead returns a copy of the head, the caller holds the mutex
+func (t *Tracker) copyHead() Head {
+	h := t.head
+	if h.Recorded != nil {
+		recorded := *h.Recorded
+		h.Recorded = &recorded
+	}
+
+	return h
+}
+
+// Head returns a copy of the head, whatever its age
+func (t *Tracker) Head() Head {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.copyHead()
+}
+
+// Current returns a copy of the head, or ErrUnknown or ErrStale when it can not be relied on
+func (t *Tracker) Current() (Head, error) {
+	h := t.Head()
+	if h.ChainHeight == 0 && h.Recorded == nil {
+		return h, ErrUnknown
+	}
+	if time.Since(h.UpdatedAt()) > t.conf.StaleAfter {
+		return h, errors.Wrapf(ErrStale, "chain id %s did not move since %s", t.conf.ChainID, h.UpdatedAt().Format(time.RFC3339))
+	}
+
+	return h, nil
+}
+
+// Subscribe returns a channel receiving the head every time it moves. A subscriber that lags behind only receives
+// the latest head.
+func (t *Tracker) Subscribe() <-chan Head {
+	c := make(chan Head, 1)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.subs = append(t.subs, c)
+
+	return c
+}
@@ -0,0 +1,185 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type EngineLoopStatus struct {
+	Engine         string    `json:"engine"`
+	Loop           string    `json:"loop"`
+	LastCycleAt    time.Time `json:"last_cycle_at"`
+	SecondsSince   int64     `json:"seconds_since"`
+	CycleCompleted bool      `json:"cycle_completed"`
+}
+
+type ChainStatus struct {
+	ChainID             string             `json:"chain_id"`
+	ChainName           string             `json:"chain_name"`
+	Healthy             bool               `json:"healthy"`
+	Ready               bool               `json:"ready"`
+	Critical            bool               `json:"critical"`
+	RPCReachable        bool               `json:"rpc_reachable"`
+	ChainHead           int64              `json:"chain_head"`
+	RecordedHeight      int64              `json:"recorded_height"`
+	ObserverLag         int64              `json:"observer_lag"`
+	SecondsSinceLastLog int64              `json:"seconds_since_last_block_log"`
+	EngineLoops         []EngineLoopStatus `json:"engine_loops"`
+	Problems            []string           `json:"problems,omitempty"`
+}
+
+type Report struct {
+	Healthy     bool           `json:"healthy"`
+	Ready       bool           `json:"ready"`
+	DBReachable bool           `json:"db_reachable"`
+	Problems    []string       `json:"problems,omitempty"`
+	Chains      []*ChainStatus `json:"chains"`
+	CheckedAt   time.Time      `json:"checked_at"`
+}
+
+// Check collects the status of the database and every configured chain.
+// The service is healthy when the database is reachable and no critical chain is degraded,
+// and ready when it is healthy and every chain has recorded a block and completed an engine cycle.
+func (c *Checker) Check(ctx context.Context) *Report {
+	r := Report{
+		Healthy:   true,
+		Ready:     true,
+		CheckedAt: time.Now(),
+	}
+
+	if err := c.checkDB(ctx); err != nil {
+		r.DBReachable = false
+		r.Healthy = false
+		r.Problems = append(r.Problems, fmt.Sprintf("db is unreachable: %s", err.Error()))
+	} else {
+		r.DBReachable = true
+	}
+
+	for _, cc := range c.conf.Chains {
+		s := c.checkChain(ctx, cc)
+		if !s.Healthy && s.Critical {
+			r.Healthy = false
+			r.Problems = append(r.Problems, fmt.Sprintf("critical chain %s is degraded", s.ChainID))
+		}
+		if !s.Ready {
+			r.Ready = false
+		}
+
+		r.Chains = append(r.Chains, s)
+	}
+
+	r.Ready = r.Ready && r.Healthy
+
+	return &r
+}
+
+func (c *Checker) checkDB(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.conf.CheckTimeout)
+	defer cancel()
+
+	sqlDB, err := c.deps.DB.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
+
+func (c *Checker) checkChain(ctx context.Context, cc ChainConfig) *ChainStatus {
+	s := ChainStatus{
+		ChainID:   cc.ChainID,
+		ChainName: cc.ChainName,
+		Healthy:   true,
+		Ready:     true,
+		Critical:  cc.Critical,
+	}
+
+	deps, ok := c.deps.Chains[cc.ChainID]
+	if !ok {
+		s.degrade("no dependencies are registered")
+		s.Ready = false
+
+		return &s
+	}
+
+	hctx, cancel := context.WithTimeout(ctx, c.conf.CheckTimeout)
+	defer cancel()
+
+	header, err := deps.Client.HeaderByNumber(hctx, nil)
+	if err != nil {
+		s.degrade(fmt.Sprintf("rpc is unreachable: %s", err.Error()))
+	} else {
+		s.RPCReachable = true
+		s.ChainHead = header.Number.Int64()
+	}
+
+	blockLog, err := deps.Observer.GetCurrentBlockLog()
+	if err != nil {
+		s.degrade(fmt.Sprintf("failed to get current block log: %s", err.Error()))
+	} else if blockLog.Height == 0 {
+		s.Ready = false
+		s.Problems = append(s.Problems, "no block has been recorded yet")
+	} else {
+		s.RecordedHeight = blockLog.Height
+		s.SecondsSinceLastLog = int64(time.Since(blockLog.CreateTime).Seconds())
+		if time.Since(blockLog.CreateTime) > cc.BlockUpdateTimeout {
+			s.degrade(fmt.Sprintf("last block log was written %d seconds ago", s.SecondsSinceLastLog))
+		}
+	}
+
+	if s.RPCReachable && s.RecordedHeight > 0 {
+		s.ObserverLag = s.ChainHead - s.RecordedHeight
+		if c.conf.MaxObserverLag > 0 && s.ObserverLag > c.conf.MaxObserverLag {
+			s.degrade(fmt.Sprintf("observer is %d blocks behind the chain head", s.ObserverLag))
+		}
+	}
+
+	engineNames := make([]string, 0, len(deps.Engines))
+	for name := range deps.Engines {
+		engineNames = append(engineNames, name)
+	}
+	sort.Strings(engineNames)
+
+	for _, name := range engineNames {
+		cycles := deps.Engines[name].LastCycles()
+		if len(cycles) == 0 {
+			s.Ready = false
+			s.Problems = append(s.Problems, fmt.Sprintf("engine %s has not completed a cycle yet", name))
+
+			continue
+		}
+
+		loops := make([]string, 0, len(cycles))
+		for loop := range cycles {
+			loops = append(loops, loop)
+		}
+		sort.Strings(loops)
+
+		for _, loop := range loops {
+			last := cycles[loop]
+			ls := EngineLoopStatus{
+				Engine:         name,
+				Loop:           loop,
+				LastCycleAt:    last,
+				SecondsSince:   int64(time.Since(last).Seconds()),
+				CycleCompleted: true,
+			}
+			if time.Since(last) > c.conf.EngineCycleTimeout {
+				s.degrade(fmt.Sprintf("engine %s loop %s has not completed a cycle for %d seconds", name, loop, ls.SecondsSince))
+			}
+
+			s.EngineLoops = append(s.EngineLoops, ls)
+		}
+	}
+
+	s.Ready = s.Ready && s.Healthy
+
+	return &s
+}
+
+func (s *ChainStatus) degrade(problem string) {
+	s.Healthy = false
+	s.Problems = append(s.Problems, problem)
+}
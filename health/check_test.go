@@ -0,0 +1,339 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/client"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/health"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/testutil"
+)
+
+// fakeClient answers HeaderByNumber only, the checker calls nothing else
+type fakeClient struct {
+	client.ETHClient
+
+	head int64
+	err  error
+}
+
+func (c *fakeClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &types.Header{Number: big.NewInt(c.head)}, nil
+}
+
+type fakeObserver struct {
+	log *block.Log
+	err error
+}
+
+func (o *fakeObserver) GetCurrentBlockLog() (*block.Log, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+
+	return o.log, nil
+}
+
+type fakeEngine map[string]time.Time
+
+func (e fakeEngine) LastCycles() map[string]time.Time {
+	return e
+}
+
+type chainCase struct {
+	head      int64
+	rpcErr    error
+	recorded  int64
+	loggedAgo time.Duration
+	logErr    error
+	engines   map[string]health.CycleReporter
+	critical  bool
+}
+
+// healthyChain returns a chain 2 blocks behind its head whose engine completed a cycle a second ago
+func healthyChain() chainCase {
+	return chainCase{
+		head:      102,
+		recorded:  100,
+		loggedAgo: time.Second,
+		engines: map[string]health.CycleReporter{
+			"swap-engine": fakeEngine{"erc721": time.Now().Add(-time.Second)},
+		},
+	}
+}
+
+func newChecker(t *testing.T, chains map[string]chainCase) *health.Checker {
+	t.Helper()
+
+	conf := &health.Config{
+		MaxObserverLag:     10,
+		EngineCycleTimeout: time.Minute,
+		CheckTimeout:       time.Second,
+	}
+	deps := &health.Dependencies{
+		DB:     testutil.NewDB(),
+		Chains: make(map[string]*health.ChainDependencies),
+	}
+	for id, cc := range chains {
+		conf.Chains = append(conf.Chains, health.ChainConfig{
+			ChainID:            id,
+			ChainName:          "chain-" + id,
+			BlockUpdateTimeout: time.Minute,
+			Critical:           cc.critical,
+		})
+		deps.Chains[id] = &health.ChainDependencies{
+			Client: &fakeClient{head: cc.head, err: cc.rpcErr},
+			Observer: &fakeObserver{
+				log: &block.Log{Height: cc.recorded, CreateTime: time.Now().Add(-cc.loggedAgo)},
+				err: cc.logErr,
+			},
+			Engines: cc.engines,
+		}
+	}
+
+	return health.NewChecker(conf, deps)
+}
+
+func hasProblem(problems []string, substr string) bool {
+	for _, p := range problems {
+		if strings.Contains(p, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestCheckChain(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cc *chainCase)
+		healthy bool
+		ready   bool
+		problem string
+		lag     int64
+	}{
+		{
+			name:    "healthy",
+			modify:  func(cc *chainCase) {},
+			healthy: true,
+			ready:   true,
+			lag:     2,
+		},
+		{
+			name:    "rpc unreachable",
+			modify:  func(cc *chainCase) { cc.rpcErr = errors.New("connection refused") },
+			problem: "rpc is unreachable",
+		},
+		{
+			name:    "observer lag over the limit",
+			modify:  func(cc *chainCase) { cc.head = 111 },
+			problem: "observer is 11 blocks behind",
+			lag:     11,
+		},
+		{
+			name:    "observer lag at the limit",
+			modify:  func(cc *chainCase) { cc.head = 110 },
+			healthy: true,
+			ready:   true,
+			lag:     10,
+		},
+		{
+			name:    "stale block log",
+			modify:  func(cc *chainCase) { cc.loggedAgo = 2 * time.Minute },
+			problem: "last block log was written",
+			lag:     2,
+		},
+		{
+			name:    "block log error",
+			modify:  func(cc *chainCase) { cc.logErr = errors.New("db is closed") },
+			problem: "failed to get current block log",
+		},
+		{
+			name:    "no block recorded",
+			modify:  func(cc *chainCase) { cc.recorded = 0 },
+			healthy: true,
+			problem: "no block has been recorded yet",
+		},
+		{
+			name: "engine cycle timed out",
+			modify: func(cc *chainCase) {
+				cc.engines = map[string]health.CycleReporter{
+					"swap-engine": fakeEngine{"erc721": time.Now().Add(-2 * time.Minute)},
+				}
+			},
+			problem: "engine swap-engine loop erc721 has not completed a cycle",
+			lag:     2,
+		},
+		{
+			name: "engine without a cycle",
+			modify: func(cc *chainCase) {
+				cc.engines = map[string]health.CycleReporter{"swap-engine": fakeEngine{}}
+			},
+			healthy: true,
+			problem: "engine swap-engine has not completed a cycle yet",
+			lag:     2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := healthyChain()
+			tt.modify(&cc)
+			report := newChecker(t, map[string]chainCase{"97": cc}).Check(context.Background())
+
+			if len(report.Chains) != 1 {
+				t.Fatalf("expected 1 chain status, got %d", len(report.Chains))
+			}
+			s := report.Chains[0]
+			if s.Healthy != tt.healthy || s.Ready != tt.ready {
+				t.Errorf("expected healthy=%t ready=%t, got healthy=%t ready=%t, problems %v", tt.healthy, tt.ready, s.Healthy, s.Ready, s.Problems)
+			}
+			if tt.problem != "" && !hasProblem(s.Problems, tt.problem) {
+				t.Errorf("expected a problem containing %q, got %v", tt.problem, s.Problems)
+			}
+			if tt.problem == "" && len(s.Problems) != 0 {
+				t.Errorf("expected no problem, got %v", s.Problems)
+			}
+			if s.ObserverLag != tt.lag {
+				t.Errorf("expected observer lag %d, got %d", tt.lag, s.ObserverLag)
+			}
+			// a chain which is not critical never marks the service unhealthy
+			if !report.Healthy {
+				t.Errorf("expected the service to be healthy, problems %v", report.Problems)
+			}
+		})
+	}
+}
+
+func TestCheckCriticalChain(t *testing.T) {
+	tests := []struct {
+		name     string
+		critical bool
+		degraded bool
+		healthy  bool
+		ready    bool
+	}{
+		{name: "critical chain healthy", critical: true, healthy: true, ready: true},
+		{name: "critical chain degraded", critical: true, degraded: true},
+		{name: "other chain degraded", degraded: true, healthy: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			degraded := healthyChain()
+			degraded.critical = tt.critical
+			if tt.degraded {
+				degraded.rpcErr = errors.New("connection refused")
+			}
+
+			report := newChecker(t, map[string]chainCase{
+				"97": healthyChain(),
+				"4":  degraded,
+			}).Check(context.Background())
+
+			if report.Healthy != tt.healthy || report.Ready != tt.ready {
+				t.Errorf("expected healthy=%t ready=%t, got healthy=%t ready=%t, problems %v", tt.healthy, tt.ready, report.Healthy, report.Ready, report.Problems)
+			}
+			if wantProblem := tt.critical && tt.degraded; hasProblem(report.Problems, "critical chain 4 is degraded") != wantProblem {
+				t.Errorf("expected critical chain problem %t, got %v", wantProblem, report.Problems)
+			}
+		})
+	}
+}
+
+func TestCheckChainWithoutDependencies(t *testing.T) {
+	checker := health.NewChecker(&health.Config{
+		CheckTimeout: time.Second,
+		Chains:       []health.ChainConfig{{ChainID: "97", Critical: true}},
+	}, &health.Dependencies{
+		DB:     testutil.NewDB(),
+		Chains: map[string]*health.ChainDependencies{},
+	})
+
+	report := checker.Check(context.Background())
+	if report.Healthy || report.Ready {
+		t.Errorf("expected unhealthy and not ready, got healthy=%t ready=%t", report.Healthy, report.Ready)
+	}
+	if !hasProblem(report.Chains[0].Problems, "no dependencies are registered") {
+		t.Errorf("unexpected problems %v", report.Chains[0].Problems)
+	}
+}
+
+func TestCheckUnreachableDB(t *testing.T) {
+	db := testutil.NewDB()
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sqlDB.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	checker := health.NewChecker(&health.Config{CheckTimeout: time.Second}, &health.Dependencies{
+		DB:     db,
+		Chains: map[string]*health.ChainDependencies{},
+	})
+
+	report := checker.Check(context.Background())
+	if report.DBReachable || report.Healthy || report.Ready {
+		t.Errorf("expected an unreachable db to fail the check, got %+v", report)
+	}
+}
+
+func TestLivenessAndReadinessEndpoints(t *testing.T) {
+	notReady := healthyChain()
+	notReady.recorded = 0
+
+	tests := []struct {
+		name      string
+		chain     chainCase
+		path      string
+		wantCode  int
+		wantReady bool
+	}{
+		{name: "healthz ok", chain: healthyChain(), path: "/healthz", wantCode: http.StatusOK, wantReady: true},
+		{name: "readyz ok", chain: healthyChain(), path: "/readyz", wantCode: http.StatusOK, wantReady: true},
+		{name: "healthz while not ready", chain: notReady, path: "/healthz", wantCode: http.StatusOK},
+		{name: "readyz while not ready", chain: notReady, path: "/readyz", wantCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := newChecker(t, map[string]chainCase{"97": tt.chain})
+			srv := httptest.NewServer(checker.Handler())
+			defer srv.Close()
+
+			res, err := http.Get(srv.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != tt.wantCode {
+				t.Errorf("expected status %d, got %d", tt.wantCode, res.StatusCode)
+			}
+			var report health.Report
+			if err := json.NewDecoder(res.Body).Decode(&report); err != nil {
+				t.Fatalf("failed to decode report: %v", err)
+			}
+			if report.Ready != tt.wantReady {
+				t.Errorf("expected ready=%t, got %t", tt.wantReady, report.Ready)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package health
+
+import (
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/client"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+)
+
+// BlockLogGetter returns the highest block log recorded by an observer
+type BlockLogGetter interface {
+	GetCurrentBlockLog() (*block.Log, error)
+}
+
+// CycleReporter returns the time each engine loop last completed a cycle
+type CycleReporter interface {
+	LastCycles() map[string]time.Time
+}
+
+type ChainConfig struct {
+	ChainID            string
+	ChainName          string
+	BlockUpdateTimeout time.Duration
+	// Critical marks the whole service unhealthy when this chain is degraded
+	Critical bool
+}
+
+type Config struct {
+	ListenAddr         string
+	MaxObserverLag     int64
+	EngineCycleTimeout time.Duration
+	CheckTimeout       time.Duration
+	Chains             []ChainConfig
+}
+
+type ChainDependencies struct {
+	Client   client.ETHClient
+	Observer BlockLogGetter
+	Engines  map[string]CycleReporter
+}
+
+type Dependencies struct {
+	DB     *gorm.DB
+	Chains map[string]*ChainDependencies
+}
+
+type Checker struct {
+	conf *Config
+	deps *Dependencies
+
+	wg sync.WaitGroup
+}
+
+// NewChecker returns the health checker instance
+func NewChecker(c *Config, d *Dependencies) *Checker {
+	return &Checker{
+		conf: c,
+		deps: d,
+	}
+}
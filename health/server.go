@@ -0,0 +1,61 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// Handler returns the handler serving /healthz and /readyz
+func (c *Checker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", c.handleHealthz)
+	mux.HandleFunc("/readyz", c.handleReadyz)
+
+	return mux
+}
+
+// Start serves /healthz and /readyz on the configured listen address
+func (c *Checker) Start(ctx context.Context) {
+	mux := c.Handler()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		util.Logger.Infof("[Checker.Start]: serving health endpoints on %s", c.conf.ListenAddr)
+		if err := util.ListenAndServe(ctx, c.conf.ListenAddr, mux); err != nil {
+			util.Logger.Errorf("[Checker.Start]: health server stopped, err=%s", err.Error())
+		}
+	}()
+}
+
+// Wait blocks until the server started by Start has answered its in-flight requests and stopped
+func (c *Checker) Wait() {
+	c.wg.Wait()
+}
+
+func (c *Checker) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	report := c.Check(r.Context())
+	writeReport(w, report, report.Healthy)
+}
+
+func (c *Checker) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	report := c.Check(r.Context())
+	writeReport(w, report, report.Ready)
+}
+
+func writeReport(w http.ResponseWriter, report *Report, ok bool) {
+	status := http.StatusOK
+	if !ok {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		util.Logger.Errorf("[writeReport]: failed to encode health report, err=%s", err.Error())
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+)
+
+// inspected is a swap or swap pair printed by the inspect command with its state transitions
+type inspected struct {
+	Standard    string                  `json:"standard"`
+	Swap        interface{}             `json:"swap,omitempty"`
+	SwapPair    interface{}             `json:"swap_pair,omitempty"`
+	Transitions []transition.Transition `json:"transitions"`
+}
+
+// runInspect prints the swaps matching an id or a request or fill tx hash, or the swap pairs matching an id, a
+// register or create tx hash or a token address, as JSON
+func runInspect(args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+
+	var find func(db *gorm.DB, key string) ([]*inspected, error)
+	switch args[0] {
+	case "swap":
+		find = inspectSwaps
+	case "pair":
+		find = inspectSwapPairs
+	default:
+		return errUsage
+	}
+
+	_, db, err := setup(false)
+	if err != nil {
+		return err
+	}
+
+	ii, err := find(db, args[1])
+	if err != nil {
+		return err
+	}
+	if len(ii) == 0 {
+		return errors.Errorf("no %s matches %s", args[0], args[1])
+	}
+
+	bz, err := json.MarshalIndent(ii, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode")
+	}
+	fmt.Println(string(bz))
+
+	return nil
+}
+
+func inspectSwaps(db *gorm.DB, key string) ([]*inspected, error) {
+	var ii []*inspected
+
+	var ss721 []erc721.Swap
+	err := db.Where(
+		"id = ? or request_tx_hash = ? or fill_tx_hash = ?",
+		key, key, key,
+	).Order(
+		"id asc",
+	).Find(
+		&ss721,
+	).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query ERC721 swaps")
+	}
+	for i := range ss721 {
+		tt, err := transition.Timeline(db, transition.EntityTypeERC721Swap, ss721[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		ii = append(ii, &inspected{Standard: "erc721", Swap: &ss721[i], Transitions: tt})
+	}
+
+	var bb721 []erc721.BatchSwap
+	err = db.Where(
+		"id = ? or request_tx_hash = ? or fill_tx_hash = ?",
+		key, key, key,
+	).Order(
+		"id asc",
+	).Find(
+		&bb721,
+	).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query ERC721 batch swaps")
+	}
+	for i := range bb721 {
+		tt, err := transition.Timeline(db, transition.EntityTypeERC721BatchSwap, bb721[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		ii = append(ii, &inspected{Standard: "erc721", Swap: &bb721[i], Transitions: tt})
+	}
+
+	var ss1155 []erc1155.Swap
+	err = db.Where(
+		"id = ? or request_tx_hash = ? or fill_tx_hash = ?",
+		key, key, key,
+	).Order(
+		"id asc",
+	).Find(
+		&ss1155,
+	).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query ERC1155 swaps")
+	}
+	for i := range ss1155 {
+		tt, err := transition.Timeline(db, transition.EntityTypeERC1155Swap, ss1155[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		ii = append(ii, &inspected{Standard: "erc1155", Swap: &ss1155[i], Transitions: tt})
+	}
+
+	var ss20 []erc20.Swap
+	err = db.Where(
+		"id = ? or request_tx_hash = ? or fill_tx_hash = ?",
+		key, key, key,
+	).Order(
+		"id asc",
+	).Find(
+		&ss20,
+	).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query ERC20 swaps")
+	}
+	for i := range ss20 {
+		tt, err := transition.Timeline(db, transition.EntityTypeERC20Swap, ss20[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		ii = append(ii, &inspected{Standard: "erc20", Swap: &ss20[i], Transitions: tt})
+	}
+
+	return ii, nil
+}
+
+func inspectSwapPairs(db *gorm.DB, key string) ([]*inspected, error) {
+	// token addresses are stored checksummed
+	addr := key
+	if common.IsHexAddress(key) {
+		addr = common.HexToAddress(key).String()
+	}
+
+	var ii []*inspected
+
+	var pp721 []erc721.SwapPair
+	err := db.Where(
+		"id = ? or register_tx_hash = ? or create_tx_hash = ? or src_token_addr = ? or dst_token_addr = ?",
+		key, key, key, addr, addr,
+	).Order(
+		"id asc",
+	).Find(
+		&pp721,
+	).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query ERC721 swap pairs")
+	}
+	for i := range pp721 {
+		tt, err := transition.Timeline(db, transition.EntityTypeERC721SwapPair, pp721[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		ii = append(ii, &inspected{Standard: "erc721", SwapPair: &pp721[i], Transitions: tt})
+	}
+
+	var pp1155 []erc1155.SwapPair
+	err = db.Where(
+		"id = ? or register_tx_hash = ? or create_tx_hash = ? or src_token_addr = ? or dst_token_addr = ?",
+		key, key, key, addr, addr,
+	).Order(
+		"id asc",
+	).Find(
+		&pp1155,
+	).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query ERC1155 swap pairs")
+	}
+	for i := range pp1155 {
+		tt, err := transition.Timeline(db, transition.EntityTypeERC1155SwapPair, pp1155[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		ii = append(ii, &inspected{Standard: "erc1155", SwapPair: &pp1155[i], Transitions: tt})
+	}
+
+	var pp20 []erc20.SwapPair
+	err = db.Where(
+		"id = ? or register_tx_hash = ? or create_tx_hash = ? or src_token_addr = ? or dst_token_addr = ?",
+		key, key, key, addr, addr,
+	).Order(
+		"id asc",
+	).Find(
+		&pp20,
+	).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query ERC20 swap pairs")
+	}
+	for i := range pp20 {
+		tt, err := transition.Timeline(db, transition.EntityTypeERC20SwapPair, pp20[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		ii = append(ii, &inspected{Standard: "erc20", SwapPair: &pp20[i], Transitions: tt})
+	}
+
+	return ii, nil
+}
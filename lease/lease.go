@@ -0,0 +1,215 @@
+// Package lease elects a leader per role among the replicas sharing a database. A role is held through a row
+// of the leases table which the holder renews before it expires. Another replica takes the role over once the
+// lease has expired.
+package lease
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/lease"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// Leader reports whether the process currently holds a role
+type Leader interface {
+	IsLeader() bool
+}
+
+type Config struct {
+	// Holder identifies the replica, it must be unique among the replicas
+	Holder string
+	// Duration is how long a lease is valid after it was acquired or renewed
+	Duration time.Duration
+	// RenewInterval is how often the leases are renewed or contended for, it must be shorter than Duration
+	RenewInterval time.Duration
+}
+
+type Dependencies struct {
+	DB *gorm.DB
+}
+
+type Manager struct {
+	conf *Config
+	deps *Dependencies
+
+	mutex  sync.RWMutex
+	leases []*Lease
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// Lease is a role contended for by the manager
+type Lease struct {
+	name string
+
+	mutex     sync.RWMutex
+	held      bool
+	expiresAt time.Time
+}
+
+func NewManager(c *Config, d *Dependencies) *Manager {
+	return &Manager{
+		conf: c,
+		deps: d,
+		stop: make(chan struct{}),
+	}
+}
+
+// Lease returns the lease of a role, the manager contends for it once started
+func (m *Manager) Lease(name string) *Lease {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, l := range m.leases {
+		if l.name == name {
+			return l
+		}
+	}
+
+	l := &Lease{name: name}
+	m.leases = append(m.leases, l)
+
+	return l
+}
+
+// Start contends for the leases and keeps renewing the held ones
+func (m *Manager) Start() {
+	go func() {
+		for {
+			m.renewAll()
+
+			select {
+			case <-m.stop:
+				return
+			case <-time.After(m.conf.RenewInterval):
+			}
+		}
+	}()
+}
+
+// Stop stops renewing the leases, they are taken over by another replica once expired
+func (m *Manager) Stop() {
+	m.once.Do(func() {
+		close(m.stop)
+	})
+}
+
+// Release gives up the held leases so another replica can take them over without waiting for expiry
+func (m *Manager) Release() error {
+	m.Stop()
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, l := range m.leases {
+		l.set(false, time.Time{})
+		err := m.deps.DB.Model(
+			&lease.Lease{},
+		).Where(
+			"name = ? and holder = ?",
+			l.name,
+			m.conf.Holder,
+		).Updates(map[string]interface{}{
+			"expires_at": time.Now().UTC(),
+			"updated_at": time.Now().UTC(),
+		}).Error
+		if err != nil {
+			return errors.Wrapf(err, "[Manager.Release]: failed to release lease %s", l.name)
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) renewAll() {
+	m.mutex.RLock()
+	leases := make([]*Lease, len(m.leases))
+	copy(leases, m.leases)
+	m.mutex.RUnlock()
+
+	for _, l := range leases {
+		select {
+		case <-m.stop:
+			return
+		default:
+		}
+
+		wasHeld := l.IsLeader()
+		held, expiresAt, err := m.acquire(l.name)
+		if err != nil {
+			util.Logger.Errorf("[Manager.renewAll]: failed to acquire lease %s, %s", l.name, err.Error())
+			// the lease stays held locally until its expiry, a later renewal may still succeed
+			continue
+		}
+
+		l.set(held, expiresAt)
+		if held && !wasHeld {
+			util.Logger.Infof("[Manager.renewAll]: %s acquired lease %s", m.conf.Holder, l.name)
+		}
+		if !held && wasHeld {
+			util.Logger.Warningf("[Manager.renewAll]: %s lost lease %s", m.conf.Holder, l.name)
+		}
+	}
+}
+
+// acquire renews the lease if it is held by this replica or takes it over if it has expired
+func (m *Manager) acquire(name string) (bool, time.Time, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(m.conf.Duration)
+
+	err := m.deps.DB.Clauses(
+		clause.OnConflict{DoNothing: true},
+	).Create(&lease.Lease{
+		Name:      name,
+		Holder:    m.conf.Holder,
+		ExpiresAt: expiresAt,
+		UpdatedAt: now,
+	}).Error
+	if err != nil {
+		return false, time.Time{}, errors.Wrap(err, "[Manager.acquire]: failed to create lease")
+	}
+
+	res := m.deps.DB.Model(
+		&lease.Lease{},
+	).Where(
+		"name = ? and (holder = ? or expires_at < ?)",
+		name,
+		m.conf.Holder,
+		now,
+	).Updates(map[string]interface{}{
+		"holder":     m.conf.Holder,
+		"expires_at": expiresAt,
+		"updated_at": now,
+	})
+	if res.Error != nil {
+		return false, time.Time{}, errors.Wrap(res.Error, "[Manager.acquire]: failed to update lease")
+	}
+
+	// the local expiry starts before the update was sent, so the role is given up before another replica can take it
+	return res.RowsAffected == 1, expiresAt, nil
+}
+
+// IsLeader reports whether the lease is held and has not expired
+func (l *Lease) IsLeader() bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.held && time.Now().UTC().Before(l.expiresAt)
+}
+
+func (l *Lease) Name() string {
+	return l.name
+}
+
+func (l *Lease) set(held bool, expiresAt time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.held = held
+	l.expiresAt = expiresAt
+}
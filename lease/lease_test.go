@@ -0,0 +1,112 @@
+package lease_test
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/lease"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/testutil"
+)
+
+const (
+	leaseDuration = 400 * time.Millisecond
+	roleName      = "swap-engine/97"
+)
+
+func newManager(db *gorm.DB, holder string) *lease.Manager {
+	return lease.NewManager(&lease.Config{
+		Holder:        holder,
+		Duration:      leaseDuration,
+		RenewInterval: leaseDuration / 4,
+	}, &lease.Dependencies{
+		DB: db.Session(&gorm.Session{}),
+	})
+}
+
+// waitLeader waits until one of the leases is held, or the lease at index want when it is not negative, and returns
+// its index. It fails as soon as two leases are held at once.
+func waitLeader(t *testing.T, want int, leases ...*lease.Lease) int {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		leader := -1
+		count := 0
+		for idx, l := range leases {
+			if l.IsLeader() {
+				leader = idx
+				count++
+			}
+		}
+		if count > 1 {
+			t.Fatalf("%d replicas hold lease %s at once", count, roleName)
+		}
+		if count == 1 && (want < 0 || leader == want) {
+			return leader
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf("no replica acquired lease %s", roleName)
+
+	return -1
+}
+
+func TestFailoverAfterExpiry(t *testing.T) {
+	db := testutil.NewDB()
+	managers := []*lease.Manager{newManager(db, "replica-a"), newManager(db, "replica-b")}
+	leases := []*lease.Lease{managers[0].Lease(roleName), managers[1].Lease(roleName)}
+	for _, m := range managers {
+		m.Start()
+		defer m.Stop()
+	}
+
+	leader := waitLeader(t, -1, leases...)
+
+	// the leader stays leader while it renews
+	time.Sleep(3 * leaseDuration)
+	if !leases[leader].IsLeader() {
+		t.Fatal("leader lost a lease it kept renewing")
+	}
+	if leases[1-leader].IsLeader() {
+		t.Fatal("standby took over a lease that was renewed")
+	}
+
+	// a leader that stops renewing, e.g. a stalled process, gives the role up locally before the standby takes it
+	managers[leader].Stop()
+	stopped := time.Now()
+	waitLeader(t, 1-leader, leases...)
+	if elapsed := time.Since(stopped); elapsed < leaseDuration/2 {
+		t.Fatalf("standby took over after %s, before the lease expired", elapsed)
+	}
+}
+
+func TestFailoverAfterRelease(t *testing.T) {
+	db := testutil.NewDB()
+	a := newManager(db, "replica-a")
+	la := a.Lease(roleName)
+	a.Start()
+	defer a.Stop()
+	waitLeader(t, -1, la)
+
+	b := newManager(db, "replica-b")
+	lb := b.Lease(roleName)
+	b.Start()
+	defer b.Stop()
+
+	if err := a.Release(); err != nil {
+		t.Fatalf("failed to release: %v", err)
+	}
+	if la.IsLeader() {
+		t.Fatal("released lease is still held")
+	}
+
+	released := time.Now()
+	waitLeader(t, -1, lb)
+	if elapsed := time.Since(released); elapsed >= leaseDuration {
+		t.Fatalf("standby took over after %s, it should not wait for the expiry", elapsed)
+	}
+}
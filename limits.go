@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+)
+
+// runLimits sets the bounds of the amount of the swaps of an ERC20 swap pair. A bound which is not given is kept,
+// an empty one is removed. The swaps requested out of the bounds are rejected.
+func runLimits(args []string) error {
+	flags := pflag.NewFlagSet("limits", pflag.ContinueOnError)
+	minAmount := flags.String("min", "", "min amount in the smallest unit of the token, empty to remove it")
+	maxAmount := flags.String("max", "", "max amount in the smallest unit of the token, empty to remove it")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		return errUsage
+	}
+
+	config, db, err := setup(false)
+	if err != nil {
+		return err
+	}
+
+	var pp []erc20.SwapPair
+	if err := db.Where("id = ?", flags.Arg(0)).Limit(1).Find(&pp).Error; err != nil {
+		return errors.Wrap(err, "failed to query the ERC20 pair")
+	}
+	if len(pp) == 0 {
+		return errors.Errorf("no ERC20 pair matches %s", flags.Arg(0))
+	}
+	p := &pp[0]
+
+	min, max := p.MinAmount, p.MaxAmount
+	if flags.Changed("min") {
+		min = *minAmount
+	}
+	if flags.Changed("max") {
+		max = *maxAmount
+	}
+	if err := p.SetAmountBounds(min, max); err != nil {
+		return err
+	}
+
+	p.UpdateSignature(config.KeyManagerConfig.HMACKey)
+	if err := model.CompareAndSave(db, p, "cli/limits"); err != nil {
+		return errors.Wrap(err, "failed to save the ERC20 pair")
+	}
+
+	fmt.Printf("pair %s: min amount %q, max amount %q\n", p.ID, p.MinAmount, p.MaxAmount)
+
+	return nil
+}
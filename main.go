@@ -3,29 +3,17 @@ package main
 import (
 	"flag"
 	"fmt"
-	"math/big"
-	"time"
+	"os"
+	"strings"
 
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
-	contractabi "github.com/synycboom/bsc-evm-compatible-bridge-core/abi"
-	erc1155agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc1155"
-	erc721agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc721"
-	"github.com/synycboom/bsc-evm-compatible-bridge-core/client"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model"
-	observer "github.com/synycboom/bsc-evm-compatible-bridge-core/observer"
-	"github.com/synycboom/bsc-evm-compatible-bridge-core/recorder"
-	sengine "github.com/synycboom/bsc-evm-compatible-bridge-core/swap-engine"
-	spengine "github.com/synycboom/bsc-evm-compatible-bridge-core/swap-pair-engine"
-	erc1155token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc1155"
-	erc721token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/migration"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
@@ -41,6 +29,51 @@ const (
 	ConfigTypeAws   = "aws"
 )
 
+// command is a subcommand of the binary, run with the arguments following its name
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []*command{
+	{
+		name:  "serve",
+		usage: "serve [--only component,...]",
+		run:   runServe,
+	},
+	{
+		name:  "migrate",
+		usage: "migrate up|down [steps]|status",
+		run:   runMigrate,
+	},
+	{
+		name:  "rescan",
+		usage: "rescan --chain id --from height --to height [--dry-run]",
+		run:   runRescan,
+	},
+	{
+		name:  "inspect",
+		usage: "inspect swap <id|tx hash> | inspect pair <id|tx hash|token address>",
+		run:   runInspect,
+	},
+	{
+		name:  "limits",
+		usage: "limits <erc20 pair id> [--min amount] [--max amount]",
+		run:   runLimits,
+	},
+	{
+		name:  "chain",
+		usage: "chain status [chain id...]",
+		run:   runChain,
+	},
+	{
+		name:  "validate-config",
+		usage: "validate-config",
+		run:   runValidateConfig,
+	},
+}
+
 func initFlags() {
 	flag.String(flagConfigPath, "", "config path")
 	flag.String(flagConfigType, "", "config type, local or aws")
@@ -48,6 +81,8 @@ func initFlags() {
 	flag.String(flagConfigAwsSecretKey, "", "aws s3 secret key")
 
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	// the flags following the command name belong to the command
+	pflag.CommandLine.SetInterspersed(false)
 	pflag.Parse()
 	err := viper.BindPFlags(pflag.CommandLine)
 	if err != nil {
@@ -56,178 +91,126 @@ func initFlags() {
 }
 
 func printUsage() {
-	fmt.Print("usage: ./swap --config-type [local or aws] --config-path config_file_path\n")
+	fmt.Print("usage: ./swap --config-type [local or aws] --config-path config_file_path [command]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Printf("  %s\n", c.usage)
+	}
+	fmt.Print("\nserve runs when no command is given\n")
 }
 
 func main() {
 	initFlags()
 
-	configType := viper.GetString(flagConfigType)
-	if configType == "" {
-		printUsage()
-		return
+	name, args := "serve", []string(nil)
+	if aa := pflag.Args(); len(aa) > 0 {
+		name, args = aa[0], aa[1:]
 	}
 
-	if configType != ConfigTypeAws && configType != ConfigTypeLocal {
-		printUsage()
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+
+		if err := c.run(args); err != nil {
+			if err == errUsage {
+				printUsage()
+			} else {
+				fmt.Printf("%s error, err=%s\n", name, err.Error())
+			}
+			os.Exit(1)
+		}
 		return
 	}
 
-	var config *util.Config
-	if configType == ConfigTypeAws {
+	printUsage()
+	os.Exit(1)
+}
+
+var errUsage = errors.New("usage")
+
+// loadConfig loads the local or aws config selected by the global flags
+func loadConfig() (*util.Config, error) {
+	configType := viper.GetString(flagConfigType)
+	switch configType {
+	case ConfigTypeAws:
 		awsSecretKey := viper.GetString(flagConfigAwsSecretKey)
 		if awsSecretKey == "" {
-			printUsage()
-			return
+			return nil, errUsage
 		}
 
 		awsRegion := viper.GetString(flagConfigAwsRegion)
 		if awsRegion == "" {
-			printUsage()
-			return
+			return nil, errUsage
 		}
 
 		configContent, err := util.GetSecret(awsSecretKey, awsRegion)
 		if err != nil {
-			fmt.Printf("get aws config error, err=%s", err.Error())
-			return
+			return nil, errors.Wrap(err, "get aws config error")
 		}
-		config = util.ParseConfigFromJson(configContent)
-	} else {
+		return util.ParseConfigFromJson(configContent), nil
+	case ConfigTypeLocal:
 		configFilePath := viper.GetString(flagConfigPath)
 		if configFilePath == "" {
-			printUsage()
-			return
+			return nil, errUsage
 		}
-		config = util.ParseConfigFromFile(configFilePath)
+		return util.ParseConfigFromFile(configFilePath), nil
+	}
+
+	return nil, errUsage
+}
+
+// setup loads and validates the config, initializes the logger and opens the database. The schema is checked
+// to be up to date unless skipSchemaCheck is set.
+func setup(skipSchemaCheck bool) (*util.Config, *gorm.DB, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
 	}
 	config.Validate()
 
 	util.InitLogger(config.LogConfig)
-	util.InitTgAlerter(config.AlertConfig)
 
-	mysqlConn := mysql.New(mysql.Config{
-		DSN: config.DBConfig.DSN,
-	})
-	db, err := gorm.Open(mysqlConn, &gorm.Config{
+	db, err := model.Open(config.DBConfig.DialectOrDefault(), config.DBConfig.DSN, &gorm.Config{
 		Logger: logger.Default.LogMode(dbLogLevel(config.DBConfig.LogLevel)),
 	})
 	if err != nil {
-		panic(errors.Wrap(err, "[main]: open db error"))
+		return nil, nil, errors.Wrap(err, "open db error")
 	}
 
-	model.InitTables(db)
-
-	erc721SwapAgents := make(map[string]erc721agent.SwapAgent)
-	erc721SwapAgentAddresses := make(map[string]common.Address)
-	erc721Tokens := make(map[string]erc721token.IToken)
-	erc1155SwapAgents := make(map[string]erc1155agent.SwapAgent)
-	erc1155SwapAgentAddresses := make(map[string]common.Address)
-	erc1155Tokens := make(map[string]erc1155token.IToken)
-	clients := make(map[string]client.ETHClient)
-	for _, c := range config.ChainConfigs {
-		ec, err := ethclient.Dial(c.Provider)
-		if err != nil {
-			panic(errors.Wrap(err, "[main]: new eth client error"))
-		}
-
-		erc721SwapAgentAddr := common.HexToAddress(c.ERC721SwapAgentAddr)
-		erc721SwapAgent, err := contractabi.NewERC721SwapAgent(erc721SwapAgentAddr, ec)
-		if err != nil {
-			panic(errors.Wrap(err, "[main]: failed to create ERC721 swap agent"))
-		}
-
-		erc1155SwapAgentAddr := common.HexToAddress(c.ERC1155SwapAgentAddr)
-		erc1155SwapAgent, err := contractabi.NewERC1155SwapAgent(erc1155SwapAgentAddr, ec)
-		if err != nil {
-			panic(errors.Wrap(err, "[main]: failed to create ERC1155 swap agent"))
+	if !skipSchemaCheck {
+		if err := migration.Check(db); err != nil {
+			return nil, nil, errors.Wrap(err, "schema check error, run the migrate up command first")
 		}
+	}
 
-		clients[c.ID] = client.NewClient(ec)
-		erc721Tokens[c.ID] = erc721token.NewToken(ec)
-		erc721SwapAgents[c.ID] = erc721SwapAgent
-		erc721SwapAgentAddresses[c.ID] = erc721SwapAgentAddr
+	return config, db, nil
+}
 
-		erc1155Tokens[c.ID] = erc1155token.NewToken(ec)
-		erc1155SwapAgents[c.ID] = erc1155SwapAgent
-		erc1155SwapAgentAddresses[c.ID] = erc1155SwapAgentAddr
+// runValidateConfig loads the config and reports the first invalid value
+func runValidateConfig(args []string) (err error) {
+	if len(args) > 0 {
+		return errUsage
 	}
 
-	recorders := make(map[string]recorder.IRecorder)
-	for _, c := range config.ChainConfigs {
-		chainID := util.StrToBigInt(c.ID)
-		if chainID.Cmp(big.NewInt(0)) == 0 {
-			panic(errors.New("[main]: chain id is 0"))
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("invalid config: %v", r)
 		}
+	}()
 
-		recorders[c.ID] = recorder.NewRecorder(&recorder.Config{
-			ChainID:   chainID,
-			ChainName: c.Name,
-			HMACKey:   config.KeyManagerConfig.HMACKey,
-		}, &recorder.Dependencies{
-			Client:           clients,
-			DB:               db.Session(&gorm.Session{}),
-			ERC721SwapAgent:  erc721SwapAgents,
-			ERC721Token:      erc721Tokens,
-			ERC1155SwapAgent: erc1155SwapAgents,
-			ERC1155Token:     erc1155Tokens,
-		})
+	config, err := loadConfig()
+	if err != nil {
+		return err
 	}
+	config.Validate()
 
+	chainIDs := make([]string, 0, len(config.ChainConfigs))
 	for _, c := range config.ChainConfigs {
-		chainID := util.StrToBigInt(c.ID)
-
-		// TODO: implement SwapAgent instance and implement mutex lock to prevent multiple calls
-		// TODO: send tg when logging has error
-		ob := observer.NewObserver(&observer.Config{
-			StartHeight:        c.StartHeight,
-			ConfirmNum:         c.ConfirmNum,
-			FetchInterval:      time.Duration(c.ObserverFetchInterval) * time.Second,
-			BlockUpdateTimeout: time.Duration(config.AlertConfig.BlockUpdateTimeout) * time.Second,
-		}, &observer.Dependencies{
-			DB:       db.Session(&gorm.Session{}),
-			Recorder: recorders[c.ID],
-		})
-		ob.Start()
-
-		e := spengine.NewEngine(&spengine.Config{
-			ChainID:                   chainID,
-			ConfirmNum:                c.ConfirmNum,
-			ExplorerURL:               c.ExplorerUrl,
-			PrivateKey:                c.PrivateKey,
-			MaxTrackRetry:             c.MaxTrackRetry,
-			ERC721SwapAgentAddresses:  erc721SwapAgentAddresses,
-			ERC1155SwapAgentAddresses: erc1155SwapAgentAddresses,
-		}, &spengine.Dependencies{
-			Client:           clients,
-			DB:               db.Session(&gorm.Session{}),
-			Recorder:         recorders,
-			ERC721SwapAgent:  erc721SwapAgents,
-			ERC1155SwapAgent: erc1155SwapAgents,
-		})
-		e.Start()
-
-		se := sengine.NewEngine(&sengine.Config{
-			ChainID:                   chainID,
-			ConfirmNum:                c.ConfirmNum,
-			ExplorerURL:               c.ExplorerUrl,
-			PrivateKey:                c.PrivateKey,
-			MaxTrackRetry:             c.MaxTrackRetry,
-			ERC721SwapAgentAddresses:  erc721SwapAgentAddresses,
-			ERC1155SwapAgentAddresses: erc1155SwapAgentAddresses,
-		}, &sengine.Dependencies{
-			Client:           clients,
-			DB:               db.Session(&gorm.Session{}),
-			Recorder:         recorders,
-			ERC721SwapAgent:  erc721SwapAgents,
-			ERC721Token:      erc721Tokens,
-			ERC1155SwapAgent: erc1155SwapAgents,
-			ERC1155Token:     erc1155Tokens,
-		})
-		se.Start()
+		chainIDs = append(chainIDs, c.ID)
 	}
+	fmt.Printf("config is valid, chains: %s\n", strings.Join(chainIDs, ", "))
 
-	select {}
+	return nil
 }
 
 func dbLogLevel(level string) logger.LogLevel {
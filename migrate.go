@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/migration"
+)
+
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	_, db, err := setup(true)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		if err := migration.Up(db); err != nil {
+			return err
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return errors.Errorf("invalid steps %s", args[1])
+			}
+			steps = n
+		}
+
+		if err := migration.Down(db, steps); err != nil {
+			return err
+		}
+	case "status":
+	default:
+		return errUsage
+	}
+
+	ss, err := migration.Statuses(db)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range ss {
+		appliedAt := "pending"
+		if s.Applied {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%04d %-30s %s\n", s.Version, s.Name, appliedAt)
+	}
+
+	return nil
+}
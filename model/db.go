@@ -0,0 +1,45 @@
+package model
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/common"
+)
+
+// Open connects to the database of the dialect
+func Open(dialect, dsn string, config *gorm.Config) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch dialect {
+	case common.DBDialectMysql:
+		dialector = mysql.New(mysql.Config{
+			DSN: dsn,
+		})
+	case common.DBDialectPostgres:
+		dialector = postgres.Open(dsn)
+	case common.DBDialectSqlite3:
+		dialector = sqlite.Open(dsn)
+	default:
+		return nil, errors.Errorf("[Open]: dialect %s is not supported", dialect)
+	}
+
+	db, err := gorm.Open(dialector, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Open]: failed to open db")
+	}
+
+	if dialect == common.DBDialectSqlite3 {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, errors.Wrap(err, "[Open]: failed to get sql db")
+		}
+
+		// SQLite allows a single writer, sharing one connection serializes the concurrent routines
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	return db, nil
+}
@@ -1,16 +1,21 @@
 package erc1155
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/pkg/errors"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
@@ -19,15 +24,16 @@ type SwapState string
 type SwapDirection string
 
 const (
-	SwapStateRequestOngoing     SwapState = "request_ongoing"
-	SwapStateRequestRejected    SwapState = "request_rejected"
-	SwapStateRequestConfirmed   SwapState = "request_confirmed"
-	SwapStateFillTxDryRunFailed SwapState = "fill_tx_dry_run_failed"
-	SwapStateFillTxCreated      SwapState = "fill_tx_created"
-	SwapStateFillTxSent         SwapState = "fill_tx_sent"
-	SwapStateFillTxConfirmed    SwapState = "fill_tx_confirmed"
-	SwapStateFillTxFailed       SwapState = "fill_tx_failed"
-	SwapStateFillTxMissing      SwapState = "fill_tx_missing"
+	SwapStateRequestOngoing         SwapState = "request_ongoing"
+	SwapStateRequestRejected        SwapState = "request_rejected"
+	SwapStateRequestConfirmed       SwapState = "request_confirmed"
+	SwapStateFillTxDryRunFailed     SwapState = "fill_tx_dry_run_failed"
+	SwapStateFillTxCreated          SwapState = "fill_tx_created"
+	SwapStateFillTxSent             SwapState = "fill_tx_sent"
+	SwapStateFillTxConfirmed        SwapState = "fill_tx_confirmed"
+	SwapStateFillTxFailed           SwapState = "fill_tx_failed"
+	SwapStateFillTxMissing          SwapState = "fill_tx_missing"
+	SwapStateUnsupportedDestination SwapState = "unsupported_destination"
 
 	SwapDirectionForward  SwapDirection = "forward"
 	SwapDirectionBackward SwapDirection = "backward"
@@ -37,12 +43,12 @@ type Swap struct {
 	ID string `gorm:"size:26;primary_key"`
 
 	// Basic Token Information
-	SrcChainID   string `gorm:"not null"`
+	SrcChainID   string `gorm:"not null;index:erc1155_swap_request_event,unique,priority:1"`
 	DstChainID   string `gorm:"not null"`
 	SrcTokenAddr string `gorm:"not null"`
 	DstTokenAddr string
-	Sender       string         `gorm:"not null"`
-	Recipient    string         `gorm:"not null"`
+	Sender       string         `gorm:"not null;index:erc1155_swap_sender"`
+	Recipient    string         `gorm:"not null;index:erc1155_swap_recipient"`
 	IDs          datatypes.JSON `gorm:"not null"`
 	Amounts      datatypes.JSON `gorm:"not null"`
 	Signature    string         `gorm:"not null"`
@@ -52,12 +58,14 @@ type Swap struct {
 	SwapDirection SwapDirection `gorm:"not null"`
 
 	// Request Transaction Information
-	RequestTxHash     string     `gorm:"not null"`
-	RequestHeight     int64      `gorm:"not null"`
-	RequestBlockHash  string     `gorm:"not null"`
-	RequestBlockLogID *string    `gorm:"size:26;index:foreign_key_request_block_log_id"`
-	RequestBlockLog   *block.Log `gorm:"foreignKey:RequestBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
-	RequestTrackRetry int64
+	RequestTxHash       string `gorm:"not null;index:erc1155_swap_request_event,unique,priority:2"`
+	RequestHeight       int64  `gorm:"not null"`
+	RequestBlockHash    string `gorm:"not null"`
+	RequestLogIndex     *uint  `gorm:"index:erc1155_swap_request_event,unique,priority:3"`
+	RequestContractAddr string
+	RequestBlockLogID   *string    `gorm:"size:26;index:erc1155_foreign_key_request_block_log_id"`
+	RequestBlockLog     *block.Log `gorm:"foreignKey:RequestBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	RequestTrackRetry   int64
 
 	// Fill Transaction Information
 	FillConsumedFeeAmount string
@@ -67,11 +75,23 @@ type Swap struct {
 	FillTxHash            string
 	FillTrackRetry        int64
 	FillBlockHash         string     `gorm:"not null"`
-	FillBlockLogID        *string    `gorm:"size:26;index:foreign_key_fill_block_log_id"`
+	FillBlockLogID        *string    `gorm:"size:26;index:erc1155_foreign_key_fill_block_log_id"`
 	FillBlockLog          *block.Log `gorm:"foreignKey:FillBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
 
+	// FillBatchTxHash is the fill batch tx the swap was last filled by, a swap whose fill failed in a batch
+	// keeps it and is filled by its own tx
+	FillBatchTxHash string
+
 	MessageLog string
 
+	// Version is increased by every update, see model.CompareAndSave
+	Version int64 `gorm:"not null;default:0"`
+
+	// loadedState, loadedVersion and loadedMessageLog hold the values read from the database
+	loadedState      SwapState
+	loadedVersion    int64
+	loadedMessageLog string
+
 	// Timestamp
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -93,6 +113,82 @@ func (s *Swap) BeforeUpdate(tx *gorm.DB) (err error) {
 	return nil
 }
 
+func (s *Swap) AfterFind(tx *gorm.DB) (err error) {
+	s.MarkLoaded()
+	return nil
+}
+
+// StateChanged tells whether the state differs from the one the Swap was loaded or last saved with
+func (s *Swap) StateChanged() bool {
+	return s.State != s.loadedState
+}
+
+// EntityName names the Swap in errors
+func (s *Swap) EntityName() string {
+	return "Swap " + s.ID
+}
+
+func (s *Swap) Entity() (transition.EntityType, string, string) {
+	return transition.EntityTypeERC1155Swap, s.ID, s.SrcChainID
+}
+
+// Loaded returns the state and version the Swap was loaded or last saved with
+func (s *Swap) Loaded() (string, int64) {
+	return string(s.loadedState), s.loadedVersion
+}
+
+func (s *Swap) SetVersion(version int64) {
+	s.Version = version
+}
+
+// MarkLoaded makes the current state, version and message of the Swap the loaded ones
+func (s *Swap) MarkLoaded() {
+	s.loadedState = s.State
+	s.loadedVersion = s.Version
+	s.loadedMessageLog = s.MessageLog
+}
+
+// Transition describes the change from the loaded state to the current one with the outbox event announcing it,
+// the message is kept only if it was set by this change
+func (s *Swap) Transition(actor string) (*transition.Transition, *outbox.Event, error) {
+	t := transition.Transition{
+		EntityType: transition.EntityTypeERC1155Swap,
+		EntityID:   s.ID,
+		FromState:  string(s.loadedState),
+		ToState:    string(s.State),
+		Actor:      actor,
+		TxHash:     s.RequestTxHash,
+	}
+	if s.FillTxHash != "" {
+		t.TxHash = s.FillTxHash
+	}
+	if s.MessageLog != s.loadedMessageLog {
+		t.Message = s.MessageLog
+	}
+
+	e, err := outbox.NewSwapEvent(&t, &outbox.Swap{
+		ID:            s.ID,
+		Standard:      "erc1155",
+		State:         string(s.State),
+		Direction:     string(s.SwapDirection),
+		SrcChainID:    s.SrcChainID,
+		DstChainID:    s.DstChainID,
+		SrcTokenAddr:  s.SrcTokenAddr,
+		DstTokenAddr:  s.DstTokenAddr,
+		Sender:        s.Sender,
+		Recipient:     s.Recipient,
+		TokenIDs:      json.RawMessage(s.IDs),
+		Amounts:       json.RawMessage(s.Amounts),
+		RequestTxHash: s.RequestTxHash,
+		FillTxHash:    s.FillTxHash,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "[Swap.Transition]: failed to create the event of Swap %s", s.ID)
+	}
+
+	return &t, e, nil
+}
+
 func (s *Swap) IsRequiredInfoValid() bool {
 	return s.DstTokenAddr != ""
 }
@@ -110,8 +206,8 @@ func (s *Swap) SignaturePayload() string {
 		s.DstTokenAddr,
 		s.Sender,
 		s.Recipient,
-		s.IDs.String(),
-		s.Amounts.String(),
+		compactJSON(s.IDs),
+		compactJSON(s.Amounts),
 		s.RequestTxHash,
 		s.RequestHeight,
 		s.FillTxHash,
@@ -132,3 +228,14 @@ func (s *Swap) UpdateSignature(hmacKey string) {
 	mac.Write([]byte(s.SignaturePayload()))
 	s.Signature = hex.EncodeToString(mac.Sum(nil))
 }
+
+// compactJSON strips the whitespace that MySQL and PostgreSQL add to stored JSON values,
+// so the signature payload is the same across dialects and before and after a reload
+func compactJSON(j datatypes.JSON) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, j); err != nil {
+		return j.String()
+	}
+
+	return buf.String()
+}
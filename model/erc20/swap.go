@@ -0,0 +1,224 @@
+package erc20
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+type SwapState string
+type SwapDirection string
+
+const (
+	SwapStateRequestOngoing         SwapState = "request_ongoing"
+	SwapStateRequestRejected        SwapState = "request_rejected"
+	SwapStateRequestConfirmed       SwapState = "request_confirmed"
+	SwapStateFillTxDryRunFailed     SwapState = "fill_tx_dry_run_failed"
+	SwapStateFillTxCreated          SwapState = "fill_tx_created"
+	SwapStateFillTxSent             SwapState = "fill_tx_sent"
+	SwapStateFillTxConfirmed        SwapState = "fill_tx_confirmed"
+	SwapStateFillTxFailed           SwapState = "fill_tx_failed"
+	SwapStateFillTxMissing          SwapState = "fill_tx_missing"
+	SwapStateUnsupportedDestination SwapState = "unsupported_destination"
+
+	SwapDirectionForward  SwapDirection = "forward"
+	SwapDirectionBackward SwapDirection = "backward"
+)
+
+type Swap struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	// Basic Token Information
+	SrcChainID   string `gorm:"not null;index:erc20_swap_request_event,unique,priority:1"`
+	DstChainID   string `gorm:"not null"`
+	SrcTokenAddr string `gorm:"not null"`
+	DstTokenAddr string
+	Sender       string `gorm:"not null;index:erc20_swap_sender"`
+	Recipient    string `gorm:"not null;index:erc20_swap_recipient"`
+	// Amount is the amount swapped in the smallest unit of the token, as a decimal string
+	Amount    string `gorm:"not null"`
+	Signature string `gorm:"not null"`
+
+	// Swap State
+	State         SwapState     `gorm:"not null"`
+	SwapDirection SwapDirection `gorm:"not null"`
+
+	// Request Transaction Information
+	RequestTxHash       string `gorm:"not null;index:erc20_swap_request_event,unique,priority:2"`
+	RequestHeight       int64  `gorm:"not null"`
+	RequestBlockHash    string `gorm:"not null"`
+	RequestLogIndex     *uint  `gorm:"index:erc20_swap_request_event,unique,priority:3"`
+	RequestContractAddr string
+	RequestBlockLogID   *string    `gorm:"size:26;index:erc20_foreign_key_request_block_log_id"`
+	RequestBlockLog     *block.Log `gorm:"foreignKey:RequestBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	RequestTrackRetry   int64
+
+	// Fill Transaction Information
+	FillConsumedFeeAmount string
+	FillGasPrice          string
+	FillGasUsed           int64
+	FillHeight            int64
+	FillTxHash            string
+	FillTrackRetry        int64
+	FillBlockHash         string     `gorm:"not null"`
+	FillBlockLogID        *string    `gorm:"size:26;index:erc20_foreign_key_fill_block_log_id"`
+	FillBlockLog          *block.Log `gorm:"foreignKey:FillBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	// FillBatchTxHash is the fill batch tx the swap was last filled by, a swap whose fill failed in a batch
+	// keeps it and is filled by its own tx
+	FillBatchTxHash string
+
+	MessageLog string
+
+	// Version is increased by every update, see model.CompareAndSave
+	Version int64 `gorm:"not null;default:0"`
+
+	// loadedState, loadedVersion and loadedMessageLog hold the values read from the database
+	loadedState      SwapState
+	loadedVersion    int64
+	loadedMessageLog string
+
+	// Timestamp
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Swap) TableName() string {
+	return "erc20_swaps"
+}
+
+func (s *Swap) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ID = util.ULID()
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Swap) BeforeUpdate(tx *gorm.DB) (err error) {
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Swap) AfterFind(tx *gorm.DB) (err error) {
+	s.MarkLoaded()
+	return nil
+}
+
+// StateChanged tells whether the state differs from the one the Swap was loaded or last saved with
+func (s *Swap) StateChanged() bool {
+	return s.State != s.loadedState
+}
+
+// EntityName names the Swap in errors
+func (s *Swap) EntityName() string {
+	return "Swap " + s.ID
+}
+
+func (s *Swap) Entity() (transition.EntityType, string, string) {
+	return transition.EntityTypeERC20Swap, s.ID, s.SrcChainID
+}
+
+// Loaded returns the state and version the Swap was loaded or last saved with
+func (s *Swap) Loaded() (string, int64) {
+	return string(s.loadedState), s.loadedVersion
+}
+
+func (s *Swap) SetVersion(version int64) {
+	s.Version = version
+}
+
+// MarkLoaded makes the current state, version and message of the Swap the loaded ones
+func (s *Swap) MarkLoaded() {
+	s.loadedState = s.State
+	s.loadedVersion = s.Version
+	s.loadedMessageLog = s.MessageLog
+}
+
+// Transition describes the change from the loaded state to the current one with the outbox event announcing it,
+// the message is kept only if it was set by this change
+func (s *Swap) Transition(actor string) (*transition.Transition, *outbox.Event, error) {
+	t := transition.Transition{
+		EntityType: transition.EntityTypeERC20Swap,
+		EntityID:   s.ID,
+		FromState:  string(s.loadedState),
+		ToState:    string(s.State),
+		Actor:      actor,
+		TxHash:     s.RequestTxHash,
+	}
+	if s.FillTxHash != "" {
+		t.TxHash = s.FillTxHash
+	}
+	if s.MessageLog != s.loadedMessageLog {
+		t.Message = s.MessageLog
+	}
+
+	e, err := outbox.NewSwapEvent(&t, &outbox.Swap{
+		ID:            s.ID,
+		Standard:      "erc20",
+		State:         string(s.State),
+		Direction:     string(s.SwapDirection),
+		SrcChainID:    s.SrcChainID,
+		DstChainID:    s.DstChainID,
+		SrcTokenAddr:  s.SrcTokenAddr,
+		DstTokenAddr:  s.DstTokenAddr,
+		Sender:        s.Sender,
+		Recipient:     s.Recipient,
+		Amount:        s.Amount,
+		RequestTxHash: s.RequestTxHash,
+		FillTxHash:    s.FillTxHash,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "[Swap.Transition]: failed to create the event of Swap %s", s.ID)
+	}
+
+	return &t, e, nil
+}
+
+func (s *Swap) IsRequiredInfoValid() bool {
+	return s.DstTokenAddr != ""
+}
+
+func (s *Swap) SetRequiredInfo(dstTokenAddr string) {
+	s.DstTokenAddr = dstTokenAddr
+}
+
+func (s *Swap) SignaturePayload() string {
+	return fmt.Sprintf("%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v",
+		s.State,
+		s.SrcChainID,
+		s.DstChainID,
+		s.SrcTokenAddr,
+		s.DstTokenAddr,
+		s.Sender,
+		s.Recipient,
+		s.Amount,
+		s.RequestTxHash,
+		s.RequestHeight,
+		s.FillTxHash,
+		s.FillHeight,
+	)
+}
+
+func (s *Swap) VerifySignature(hmacKey string) bool {
+	oldSig := s.Signature
+	s.UpdateSignature(hmacKey)
+	newSig := s.Signature
+
+	return oldSig == newSig
+}
+
+func (s *Swap) UpdateSignature(hmacKey string) {
+	mac := hmac.New(sha256.New, []byte(hmacKey))
+	mac.Write([]byte(s.SignaturePayload()))
+	s.Signature = hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,288 @@
+package erc20
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+type SwapPairState string
+
+const (
+	SwapPairStateRegistrationOngoing    SwapPairState = "registration_ongoing"
+	SwapPairStateRegistrationConfirmed  SwapPairState = "registration_confirmed"
+	SwapPairStateCreationTxDryRunFailed SwapPairState = "creation_tx_dry_run_failed"
+	SwapPairStateCreationTxCreated      SwapPairState = "creation_tx_created"
+	SwapPairStateCreationTxSent         SwapPairState = "creation_tx_sent"
+	SwapPairStateCreationTxConfirmed    SwapPairState = "creation_tx_confirmed"
+	SwapPairStateCreationTxFailed       SwapPairState = "creation_tx_failed"
+	SwapPairStateCreationTxMissing      SwapPairState = "creation_tx_missing"
+	SwapPairStateUnsupportedDestination SwapPairState = "unsupported_destination"
+)
+
+type SwapPair struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	// Basic Token Information
+	SrcChainID   string `gorm:"not null;index:erc20_unique_registration,unique,priority:1;index:erc20_swap_pair_register_event,unique,priority:1"`
+	DstChainID   string `gorm:"not null;index:erc20_unique_registration,unique,priority:2"`
+	SrcTokenAddr string `gorm:"not null;index:erc20_unique_registration,unique,priority:3"`
+	DstTokenAddr string
+	SrcTokenName string `gorm:"not null"`
+	DstTokenName string
+	Sponsor      string `gorm:"not null"`
+	Available    bool   `gorm:"not null"`
+	Signature    string `gorm:"not null"`
+	Symbol       string `gorm:"not null"`
+	// Decimals of the token, the mirrored token is created with the same decimals
+	Decimals uint8 `gorm:"not null"`
+	// MinAmount and MaxAmount bound the amount of a swap in the smallest unit of the token, as decimal strings.
+	// An empty bound is not enforced.
+	MinAmount string `gorm:"not null;default:''"`
+	MaxAmount string `gorm:"not null;default:''"`
+
+	// Pair State
+	State SwapPairState `gorm:"not null"`
+
+	// Registration Transaction Information
+	RegisterTxHash       string `gorm:"not null;index:erc20_unique_registration,unique,priority:4;index:erc20_swap_pair_register_event,unique,priority:2"`
+	RegisterHeight       int64  `gorm:"not null;index:erc20_unique_registration,unique,priority:5"`
+	RegisterBlockHash    string `gorm:"not null"`
+	RegisterLogIndex     *uint  `gorm:"index:erc20_swap_pair_register_event,unique,priority:3"`
+	RegisterContractAddr string
+	RegisterBlockLogID   *string    `gorm:"size:26;index:erc20_foreign_key_register_block_log_id"`
+	RegisterBlockLog     *block.Log `gorm:"foreignKey:RegisterBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	// Creation Transaction Information
+	CreateConsumedFeeAmount string
+	CreateGasPrice          string
+	CreateGasUsed           int64
+	CreateHeight            int64
+	CreateTxHash            string
+	CreateTrackRetry        int64
+	CreateBlockHash         string     `gorm:"not null"`
+	CreateBlockLogID        *string    `gorm:"size:26;index:erc20_foreign_key_create_block_log_id"`
+	CreateBlockLog          *block.Log `gorm:"foreignKey:CreateBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	MessageLog string
+
+	// Version is increased by every update, see model.CompareAndSave
+	Version int64 `gorm:"not null;default:0"`
+
+	// loadedState, loadedVersion and loadedMessageLog hold the values read from the database
+	loadedState      SwapPairState
+	loadedVersion    int64
+	loadedMessageLog string
+
+	// Timestamp
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (SwapPair) TableName() string {
+	return "erc20_swap_pairs"
+}
+
+func (s *SwapPair) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ID = util.ULID()
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *SwapPair) BeforeUpdate(tx *gorm.DB) (err error) {
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *SwapPair) AfterFind(tx *gorm.DB) (err error) {
+	s.MarkLoaded()
+	return nil
+}
+
+// StateChanged tells whether the state differs from the one the SwapPair was loaded or last saved with
+func (s *SwapPair) StateChanged() bool {
+	return s.State != s.loadedState
+}
+
+// EntityName names the SwapPair in errors
+func (s *SwapPair) EntityName() string {
+	return "SwapPair " + s.ID
+}
+
+func (s *SwapPair) Entity() (transition.EntityType, string, string) {
+	return transition.EntityTypeERC20SwapPair, s.ID, s.SrcChainID
+}
+
+// Loaded returns the state and version the SwapPair was loaded or last saved with
+func (s *SwapPair) Loaded() (string, int64) {
+	return string(s.loadedState), s.loadedVersion
+}
+
+func (s *SwapPair) SetVersion(version int64) {
+	s.Version = version
+}
+
+// MarkLoaded makes the current state, version and message of the SwapPair the loaded ones
+func (s *SwapPair) MarkLoaded() {
+	s.loadedState = s.State
+	s.loadedVersion = s.Version
+	s.loadedMessageLog = s.MessageLog
+}
+
+// Transition describes the change from the loaded state to the current one with the outbox event announcing it,
+// the message is kept only if it was set by this change
+func (s *SwapPair) Transition(actor string) (*transition.Transition, *outbox.Event, error) {
+	t := transition.Transition{
+		EntityType: transition.EntityTypeERC20SwapPair,
+		EntityID:   s.ID,
+		FromState:  string(s.loadedState),
+		ToState:    string(s.State),
+		Actor:      actor,
+		TxHash:     s.RegisterTxHash,
+	}
+	if s.CreateTxHash != "" {
+		t.TxHash = s.CreateTxHash
+	}
+	if s.MessageLog != s.loadedMessageLog {
+		t.Message = s.MessageLog
+	}
+
+	decimals := s.Decimals
+	e, err := outbox.NewSwapPairEvent(&t, &outbox.SwapPair{
+		ID:             s.ID,
+		Standard:       "erc20",
+		State:          string(s.State),
+		Available:      s.Available,
+		SrcChainID:     s.SrcChainID,
+		DstChainID:     s.DstChainID,
+		SrcTokenAddr:   s.SrcTokenAddr,
+		DstTokenAddr:   s.DstTokenAddr,
+		SrcTokenName:   s.SrcTokenName,
+		DstTokenName:   s.DstTokenName,
+		Symbol:         s.Symbol,
+		Decimals:       &decimals,
+		MinAmount:      s.MinAmount,
+		MaxAmount:      s.MaxAmount,
+		RegisterTxHash: s.RegisterTxHash,
+		CreateTxHash:   s.CreateTxHash,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "[SwapPair.Transition]: failed to create the event of SwapPair %s", s.ID)
+	}
+
+	return &t, e, nil
+}
+
+func (s *SwapPair) SignaturePayload() string {
+	return fmt.Sprintf("%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v",
+		s.State,
+		s.Symbol,
+		s.Decimals,
+		s.MinAmount,
+		s.MaxAmount,
+		s.SrcChainID,
+		s.DstChainID,
+		s.SrcTokenAddr,
+		s.DstTokenAddr,
+		s.SrcTokenName,
+		s.DstTokenName,
+		s.RegisterTxHash,
+		s.RegisterHeight,
+		s.CreateTxHash,
+		s.CreateHeight,
+	)
+}
+
+func (s *SwapPair) VerifySignature(hmacKey string) bool {
+	oldSig := s.Signature
+	s.UpdateSignature(hmacKey)
+	newSig := s.Signature
+
+	return oldSig == newSig
+}
+
+func (s *SwapPair) UpdateSignature(hmacKey string) {
+	mac := hmac.New(sha256.New, []byte(hmacKey))
+	mac.Write([]byte(s.SignaturePayload()))
+	s.Signature = hex.EncodeToString(mac.Sum(nil))
+}
+
+// CheckAmount returns an error if the amount of a swap is not a positive integer within the bounds of the SwapPair
+func (s *SwapPair) CheckAmount(amount string) error {
+	a, err := util.ParseBigInt(amount)
+	if err != nil {
+		return errors.Wrap(err, "[SwapPair.CheckAmount]: invalid amount")
+	}
+	if a.Sign() <= 0 {
+		return errors.Errorf("[SwapPair.CheckAmount]: amount %s is not positive", amount)
+	}
+	if s.MinAmount != "" {
+		min, err := util.ParseBigInt(s.MinAmount)
+		if err != nil {
+			return errors.Wrapf(err, "[SwapPair.CheckAmount]: invalid min amount of SwapPair %s", s.ID)
+		}
+		if a.Cmp(min) < 0 {
+			return errors.Errorf("[SwapPair.CheckAmount]: amount %s is below the min amount %s", amount, s.MinAmount)
+		}
+	}
+	if s.MaxAmount != "" {
+		max, err := util.ParseBigInt(s.MaxAmount)
+		if err != nil {
+			return errors.Wrapf(err, "[SwapPair.CheckAmount]: invalid max amount of SwapPair %s", s.ID)
+		}
+		if a.Cmp(max) > 0 {
+			return errors.Errorf("[SwapPair.CheckAmount]: amount %s is above the max amount %s", amount, s.MaxAmount)
+		}
+	}
+
+	return nil
+}
+
+// SetAmountBounds sets the bounds of the amount of a swap, an empty bound is not enforced
+func (s *SwapPair) SetAmountBounds(minAmount, maxAmount string) error {
+	min, err := parseBound(minAmount)
+	if err != nil {
+		return errors.Wrap(err, "[SwapPair.SetAmountBounds]: invalid min amount")
+	}
+	max, err := parseBound(maxAmount)
+	if err != nil {
+		return errors.Wrap(err, "[SwapPair.SetAmountBounds]: invalid max amount")
+	}
+	if min != nil && max != nil && min.Cmp(max) > 0 {
+		return errors.Errorf("[SwapPair.SetAmountBounds]: min amount %s is above max amount %s", minAmount, maxAmount)
+	}
+
+	s.MinAmount = minAmount
+	s.MaxAmount = maxAmount
+
+	return nil
+}
+
+// parseBound parses a bound written as a canonical non-negative integer, it returns nil for an empty bound
+func parseBound(bound string) (*big.Int, error) {
+	if bound == "" {
+		return nil, nil
+	}
+
+	v, err := util.ParseBigInt(bound)
+	if err != nil {
+		return nil, err
+	}
+	if v.Sign() < 0 || v.String() != bound {
+		return nil, errors.Errorf("%q is not a non-negative integer", bound)
+	}
+
+	return v, nil
+}
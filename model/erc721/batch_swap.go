@@ -0,0 +1,441 @@
+package erc721
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+const (
+	BatchTokenStatePending = "pending"
+	BatchTokenStateFilling = "filling"
+	BatchTokenStateFilled  = "filled"
+)
+
+// BatchSwap is a swap of several tokens of a collection requested at once. It goes through the states of a Swap,
+// except that it is filled in chunks of token ids small enough for a fill tx to stay under the block gas limit. A
+// confirmed chunk is added to Fills and the BatchSwap goes back to request_confirmed until every token is filled.
+type BatchSwap struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	// Basic Token Information
+	SrcChainID   string `gorm:"not null;index:erc721_batch_swap_request_event,unique,priority:1"`
+	DstChainID   string `gorm:"not null"`
+	SrcTokenAddr string `gorm:"not null"`
+	DstTokenAddr string
+	SrcTokenName string
+	DstTokenName string
+	Sender       string         `gorm:"not null;index:erc721_batch_swap_sender"`
+	Recipient    string         `gorm:"not null;index:erc721_batch_swap_recipient"`
+	IDs          datatypes.JSON `gorm:"not null"`
+	// TokenURIs holds the uri of every token of IDs in the same order, it is only set for forward swaps
+	TokenURIs datatypes.JSON
+	BaseURI   string
+	// ChunkIDs holds the token ids of the fill tx in flight
+	ChunkIDs datatypes.JSON
+	// Fills holds the confirmed fill txs with their token ids
+	Fills     datatypes.JSON
+	Signature string `gorm:"not null"`
+
+	// Swap State
+	State         SwapState     `gorm:"not null"`
+	SwapDirection SwapDirection `gorm:"not null"`
+
+	// Request Transaction Information
+	RequestTxHash       string `gorm:"not null;index:erc721_batch_swap_request_event,unique,priority:2"`
+	RequestHeight       int64  `gorm:"not null"`
+	RequestBlockHash    string `gorm:"not null"`
+	RequestLogIndex     *uint  `gorm:"index:erc721_batch_swap_request_event,unique,priority:3"`
+	RequestContractAddr string
+	RequestBlockLogID   *string    `gorm:"size:26;index:erc721_batch_foreign_key_request_block_log_id"`
+	RequestBlockLog     *block.Log `gorm:"foreignKey:RequestBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	RequestTrackRetry   int64
+
+	// Fill Transaction Information of the chunk in flight
+	FillConsumedFeeAmount string
+	FillGasPrice          string
+	FillGasUsed           int64
+	FillHeight            int64
+	FillTxHash            string
+	FillTrackRetry        int64
+	FillBlockHash         string     `gorm:"not null"`
+	FillBlockLogID        *string    `gorm:"size:26;index:erc721_batch_foreign_key_fill_block_log_id"`
+	FillBlockLog          *block.Log `gorm:"foreignKey:FillBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	MessageLog string
+
+	// Version is increased by every update, see model.CompareAndSave
+	Version int64 `gorm:"not null;default:0"`
+
+	// loadedState, loadedVersion and loadedMessageLog hold the values read from the database
+	loadedState      SwapState
+	loadedVersion    int64
+	loadedMessageLog string
+
+	// Timestamp
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// BatchFill is a confirmed fill tx of a BatchSwap
+type BatchFill struct {
+	TxHash string   `json:"tx_hash"`
+	Height int64    `json:"height"`
+	IDs    []string `json:"ids"`
+}
+
+// BatchToken is the fill status of a token of a BatchSwap
+type BatchToken struct {
+	TokenID string `json:"token_id"`
+	// State is pending, filling or filled
+	State      string `json:"state"`
+	FillTxHash string `json:"fill_tx_hash,omitempty"`
+}
+
+func (BatchSwap) TableName() string {
+	return "erc721_batch_swaps"
+}
+
+func (s *BatchSwap) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ID = util.ULID()
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *BatchSwap) BeforeUpdate(tx *gorm.DB) (err error) {
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *BatchSwap) AfterFind(tx *gorm.DB) (err error) {
+	s.MarkLoaded()
+	return nil
+}
+
+// StateChanged tells whether the state differs from the one the BatchSwap was loaded or last saved with
+func (s *BatchSwap) StateChanged() bool {
+	return s.State != s.loadedState
+}
+
+// EntityName names the BatchSwap in errors
+func (s *BatchSwap) EntityName() string {
+	return "BatchSwap " + s.ID
+}
+
+func (s *BatchSwap) Entity() (transition.EntityType, string, string) {
+	return transition.EntityTypeERC721BatchSwap, s.ID, s.SrcChainID
+}
+
+// Loaded returns the state and version the BatchSwap was loaded or last saved with
+func (s *BatchSwap) Loaded() (string, int64) {
+	return string(s.loadedState), s.loadedVersion
+}
+
+func (s *BatchSwap) SetVersion(version int64) {
+	s.Version = version
+}
+
+// MarkLoaded makes the current state, version and message of the BatchSwap the loaded ones
+func (s *BatchSwap) MarkLoaded() {
+	s.loadedState = s.State
+	s.loadedVersion = s.Version
+	s.loadedMessageLog = s.MessageLog
+}
+
+// Transition describes the change from the loaded state to the current one with the outbox event announcing it,
+// the message is kept only if it was set by this change
+func (s *BatchSwap) Transition(actor string) (*transition.Transition, *outbox.Event, error) {
+	t := transition.Transition{
+		EntityType: transition.EntityTypeERC721BatchSwap,
+		EntityID:   s.ID,
+		FromState:  string(s.loadedState),
+		ToState:    string(s.State),
+		Actor:      actor,
+		TxHash:     s.RequestTxHash,
+	}
+	if s.FillTxHash != "" {
+		t.TxHash = s.FillTxHash
+	}
+	if s.MessageLog != s.loadedMessageLog {
+		t.Message = s.MessageLog
+	}
+
+	tokens, err := s.Tokens()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "[BatchSwap.Transition]: failed to get the tokens of BatchSwap %s", s.ID)
+	}
+	bz, err := json.Marshal(tokens)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "[BatchSwap.Transition]: failed to encode the tokens of BatchSwap %s", s.ID)
+	}
+
+	e, err := outbox.NewSwapEvent(&t, &outbox.Swap{
+		ID:            s.ID,
+		Standard:      "erc721",
+		State:         string(s.State),
+		Direction:     string(s.SwapDirection),
+		SrcChainID:    s.SrcChainID,
+		DstChainID:    s.DstChainID,
+		SrcTokenAddr:  s.SrcTokenAddr,
+		DstTokenAddr:  s.DstTokenAddr,
+		Sender:        s.Sender,
+		Recipient:     s.Recipient,
+		TokenIDs:      json.RawMessage(s.IDs),
+		Tokens:        json.RawMessage(bz),
+		RequestTxHash: s.RequestTxHash,
+		FillTxHash:    s.FillTxHash,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "[BatchSwap.Transition]: failed to create the event of BatchSwap %s", s.ID)
+	}
+
+	return &t, e, nil
+}
+
+func (s *BatchSwap) IsRequiredInfoValid() bool {
+	if s.SrcTokenName == "" {
+		return false
+	}
+	if s.DstTokenAddr == "" {
+		return false
+	}
+	if s.DstTokenName == "" {
+		return false
+	}
+	if s.SwapDirection == SwapDirectionForward && len(s.TokenURIs) == 0 {
+		return false
+	}
+
+	return true
+}
+
+func (s *BatchSwap) SetRequiredInfo(srcTokenName, dstTokenName, dstTokenAddr, baseURI string, tokenURIs []string) error {
+	s.SrcTokenName = srcTokenName
+	s.DstTokenName = dstTokenName
+	s.DstTokenAddr = dstTokenAddr
+	s.BaseURI = baseURI
+	s.TokenURIs = nil
+	if tokenURIs != nil {
+		bz, err := json.Marshal(tokenURIs)
+		if err != nil {
+			return errors.Wrap(err, "[BatchSwap.SetRequiredInfo]: failed to encode token uris")
+		}
+		s.TokenURIs = datatypes.JSON(bz)
+	}
+
+	return nil
+}
+
+// TokenIDs returns the token ids of the request
+func (s *BatchSwap) TokenIDs() ([]string, error) {
+	var ids []string
+	if err := json.Unmarshal(s.IDs, &ids); err != nil {
+		return nil, errors.Wrap(err, "[BatchSwap.TokenIDs]: failed to decode ids")
+	}
+
+	return ids, nil
+}
+
+// TokenURI returns the uri of a token of a forward swap
+func (s *BatchSwap) TokenURI(tokenID string) (string, error) {
+	ids, err := s.TokenIDs()
+	if err != nil {
+		return "", err
+	}
+	var uris []string
+	if err := json.Unmarshal(s.TokenURIs, &uris); err != nil {
+		return "", errors.Wrap(err, "[BatchSwap.TokenURI]: failed to decode token uris")
+	}
+	if len(uris) != len(ids) {
+		return "", errors.Errorf("[BatchSwap.TokenURI]: %d token uris for %d token ids", len(uris), len(ids))
+	}
+
+	for i, id := range ids {
+		if id == tokenID {
+			return uris[i], nil
+		}
+	}
+
+	return "", errors.Errorf("[BatchSwap.TokenURI]: token id %s is not swapped", tokenID)
+}
+
+// BatchFills returns the confirmed fill txs
+func (s *BatchSwap) BatchFills() ([]BatchFill, error) {
+	var ff []BatchFill
+	if len(s.Fills) == 0 {
+		return ff, nil
+	}
+	if err := json.Unmarshal(s.Fills, &ff); err != nil {
+		return nil, errors.Wrap(err, "[BatchSwap.BatchFills]: failed to decode fills")
+	}
+
+	return ff, nil
+}
+
+// Chunk returns the token ids of the fill tx in flight
+func (s *BatchSwap) Chunk() ([]string, error) {
+	var ids []string
+	if len(s.ChunkIDs) == 0 {
+		return ids, nil
+	}
+	if err := json.Unmarshal(s.ChunkIDs, &ids); err != nil {
+		return nil, errors.Wrap(err, "[BatchSwap.Chunk]: failed to decode chunk ids")
+	}
+
+	return ids, nil
+}
+
+// SetChunk sets the token ids of the next fill tx
+func (s *BatchSwap) SetChunk(ids []string) error {
+	bz, err := json.Marshal(ids)
+	if err != nil {
+		return errors.Wrap(err, "[BatchSwap.SetChunk]: failed to encode chunk ids")
+	}
+	s.ChunkIDs = datatypes.JSON(bz)
+
+	return nil
+}
+
+// RemainingIDs returns the token ids which are not filled yet, in the order of the request
+func (s *BatchSwap) RemainingIDs() ([]string, error) {
+	tokens, err := s.Tokens()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, t := range tokens {
+		if t.State != BatchTokenStateFilled {
+			ids = append(ids, t.TokenID)
+		}
+	}
+
+	return ids, nil
+}
+
+// AddFill records the confirmation of the fill tx in flight with its chunk, and clears the chunk
+func (s *BatchSwap) AddFill() error {
+	ff, err := s.BatchFills()
+	if err != nil {
+		return err
+	}
+	chunk, err := s.Chunk()
+	if err != nil {
+		return err
+	}
+
+	ff = append(ff, BatchFill{
+		TxHash: s.FillTxHash,
+		Height: s.FillHeight,
+		IDs:    chunk,
+	})
+	bz, err := json.Marshal(ff)
+	if err != nil {
+		return errors.Wrap(err, "[BatchSwap.AddFill]: failed to encode fills")
+	}
+	s.Fills = datatypes.JSON(bz)
+	s.ChunkIDs = nil
+
+	return nil
+}
+
+// Tokens returns the fill status of every token of the request
+func (s *BatchSwap) Tokens() ([]BatchToken, error) {
+	ids, err := s.TokenIDs()
+	if err != nil {
+		return nil, err
+	}
+	ff, err := s.BatchFills()
+	if err != nil {
+		return nil, err
+	}
+	chunk, err := s.Chunk()
+	if err != nil {
+		return nil, err
+	}
+
+	filled := make(map[string]string)
+	for _, f := range ff {
+		for _, id := range f.IDs {
+			filled[id] = f.TxHash
+		}
+	}
+	filling := make(map[string]bool)
+	for _, id := range chunk {
+		filling[id] = true
+	}
+
+	tokens := make([]BatchToken, 0, len(ids))
+	for _, id := range ids {
+		t := BatchToken{TokenID: id, State: BatchTokenStatePending}
+		if txHash, ok := filled[id]; ok {
+			t.State = BatchTokenStateFilled
+			t.FillTxHash = txHash
+		} else if filling[id] {
+			t.State = BatchTokenStateFilling
+			t.FillTxHash = s.FillTxHash
+		}
+		tokens = append(tokens, t)
+	}
+
+	return tokens, nil
+}
+
+func (s *BatchSwap) SignaturePayload() string {
+	return fmt.Sprintf("%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v",
+		s.State,
+		s.SrcChainID,
+		s.DstChainID,
+		s.SrcTokenAddr,
+		s.DstTokenAddr,
+		s.SrcTokenName,
+		s.DstTokenName,
+		s.Sender,
+		s.Recipient,
+		compactJSON(s.IDs),
+		compactJSON(s.Fills),
+		s.RequestTxHash,
+		s.RequestHeight,
+		s.FillTxHash,
+		s.FillHeight,
+	)
+}
+
+func (s *BatchSwap) VerifySignature(hmacKey string) bool {
+	oldSig := s.Signature
+	s.UpdateSignature(hmacKey)
+	newSig := s.Signature
+
+	return oldSig == newSig
+}
+
+func (s *BatchSwap) UpdateSignature(hmacKey string) {
+	mac := hmac.New(sha256.New, []byte(hmacKey))
+	mac.Write([]byte(s.SignaturePayload()))
+	s.Signature = hex.EncodeToString(mac.Sum(nil))
+}
+
+// compactJSON strips the whitespace that MySQL and PostgreSQL add to stored JSON values,
+// so the signature payload is the same across dialects and before and after a reload
+func compactJSON(j datatypes.JSON) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, j); err != nil {
+		return j.String()
+	}
+
+	return buf.String()
+}
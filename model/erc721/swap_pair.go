@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
@@ -24,13 +27,14 @@ const (
 	SwapPairStateCreationTxConfirmed    SwapPairState = "creation_tx_confirmed"
 	SwapPairStateCreationTxFailed       SwapPairState = "creation_tx_failed"
 	SwapPairStateCreationTxMissing      SwapPairState = "creation_tx_missing"
+	SwapPairStateUnsupportedDestination SwapPairState = "unsupported_destination"
 )
 
 type SwapPair struct {
 	ID string `gorm:"size:26;primary_key"`
 
 	// Basic Token Information
-	SrcChainID   string `gorm:"not null;index:unique_registration,unique,priority:1"`
+	SrcChainID   string `gorm:"not null;index:unique_registration,unique,priority:1;index:erc721_swap_pair_register_event,unique,priority:1"`
 	DstChainID   string `gorm:"not null;index:unique_registration,unique,priority:2"`
 	SrcTokenAddr string `gorm:"not null;index:unique_registration,unique,priority:3"`
 	DstTokenAddr string
@@ -46,11 +50,13 @@ type SwapPair struct {
 	State SwapPairState `gorm:"not null"`
 
 	// Registration Transaction Information
-	RegisterTxHash     string     `gorm:"not null;index:unique_registration,unique,priority:4"`
-	RegisterHeight     int64      `gorm:"not null;index:unique_registration,unique,priority:5"`
-	RegisterBlockHash  string     `gorm:"not null"`
-	RegisterBlockLogID *string    `gorm:"size:26;index:foreign_key_register_block_log_id"`
-	RegisterBlockLog   *block.Log `gorm:"foreignKey:RegisterBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	RegisterTxHash       string `gorm:"not null;index:unique_registration,unique,priority:4;index:erc721_swap_pair_register_event,unique,priority:2"`
+	RegisterHeight       int64  `gorm:"not null;index:unique_registration,unique,priority:5"`
+	RegisterBlockHash    string `gorm:"not null"`
+	RegisterLogIndex     *uint  `gorm:"index:erc721_swap_pair_register_event,unique,priority:3"`
+	RegisterContractAddr string
+	RegisterBlockLogID   *string    `gorm:"size:26;index:foreign_key_register_block_log_id"`
+	RegisterBlockLog     *block.Log `gorm:"foreignKey:RegisterBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
 
 	// Creation Transaction Information
 	CreateConsumedFeeAmount string
@@ -65,6 +71,14 @@ type SwapPair struct {
 
 	MessageLog string
 
+	// Version is increased by every update, see model.CompareAndSave
+	Version int64 `gorm:"not null;default:0"`
+
+	// loadedState, loadedVersion and loadedMessageLog hold the values read from the database
+	loadedState      SwapPairState
+	loadedVersion    int64
+	loadedMessageLog string
+
 	// Timestamp
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -86,6 +100,82 @@ func (s *SwapPair) BeforeUpdate(tx *gorm.DB) (err error) {
 	return nil
 }
 
+func (s *SwapPair) AfterFind(tx *gorm.DB) (err error) {
+	s.MarkLoaded()
+	return nil
+}
+
+// StateChanged tells whether the state differs from the one the SwapPair was loaded or last saved with
+func (s *SwapPair) StateChanged() bool {
+	return s.State != s.loadedState
+}
+
+// EntityName names the SwapPair in errors
+func (s *SwapPair) EntityName() string {
+	return "SwapPair " + s.ID
+}
+
+func (s *SwapPair) Entity() (transition.EntityType, string, string) {
+	return transition.EntityTypeERC721SwapPair, s.ID, s.SrcChainID
+}
+
+// Loaded returns the state and version the SwapPair was loaded or last saved with
+func (s *SwapPair) Loaded() (string, int64) {
+	return string(s.loadedState), s.loadedVersion
+}
+
+func (s *SwapPair) SetVersion(version int64) {
+	s.Version = version
+}
+
+// MarkLoaded makes the current state, version and message of the SwapPair the loaded ones
+func (s *SwapPair) MarkLoaded() {
+	s.loadedState = s.State
+	s.loadedVersion = s.Version
+	s.loadedMessageLog = s.MessageLog
+}
+
+// Transition describes the change from the loaded state to the current one with the outbox event announcing it,
+// the message is kept only if it was set by this change
+func (s *SwapPair) Transition(actor string) (*transition.Transition, *outbox.Event, error) {
+	t := transition.Transition{
+		EntityType: transition.EntityTypeERC721SwapPair,
+		EntityID:   s.ID,
+		FromState:  string(s.loadedState),
+		ToState:    string(s.State),
+		Actor:      actor,
+		TxHash:     s.RegisterTxHash,
+	}
+	if s.CreateTxHash != "" {
+		t.TxHash = s.CreateTxHash
+	}
+	if s.MessageLog != s.loadedMessageLog {
+		t.Message = s.MessageLog
+	}
+
+	e, err := outbox.NewSwapPairEvent(&t, &outbox.SwapPair{
+		ID:             s.ID,
+		Standard:       "erc721",
+		State:          string(s.State),
+		Available:      s.Available,
+		SrcChainID:     s.SrcChainID,
+		DstChainID:     s.DstChainID,
+		SrcTokenAddr:   s.SrcTokenAddr,
+		DstTokenAddr:   s.DstTokenAddr,
+		SrcTokenName:   s.SrcTokenName,
+		DstTokenName:   s.DstTokenName,
+		Symbol:         s.Symbol,
+		BaseURI:        s.BaseURI,
+		RegisterTxHash: s.RegisterTxHash,
+		CreateTxHash:   s.CreateTxHash,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "[SwapPair.Transition]: failed to create the event of SwapPair %s", s.ID)
+	}
+
+	return &t, e, nil
+}
+
 func (s *SwapPair) SignaturePayload() string {
 	return fmt.Sprintf("%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v#%v",
 		s.State,
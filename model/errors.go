@@ -0,0 +1,8 @@
+package model
+
+import (
+	"errors"
+)
+
+// ErrVersionConflict is returned when a row was updated by another process since it was loaded
+var ErrVersionConflict = errors.New("row was updated by another process")
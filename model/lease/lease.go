@@ -0,0 +1,17 @@
+package lease
+
+import (
+	"time"
+)
+
+// Lease grants the role of its name to the holder until it expires
+type Lease struct {
+	Name      string    `gorm:"size:191;primary_key"`
+	Holder    string    `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time
+}
+
+func (Lease) TableName() string {
+	return "leases"
+}
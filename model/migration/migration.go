@@ -0,0 +1,212 @@
+// Package migration holds the versioned schema migrations of the database.
+// A migration is never changed once released, every schema change is appended as a new migration.
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SchemaMigration records an applied migration
+type SchemaMigration struct {
+	Version   uint   `gorm:"primary_key;autoIncrement:false"`
+	Name      string `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Status struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// migrations holds every migration, ordered by version
+var migrations = []*Migration{
+	v1Initial,
+	v2EventIdentity,
+	v3Version,
+	v4Leases,
+	v5StateTransitions,
+	v6SwapPartyIndexes,
+	v7Webhooks,
+	v8OutboxPublishing,
+	v9ERC20,
+	v10ERC721BatchSwaps,
+	v11StateTransitionCursors,
+	v12FillBatches,
+}
+
+func init() {
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for idx, m := range migrations {
+		if m.Version != uint(idx+1) {
+			panic(fmt.Sprintf("migration %s has version %d, expected %d", m.Name, m.Version, idx+1))
+		}
+	}
+}
+
+// Latest returns the version of the latest migration known by the binary
+func Latest() uint {
+	return migrations[len(migrations)-1].Version
+}
+
+// Up applies all pending migrations in order
+func Up(db *gorm.DB) error {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return errors.Wrap(err, "[Up]: failed to get applied migrations")
+	}
+
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+
+			return tx.Create(&SchemaMigration{
+				Version:   m.Version,
+				Name:      m.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return errors.Wrapf(err, "[Up]: failed to apply migration %d %s", m.Version, m.Name)
+		}
+	}
+
+	return nil
+}
+
+// Down rolls back the latest steps applied migrations
+func Down(db *gorm.DB, steps int) error {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return errors.Wrap(err, "[Down]: failed to get applied migrations")
+	}
+
+	for idx := len(migrations) - 1; idx >= 0 && steps > 0; idx-- {
+		m := migrations[idx]
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+
+			return tx.Delete(&SchemaMigration{}, m.Version).Error
+		})
+		if err != nil {
+			return errors.Wrapf(err, "[Down]: failed to roll back migration %d %s", m.Version, m.Name)
+		}
+		steps--
+	}
+
+	return nil
+}
+
+// Statuses returns the state of every migration known by the binary
+func Statuses(db *gorm.DB) ([]*Status, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Statuses]: failed to get applied migrations")
+	}
+
+	var ss []*Status
+	for _, m := range migrations {
+		s := &Status{
+			Version: m.Version,
+			Name:    m.Name,
+		}
+		if a, ok := applied[m.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = &a.AppliedAt
+		}
+		ss = append(ss, s)
+	}
+
+	return ss, nil
+}
+
+// Check returns an error unless the schema matches the migrations known by the binary
+func Check(db *gorm.DB) error {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return errors.Wrap(err, "[Check]: failed to get applied migrations")
+	}
+
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; !ok {
+			return errors.Errorf("[Check]: schema is behind, migration %d %s is not applied", m.Version, m.Name)
+		}
+	}
+	for version := range applied {
+		if version > Latest() {
+			return errors.Errorf("[Check]: schema is ahead, migration %d is unknown to this binary", version)
+		}
+	}
+
+	return nil
+}
+
+// dropColumn drops the column of the model field. SQLite drops it in place, since the migrator would rebuild
+// the table and lose the indexes of earlier migrations on the way
+func dropColumn(tx *gorm.DB, model interface{}, field string) error {
+	if tx.Dialector.Name() != "sqlite" {
+		return tx.Migrator().DropColumn(model, field)
+	}
+
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil {
+		return errors.Wrap(err, "[dropColumn]: failed to parse model")
+	}
+
+	column := field
+	if f := stmt.Schema.LookUpField(field); f != nil {
+		column = f.DBName
+	}
+
+	return tx.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: stmt.Table}, clause.Column{Name: column}).Error
+}
+
+func appliedMigrations(db *gorm.DB) (map[uint]*SchemaMigration, error) {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, errors.Wrap(err, "[appliedMigrations]: failed to create schema_migrations table")
+	}
+
+	var mm []*SchemaMigration
+	if err := db.Order("version asc").Find(&mm).Error; err != nil {
+		return nil, errors.Wrap(err, "[appliedMigrations]: failed to query schema_migrations")
+	}
+
+	applied := make(map[uint]*SchemaMigration, len(mm))
+	for _, m := range mm {
+		applied[m.Version] = m
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,110 @@
+package migration_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/common"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/migration"
+)
+
+// dialects returns the databases to run the migrations against. SQLite always runs in memory, MySQL and Postgres
+// run when MIGRATION_TEST_MYSQL_DSN and MIGRATION_TEST_POSTGRES_DSN point to an empty database.
+func dialects() map[string]string {
+	dsns := map[string]string{
+		common.DBDialectSqlite3: "file:migration_test?mode=memory&cache=shared",
+	}
+	if dsn := os.Getenv("MIGRATION_TEST_MYSQL_DSN"); dsn != "" {
+		dsns[common.DBDialectMysql] = dsn
+	}
+	if dsn := os.Getenv("MIGRATION_TEST_POSTGRES_DSN"); dsn != "" {
+		dsns[common.DBDialectPostgres] = dsn
+	}
+
+	return dsns
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, dialect := range []string{common.DBDialectSqlite3, common.DBDialectMysql, common.DBDialectPostgres} {
+		dialect := dialect
+		t.Run(dialect, func(t *testing.T) {
+			dsn, ok := dialects()[dialect]
+			if !ok {
+				t.Skipf("no %s database configured", dialect)
+			}
+
+			db, err := model.Open(dialect, dsn, &gorm.Config{
+				Logger: logger.Default.LogMode(logger.Silent),
+			})
+			if err != nil {
+				t.Fatalf("failed to open db: %v", err)
+			}
+
+			roundTrip(t, db)
+		})
+	}
+}
+
+func roundTrip(t *testing.T, db *gorm.DB) {
+	if err := migration.Up(db); err != nil {
+		t.Fatalf("failed to migrate up: %v", err)
+	}
+	if err := migration.Check(db); err != nil {
+		t.Fatalf("schema does not match after up: %v", err)
+	}
+	objects := schemaObjects(t, db)
+
+	// roll back one migration at a time and apply it again, so that every down leaves the schema its up expects
+	for step := 1; step <= int(migration.Latest()); step++ {
+		if err := migration.Down(db, step); err != nil {
+			t.Fatalf("failed to migrate down %d steps: %v", step, err)
+		}
+		if err := migration.Up(db); err != nil {
+			t.Fatalf("failed to migrate up again after %d steps down: %v", step, err)
+		}
+		if err := migration.Check(db); err != nil {
+			t.Fatalf("schema does not match after %d steps down and up: %v", step, err)
+		}
+	}
+
+	if got := schemaObjects(t, db); fmt.Sprint(got) != fmt.Sprint(objects) {
+		t.Fatalf("tables or indexes changed after the round trip, got %v, want %v", got, objects)
+	}
+
+	if err := migration.Down(db, int(migration.Latest())); err != nil {
+		t.Fatalf("failed to migrate down: %v", err)
+	}
+	if got := schemaObjects(t, db); len(got) != 1 || got[0] != "schema_migrations" {
+		t.Fatalf("expected only schema_migrations after a full down, got %v", got)
+	}
+}
+
+// schemaObjects returns the names of the tables, and of the indexes as table.index, sorted
+func schemaObjects(t *testing.T, db *gorm.DB) []string {
+	var query string
+	switch db.Dialector.Name() {
+	case "sqlite":
+		query = `SELECT CASE type WHEN 'index' THEN tbl_name || '.' || name ELSE name END AS name FROM sqlite_master
+			WHERE type IN ('table', 'index') AND name NOT LIKE 'sqlite_%' ORDER BY 1`
+	case "mysql":
+		query = `SELECT table_name AS name FROM information_schema.tables WHERE table_schema = DATABASE()
+			UNION SELECT DISTINCT CONCAT(table_name, '.', index_name) FROM information_schema.statistics
+			WHERE table_schema = DATABASE() AND index_name <> 'PRIMARY' ORDER BY 1`
+	default:
+		query = `SELECT table_name AS name FROM information_schema.tables WHERE table_schema = CURRENT_SCHEMA()
+			UNION SELECT tablename || '.' || indexname FROM pg_indexes
+			WHERE schemaname = CURRENT_SCHEMA() AND indexname NOT LIKE '%_pkey' ORDER BY 1`
+	}
+
+	var names []string
+	if err := db.Raw(query).Scan(&names).Error; err != nil {
+		t.Fatalf("failed to list schema objects: %v", err)
+	}
+
+	return names
+}
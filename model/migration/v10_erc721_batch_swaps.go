@@ -0,0 +1,71 @@
+package migration
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// v10ERC721BatchSwaps creates the table of the ERC721 swaps of several tokens at once
+var v10ERC721BatchSwaps = &Migration{
+	Version: 10,
+	Name:    "erc721_batch_swaps",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&v10ERC721BatchSwap{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&v10ERC721BatchSwap{})
+	},
+}
+
+type v10ERC721BatchSwap struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	SrcChainID   string `gorm:"not null;index:erc721_batch_swap_request_event,unique,priority:1"`
+	DstChainID   string `gorm:"not null"`
+	SrcTokenAddr string `gorm:"not null"`
+	DstTokenAddr string
+	SrcTokenName string
+	DstTokenName string
+	Sender       string         `gorm:"not null;index:erc721_batch_swap_sender"`
+	Recipient    string         `gorm:"not null;index:erc721_batch_swap_recipient"`
+	IDs          datatypes.JSON `gorm:"not null"`
+	TokenURIs    datatypes.JSON
+	BaseURI      string
+	ChunkIDs     datatypes.JSON
+	Fills        datatypes.JSON
+	Signature    string `gorm:"not null"`
+
+	State         string `gorm:"not null"`
+	SwapDirection string `gorm:"not null"`
+
+	RequestTxHash       string `gorm:"not null;index:erc721_batch_swap_request_event,unique,priority:2"`
+	RequestHeight       int64  `gorm:"not null"`
+	RequestBlockHash    string `gorm:"not null"`
+	RequestLogIndex     *uint  `gorm:"index:erc721_batch_swap_request_event,unique,priority:3"`
+	RequestContractAddr string
+	RequestBlockLogID   *string     `gorm:"size:26;index:erc721_batch_foreign_key_request_block_log_id"`
+	RequestBlockLog     *v1BlockLog `gorm:"foreignKey:RequestBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	RequestTrackRetry   int64
+
+	FillConsumedFeeAmount string
+	FillGasPrice          string
+	FillGasUsed           int64
+	FillHeight            int64
+	FillTxHash            string
+	FillTrackRetry        int64
+	FillBlockHash         string      `gorm:"not null"`
+	FillBlockLogID        *string     `gorm:"size:26;index:erc721_batch_foreign_key_fill_block_log_id"`
+	FillBlockLog          *v1BlockLog `gorm:"foreignKey:FillBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	MessageLog string
+	Version    int64 `gorm:"not null;default:0"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (v10ERC721BatchSwap) TableName() string {
+	return "erc721_batch_swaps"
+}
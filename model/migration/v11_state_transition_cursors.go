@@ -0,0 +1,29 @@
+package migration
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// v11StateTransitionCursors creates the table of the positions of the consumers reading the state transitions
+var v11StateTransitionCursors = &Migration{
+	Version: 11,
+	Name:    "state_transition_cursors",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&v11StateTransitionCursor{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&v11StateTransitionCursor{})
+	},
+}
+
+type v11StateTransitionCursor struct {
+	Name         string `gorm:"size:191;primary_key"`
+	TransitionID string `gorm:"size:26;not null"`
+	UpdatedAt    time.Time
+}
+
+func (v11StateTransitionCursor) TableName() string {
+	return "state_transition_cursors"
+}
@@ -0,0 +1,55 @@
+package migration
+
+import (
+	"gorm.io/gorm"
+)
+
+// v12FillBatches adds the fill batch tx hash of the swaps filled in a batch
+var v12FillBatches = &Migration{
+	Version: 12,
+	Name:    "fill_batches",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(
+			&v12ERC721Swap{},
+			&v12ERC1155Swap{},
+			&v12ERC20Swap{},
+		)
+	},
+	Down: func(tx *gorm.DB) error {
+		for _, m := range []interface{}{
+			&v12ERC721Swap{},
+			&v12ERC1155Swap{},
+			&v12ERC20Swap{},
+		} {
+			if err := dropColumn(tx, m, "FillBatchTxHash"); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+type v12ERC721Swap struct {
+	FillBatchTxHash string
+}
+
+func (v12ERC721Swap) TableName() string {
+	return "erc721_swaps"
+}
+
+type v12ERC1155Swap struct {
+	FillBatchTxHash string
+}
+
+func (v12ERC1155Swap) TableName() string {
+	return "erc1155_swaps"
+}
+
+type v12ERC20Swap struct {
+	FillBatchTxHash string
+}
+
+func (v12ERC20Swap) TableName() string {
+	return "erc20_swaps"
+}
@@ -0,0 +1,252 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// v1Initial creates the tables of the original models. On a database created by AutoMigrate it only
+// renames the ERC1155 indexes that shared their names with the ERC721 ones.
+var v1Initial = &Migration{
+	Version: 1,
+	Name:    "initial",
+	Up: func(tx *gorm.DB) error {
+		if err := v1RenameLegacyIndexes(tx); err != nil {
+			return err
+		}
+
+		return tx.AutoMigrate(
+			&v1BlockLog{},
+			&v1ERC721SwapPair{},
+			&v1ERC721Swap{},
+			&v1ERC1155SwapPair{},
+			&v1ERC1155Swap{},
+		)
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(
+			&v1ERC1155Swap{},
+			&v1ERC1155SwapPair{},
+			&v1ERC721Swap{},
+			&v1ERC721SwapPair{},
+			&v1BlockLog{},
+		)
+	},
+}
+
+var v1LegacyIndexes = map[string]string{
+	"unique_registration":               "erc1155_unique_registration",
+	"foreign_key_register_block_log_id": "erc1155_foreign_key_register_block_log_id",
+	"foreign_key_create_block_log_id":   "erc1155_foreign_key_create_block_log_id",
+	"foreign_key_request_block_log_id":  "erc1155_foreign_key_request_block_log_id",
+	"foreign_key_fill_block_log_id":     "erc1155_foreign_key_fill_block_log_id",
+}
+
+func v1RenameLegacyIndexes(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	for _, m := range []interface{}{&v1ERC1155SwapPair{}, &v1ERC1155Swap{}} {
+		if !migrator.HasTable(m) {
+			continue
+		}
+
+		for oldName, newName := range v1LegacyIndexes {
+			if !migrator.HasIndex(m, oldName) {
+				continue
+			}
+			if err := migrator.RenameIndex(m, oldName, newName); err != nil {
+				return errors.Wrapf(err, "[v1RenameLegacyIndexes]: failed to rename index %s of %T", oldName, m)
+			}
+		}
+	}
+
+	return nil
+}
+
+type v1BlockLog struct {
+	ID         string `gorm:"size:26;primary_key"`
+	ChainID    string `gorm:"not null;index:chain_id"`
+	BlockHash  string `gorm:"not null;index:block_hash"`
+	ParentHash string `gorm:"not null;index:parent_hash"`
+	Height     int64  `gorm:"not null;index:height"`
+	BlockTime  int64
+	CreateTime time.Time
+}
+
+func (v1BlockLog) TableName() string {
+	return "block_logs"
+}
+
+type v1ERC721SwapPair struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	SrcChainID   string `gorm:"not null;index:unique_registration,unique,priority:1"`
+	DstChainID   string `gorm:"not null;index:unique_registration,unique,priority:2"`
+	SrcTokenAddr string `gorm:"not null;index:unique_registration,unique,priority:3"`
+	DstTokenAddr string
+	SrcTokenName string `gorm:"not null"`
+	DstTokenName string
+	Sponsor      string `gorm:"not null"`
+	Available    bool   `gorm:"not null"`
+	Signature    string `gorm:"not null"`
+	Symbol       string `gorm:"not null"`
+	BaseURI      string
+
+	State string `gorm:"not null"`
+
+	RegisterTxHash     string      `gorm:"not null;index:unique_registration,unique,priority:4"`
+	RegisterHeight     int64       `gorm:"not null;index:unique_registration,unique,priority:5"`
+	RegisterBlockHash  string      `gorm:"not null"`
+	RegisterBlockLogID *string     `gorm:"size:26;index:foreign_key_register_block_log_id"`
+	RegisterBlockLog   *v1BlockLog `gorm:"foreignKey:RegisterBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	CreateConsumedFeeAmount string
+	CreateGasPrice          string
+	CreateGasUsed           int64
+	CreateHeight            int64
+	CreateTxHash            string
+	CreateTrackRetry        int64
+	CreateBlockHash         string      `gorm:"not null"`
+	CreateBlockLogID        *string     `gorm:"size:26;index:foreign_key_create_block_log_id"`
+	CreateBlockLog          *v1BlockLog `gorm:"foreignKey:CreateBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	MessageLog string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (v1ERC721SwapPair) TableName() string {
+	return "erc721_swap_pairs"
+}
+
+type v1ERC721Swap struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	SrcChainID   string `gorm:"not null"`
+	DstChainID   string `gorm:"not null"`
+	SrcTokenAddr string `gorm:"not null"`
+	DstTokenAddr string
+	SrcTokenName string
+	DstTokenName string
+	Sender       string `gorm:"not null"`
+	Recipient    string `gorm:"not null"`
+	TokenID      string `gorm:"not null"`
+	TokenURI     string `gorm:"not null"`
+	BaseURI      string
+	Signature    string `gorm:"not null"`
+
+	State         string `gorm:"not null"`
+	SwapDirection string `gorm:"not null"`
+
+	RequestTxHash     string      `gorm:"not null"`
+	RequestHeight     int64       `gorm:"not null"`
+	RequestBlockHash  string      `gorm:"not null"`
+	RequestBlockLogID *string     `gorm:"size:26;index:foreign_key_request_block_log_id"`
+	RequestBlockLog   *v1BlockLog `gorm:"foreignKey:RequestBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	RequestTrackRetry int64
+
+	FillConsumedFeeAmount string
+	FillGasPrice          string
+	FillGasUsed           int64
+	FillHeight            int64
+	FillTxHash            string
+	FillTrackRetry        int64
+	FillBlockHash         string      `gorm:"not null"`
+	FillBlockLogID        *string     `gorm:"size:26;index:foreign_key_fill_block_log_id"`
+	FillBlockLog          *v1BlockLog `gorm:"foreignKey:FillBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	MessageLog string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (v1ERC721Swap) TableName() string {
+	return "erc721_swaps"
+}
+
+type v1ERC1155SwapPair struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	SrcChainID   string `gorm:"not null;index:erc1155_unique_registration,unique,priority:1"`
+	DstChainID   string `gorm:"not null;index:erc1155_unique_registration,unique,priority:2"`
+	SrcTokenAddr string `gorm:"not null;index:erc1155_unique_registration,unique,priority:3"`
+	DstTokenAddr string
+	Sponsor      string `gorm:"not null"`
+	Available    bool   `gorm:"not null"`
+	Signature    string `gorm:"not null"`
+	URI          string
+
+	State string `gorm:"not null"`
+
+	RegisterTxHash     string      `gorm:"not null;index:erc1155_unique_registration,unique,priority:4"`
+	RegisterHeight     int64       `gorm:"not null;index:erc1155_unique_registration,unique,priority:5"`
+	RegisterBlockHash  string      `gorm:"not null"`
+	RegisterBlockLogID *string     `gorm:"size:26;index:erc1155_foreign_key_register_block_log_id"`
+	RegisterBlockLog   *v1BlockLog `gorm:"foreignKey:RegisterBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	CreateConsumedFeeAmount string
+	CreateGasPrice          string
+	CreateGasUsed           int64
+	CreateHeight            int64
+	CreateTxHash            string
+	CreateTrackRetry        int64
+	CreateBlockHash         string      `gorm:"not null"`
+	CreateBlockLogID        *string     `gorm:"size:26;index:erc1155_foreign_key_create_block_log_id"`
+	CreateBlockLog          *v1BlockLog `gorm:"foreignKey:CreateBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	MessageLog string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (v1ERC1155SwapPair) TableName() string {
+	return "erc1155_swap_pairs"
+}
+
+type v1ERC1155Swap struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	SrcChainID   string `gorm:"not null"`
+	DstChainID   string `gorm:"not null"`
+	SrcTokenAddr string `gorm:"not null"`
+	DstTokenAddr string
+	Sender       string         `gorm:"not null"`
+	Recipient    string         `gorm:"not null"`
+	IDs          datatypes.JSON `gorm:"not null"`
+	Amounts      datatypes.JSON `gorm:"not null"`
+	Signature    string         `gorm:"not null"`
+
+	State         string `gorm:"not null"`
+	SwapDirection string `gorm:"not null"`
+
+	RequestTxHash     string      `gorm:"not null"`
+	RequestHeight     int64       `gorm:"not null"`
+	RequestBlockHash  string      `gorm:"not null"`
+	RequestBlockLogID *string     `gorm:"size:26;index:erc1155_foreign_key_request_block_log_id"`
+	RequestBlockLog   *v1BlockLog `gorm:"foreignKey:RequestBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	RequestTrackRetry int64
+
+	FillConsumedFeeAmount string
+	FillGasPrice          string
+	FillGasUsed           int64
+	FillHeight            int64
+	FillTxHash            string
+	FillTrackRetry        int64
+	FillBlockHash         string      `gorm:"not null"`
+	FillBlockLogID        *string     `gorm:"size:26;index:erc1155_foreign_key_fill_block_log_id"`
+	FillBlockLog          *v1BlockLog `gorm:"foreignKey:FillBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	MessageLog string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (v1ERC1155Swap) TableName() string {
+	return "erc1155_swaps"
+}
@@ -0,0 +1,231 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// v2EventIdentity stores the log index and the contract address of the recorded events, and makes
+// (chain, tx hash, log index) unique. Rows recorded before keep a NULL log index, their exact duplicates
+// are removed, and the recorder stores the log index of such a row once it records its event again.
+var v2EventIdentity = &Migration{
+	Version: 2,
+	Name:    "event_identity",
+	Up: func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(
+			&v2ERC721Swap{},
+			&v2ERC1155Swap{},
+			&v2ERC721SwapPair{},
+			&v2ERC1155SwapPair{},
+		); err != nil {
+			return err
+		}
+
+		for _, t := range v2DedupeTables {
+			if err := v2Dedupe(tx, t); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+	Down: func(tx *gorm.DB) error {
+		migrator := tx.Migrator()
+		for _, m := range []struct {
+			model  interface{}
+			index  string
+			prefix string
+		}{
+			{&v2ERC721Swap{}, "erc721_swap_request_event", "Request"},
+			{&v2ERC1155Swap{}, "erc1155_swap_request_event", "Request"},
+			{&v2ERC721SwapPair{}, "erc721_swap_pair_register_event", "Register"},
+			{&v2ERC1155SwapPair{}, "erc1155_swap_pair_register_event", "Register"},
+		} {
+			if err := migrator.DropIndex(m.model, m.index); err != nil {
+				return err
+			}
+			if err := dropColumn(tx, m.model, m.prefix+"LogIndex"); err != nil {
+				return err
+			}
+			if err := dropColumn(tx, m.model, m.prefix+"ContractAddr"); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+type v2ERC721Swap struct {
+	SrcChainID          string `gorm:"not null;index:erc721_swap_request_event,unique,priority:1"`
+	RequestTxHash       string `gorm:"not null;index:erc721_swap_request_event,unique,priority:2"`
+	RequestLogIndex     *uint  `gorm:"index:erc721_swap_request_event,unique,priority:3"`
+	RequestContractAddr string
+}
+
+func (v2ERC721Swap) TableName() string {
+	return "erc721_swaps"
+}
+
+type v2ERC1155Swap struct {
+	SrcChainID          string `gorm:"not null;index:erc1155_swap_request_event,unique,priority:1"`
+	RequestTxHash       string `gorm:"not null;index:erc1155_swap_request_event,unique,priority:2"`
+	RequestLogIndex     *uint  `gorm:"index:erc1155_swap_request_event,unique,priority:3"`
+	RequestContractAddr string
+}
+
+func (v2ERC1155Swap) TableName() string {
+	return "erc1155_swaps"
+}
+
+type v2ERC721SwapPair struct {
+	SrcChainID           string `gorm:"not null;index:erc721_swap_pair_register_event,unique,priority:1"`
+	RegisterTxHash       string `gorm:"not null;index:erc721_swap_pair_register_event,unique,priority:2"`
+	RegisterLogIndex     *uint  `gorm:"index:erc721_swap_pair_register_event,unique,priority:3"`
+	RegisterContractAddr string
+}
+
+func (v2ERC721SwapPair) TableName() string {
+	return "erc721_swap_pairs"
+}
+
+type v2ERC1155SwapPair struct {
+	SrcChainID           string `gorm:"not null;index:erc1155_swap_pair_register_event,unique,priority:1"`
+	RegisterTxHash       string `gorm:"not null;index:erc1155_swap_pair_register_event,unique,priority:2"`
+	RegisterLogIndex     *uint  `gorm:"index:erc1155_swap_pair_register_event,unique,priority:3"`
+	RegisterContractAddr string
+}
+
+func (v2ERC1155SwapPair) TableName() string {
+	return "erc1155_swap_pairs"
+}
+
+type v2DedupeTable struct {
+	table string
+	// keyColumns identify the same event recorded more than once
+	keyColumns     []string
+	logIndexColumn string
+	// progressColumn is not empty once a transaction was sent for the row
+	progressColumn string
+}
+
+var v2DedupeTables = []*v2DedupeTable{
+	{
+		table:          "erc721_swaps",
+		keyColumns:     []string{"src_chain_id", "request_tx_hash", "swap_direction", "src_token_addr", "sender", "recipient", "token_id"},
+		logIndexColumn: "request_log_index",
+		progressColumn: "fill_tx_hash",
+	},
+	{
+		table:          "erc1155_swaps",
+		keyColumns:     []string{"src_chain_id", "request_tx_hash", "swap_direction", "src_token_addr", "sender", "recipient", "ids", "amounts"},
+		logIndexColumn: "request_log_index",
+		progressColumn: "fill_tx_hash",
+	},
+	{
+		table:          "erc721_swap_pairs",
+		keyColumns:     []string{"src_chain_id", "dst_chain_id", "src_token_addr", "register_tx_hash"},
+		logIndexColumn: "register_log_index",
+		progressColumn: "create_tx_hash",
+	},
+	{
+		table:          "erc1155_swap_pairs",
+		keyColumns:     []string{"src_chain_id", "dst_chain_id", "src_token_addr", "register_tx_hash"},
+		logIndexColumn: "register_log_index",
+		progressColumn: "create_tx_hash",
+	},
+}
+
+// v2Dedupe removes the duplicates of every group of rows recorded for the same event. The row a transaction
+// was sent for is kept, otherwise the oldest one. A group with more than one sent transaction fails the migration,
+// since only an operator can tell which of them was mined.
+func v2Dedupe(tx *gorm.DB, t *v2DedupeTable) error {
+	var rows []map[string]interface{}
+	err := tx.Table(
+		t.table,
+	).Select(
+		append([]string{"id", t.progressColumn}, t.keyColumns...),
+	).Where(
+		fmt.Sprintf("%s is null", t.logIndexColumn),
+	).Order(
+		"id asc",
+	).Find(&rows).Error
+	if err != nil {
+		return errors.Wrapf(err, "[v2Dedupe]: failed to query %s", t.table)
+	}
+
+	var keys []string
+	groups := make(map[string][]map[string]interface{})
+	for _, r := range rows {
+		var values []string
+		for _, c := range t.keyColumns {
+			values = append(values, v2String(r[c]))
+		}
+
+		key := strings.Join(values, "#")
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], r)
+	}
+
+	var deleted []string
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) < 2 {
+			continue
+		}
+
+		var progressed []string
+		for _, r := range group {
+			if v2String(r[t.progressColumn]) != "" {
+				progressed = append(progressed, v2String(r["id"]))
+			}
+		}
+		if len(progressed) > 1 {
+			return errors.Errorf("[v2Dedupe]: %s rows %s were recorded for the same event and all sent a transaction, "+
+				"keep the row whose transaction was mined, delete the others and run the migration again",
+				t.table, strings.Join(progressed, ", "))
+		}
+
+		keep := v2String(group[0]["id"])
+		if len(progressed) == 1 {
+			keep = progressed[0]
+		}
+
+		var ids []string
+		for _, r := range group {
+			if id := v2String(r["id"]); id != keep {
+				ids = append(ids, id)
+			}
+		}
+		if err := tx.Exec(fmt.Sprintf("delete from %s where id in ?", t.table), ids).Error; err != nil {
+			return errors.Wrapf(err, "[v2Dedupe]: failed to delete duplicates of %s row %s", t.table, keep)
+		}
+
+		util.Logger.Infof("[v2Dedupe]: %s kept row %s, deleted duplicates %s", t.table, keep, strings.Join(ids, ", "))
+		deleted = append(deleted, ids...)
+	}
+
+	if len(deleted) > 0 {
+		util.Logger.Infof("[v2Dedupe]: %s deleted %d duplicated rows", t.table, len(deleted))
+	}
+
+	return nil
+}
+
+func v2String(v interface{}) string {
+	switch vv := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(vv)
+	}
+
+	return fmt.Sprint(v)
+}
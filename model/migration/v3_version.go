@@ -0,0 +1,65 @@
+package migration
+
+import (
+	"gorm.io/gorm"
+)
+
+// v3Version adds the version column used by the optimistic locking of state transitions
+var v3Version = &Migration{
+	Version: 3,
+	Name:    "version",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(
+			&v3ERC721Swap{},
+			&v3ERC1155Swap{},
+			&v3ERC721SwapPair{},
+			&v3ERC1155SwapPair{},
+		)
+	},
+	Down: func(tx *gorm.DB) error {
+		for _, m := range []interface{}{
+			&v3ERC721Swap{},
+			&v3ERC1155Swap{},
+			&v3ERC721SwapPair{},
+			&v3ERC1155SwapPair{},
+		} {
+			if err := dropColumn(tx, m, "Version"); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+type v3ERC721Swap struct {
+	Version int64 `gorm:"not null;default:0"`
+}
+
+func (v3ERC721Swap) TableName() string {
+	return "erc721_swaps"
+}
+
+type v3ERC1155Swap struct {
+	Version int64 `gorm:"not null;default:0"`
+}
+
+func (v3ERC1155Swap) TableName() string {
+	return "erc1155_swaps"
+}
+
+type v3ERC721SwapPair struct {
+	Version int64 `gorm:"not null;default:0"`
+}
+
+func (v3ERC721SwapPair) TableName() string {
+	return "erc721_swap_pairs"
+}
+
+type v3ERC1155SwapPair struct {
+	Version int64 `gorm:"not null;default:0"`
+}
+
+func (v3ERC1155SwapPair) TableName() string {
+	return "erc1155_swap_pairs"
+}
@@ -0,0 +1,30 @@
+package migration
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// v4Leases creates the table of the leases electing a leader per role among the replicas
+var v4Leases = &Migration{
+	Version: 4,
+	Name:    "leases",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&v4Lease{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&v4Lease{})
+	},
+}
+
+type v4Lease struct {
+	Name      string    `gorm:"size:191;primary_key"`
+	Holder    string    `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time
+}
+
+func (v4Lease) TableName() string {
+	return "leases"
+}
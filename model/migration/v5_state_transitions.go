@@ -0,0 +1,35 @@
+package migration
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// v5StateTransitions creates the audit log of swap and swap pair state changes
+var v5StateTransitions = &Migration{
+	Version: 5,
+	Name:    "state_transitions",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&v5StateTransition{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&v5StateTransition{})
+	},
+}
+
+type v5StateTransition struct {
+	ID         string `gorm:"size:26;primary_key"`
+	EntityType string `gorm:"size:32;not null;index:state_transition_entity,priority:1"`
+	EntityID   string `gorm:"size:26;not null;index:state_transition_entity,priority:2"`
+	FromState  string
+	ToState    string `gorm:"not null"`
+	Actor      string `gorm:"not null"`
+	TxHash     string
+	Message    string `gorm:"type:text"`
+	CreatedAt  time.Time
+}
+
+func (v5StateTransition) TableName() string {
+	return "state_transitions"
+}
@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"gorm.io/gorm"
+)
+
+// v6SwapPartyIndexes indexes the sender and the recipient of swaps for the public api lookups
+var v6SwapPartyIndexes = &Migration{
+	Version: 6,
+	Name:    "swap_party_indexes",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(
+			&v6ERC721Swap{},
+			&v6ERC1155Swap{},
+		)
+	},
+	Down: func(tx *gorm.DB) error {
+		migrator := tx.Migrator()
+		for _, m := range []struct {
+			model  interface{}
+			prefix string
+		}{
+			{&v6ERC721Swap{}, "erc721"},
+			{&v6ERC1155Swap{}, "erc1155"},
+		} {
+			if err := migrator.DropIndex(m.model, m.prefix+"_swap_sender"); err != nil {
+				return err
+			}
+			if err := migrator.DropIndex(m.model, m.prefix+"_swap_recipient"); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+type v6ERC721Swap struct {
+	Sender    string `gorm:"not null;index:erc721_swap_sender"`
+	Recipient string `gorm:"not null;index:erc721_swap_recipient"`
+}
+
+func (v6ERC721Swap) TableName() string {
+	return "erc721_swaps"
+}
+
+type v6ERC1155Swap struct {
+	Sender    string `gorm:"not null;index:erc1155_swap_sender"`
+	Recipient string `gorm:"not null;index:erc1155_swap_recipient"`
+}
+
+func (v6ERC1155Swap) TableName() string {
+	return "erc1155_swaps"
+}
@@ -0,0 +1,80 @@
+package migration
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// v7Webhooks creates the outbox of state transition events and the webhook subscriptions delivering them
+var v7Webhooks = &Migration{
+	Version: 7,
+	Name:    "webhooks",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(
+			&v7OutboxEvent{},
+			&v7WebhookSubscription{},
+			&v7WebhookDelivery{},
+		)
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(
+			&v7WebhookDelivery{},
+			&v7WebhookSubscription{},
+			&v7OutboxEvent{},
+		)
+	},
+}
+
+type v7OutboxEvent struct {
+	ID           string `gorm:"size:26;primary_key"`
+	Type         string `gorm:"size:64;not null"`
+	EntityType   string `gorm:"size:32;not null"`
+	EntityID     string `gorm:"size:26;not null"`
+	SrcChainID   string `gorm:"not null"`
+	DstChainID   string `gorm:"not null"`
+	SrcTokenAddr string `gorm:"not null"`
+	DstTokenAddr string
+	Payload      datatypes.JSON `gorm:"not null"`
+	FannedOut    bool           `gorm:"not null;default:false;index:outbox_event_fanned_out"`
+	CreatedAt    time.Time
+}
+
+func (v7OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+type v7WebhookSubscription struct {
+	ID         string `gorm:"size:26;primary_key"`
+	URL        string `gorm:"not null"`
+	Secret     string `gorm:"not null"`
+	EventTypes datatypes.JSON
+	ChainIDs   datatypes.JSON
+	TokenAddrs datatypes.JSON
+	Enabled    bool `gorm:"not null;default:true"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (v7WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+type v7WebhookDelivery struct {
+	ID             string    `gorm:"size:26;primary_key"`
+	SubscriptionID string    `gorm:"size:26;not null;index:webhook_delivery_event,unique,priority:1"`
+	EventID        string    `gorm:"size:26;not null;index:webhook_delivery_event,unique,priority:2"`
+	State          string    `gorm:"size:16;not null;index:webhook_delivery_due,priority:1"`
+	Attempts       int       `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time `gorm:"not null;index:webhook_delivery_due,priority:2"`
+	LastStatusCode int
+	LastError      string `gorm:"type:text"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (v7WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"gorm.io/gorm"
+)
+
+// v8OutboxPublishing tracks which outbox events were published to the message broker, with the tx hash their
+// broker key is made of. Events written before are considered published.
+var v8OutboxPublishing = &Migration{
+	Version: 8,
+	Name:    "outbox_publishing",
+	Up: func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&v8OutboxEvent{}); err != nil {
+			return err
+		}
+
+		return tx.Model(&v8OutboxEvent{}).Where("1 = 1").Update("published", true).Error
+	},
+	Down: func(tx *gorm.DB) error {
+		migrator := tx.Migrator()
+		if err := migrator.DropIndex(&v8OutboxEvent{}, "outbox_event_published"); err != nil {
+			return err
+		}
+		if err := dropColumn(tx, &v8OutboxEvent{}, "Published"); err != nil {
+			return err
+		}
+
+		return dropColumn(tx, &v8OutboxEvent{}, "RequestTxHash")
+	},
+}
+
+type v8OutboxEvent struct {
+	RequestTxHash string `gorm:"not null;default:''"`
+	Published     bool   `gorm:"not null;default:false;index:outbox_event_published"`
+}
+
+func (v8OutboxEvent) TableName() string {
+	return "outbox_events"
+}
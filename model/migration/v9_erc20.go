@@ -0,0 +1,118 @@
+package migration
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// v9ERC20 creates the tables of the ERC20 swap pairs and swaps
+var v9ERC20 = &Migration{
+	Version: 9,
+	Name:    "erc20",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(
+			&v9ERC20SwapPair{},
+			&v9ERC20Swap{},
+		)
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(
+			&v9ERC20Swap{},
+			&v9ERC20SwapPair{},
+		)
+	},
+}
+
+type v9ERC20SwapPair struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	SrcChainID   string `gorm:"not null;index:erc20_unique_registration,unique,priority:1;index:erc20_swap_pair_register_event,unique,priority:1"`
+	DstChainID   string `gorm:"not null;index:erc20_unique_registration,unique,priority:2"`
+	SrcTokenAddr string `gorm:"not null;index:erc20_unique_registration,unique,priority:3"`
+	DstTokenAddr string
+	SrcTokenName string `gorm:"not null"`
+	DstTokenName string
+	Sponsor      string `gorm:"not null"`
+	Available    bool   `gorm:"not null"`
+	Signature    string `gorm:"not null"`
+	Symbol       string `gorm:"not null"`
+	Decimals     uint8  `gorm:"not null"`
+	MinAmount    string `gorm:"not null;default:''"`
+	MaxAmount    string `gorm:"not null;default:''"`
+
+	State string `gorm:"not null"`
+
+	RegisterTxHash       string `gorm:"not null;index:erc20_unique_registration,unique,priority:4;index:erc20_swap_pair_register_event,unique,priority:2"`
+	RegisterHeight       int64  `gorm:"not null;index:erc20_unique_registration,unique,priority:5"`
+	RegisterBlockHash    string `gorm:"not null"`
+	RegisterLogIndex     *uint  `gorm:"index:erc20_swap_pair_register_event,unique,priority:3"`
+	RegisterContractAddr string
+	RegisterBlockLogID   *string     `gorm:"size:26;index:erc20_foreign_key_register_block_log_id"`
+	RegisterBlockLog     *v1BlockLog `gorm:"foreignKey:RegisterBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	CreateConsumedFeeAmount string
+	CreateGasPrice          string
+	CreateGasUsed           int64
+	CreateHeight            int64
+	CreateTxHash            string
+	CreateTrackRetry        int64
+	CreateBlockHash         string      `gorm:"not null"`
+	CreateBlockLogID        *string     `gorm:"size:26;index:erc20_foreign_key_create_block_log_id"`
+	CreateBlockLog          *v1BlockLog `gorm:"foreignKey:CreateBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	MessageLog string
+	Version    int64 `gorm:"not null;default:0"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (v9ERC20SwapPair) TableName() string {
+	return "erc20_swap_pairs"
+}
+
+type v9ERC20Swap struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	SrcChainID   string `gorm:"not null;index:erc20_swap_request_event,unique,priority:1"`
+	DstChainID   string `gorm:"not null"`
+	SrcTokenAddr string `gorm:"not null"`
+	DstTokenAddr string
+	Sender       string `gorm:"not null;index:erc20_swap_sender"`
+	Recipient    string `gorm:"not null;index:erc20_swap_recipient"`
+	Amount       string `gorm:"not null"`
+	Signature    string `gorm:"not null"`
+
+	State         string `gorm:"not null"`
+	SwapDirection string `gorm:"not null"`
+
+	RequestTxHash       string `gorm:"not null;index:erc20_swap_request_event,unique,priority:2"`
+	RequestHeight       int64  `gorm:"not null"`
+	RequestBlockHash    string `gorm:"not null"`
+	RequestLogIndex     *uint  `gorm:"index:erc20_swap_request_event,unique,priority:3"`
+	RequestContractAddr string
+	RequestBlockLogID   *string     `gorm:"size:26;index:erc20_foreign_key_request_block_log_id"`
+	RequestBlockLog     *v1BlockLog `gorm:"foreignKey:RequestBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	RequestTrackRetry   int64
+
+	FillConsumedFeeAmount string
+	FillGasPrice          string
+	FillGasUsed           int64
+	FillHeight            int64
+	FillTxHash            string
+	FillTrackRetry        int64
+	FillBlockHash         string      `gorm:"not null"`
+	FillBlockLogID        *string     `gorm:"size:26;index:erc20_foreign_key_fill_block_log_id"`
+	FillBlockLog          *v1BlockLog `gorm:"foreignKey:FillBlockLogID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+
+	MessageLog string
+	Version    int64 `gorm:"not null;default:0"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (v9ERC20Swap) TableName() string {
+	return "erc20_swaps"
+}
@@ -0,0 +1,173 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// Event announces a state transition to the webhook subscribers. It is written in the same transaction as the
+// transition, so no transition is lost even if the process stops before the webhooks are delivered.
+type Event struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	// Type is <entity type>.<new state>, e.g. erc721_swap.fill_tx_confirmed
+	Type         string `gorm:"size:64;not null"`
+	EntityType   string `gorm:"size:32;not null"`
+	EntityID     string `gorm:"size:26;not null"`
+	SrcChainID   string `gorm:"not null"`
+	DstChainID   string `gorm:"not null"`
+	SrcTokenAddr string `gorm:"not null"`
+	DstTokenAddr string
+	// RequestTxHash is the tx requesting the swap or registering the swap pair
+	RequestTxHash string         `gorm:"not null;default:''"`
+	Payload       datatypes.JSON `gorm:"not null"`
+	// FannedOut is set once a delivery was created for every matching subscription
+	FannedOut bool `gorm:"not null;default:false;index:outbox_event_fanned_out"`
+	// Published is set once the event was published to the message broker
+	Published bool `gorm:"not null;default:false;index:outbox_event_published"`
+
+	// Timestamp
+	CreatedAt time.Time
+}
+
+func (Event) TableName() string {
+	return "outbox_events"
+}
+
+func (e *Event) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = util.ULID()
+	e.CreatedAt = time.Now()
+	return nil
+}
+
+// Message is the body of the webhooks and the broker messages announcing an event
+type Message struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Message returns the encoded message announcing the event
+func (e *Event) Message() ([]byte, error) {
+	body, err := json.Marshal(&Message{
+		ID:        e.ID,
+		Type:      e.Type,
+		CreatedAt: e.CreatedAt,
+		Data:      json.RawMessage(e.Payload),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Event.Message]: failed to encode message of Event %s", e.ID)
+	}
+
+	return body, nil
+}
+
+// Payload is the data of an event
+type Payload struct {
+	Transition *Transition `json:"transition"`
+	Swap       *Swap       `json:"swap,omitempty"`
+	SwapPair   *SwapPair   `json:"swap_pair,omitempty"`
+}
+
+type Transition struct {
+	FromState string `json:"from_state"`
+	ToState   string `json:"to_state"`
+	Actor     string `json:"actor"`
+	TxHash    string `json:"tx_hash,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+type Swap struct {
+	ID           string          `json:"id"`
+	Standard     string          `json:"standard"`
+	State        string          `json:"state"`
+	Direction    string          `json:"direction"`
+	SrcChainID   string          `json:"src_chain_id"`
+	DstChainID   string          `json:"dst_chain_id"`
+	SrcTokenAddr string          `json:"src_token_addr"`
+	DstTokenAddr string          `json:"dst_token_addr"`
+	Sender       string          `json:"sender"`
+	Recipient    string          `json:"recipient"`
+	TokenID      string          `json:"token_id,omitempty"`
+	TokenIDs     json.RawMessage `json:"token_ids,omitempty"`
+	// Tokens holds the fill status of every token of a batch swap
+	Tokens        json.RawMessage `json:"tokens,omitempty"`
+	Amounts       json.RawMessage `json:"amounts,omitempty"`
+	Amount        string          `json:"amount,omitempty"`
+	RequestTxHash string          `json:"request_tx_hash"`
+	FillTxHash    string          `json:"fill_tx_hash,omitempty"`
+}
+
+type SwapPair struct {
+	ID             string `json:"id"`
+	Standard       string `json:"standard"`
+	State          string `json:"state"`
+	Available      bool   `json:"available"`
+	SrcChainID     string `json:"src_chain_id"`
+	DstChainID     string `json:"dst_chain_id"`
+	SrcTokenAddr   string `json:"src_token_addr"`
+	DstTokenAddr   string `json:"dst_token_addr"`
+	SrcTokenName   string `json:"src_token_name,omitempty"`
+	DstTokenName   string `json:"dst_token_name,omitempty"`
+	Symbol         string `json:"symbol,omitempty"`
+	BaseURI        string `json:"base_uri,omitempty"`
+	URI            string `json:"uri,omitempty"`
+	Decimals       *uint8 `json:"decimals,omitempty"`
+	MinAmount      string `json:"min_amount,omitempty"`
+	MaxAmount      string `json:"max_amount,omitempty"`
+	RegisterTxHash string `json:"register_tx_hash"`
+	CreateTxHash   string `json:"create_tx_hash,omitempty"`
+}
+
+// NewSwapEvent returns the event announcing the transition of a swap
+func NewSwapEvent(t *transition.Transition, s *Swap) (*Event, error) {
+	return newEvent(t, s.SrcChainID, s.DstChainID, s.SrcTokenAddr, s.DstTokenAddr, s.RequestTxHash, &Payload{
+		Transition: newTransition(t),
+		Swap:       s,
+	})
+}
+
+// NewSwapPairEvent returns the event announcing the transition of a swap pair
+func NewSwapPairEvent(t *transition.Transition, p *SwapPair) (*Event, error) {
+	return newEvent(t, p.SrcChainID, p.DstChainID, p.SrcTokenAddr, p.DstTokenAddr, p.RegisterTxHash, &Payload{
+		Transition: newTransition(t),
+		SwapPair:   p,
+	})
+}
+
+func newEvent(t *transition.Transition, srcChainID, dstChainID, srcTokenAddr, dstTokenAddr, requestTxHash string, p *Payload) (*Event, error) {
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return nil, errors.Wrap(err, "[newEvent]: failed to encode payload")
+	}
+
+	return &Event{
+		Type:          string(t.EntityType) + "." + t.ToState,
+		EntityType:    string(t.EntityType),
+		EntityID:      t.EntityID,
+		SrcChainID:    srcChainID,
+		DstChainID:    dstChainID,
+		SrcTokenAddr:  srcTokenAddr,
+		DstTokenAddr:  dstTokenAddr,
+		RequestTxHash: requestTxHash,
+		Payload:       datatypes.JSON(payload),
+	}, nil
+}
+
+func newTransition(t *transition.Transition) *Transition {
+	return &Transition{
+		FromState: t.FromState,
+		ToState:   t.ToState,
+		Actor:     t.Actor,
+		TxHash:    t.TxHash,
+		Message:   t.Message,
+	}
+}
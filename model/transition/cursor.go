@@ -0,0 +1,50 @@
+package transition
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Cursor is the position of a consumer reading the transitions in id order, ids are ULIDs and sort by creation time
+type Cursor struct {
+	Name         string `gorm:"size:191;primary_key"`
+	TransitionID string `gorm:"size:26;not null"`
+	UpdatedAt    time.Time
+}
+
+func (Cursor) TableName() string {
+	return "state_transition_cursors"
+}
+
+// LoadCursor returns the id of the last transition read by the consumer, found is false when it has not read any
+func LoadCursor(db *gorm.DB, name string) (id string, found bool, err error) {
+	var c Cursor
+	err = db.Where("name = ?", name).Take(&c).Error
+	if err == gorm.ErrRecordNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, errors.Wrap(err, "[LoadCursor]: failed to query cursor")
+	}
+
+	return c.TransitionID, true, nil
+}
+
+// SaveCursor moves the cursor of the consumer to the transition id
+func SaveCursor(db *gorm.DB, name, id string) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"transition_id", "updated_at"}),
+	}).Create(&Cursor{
+		Name:         name,
+		TransitionID: id,
+	}).Error
+	if err != nil {
+		return errors.Wrap(err, "[SaveCursor]: failed to save cursor")
+	}
+
+	return nil
+}
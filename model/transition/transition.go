@@ -0,0 +1,67 @@
+package transition
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+type EntityType string
+
+const (
+	EntityTypeERC721Swap      EntityType = "erc721_swap"
+	EntityTypeERC721SwapPair  EntityType = "erc721_swap_pair"
+	EntityTypeERC721BatchSwap EntityType = "erc721_batch_swap"
+	EntityTypeERC1155Swap     EntityType = "erc1155_swap"
+	EntityTypeERC1155SwapPair EntityType = "erc1155_swap_pair"
+	EntityTypeERC20Swap       EntityType = "erc20_swap"
+	EntityTypeERC20SwapPair   EntityType = "erc20_swap_pair"
+)
+
+// Transition records a state change of a swap or a swap pair
+type Transition struct {
+	ID string `gorm:"size:26;primary_key"`
+
+	EntityType EntityType `gorm:"size:32;not null;index:state_transition_entity,priority:1"`
+	EntityID   string     `gorm:"size:26;not null;index:state_transition_entity,priority:2"`
+	// FromState is empty when the entity was created
+	FromState string
+	ToState   string `gorm:"not null"`
+	// Actor is who made the change, e.g. recorder/<chain id> or swap-engine/<loop name>
+	Actor   string `gorm:"not null"`
+	TxHash  string
+	Message string `gorm:"type:text"`
+
+	// Timestamp
+	CreatedAt time.Time
+}
+
+func (Transition) TableName() string {
+	return "state_transitions"
+}
+
+func (t *Transition) BeforeCreate(tx *gorm.DB) (err error) {
+	t.ID = util.ULID()
+	t.CreatedAt = time.Now()
+	return nil
+}
+
+// Timeline returns the transitions of an entity, oldest first
+func Timeline(db *gorm.DB, entityType EntityType, entityID string) ([]Transition, error) {
+	var tt []Transition
+	err := db.Where(
+		"entity_type = ? and entity_id = ?",
+		entityType,
+		entityID,
+	).Order(
+		"created_at asc, id asc",
+	).Find(&tt).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "[Timeline]: failed to query transitions")
+	}
+
+	return tt, nil
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+)
+
+// Versioned is a row with a state and a version which is saved by CompareAndSave
+type Versioned interface {
+	// EntityName names the row in errors, e.g. "Swap 01FGX..."
+	EntityName() string
+	// Entity returns the type and id of the row in the state transitions and the source chain it belongs to
+	Entity() (entityType transition.EntityType, id string, srcChainID string)
+	// Loaded returns the state and version the row was loaded or last saved with
+	Loaded() (state string, version int64)
+	StateChanged() bool
+	SetVersion(version int64)
+	// MarkLoaded makes the current values of the row the loaded ones once it is saved
+	MarkLoaded()
+	// Transition describes the change from the loaded state to the current one with the outbox event announcing it
+	Transition(actor string) (*transition.Transition, *outbox.Event, error)
+}
+
+// CompareAndSave saves all fields of the row if its state and version in the database are still the ones it was
+// loaded with. It returns ErrVersionConflict if another process updated the row in between. A state change is
+// recorded as a transition by the actor in the same transaction.
+func CompareAndSave(tx *gorm.DB, v Versioned, actor string) error {
+	loadedState, loadedVersion := v.Loaded()
+	v.SetVersion(loadedVersion + 1)
+	err := tx.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(v).Select(
+			"*",
+		).Omit(
+			clause.Associations,
+		).Where(
+			"state = ? and version = ?",
+			loadedState,
+			loadedVersion,
+		).Updates(v)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errors.Wrapf(ErrVersionConflict, "%s in state '%s' version %d", v.EntityName(), loadedState, loadedVersion)
+		}
+		if !v.StateChanged() {
+			return nil
+		}
+
+		t, ev, err := v.Transition(actor)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(t).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(ev).Error
+	})
+	if err != nil {
+		v.SetVersion(loadedVersion)
+		return err
+	}
+
+	v.MarkLoaded()
+	return nil
+}
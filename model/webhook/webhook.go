@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+type DeliveryState string
+
+const (
+	DeliveryStatePending   DeliveryState = "pending"
+	DeliveryStateDelivered DeliveryState = "delivered"
+	// DeliveryStateDead marks a delivery that failed every attempt, it is retried only when replayed
+	DeliveryStateDead DeliveryState = "dead"
+)
+
+// Subscription receives the outbox events matching its filters, empty filters match every event
+type Subscription struct {
+	ID     string `gorm:"size:26;primary_key"`
+	URL    string `gorm:"not null"`
+	Secret string `gorm:"not null"`
+	// EventTypes, ChainIDs and TokenAddrs are JSON string arrays. An event type ending with .* matches every
+	// state of an entity type; chain ids and token addresses match either side of a swap.
+	EventTypes datatypes.JSON
+	ChainIDs   datatypes.JSON
+	TokenAddrs datatypes.JSON
+	Enabled    bool `gorm:"not null;default:true"`
+
+	// Timestamp
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (s *Subscription) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ID = util.ULID()
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Subscription) BeforeUpdate(tx *gorm.DB) (err error) {
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Delivery is an outbox event to be posted to a subscription
+type Delivery struct {
+	ID             string        `gorm:"size:26;primary_key"`
+	SubscriptionID string        `gorm:"size:26;not null;index:webhook_delivery_event,unique,priority:1"`
+	EventID        string        `gorm:"size:26;not null;index:webhook_delivery_event,unique,priority:2"`
+	State          DeliveryState `gorm:"size:16;not null;index:webhook_delivery_due,priority:1"`
+	Attempts       int           `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time     `gorm:"not null;index:webhook_delivery_due,priority:2"`
+	LastStatusCode int
+	LastError      string `gorm:"type:text"`
+	DeliveredAt    *time.Time
+
+	// Timestamp
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (d *Delivery) BeforeCreate(tx *gorm.DB) (err error) {
+	d.ID = util.ULID()
+	d.CreatedAt = time.Now()
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+func (d *Delivery) BeforeUpdate(tx *gorm.DB) (err error) {
+	d.UpdatedAt = time.Now()
+	return nil
+}
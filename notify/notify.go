@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+)
+
+// Notification tells that a block of a chain was recorded, or that a swap or a swap pair moved to a state
+type Notification struct {
+	// ChainID is the chain of the block, or the source chain of the swap or swap pair
+	ChainID string
+	// Height is set for a block
+	Height int64
+	// EntityType, EntityID and State are set for a state change
+	EntityType transition.EntityType
+	EntityID   string
+	State      string
+}
+
+// IsBlock tells whether the notification is about a block rather than a state change
+func (n *Notification) IsBlock() bool {
+	return n.EntityType == ""
+}
+
+// Matcher selects the notifications a subscription is woken up by
+type Matcher func(n *Notification) bool
+
+// Block matches the blocks of a chain, or of every chain when chainID is empty
+func Block(chainID string) Matcher {
+	return func(n *Notification) bool {
+		return n.IsBlock() && (chainID == "" || n.ChainID == chainID)
+	}
+}
+
+// State matches the swaps or swap pairs of a source chain moving to one of the states
+func State(entityType transition.EntityType, chainID string, states ...string) Matcher {
+	return func(n *Notification) bool {
+		if n.EntityType != entityType || n.ChainID != chainID {
+			return false
+		}
+		for _, s := range states {
+			if n.State == s {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Any matches the notifications matched by one of mm
+func Any(mm ...Matcher) Matcher {
+	return func(n *Notification) bool {
+		for _, m := range mm {
+			if m(n) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Bus delivers the notifications to the subscriptions of the process. A nil bus drops them, its subscribers only
+// wake up periodically.
+type Bus struct {
+	mutex sync.RWMutex
+	subs  []*Subscription
+}
+
+// NewBus returns the notification bus instance
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Publish wakes up the subscriptions matching n, it never blocks
+func (b *Bus) Publish(n *Notification) {
+	if b == nil {
+		return
+	}
+
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for _, s := range b.subs {
+		if !s.match(n) {
+			continue
+		}
+
+		select {
+		case s.c <- struct{}{}:
+		default:
+			// a wake up is already pending
+		}
+	}
+}
+
+// Subscribe returns a subscription woken up by the notifications m matches, or nil for a nil bus
+func (b *Bus) Subscribe(m Matcher) *Subscription {
+	if b == nil {
+		return nil
+	}
+
+	s := &Subscription{
+		match: m,
+		c:     make(chan struct{}, 1),
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.subs = append(b.subs, s)
+
+	return s
+}
+
+// Subscription coalesces the notifications published until its subscriber wakes up into a single wake up
+type Subscription struct {
+	match Matcher
+	c     chan struct{}
+}
+
+// Wait pauses for d or until a notification matches s, whichever comes first, and returns true. It returns false
+// as soon as ctx is done. A nil subscription only waits for d.
+func (s *Subscription) Wait(ctx context.Context, d time.Duration) bool {
+	var c chan struct{}
+	if s != nil {
+		c = s.c
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	case <-c:
+		return true
+	}
+}
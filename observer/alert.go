@@ -1,32 +1,51 @@
 package observer
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/alert"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/common"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-// Alert sends alerts to tg group if there is no new block fetched in a specific time
-func (o *Observer) Alert() {
-	for {
+// Alert sends alerts if there is no new block fetched in a specific time,
+// and a resolved notification once blocks are fetched again. Only the leader alerts, so that replicas
+// do not send every alert once each. It returns once ctx is done.
+func (o *Observer) Alert(ctx context.Context) {
+	chainID := o.deps.Recorder.ChainID()
+	key := fmt.Sprintf("observer-stale-block-%s", chainID)
+	for ctx.Err() == nil {
+		if !o.isLeader() {
+			util.Sleep(ctx, common.ObserverAlertInterval)
+
+			continue
+		}
+
 		curOtherChainBlockLog, err := o.GetCurrentBlockLog()
 		if err != nil {
 			util.Logger.Errorf("[Observer.Alert]: get current block log error, err=%s", err.Error())
-			time.Sleep(common.ObserverAlertInterval)
+			util.Sleep(ctx, common.ObserverAlertInterval)
 
 			continue
 		}
 
 		if curOtherChainBlockLog.Height > 0 {
 			if time.Now().Unix()-curOtherChainBlockLog.CreateTime.Unix() > int64(o.conf.BlockUpdateTimeout.Seconds()) {
-				msg := fmt.Sprintf("[Observer.Alert]: last block fetched at %s, chain=%s, height=%d",
-					time.Unix(curOtherChainBlockLog.CreateTime.Unix(), 0).String(), o.deps.Recorder.ChainID(), curOtherChainBlockLog.Height)
-				util.SendTelegramMessage(msg)
+				o.deps.Alerter.Fire(&alert.Alert{
+					Key:      key,
+					Severity: alert.SeverityCritical,
+					ChainID:  chainID,
+					Title:    "no new block fetched",
+					Message: fmt.Sprintf("last block fetched at %s, height=%d",
+						time.Unix(curOtherChainBlockLog.CreateTime.Unix(), 0).String(), curOtherChainBlockLog.Height),
+				})
+			} else {
+				o.deps.Alerter.Resolve(key, fmt.Sprintf("blocks are fetched again, height=%d", curOtherChainBlockLog.Height))
 			}
 		}
 
-		time.Sleep(common.ObserverAlertInterval)
+		util.Sleep(ctx, common.ObserverAlertInterval)
 	}
 }
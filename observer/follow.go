@@ -0,0 +1,55 @@
+package observer
+
+import (
+	"context"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/notify"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// Follow keeps the recorder cache in step with the block logs written by the observer of another process,
+// so engines can run without an observer. It stops once ctx is done.
+func (ob *Observer) Follow(ctx context.Context) {
+	ob.wg.Add(1)
+	go func() {
+		defer ob.wg.Done()
+
+		for ctx.Err() == nil {
+			curBlockLog, err := ob.GetCurrentBlockLog()
+			if err != nil {
+				util.Logger.Errorf("[Observer.Follow]: get current block log from db error: %s", err.Error())
+			} else {
+				ob.followBlock(ctx, curBlockLog)
+			}
+
+			util.Sleep(ctx, ob.conf.FetchInterval)
+		}
+	}()
+}
+
+// followBlock caches the block of the current block log in the recorder
+func (ob *Observer) followBlock(ctx context.Context, curBlockLog *block.Log) {
+	if curBlockLog.Height == 0 {
+		return
+	}
+
+	if _, err := ob.deps.Recorder.Block(ctx, curBlockLog.Height); err != nil {
+		util.Logger.Debugf("[Observer.followBlock]: failed to follow chain id %s, err=%s", ob.deps.Recorder.ChainID(), err.Error())
+		return
+	}
+
+	// the same block log is followed until the next one is written
+	if curBlockLog.Height != ob.followedHeight {
+		ob.followedHeight = curBlockLog.Height
+		ob.notifyBlock(curBlockLog.Height)
+	}
+}
+
+// notifyBlock tells the engines of this process that a block was recorded
+func (ob *Observer) notifyBlock(height int64) {
+	ob.deps.Bus.Publish(&notify.Notification{
+		ChainID: ob.deps.Recorder.ChainID(),
+		Height:  height,
+	})
+}
@@ -1,10 +1,16 @@
 package observer
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"gorm.io/gorm"
 
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/alert"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/head"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/lease"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/notify"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/recorder"
 )
 
@@ -18,11 +24,23 @@ type Config struct {
 type Dependencies struct {
 	DB       *gorm.DB
 	Recorder recorder.IRecorder
+	Alerter  alert.Dispatcher
+	// Leader is nil when a single replica runs, otherwise only the leader writes block logs and sends alerts
+	Leader lease.Leader
+	// Bus is notified of every block recorded or followed, it may be nil
+	Bus *notify.Bus
+	// Head is the head tracker of the chain, the observer waits for it to move once caught up. It may be nil.
+	Head *head.Tracker
 }
 
 type Observer struct {
 	conf *Config
 	deps *Dependencies
+
+	// followedHeight is the height of the block log last followed
+	followedHeight int64
+
+	wg sync.WaitGroup
 }
 
 // NewObserver returns the observer instance
@@ -33,9 +51,23 @@ func NewObserver(c *Config, d *Dependencies) *Observer {
 	}
 }
 
-// Start starts the routines of observer
-func (o *Observer) Start() {
-	go o.Update()
-	go o.Prune()
-	go o.Alert()
+// Start starts the routines of observer, they stop once ctx is done
+func (o *Observer) Start(ctx context.Context) {
+	for _, fn := range []func(context.Context){o.Update, o.Prune, o.Alert} {
+		fn := fn
+		o.wg.Add(1)
+		go func() {
+			defer o.wg.Done()
+			fn(ctx)
+		}()
+	}
+}
+
+// Wait blocks until the routines started by Start or Follow have returned
+func (o *Observer) Wait() {
+	o.wg.Wait()
+}
+
+func (o *Observer) isLeader() bool {
+	return o.deps.Leader == nil || o.deps.Leader.IsLeader()
 }
@@ -1,26 +1,31 @@
 package observer
 
 import (
-	"time"
+	"context"
 
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/common"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-// Prune prunes the outdated blocks
-func (ob *Observer) Prune() {
-	for {
+// Prune prunes the outdated blocks, it returns once ctx is done
+func (ob *Observer) Prune(ctx context.Context) {
+	for ctx.Err() == nil {
+		if !ob.isLeader() {
+			util.Sleep(ctx, common.ObserverPruneInterval)
+			continue
+		}
+
 		curBlockLog, err := ob.GetCurrentBlockLog()
 		if err != nil {
 			util.Logger.Errorf("[Observer.Prune]: get current block log error, err=%s", err.Error())
-			time.Sleep(common.ObserverPruneInterval)
+			util.Sleep(ctx, common.ObserverPruneInterval)
 			continue
 		}
 
 		height := curBlockLog.Height - common.ObserverMaxBlockNumber
 		if height > 0 {
-			err = ob.deps.DB.Where(
+			err = ob.deps.DB.WithContext(ctx).Where(
 				"chain_id = ? and height < ?",
 				ob.deps.Recorder.ChainID(),
 				height,
@@ -32,6 +37,6 @@ func (ob *Observer) Prune() {
 			}
 		}
 
-		time.Sleep(common.ObserverPruneInterval)
+		util.Sleep(ctx, common.ObserverPruneInterval)
 	}
 }
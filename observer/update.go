@@ -1,25 +1,41 @@
 package observer
 
 import (
+	"context"
 	"time"
 
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/common"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/head"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-// Update starts the main routine for updating blocks
-func (ob *Observer) Update() {
+// Update starts the main routine for updating blocks, it returns once ctx is done
+func (ob *Observer) Update(ctx context.Context) {
 	chainID := ob.deps.Recorder.ChainID()
 	startHeight := ob.conf.StartHeight
-	for {
+
+	var heads <-chan head.Head
+	if ob.deps.Head != nil {
+		heads = ob.deps.Head.Subscribe()
+	}
+
+	for ctx.Err() == nil {
 		curBlockLog, err := ob.GetCurrentBlockLog()
 		if err != nil {
 			util.Logger.Errorf("[Observer.Update]: get current block log from db error: %s", err.Error())
-			time.Sleep(ob.conf.FetchInterval)
+			util.Sleep(ctx, ob.conf.FetchInterval)
+			continue
+		}
+
+		if !ob.isLeader() {
+			// followers keep the recorder cache in step with the leader without writing block logs
+			ob.followBlock(ctx, curBlockLog)
+
+			util.Sleep(ctx, ob.conf.FetchInterval)
 			continue
 		}
 
@@ -29,44 +45,66 @@ func (ob *Observer) Update() {
 		}
 
 		util.Logger.Debugf("[Observer.Update]: fetch from chain id %s, height=%d", chainID, nextHeight)
-		err = ob.updateBlock(curBlockLog.Height, nextHeight, curBlockLog.BlockHash)
+		err = ob.updateBlock(ctx, curBlockLog.Height, nextHeight, curBlockLog.BlockHash)
 		if err != nil {
-			if errors.Cause(err) != common.ErrBlockNotFound {
-				util.Logger.Errorf("[Observer.Update]: fetch from chain id %s error, err=%s", chainID, err.Error())
+			if errors.Cause(err) == common.ErrBlockNotFound {
+				ob.waitHead(ctx, heads, nextHeight)
+				continue
 			}
 
-			util.Logger.Debugf("[Observer.Update]: failed to fetch from chain id %s error, err=%s", chainID, err.Error())
+			util.Logger.Errorf("[Observer.Update]: fetch from chain id %s error, err=%s", chainID, err.Error())
+			util.Sleep(ctx, ob.conf.FetchInterval)
+		}
+	}
+}
 
-			time.Sleep(ob.conf.FetchInterval)
+// waitHead waits for the chain head to reach a height, for the fetch interval at most
+func (ob *Observer) waitHead(ctx context.Context, heads <-chan head.Head, height int64) {
+	t := time.NewTimer(ob.conf.FetchInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			return
+		case h := <-heads:
+			if h.ChainHeight >= height {
+				return
+			}
 		}
 	}
 }
 
 // updateBlock fetches the next block of BSC and saves it to database. if the next block hash
 // does not match to the parent hash, the current block will be deleted for there is a fork.
-func (ob *Observer) updateBlock(curHeight, nextHeight int64, curBlockHash string) error {
-	block, err := ob.deps.Recorder.Block(nextHeight)
+func (ob *Observer) updateBlock(ctx context.Context, curHeight, nextHeight int64, curBlockHash string) error {
+	block, err := ob.deps.Recorder.Block(ctx, nextHeight)
 	if err != nil {
 		return errors.Wrapf(err, "[Observer.updateBlock]: failed to get block info, height=%d", nextHeight)
 	}
 
 	if curHeight != 0 && block.ParentBlockHash != curBlockHash {
-		if err := ob.DeleteBlock(curHeight); err != nil {
+		if err := ob.DeleteBlock(ctx, curHeight); err != nil {
 			return errors.Wrap(err, "[Observer.updateBlock]: failed to delete a forked block")
 		}
 
 		return nil
 	}
 
-	if err := ob.RecordBlockAndTxs(block); err != nil {
+	if err := ob.RecordBlockAndTxs(ctx, block); err != nil {
 		return errors.Wrap(err, "[Observer.updateBlock]: failed to save and process block")
 	}
+	ob.notifyBlock(block.Height)
 
 	return nil
 }
 
-func (ob *Observer) RecordBlockAndTxs(b *common.Block) error {
-	if err := ob.deps.DB.Transaction(func(tx *gorm.DB) error {
+// RecordBlockAndTxs saves a block log and records its events in a transaction, which is rolled back when ctx is
+// canceled halfway
+func (ob *Observer) RecordBlockAndTxs(ctx context.Context, b *common.Block) error {
+	if err := ob.deps.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		nextBlockLog := block.Log{
 			BlockHash:  b.BlockHash,
 			ParentHash: b.ParentBlockHash,
@@ -78,7 +116,7 @@ func (ob *Observer) RecordBlockAndTxs(b *common.Block) error {
 			return errors.Wrap(err, "[Observer.RecordBlockAndTxs]: failed to create block log")
 		}
 
-		if err := ob.deps.Recorder.Record(tx, &nextBlockLog); err != nil {
+		if err := ob.deps.Recorder.Record(ctx, tx, &nextBlockLog); err != nil {
 			return errors.Wrap(err, "[Observer.RecordBlockAndTxs]: failed to record a new block from recorder")
 		}
 
@@ -90,8 +128,8 @@ func (ob *Observer) RecordBlockAndTxs(b *common.Block) error {
 	return nil
 }
 
-func (ob *Observer) DeleteBlock(height int64) error {
-	if err := ob.deps.DB.Transaction(func(tx *gorm.DB) error {
+func (ob *Observer) DeleteBlock(ctx context.Context, height int64) error {
+	if err := ob.deps.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Where("height = ?", height).Delete(block.Log{}).Error; err != nil {
 			return errors.Wrap(err, "[Observer.DeleteBlock]: failed to delete block log")
 		}
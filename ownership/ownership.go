@@ -0,0 +1,205 @@
+package ownership
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/alert"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/lease"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+const (
+	// maxBlockRange limits the number of blocks filtered in a single request
+	maxBlockRange = 1000
+	// requestTimeout bounds every request to the provider
+	requestTimeout = 20 * time.Second
+)
+
+// ownershipTransferredTopic is the topic of OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+var ownershipTransferredTopic = crypto.Keccak256Hash([]byte("OwnershipTransferred(address,address)"))
+
+// Client is the part of an ethereum client the watcher needs
+type Client interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+type Config struct {
+	ChainID      string
+	PollInterval time.Duration
+	// Agents are the swap agent contracts of the chain, keyed by address
+	Agents map[common.Address]string
+	// Relayers are the addresses sending fill and swap pair creation transactions to the agents
+	Relayers []common.Address
+}
+
+type Dependencies struct {
+	Client  Client
+	Alerter alert.Dispatcher
+	// Leader is nil when a single replica runs, otherwise only the leader sends alerts
+	Leader lease.Leader
+}
+
+// Watcher alerts on every ownership transfer of the swap agents of a chain
+type Watcher struct {
+	conf *Config
+	deps *Dependencies
+
+	// height is the height of the block last filtered
+	height uint64
+
+	wg sync.WaitGroup
+}
+
+// NewWatcher returns the ownership watcher instance
+func NewWatcher(c *Config, d *Dependencies) *Watcher {
+	return &Watcher{
+		conf: c,
+		deps: d,
+	}
+}
+
+// Start starts the routine of the watcher, it stops once ctx is done
+func (w *Watcher) Start(ctx context.Context) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.Watch(ctx)
+	}()
+}
+
+// Wait blocks until the routine started by Start has returned
+func (w *Watcher) Wait() {
+	w.wg.Wait()
+}
+
+// Watch filters the ownership transfers of the agents from the chain head onwards, it returns once ctx is done
+func (w *Watcher) Watch(ctx context.Context) {
+	for ctx.Err() == nil {
+		if err := w.check(ctx); err != nil {
+			util.Logger.Error(errors.Wrapf(err, "[Watcher.Watch]: failed to check ownership transfers of chain %s", w.conf.ChainID))
+		}
+
+		util.Sleep(ctx, w.conf.PollInterval)
+	}
+}
+
+func (w *Watcher) check(ctx context.Context) error {
+	reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+	header, err := w.deps.Client.HeaderByNumber(reqCtx, nil)
+	cancel()
+	if err != nil {
+		return errors.Wrap(err, "[Watcher.check]: failed to get the chain head")
+	}
+
+	latest := header.Number.Uint64()
+	if w.height == 0 {
+		// transfers before the start are covered by the owner check at startup
+		w.height = latest
+		return nil
+	}
+
+	for w.height < latest && ctx.Err() == nil {
+		from := w.height + 1
+		to := latest
+		if to-from+1 > maxBlockRange {
+			to = from + maxBlockRange - 1
+		}
+
+		ll, err := w.filter(ctx, from, to)
+		if err != nil {
+			return err
+		}
+		for _, l := range ll {
+			w.alert(l)
+		}
+
+		w.height = to
+	}
+
+	return nil
+}
+
+func (w *Watcher) filter(ctx context.Context, from, to uint64) ([]types.Log, error) {
+	agents := make([]common.Address, 0, len(w.conf.Agents))
+	for addr := range w.conf.Agents {
+		agents = append(agents, addr)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	ll, err := w.deps.Client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: agents,
+		Topics:    [][]common.Hash{{ownershipTransferredTopic}},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Watcher.filter]: failed to filter logs from %d to %d", from, to)
+	}
+
+	return ll, nil
+}
+
+func (w *Watcher) alert(l types.Log) {
+	if len(l.Topics) < 3 || l.Removed {
+		return
+	}
+
+	previousOwner := common.BytesToAddress(l.Topics[1].Bytes())
+	newOwner := common.BytesToAddress(l.Topics[2].Bytes())
+	util.Logger.Infof("[Watcher.alert]: ownership of %s %s on chain %s transferred from %s to %s in tx %s",
+		w.conf.Agents[l.Address], l.Address.String(), w.conf.ChainID, previousOwner.String(), newOwner.String(), l.TxHash.String())
+
+	if w.deps.Leader != nil && !w.deps.Leader.IsLeader() {
+		return
+	}
+
+	severity := alert.SeverityWarning
+	consequence := "the new owner is a relayer"
+	if !w.isRelayer(newOwner) {
+		severity = alert.SeverityCritical
+		consequence = fmt.Sprintf("the new owner is not a relayer (%s), fills and swap pair creations will fail", w.relayers())
+	}
+
+	w.deps.Alerter.Notify(&alert.Alert{
+		Key:      fmt.Sprintf("ownership-transferred-%s-%s-%d", w.conf.ChainID, l.TxHash.String(), l.Index),
+		Severity: severity,
+		ChainID:  w.conf.ChainID,
+		Title:    fmt.Sprintf("%s ownership transferred", w.conf.Agents[l.Address]),
+		Message: fmt.Sprintf("%s %s transferred from %s to %s in tx %s at height %d, %s",
+			w.conf.Agents[l.Address], l.Address.String(), previousOwner.String(), newOwner.String(), l.TxHash.String(),
+			l.BlockNumber, consequence),
+	})
+}
+
+func (w *Watcher) isRelayer(addr common.Address) bool {
+	for _, r := range w.conf.Relayers {
+		if r == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *Watcher) relayers() string {
+	ss := make([]string, len(w.conf.Relayers))
+	for i, r := range w.conf.Relayers {
+		ss[i] = r.String()
+	}
+
+	return strings.Join(ss, ", ")
+}
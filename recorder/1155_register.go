@@ -14,8 +14,8 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (r *Recorder) recordERC1155RegisterTx(tx *gorm.DB, b *block.Log) error {
-	ctx, cancel := context.WithTimeout(context.Background(), registerFilterLogsTimeout)
+func (r *Recorder) recordERC1155RegisterTx(ctx context.Context, tx *gorm.DB, b *block.Log, d *discoveries) error {
+	ctx, cancel := context.WithTimeout(ctx, registerFilterLogsTimeout)
 	defer cancel()
 
 	height := uint64(b.Height)
@@ -36,6 +36,7 @@ func (r *Recorder) recordERC1155RegisterTx(tx *gorm.DB, b *block.Log) error {
 
 	var ss []erc1155.SwapPair
 	for iter.Next() {
+		logIndex := iter.Event.Raw.Index
 		s := erc1155.SwapPair{
 			SrcChainID:   r.ChainID(),
 			DstChainID:   iter.Event.ToChainId.String(),
@@ -48,11 +49,13 @@ func (r *Recorder) recordERC1155RegisterTx(tx *gorm.DB, b *block.Log) error {
 
 			State: erc1155.SwapPairStateRegistrationOngoing,
 
-			RegisterTxHash:     iter.Event.Raw.TxHash.String(),
-			RegisterHeight:     int64(iter.Event.Raw.BlockNumber),
-			RegisterBlockHash:  iter.Event.Raw.BlockHash.String(),
-			RegisterBlockLog:   nil,
-			RegisterBlockLogID: &b.ID,
+			RegisterTxHash:       iter.Event.Raw.TxHash.String(),
+			RegisterHeight:       int64(iter.Event.Raw.BlockNumber),
+			RegisterBlockHash:    iter.Event.Raw.BlockHash.String(),
+			RegisterLogIndex:     &logIndex,
+			RegisterContractAddr: iter.Event.Raw.Address.String(),
+			RegisterBlockLog:     nil,
+			RegisterBlockLogID:   blockLogID(b),
 
 			CreateTxHash:     "",
 			CreateHeight:     math.MaxInt64,
@@ -61,7 +64,7 @@ func (r *Recorder) recordERC1155RegisterTx(tx *gorm.DB, b *block.Log) error {
 			CreateBlockLogID: nil,
 		}
 
-		uri, err := r.retrieveERC1155URI(s.SrcTokenAddr)
+		uri, err := r.retrieveERC1155URI(ctx, s.SrcTokenAddr)
 		if err != nil {
 			return errors.Wrap(err, "[Recorder.recordERC1155RegisterTx]: failed to get uri")
 		}
@@ -74,6 +77,10 @@ func (r *Recorder) recordERC1155RegisterTx(tx *gorm.DB, b *block.Log) error {
 		return errors.Wrap(err, "[Recorder.recordERC1155RegisterTx]: failed to iterate events")
 	}
 
+	if err := r.claimLegacyRows(tx, erc1155SwapPairLegacyTable, erc1155SwapPairLegacyEvents(ss)); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC1155RegisterTx]: failed to claim rows recorded before migration v2")
+	}
+
 	err = tx.Clauses(
 		clause.OnConflict{DoNothing: true},
 	).Omit(
@@ -85,10 +92,18 @@ func (r *Recorder) recordERC1155RegisterTx(tx *gorm.DB, b *block.Log) error {
 		return errors.Wrap(err, "[Recorder.recordERC1155RegisterTx]: failed to bulk create")
 	}
 
+	ee := make([]entity, 0, len(ss))
+	for i := range ss {
+		ee = append(ee, &ss[i])
+	}
+	if err := r.recordCreated(tx, &erc1155.SwapPair{}, ee, d); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC1155RegisterTx]: failed to record creations")
+	}
+
 	return nil
 }
 
-func (r *Recorder) retrieveERC1155URI(tokenAddr string) (string, error) {
+func (r *Recorder) retrieveERC1155URI(ctx context.Context, tokenAddr string) (string, error) {
 	token, ok := r.deps.ERC1155Token[r.ChainID()]
 	if !ok {
 		return "", errors.Errorf("[Recorder.retrieveERC1155URI]: unsupported chain id %s", r.ChainID())
@@ -96,6 +111,7 @@ func (r *Recorder) retrieveERC1155URI(tokenAddr string) (string, error) {
 
 	opts := &bind.CallOpts{
 		Pending: true,
+		Context: ctx,
 	}
 	uri, err := token.URI(opts, tokenAddr)
 	if err != nil {
@@ -16,8 +16,8 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (r *Recorder) recordERC1155SwapTx(tx *gorm.DB, b *block.Log) error {
-	ctx, cancel := context.WithTimeout(context.Background(), swapFilterLogsTimeout)
+func (r *Recorder) recordERC1155SwapTx(ctx context.Context, tx *gorm.DB, b *block.Log, d *discoveries) error {
+	ctx, cancel := context.WithTimeout(ctx, swapFilterLogsTimeout)
 	defer cancel()
 
 	height := uint64(b.Height)
@@ -59,6 +59,7 @@ func (r *Recorder) recordERC1155SwapTx(tx *gorm.DB, b *block.Log) error {
 			return errors.Wrap(err, "[Recorder.recordERC1155SwapTx]: failed to marshal amounts")
 		}
 
+		logIndex := iter.Event.Raw.Index
 		s := erc1155.Swap{
 			SrcChainID:            r.ChainID(),
 			DstChainID:            iter.Event.DstChainId.String(),
@@ -74,7 +75,9 @@ func (r *Recorder) recordERC1155SwapTx(tx *gorm.DB, b *block.Log) error {
 			RequestTxHash:         iter.Event.Raw.TxHash.String(),
 			RequestHeight:         int64(iter.Event.Raw.BlockNumber),
 			RequestBlockHash:      iter.Event.Raw.BlockHash.String(),
-			RequestBlockLogID:     &b.ID,
+			RequestLogIndex:       &logIndex,
+			RequestContractAddr:   iter.Event.Raw.Address.String(),
+			RequestBlockLogID:     blockLogID(b),
 			RequestBlockLog:       nil,
 			RequestTrackRetry:     0,
 			FillConsumedFeeAmount: "",
@@ -96,6 +99,14 @@ func (r *Recorder) recordERC1155SwapTx(tx *gorm.DB, b *block.Log) error {
 		return errors.Wrap(err, "[Recorder.recordERC1155SwapTx]: failed to iterate events")
 	}
 
+	legacy, err := erc1155SwapLegacyEvents(ss)
+	if err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC1155SwapTx]: failed to describe events")
+	}
+	if err := r.claimLegacyRows(tx, erc1155SwapLegacyTable, legacy); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC1155SwapTx]: failed to claim rows recorded before migration v2")
+	}
+
 	err = tx.Clauses(
 		clause.OnConflict{DoNothing: true},
 	).Omit(
@@ -107,11 +118,19 @@ func (r *Recorder) recordERC1155SwapTx(tx *gorm.DB, b *block.Log) error {
 		return errors.Wrap(err, "[Recorder.recordERC1155SwapTx]: failed to bulk create")
 	}
 
+	ee := make([]entity, 0, len(ss))
+	for i := range ss {
+		ee = append(ee, &ss[i])
+	}
+	if err := r.recordCreated(tx, &erc1155.Swap{}, ee, d); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC1155SwapTx]: failed to record creations")
+	}
+
 	return nil
 }
 
-func (r *Recorder) recordERC1155BackwardSwapTx(tx *gorm.DB, b *block.Log) error {
-	ctx, cancel := context.WithTimeout(context.Background(), swapFilterLogsTimeout)
+func (r *Recorder) recordERC1155BackwardSwapTx(ctx context.Context, tx *gorm.DB, b *block.Log, d *discoveries) error {
+	ctx, cancel := context.WithTimeout(ctx, swapFilterLogsTimeout)
 	defer cancel()
 
 	height := uint64(b.Height)
@@ -153,6 +172,7 @@ func (r *Recorder) recordERC1155BackwardSwapTx(tx *gorm.DB, b *block.Log) error
 			return errors.Wrap(err, "[Recorder.recordERC1155BackwardSwapTx]: failed to marshal amounts")
 		}
 
+		logIndex := iter.Event.Raw.Index
 		s := erc1155.Swap{
 			SrcChainID:            r.ChainID(),
 			DstChainID:            iter.Event.DstChainId.String(),
@@ -168,7 +188,9 @@ func (r *Recorder) recordERC1155BackwardSwapTx(tx *gorm.DB, b *block.Log) error
 			RequestTxHash:         iter.Event.Raw.TxHash.String(),
 			RequestHeight:         int64(iter.Event.Raw.BlockNumber),
 			RequestBlockHash:      iter.Event.Raw.BlockHash.String(),
-			RequestBlockLogID:     &b.ID,
+			RequestLogIndex:       &logIndex,
+			RequestContractAddr:   iter.Event.Raw.Address.String(),
+			RequestBlockLogID:     blockLogID(b),
 			RequestBlockLog:       nil,
 			RequestTrackRetry:     0,
 			FillConsumedFeeAmount: "",
@@ -190,6 +212,14 @@ func (r *Recorder) recordERC1155BackwardSwapTx(tx *gorm.DB, b *block.Log) error
 		return errors.Wrap(err, "[Recorder.recordERC1155BackwardSwapTx]: failed to iterate events")
 	}
 
+	legacy, err := erc1155SwapLegacyEvents(ss)
+	if err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC1155BackwardSwapTx]: failed to describe events")
+	}
+	if err := r.claimLegacyRows(tx, erc1155SwapLegacyTable, legacy); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC1155BackwardSwapTx]: failed to claim rows recorded before migration v2")
+	}
+
 	err = tx.Clauses(
 		clause.OnConflict{DoNothing: true},
 	).Omit(
@@ -201,5 +231,13 @@ func (r *Recorder) recordERC1155BackwardSwapTx(tx *gorm.DB, b *block.Log) error
 		return errors.Wrap(err, "[Recorder.recordERC1155BackwardSwapTx]: failed to bulk create")
 	}
 
+	ee := make([]entity, 0, len(ss))
+	for i := range ss {
+		ee = append(ee, &ss[i])
+	}
+	if err := r.recordCreated(tx, &erc1155.Swap{}, ee, d); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC1155BackwardSwapTx]: failed to record creations")
+	}
+
 	return nil
 }
@@ -0,0 +1,100 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+func (r *Recorder) recordERC20RegisterTx(ctx context.Context, tx *gorm.DB, b *block.Log, d *discoveries) error {
+	ctx, cancel := context.WithTimeout(ctx, registerFilterLogsTimeout)
+	defer cancel()
+
+	height := uint64(b.Height)
+	opts := bind.FilterOpts{
+		Start:   height,
+		End:     &height,
+		Context: ctx,
+	}
+	iter, err := r.deps.ERC20SwapAgent[r.ChainID()].FilterSwapPairRegister(&opts, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC20RegisterTx]: failed to filter logs")
+	}
+	defer func() {
+		if err := iter.Close(); err != nil {
+			util.Logger.Errorf("[Recorder.recordERC20RegisterTx]: failed to close iterator, %s", err.Error())
+		}
+	}()
+
+	var ss []erc20.SwapPair
+	for iter.Next() {
+		logIndex := iter.Event.Raw.Index
+		s := erc20.SwapPair{
+			SrcChainID:   r.ChainID(),
+			DstChainID:   iter.Event.ToChainId.String(),
+			SrcTokenAddr: iter.Event.TokenAddress.String(),
+			DstTokenAddr: "",
+			SrcTokenName: iter.Event.TokenName,
+			DstTokenName: fmt.Sprintf("%s mirrored from %s", iter.Event.TokenName, r.ChainName()),
+			Sponsor:      iter.Event.Sponsor.String(),
+			Available:    false,
+			Signature:    "",
+			Symbol:       iter.Event.TokenSymbol,
+			Decimals:     iter.Event.TokenDecimals,
+			MinAmount:    "",
+			MaxAmount:    "",
+
+			State: erc20.SwapPairStateRegistrationOngoing,
+
+			RegisterTxHash:       iter.Event.Raw.TxHash.String(),
+			RegisterHeight:       int64(iter.Event.Raw.BlockNumber),
+			RegisterBlockHash:    iter.Event.Raw.BlockHash.String(),
+			RegisterLogIndex:     &logIndex,
+			RegisterContractAddr: iter.Event.Raw.Address.String(),
+			RegisterBlockLog:     nil,
+			RegisterBlockLogID:   blockLogID(b),
+
+			CreateTxHash:     "",
+			CreateHeight:     math.MaxInt64,
+			CreateBlockHash:  "",
+			CreateBlockLog:   nil,
+			CreateBlockLogID: nil,
+		}
+
+		ss = append(ss, s)
+	}
+
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC20RegisterTx]: failed to iterate events")
+	}
+
+	err = tx.Clauses(
+		clause.OnConflict{DoNothing: true},
+	).Omit(
+		clause.Associations,
+	).CreateInBatches(
+		&ss, 100,
+	).Error
+	if err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC20RegisterTx]: failed to bulk create")
+	}
+
+	ee := make([]entity, 0, len(ss))
+	for i := range ss {
+		ee = append(ee, &ss[i])
+	}
+	if err := r.recordCreated(tx, &erc20.SwapPair{}, ee, d); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC20RegisterTx]: failed to record creations")
+	}
+
+	return nil
+}
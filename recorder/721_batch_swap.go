@@ -0,0 +1,233 @@
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+func (r *Recorder) recordERC721BatchSwapTx(ctx context.Context, tx *gorm.DB, b *block.Log, d *discoveries) error {
+	ctx, cancel := context.WithTimeout(ctx, swapFilterLogsTimeout)
+	defer cancel()
+
+	height := uint64(b.Height)
+	opts := bind.FilterOpts{
+		Start:   height,
+		End:     &height,
+		Context: ctx,
+	}
+	iter, err := r.deps.ERC721BatchSwapAgent[r.ChainID()].FilterBatchSwapStarted(&opts, nil, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721BatchSwapTx]: failed to filter logs")
+	}
+	defer func() {
+		if err := iter.Close(); err != nil {
+			util.Logger.Errorf("[Recorder.recordERC721BatchSwapTx]: failed to close iterator, %s", err.Error())
+		}
+	}()
+
+	var ss []erc721.BatchSwap
+	for iter.Next() {
+		ids, ok := batchTokenIDs(iter.Event.TokenIds)
+		if !ok {
+			util.Logger.Warningf(
+				"[Recorder.recordERC721BatchSwapTx]: chain id %s, token %s, tx %s, token ids are empty or not unique",
+				r.ChainID(),
+				iter.Event.TokenAddr.String(),
+				iter.Event.Raw.TxHash.String(),
+			)
+
+			continue
+		}
+
+		logIndex := iter.Event.Raw.Index
+		s := erc721.BatchSwap{
+			SrcChainID:            r.ChainID(),
+			DstChainID:            iter.Event.DstChainId.String(),
+			SrcTokenAddr:          iter.Event.TokenAddr.String(),
+			DstTokenAddr:          "",
+			SrcTokenName:          "",
+			DstTokenName:          "",
+			Sender:                iter.Event.Sender.String(),
+			Recipient:             iter.Event.Recipient.String(),
+			IDs:                   ids,
+			Signature:             "",
+			State:                 erc721.SwapStateRequestOngoing,
+			SwapDirection:         erc721.SwapDirectionForward,
+			RequestTxHash:         iter.Event.Raw.TxHash.String(),
+			RequestHeight:         int64(iter.Event.Raw.BlockNumber),
+			RequestBlockHash:      iter.Event.Raw.BlockHash.String(),
+			RequestLogIndex:       &logIndex,
+			RequestContractAddr:   iter.Event.Raw.Address.String(),
+			RequestBlockLogID:     blockLogID(b),
+			RequestBlockLog:       nil,
+			RequestTrackRetry:     0,
+			FillConsumedFeeAmount: "",
+			FillGasPrice:          "",
+			FillGasUsed:           0,
+			FillHeight:            math.MaxInt64,
+			FillTxHash:            "",
+			FillTrackRetry:        0,
+			FillBlockHash:         "",
+			FillBlockLogID:        nil,
+			FillBlockLog:          nil,
+			MessageLog:            "",
+		}
+
+		ss = append(ss, s)
+	}
+
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721BatchSwapTx]: failed to iterate events")
+	}
+
+	err = tx.Clauses(
+		clause.OnConflict{DoNothing: true},
+	).Omit(
+		clause.Associations,
+	).CreateInBatches(
+		&ss, 100,
+	).Error
+	if err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721BatchSwapTx]: failed to bulk create")
+	}
+
+	ee := make([]entity, 0, len(ss))
+	for i := range ss {
+		ee = append(ee, &ss[i])
+	}
+	if err := r.recordCreated(tx, &erc721.BatchSwap{}, ee, d); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721BatchSwapTx]: failed to record creations")
+	}
+
+	return nil
+}
+
+func (r *Recorder) recordERC721BatchBackwardSwapTx(ctx context.Context, tx *gorm.DB, b *block.Log, d *discoveries) error {
+	ctx, cancel := context.WithTimeout(ctx, swapFilterLogsTimeout)
+	defer cancel()
+
+	height := uint64(b.Height)
+	opts := bind.FilterOpts{
+		Start:   height,
+		End:     &height,
+		Context: ctx,
+	}
+	iter, err := r.deps.ERC721BatchSwapAgent[r.ChainID()].FilterBatchBackwardSwapStarted(&opts, nil, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721BatchBackwardSwapTx]: failed to filter logs")
+	}
+	defer func() {
+		if err := iter.Close(); err != nil {
+			util.Logger.Errorf("[Recorder.recordERC721BatchBackwardSwapTx]: failed to close iterator, %s", err.Error())
+		}
+	}()
+
+	var ss []erc721.BatchSwap
+	for iter.Next() {
+		ids, ok := batchTokenIDs(iter.Event.TokenIds)
+		if !ok {
+			util.Logger.Warningf(
+				"[Recorder.recordERC721BatchBackwardSwapTx]: chain id %s, token %s, tx %s, token ids are empty or not unique",
+				r.ChainID(),
+				iter.Event.MirroredTokenAddr.String(),
+				iter.Event.Raw.TxHash.String(),
+			)
+
+			continue
+		}
+
+		logIndex := iter.Event.Raw.Index
+		s := erc721.BatchSwap{
+			SrcChainID:            r.ChainID(),
+			DstChainID:            iter.Event.DstChainId.String(),
+			SrcTokenAddr:          iter.Event.MirroredTokenAddr.String(),
+			DstTokenAddr:          "",
+			SrcTokenName:          "",
+			DstTokenName:          "",
+			Sender:                iter.Event.Sender.String(),
+			Recipient:             iter.Event.Recipient.String(),
+			IDs:                   ids,
+			Signature:             "",
+			State:                 erc721.SwapStateRequestOngoing,
+			SwapDirection:         erc721.SwapDirectionBackward,
+			RequestTxHash:         iter.Event.Raw.TxHash.String(),
+			RequestHeight:         int64(iter.Event.Raw.BlockNumber),
+			RequestBlockHash:      iter.Event.Raw.BlockHash.String(),
+			RequestLogIndex:       &logIndex,
+			RequestContractAddr:   iter.Event.Raw.Address.String(),
+			RequestBlockLogID:     blockLogID(b),
+			RequestBlockLog:       nil,
+			RequestTrackRetry:     0,
+			FillConsumedFeeAmount: "",
+			FillGasPrice:          "",
+			FillGasUsed:           0,
+			FillHeight:            math.MaxInt64,
+			FillTxHash:            "",
+			FillTrackRetry:        0,
+			FillBlockHash:         "",
+			FillBlockLogID:        nil,
+			FillBlockLog:          nil,
+			MessageLog:            "",
+		}
+
+		ss = append(ss, s)
+	}
+
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721BatchBackwardSwapTx]: failed to iterate events")
+	}
+
+	err = tx.Clauses(
+		clause.OnConflict{DoNothing: true},
+	).Omit(
+		clause.Associations,
+	).CreateInBatches(
+		&ss, 100,
+	).Error
+	if err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721BatchBackwardSwapTx]: failed to bulk create")
+	}
+
+	ee := make([]entity, 0, len(ss))
+	for i := range ss {
+		ee = append(ee, &ss[i])
+	}
+	if err := r.recordCreated(tx, &erc721.BatchSwap{}, ee, d); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721BatchBackwardSwapTx]: failed to record creations")
+	}
+
+	return nil
+}
+
+// batchTokenIDs encodes the token ids of a batch swap, a batch without token ids or with a token id twice is invalid
+func batchTokenIDs(tokenIDs []*big.Int) (datatypes.JSON, bool) {
+	idList := util.BigIntSliceToStrSlice(tokenIDs)
+	if len(idList) == 0 {
+		return nil, false
+	}
+
+	seen := make(map[string]bool, len(idList))
+	for _, id := range idList {
+		if seen[id] {
+			return nil, false
+		}
+		seen[id] = true
+	}
+
+	// a list of decimal strings always encodes
+	ids, _ := json.Marshal(idList)
+
+	return datatypes.JSON(ids), true
+}
@@ -22,8 +22,8 @@ var (
 	registerFilterLogsTimeout = time.Duration(20) * time.Second
 )
 
-func (r *Recorder) recordERC721RegisterTx(tx *gorm.DB, b *block.Log) error {
-	ctx, cancel := context.WithTimeout(context.Background(), registerFilterLogsTimeout)
+func (r *Recorder) recordERC721RegisterTx(ctx context.Context, tx *gorm.DB, b *block.Log, d *discoveries) error {
+	ctx, cancel := context.WithTimeout(ctx, registerFilterLogsTimeout)
 	defer cancel()
 
 	height := uint64(b.Height)
@@ -44,6 +44,7 @@ func (r *Recorder) recordERC721RegisterTx(tx *gorm.DB, b *block.Log) error {
 
 	var ss []erc721.SwapPair
 	for iter.Next() {
+		logIndex := iter.Event.Raw.Index
 		s := erc721.SwapPair{
 			SrcChainID:   r.ChainID(),
 			DstChainID:   iter.Event.ToChainId.String(),
@@ -59,11 +60,13 @@ func (r *Recorder) recordERC721RegisterTx(tx *gorm.DB, b *block.Log) error {
 
 			State: erc721.SwapPairStateRegistrationOngoing,
 
-			RegisterTxHash:     iter.Event.Raw.TxHash.String(),
-			RegisterHeight:     int64(iter.Event.Raw.BlockNumber),
-			RegisterBlockHash:  iter.Event.Raw.BlockHash.String(),
-			RegisterBlockLog:   nil,
-			RegisterBlockLogID: &b.ID,
+			RegisterTxHash:       iter.Event.Raw.TxHash.String(),
+			RegisterHeight:       int64(iter.Event.Raw.BlockNumber),
+			RegisterBlockHash:    iter.Event.Raw.BlockHash.String(),
+			RegisterLogIndex:     &logIndex,
+			RegisterContractAddr: iter.Event.Raw.Address.String(),
+			RegisterBlockLog:     nil,
+			RegisterBlockLogID:   blockLogID(b),
 
 			CreateTxHash:     "",
 			CreateHeight:     math.MaxInt64,
@@ -72,7 +75,7 @@ func (r *Recorder) recordERC721RegisterTx(tx *gorm.DB, b *block.Log) error {
 			CreateBlockLogID: nil,
 		}
 
-		baseURI, err := r.retrieveERC21BaseURI(s.SrcTokenAddr)
+		baseURI, err := r.retrieveERC21BaseURI(ctx, s.SrcTokenAddr)
 		if err != nil {
 			return errors.Wrap(err, "[Recorder.recordERC721RegisterTx]: failed to get baseURI")
 		}
@@ -88,6 +91,10 @@ func (r *Recorder) recordERC721RegisterTx(tx *gorm.DB, b *block.Log) error {
 		return errors.Wrap(err, "[Recorder.recordERC721RegisterTx]: failed to iterate events")
 	}
 
+	if err := r.claimLegacyRows(tx, erc721SwapPairLegacyTable, erc721SwapPairLegacyEvents(ss)); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721RegisterTx]: failed to claim rows recorded before migration v2")
+	}
+
 	err = tx.Clauses(
 		clause.OnConflict{DoNothing: true},
 	).Omit(
@@ -99,10 +106,18 @@ func (r *Recorder) recordERC721RegisterTx(tx *gorm.DB, b *block.Log) error {
 		return errors.Wrap(err, "[Recorder.recordERC721RegisterTx]: failed to bulk create")
 	}
 
+	ee := make([]entity, 0, len(ss))
+	for i := range ss {
+		ee = append(ee, &ss[i])
+	}
+	if err := r.recordCreated(tx, &erc721.SwapPair{}, ee, d); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721RegisterTx]: failed to record creations")
+	}
+
 	return nil
 }
 
-func (r *Recorder) retrieveERC21BaseURI(tokenAddr string) (string, error) {
+func (r *Recorder) retrieveERC21BaseURI(ctx context.Context, tokenAddr string) (string, error) {
 	token, ok := r.deps.ERC721Token[r.ChainID()]
 	if !ok {
 		return "", errors.Errorf("[Recorder.retrieveERC21BaseURI]: unsupported chain id %s", r.ChainID())
@@ -110,6 +125,7 @@ func (r *Recorder) retrieveERC21BaseURI(tokenAddr string) (string, error) {
 
 	opts := &bind.CallOpts{
 		Pending: true,
+		Context: ctx,
 	}
 	uri, err := token.BaseURI(opts, tokenAddr)
 	if err != nil {
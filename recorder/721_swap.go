@@ -19,8 +19,8 @@ var (
 	swapFilterLogsTimeout = time.Duration(20) * time.Second
 )
 
-func (r *Recorder) recordERC721SwapTx(tx *gorm.DB, b *block.Log) error {
-	ctx, cancel := context.WithTimeout(context.Background(), swapFilterLogsTimeout)
+func (r *Recorder) recordERC721SwapTx(ctx context.Context, tx *gorm.DB, b *block.Log, d *discoveries) error {
+	ctx, cancel := context.WithTimeout(ctx, swapFilterLogsTimeout)
 	defer cancel()
 
 	height := uint64(b.Height)
@@ -41,6 +41,7 @@ func (r *Recorder) recordERC721SwapTx(tx *gorm.DB, b *block.Log) error {
 
 	var ss []erc721.Swap
 	for iter.Next() {
+		logIndex := iter.Event.Raw.Index
 		s := erc721.Swap{
 			SrcChainID:            r.ChainID(),
 			DstChainID:            iter.Event.DstChainId.String(),
@@ -58,7 +59,9 @@ func (r *Recorder) recordERC721SwapTx(tx *gorm.DB, b *block.Log) error {
 			RequestTxHash:         iter.Event.Raw.TxHash.String(),
 			RequestHeight:         int64(iter.Event.Raw.BlockNumber),
 			RequestBlockHash:      iter.Event.Raw.BlockHash.String(),
-			RequestBlockLogID:     &b.ID,
+			RequestLogIndex:       &logIndex,
+			RequestContractAddr:   iter.Event.Raw.Address.String(),
+			RequestBlockLogID:     blockLogID(b),
 			RequestBlockLog:       nil,
 			RequestTrackRetry:     0,
 			FillConsumedFeeAmount: "",
@@ -80,6 +83,10 @@ func (r *Recorder) recordERC721SwapTx(tx *gorm.DB, b *block.Log) error {
 		return errors.Wrap(err, "[Recorder.recordERC721SwapTx]: failed to iterate events")
 	}
 
+	if err := r.claimLegacyRows(tx, erc721SwapLegacyTable, erc721SwapLegacyEvents(ss)); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721SwapTx]: failed to claim rows recorded before migration v2")
+	}
+
 	err = tx.Clauses(
 		clause.OnConflict{DoNothing: true},
 	).Omit(
@@ -91,11 +98,19 @@ func (r *Recorder) recordERC721SwapTx(tx *gorm.DB, b *block.Log) error {
 		return errors.Wrap(err, "[Recorder.recordERC721SwapTx]: failed to bulk create")
 	}
 
+	ee := make([]entity, 0, len(ss))
+	for i := range ss {
+		ee = append(ee, &ss[i])
+	}
+	if err := r.recordCreated(tx, &erc721.Swap{}, ee, d); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721SwapTx]: failed to record creations")
+	}
+
 	return nil
 }
 
-func (r *Recorder) recordERC721BackwardSwapTx(tx *gorm.DB, b *block.Log) error {
-	ctx, cancel := context.WithTimeout(context.Background(), swapFilterLogsTimeout)
+func (r *Recorder) recordERC721BackwardSwapTx(ctx context.Context, tx *gorm.DB, b *block.Log, d *discoveries) error {
+	ctx, cancel := context.WithTimeout(ctx, swapFilterLogsTimeout)
 	defer cancel()
 
 	height := uint64(b.Height)
@@ -116,6 +131,7 @@ func (r *Recorder) recordERC721BackwardSwapTx(tx *gorm.DB, b *block.Log) error {
 
 	var ss []erc721.Swap
 	for iter.Next() {
+		logIndex := iter.Event.Raw.Index
 		s := erc721.Swap{
 			SrcChainID:            r.ChainID(),
 			DstChainID:            iter.Event.DstChainId.String(),
@@ -133,7 +149,9 @@ func (r *Recorder) recordERC721BackwardSwapTx(tx *gorm.DB, b *block.Log) error {
 			RequestTxHash:         iter.Event.Raw.TxHash.String(),
 			RequestHeight:         int64(iter.Event.Raw.BlockNumber),
 			RequestBlockHash:      iter.Event.Raw.BlockHash.String(),
-			RequestBlockLogID:     &b.ID,
+			RequestLogIndex:       &logIndex,
+			RequestContractAddr:   iter.Event.Raw.Address.String(),
+			RequestBlockLogID:     blockLogID(b),
 			RequestBlockLog:       nil,
 			RequestTrackRetry:     0,
 			FillConsumedFeeAmount: "",
@@ -155,6 +173,10 @@ func (r *Recorder) recordERC721BackwardSwapTx(tx *gorm.DB, b *block.Log) error {
 		return errors.Wrap(err, "[Recorder.recordERC721BackwardSwapTx]: failed to iterate events")
 	}
 
+	if err := r.claimLegacyRows(tx, erc721SwapLegacyTable, erc721SwapLegacyEvents(ss)); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721BackwardSwapTx]: failed to claim rows recorded before migration v2")
+	}
+
 	err = tx.Clauses(
 		clause.OnConflict{DoNothing: true},
 	).Omit(
@@ -166,5 +188,13 @@ func (r *Recorder) recordERC721BackwardSwapTx(tx *gorm.DB, b *block.Log) error {
 		return errors.Wrap(err, "[Recorder.recordERC721BackwardSwapTx]: failed to bulk create")
 	}
 
+	ee := make([]entity, 0, len(ss))
+	for i := range ss {
+		ee = append(ee, &ss[i])
+	}
+	if err := r.recordCreated(tx, &erc721.Swap{}, ee, d); err != nil {
+		return errors.Wrap(err, "[Recorder.recordERC721BackwardSwapTx]: failed to record creations")
+	}
+
 	return nil
 }
@@ -9,8 +9,8 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/common"
 )
 
-func (r *Recorder) Block(height int64) (*common.Block, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *Recorder) Block(ctx context.Context, height int64) (*common.Block, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	header, err := r.deps.Client[r.ChainID()].HeaderByNumber(ctx, big.NewInt(height))
@@ -18,17 +18,14 @@ func (r *Recorder) Block(height int64) (*common.Block, error) {
 		return nil, errors.Wrap(err, "[Recorder.Block]: failed to get block")
 	}
 
-	r.latestBlockCached = &common.Block{
+	b := &common.Block{
 		Height:          height,
 		Chain:           r.conf.ChainID.String(),
 		BlockHash:       header.Hash().String(),
 		ParentBlockHash: header.ParentHash.String(),
 		BlockTime:       int64(header.Time),
 	}
+	r.deps.Head.SetRecorded(b)
 
-	return r.latestBlockCached, nil
-}
-
-func (r *Recorder) LatestBlockCached() *common.Block {
-	return r.latestBlockCached
+	return b, nil
 }
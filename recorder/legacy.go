@@ -0,0 +1,233 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// legacyTable names the event identity columns of a table created before migration v2
+type legacyTable struct {
+	name               string
+	txHashColumn       string
+	logIndexColumn     string
+	contractAddrColumn string
+}
+
+var (
+	erc721SwapLegacyTable = &legacyTable{
+		name:               "erc721_swaps",
+		txHashColumn:       "request_tx_hash",
+		logIndexColumn:     "request_log_index",
+		contractAddrColumn: "request_contract_addr",
+	}
+	erc1155SwapLegacyTable = &legacyTable{
+		name:               "erc1155_swaps",
+		txHashColumn:       "request_tx_hash",
+		logIndexColumn:     "request_log_index",
+		contractAddrColumn: "request_contract_addr",
+	}
+	erc721SwapPairLegacyTable = &legacyTable{
+		name:               "erc721_swap_pairs",
+		txHashColumn:       "register_tx_hash",
+		logIndexColumn:     "register_log_index",
+		contractAddrColumn: "register_contract_addr",
+	}
+	erc1155SwapPairLegacyTable = &legacyTable{
+		name:               "erc1155_swap_pairs",
+		txHashColumn:       "register_tx_hash",
+		logIndexColumn:     "register_log_index",
+		contractAddrColumn: "register_contract_addr",
+	}
+)
+
+// legacyEvent is an event about to be recorded, with the columns identifying the row recorded for it before its
+// log index was stored. These are the columns migration v2 deduplicated on.
+type legacyEvent struct {
+	txHash       string
+	logIndex     uint
+	contractAddr string
+	columns      map[string]string
+	// jsonColumns are compared by value, since databases do not keep the JSON text as it was written
+	jsonColumns map[string][]string
+}
+
+// claimLegacyRows stores the log index and the contract address of events in the rows recorded for them before
+// migration v2. Those rows have a NULL log index, which the unique event index never matches, so recording the
+// event again, e.g. by a rescan, would insert a second swap and fill it twice. Once claimed, the row makes the insert
+// that follows a no-op.
+func (r *Recorder) claimLegacyRows(tx *gorm.DB, t *legacyTable, ee []*legacyEvent) error {
+	for _, e := range ee {
+		var rows []map[string]interface{}
+		err := tx.Table(
+			t.name,
+		).Where(
+			fmt.Sprintf("src_chain_id = ? and %s = ? and %s is null", t.txHashColumn, t.logIndexColumn),
+			r.ChainID(),
+			e.txHash,
+		).Order(
+			"id asc",
+		).Find(&rows).Error
+		if err != nil {
+			return errors.Wrapf(err, "[Recorder.claimLegacyRows]: failed to query %s", t.name)
+		}
+
+		for _, row := range rows {
+			ok, err := e.matches(row)
+			if err != nil {
+				return errors.Wrapf(err, "[Recorder.claimLegacyRows]: failed to compare %s row %s", t.name, legacyString(row["id"]))
+			}
+			if !ok {
+				continue
+			}
+
+			id := legacyString(row["id"])
+			err = tx.Table(
+				t.name,
+			).Where(
+				"id = ?",
+				id,
+			).UpdateColumns(map[string]interface{}{
+				t.logIndexColumn:     e.logIndex,
+				t.contractAddrColumn: e.contractAddr,
+			}).Error
+			if err != nil {
+				return errors.Wrapf(err, "[Recorder.claimLegacyRows]: failed to update %s row %s", t.name, id)
+			}
+
+			util.Logger.Infof("[Recorder.claimLegacyRows]: %s row %s recorded before migration v2 is log %d of tx %s",
+				t.name, id, e.logIndex, e.txHash)
+
+			break
+		}
+	}
+
+	return nil
+}
+
+func (e *legacyEvent) matches(row map[string]interface{}) (bool, error) {
+	for column, value := range e.columns {
+		if legacyString(row[column]) != value {
+			return false, nil
+		}
+	}
+
+	for column, value := range e.jsonColumns {
+		var stored []string
+		if err := json.Unmarshal([]byte(legacyString(row[column])), &stored); err != nil {
+			return false, errors.Wrapf(err, "[legacyEvent.matches]: invalid %s", column)
+		}
+		if len(stored) != len(value) {
+			return false, nil
+		}
+		for i := range stored {
+			if stored[i] != value[i] {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func legacyString(v interface{}) string {
+	switch vv := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(vv)
+	}
+
+	return fmt.Sprint(v)
+}
+
+func erc721SwapLegacyEvents(ss []erc721.Swap) []*legacyEvent {
+	ee := make([]*legacyEvent, 0, len(ss))
+	for _, s := range ss {
+		ee = append(ee, &legacyEvent{
+			txHash:       s.RequestTxHash,
+			logIndex:     *s.RequestLogIndex,
+			contractAddr: s.RequestContractAddr,
+			columns: map[string]string{
+				"swap_direction": string(s.SwapDirection),
+				"src_token_addr": s.SrcTokenAddr,
+				"sender":         s.Sender,
+				"recipient":      s.Recipient,
+				"token_id":       s.TokenID,
+			},
+		})
+	}
+
+	return ee
+}
+
+func erc1155SwapLegacyEvents(ss []erc1155.Swap) ([]*legacyEvent, error) {
+	ee := make([]*legacyEvent, 0, len(ss))
+	for _, s := range ss {
+		var ids, amounts []string
+		if err := json.Unmarshal(s.IDs, &ids); err != nil {
+			return nil, errors.Wrap(err, "[erc1155SwapLegacyEvents]: invalid ids")
+		}
+		if err := json.Unmarshal(s.Amounts, &amounts); err != nil {
+			return nil, errors.Wrap(err, "[erc1155SwapLegacyEvents]: invalid amounts")
+		}
+
+		ee = append(ee, &legacyEvent{
+			txHash:       s.RequestTxHash,
+			logIndex:     *s.RequestLogIndex,
+			contractAddr: s.RequestContractAddr,
+			columns: map[string]string{
+				"swap_direction": string(s.SwapDirection),
+				"src_token_addr": s.SrcTokenAddr,
+				"sender":         s.Sender,
+				"recipient":      s.Recipient,
+			},
+			jsonColumns: map[string][]string{
+				"ids":     ids,
+				"amounts": amounts,
+			},
+		})
+	}
+
+	return ee, nil
+}
+
+func erc721SwapPairLegacyEvents(ss []erc721.SwapPair) []*legacyEvent {
+	ee := make([]*legacyEvent, 0, len(ss))
+	for _, s := range ss {
+		ee = append(ee, &legacyEvent{
+			txHash:       s.RegisterTxHash,
+			logIndex:     *s.RegisterLogIndex,
+			contractAddr: s.RegisterContractAddr,
+			columns: map[string]string{
+				"dst_chain_id":   s.DstChainID,
+				"src_token_addr": s.SrcTokenAddr,
+			},
+		})
+	}
+
+	return ee
+}
+
+func erc1155SwapPairLegacyEvents(ss []erc1155.SwapPair) []*legacyEvent {
+	ee := make([]*legacyEvent, 0, len(ss))
+	for _, s := range ss {
+		ee = append(ee, &legacyEvent{
+			txHash:       s.RegisterTxHash,
+			logIndex:     *s.RegisterLogIndex,
+			contractAddr: s.RegisterContractAddr,
+			columns: map[string]string{
+				"dst_chain_id":   s.DstChainID,
+				"src_token_addr": s.SrcTokenAddr,
+			},
+		})
+	}
+
+	return ee
+}
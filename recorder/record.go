@@ -1,6 +1,8 @@
 package recorder
 
 import (
+	"context"
+
 	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
 
@@ -9,38 +11,68 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
 )
 
-func (r *Recorder) Record(tx *gorm.DB, b *block.Log) error {
+func (r *Recorder) Record(ctx context.Context, tx *gorm.DB, b *block.Log) error {
+	return r.record(ctx, tx, b, nil)
+}
+
+func (r *Recorder) record(ctx context.Context, tx *gorm.DB, b *block.Log, d *discoveries) error {
 	var g errgroup.Group
 	g.Go(func() error {
-		if err := r.recordERC721RegisterTx(tx, b); err != nil {
-			return errors.Wrap(err, "[Recorder.Record]: failed to record ERC721 register tx")
+		if err := r.recordERC721RegisterTx(ctx, tx, b, d); err != nil {
+			return errors.Wrap(err, "[Recorder.record]: failed to record ERC721 register tx")
+		}
+		if err := r.recordERC721SwapTx(ctx, tx, b, d); err != nil {
+			return errors.Wrap(err, "[Recorder.record]: failed to record ERC721 swap tx")
 		}
-		if err := r.recordERC721SwapTx(tx, b); err != nil {
-			return errors.Wrap(err, "[Recorder.Record]: failed to record ERC721 swap tx")
+		if err := r.recordERC721BackwardSwapTx(ctx, tx, b, d); err != nil {
+			return errors.Wrap(err, "[Recorder.record]: failed to record ERC721 backward swap tx")
 		}
-		if err := r.recordERC721BackwardSwapTx(tx, b); err != nil {
-			return errors.Wrap(err, "[Recorder.Record]: failed to record ERC721 backward swap tx")
+		if _, ok := r.deps.ERC721BatchSwapAgent[r.ChainID()]; !ok {
+			return nil
+		}
+		if err := r.recordERC721BatchSwapTx(ctx, tx, b, d); err != nil {
+			return errors.Wrap(err, "[Recorder.record]: failed to record ERC721 batch swap tx")
+		}
+		if err := r.recordERC721BatchBackwardSwapTx(ctx, tx, b, d); err != nil {
+			return errors.Wrap(err, "[Recorder.record]: failed to record ERC721 batch backward swap tx")
 		}
 
 		return nil
 	})
 
 	g.Go(func() error {
-		if err := r.recordERC1155RegisterTx(tx, b); err != nil {
-			return errors.Wrap(err, "[Recorder.Record]: failed to record ERC1155 register tx")
+		if err := r.recordERC1155RegisterTx(ctx, tx, b, d); err != nil {
+			return errors.Wrap(err, "[Recorder.record]: failed to record ERC1155 register tx")
+		}
+		if err := r.recordERC1155SwapTx(ctx, tx, b, d); err != nil {
+			return errors.Wrap(err, "[Recorder.record]: failed to record ERC1155 swap tx")
+		}
+		if err := r.recordERC1155BackwardSwapTx(ctx, tx, b, d); err != nil {
+			return errors.Wrap(err, "[Recorder.record]: failed to record ERC1155 backward swap tx")
+		}
+
+		return nil
+	})
+
+	g.Go(func() error {
+		if _, ok := r.deps.ERC20SwapAgent[r.ChainID()]; !ok {
+			return nil
+		}
+		if err := r.recordERC20RegisterTx(ctx, tx, b, d); err != nil {
+			return errors.Wrap(err, "[Recorder.record]: failed to record ERC20 register tx")
 		}
-		if err := r.recordERC1155SwapTx(tx, b); err != nil {
-			return errors.Wrap(err, "[Recorder.Record]: failed to record ERC1155 swap tx")
+		if err := r.recordERC20SwapTx(ctx, tx, b, d); err != nil {
+			return errors.Wrap(err, "[Recorder.record]: failed to record ERC20 swap tx")
 		}
-		if err := r.recordERC1155BackwardSwapTx(tx, b); err != nil {
-			return errors.Wrap(err, "[Recorder.Record]: failed to record ERC1155 backward swap tx")
+		if err := r.recordERC20BackwardSwapTx(ctx, tx, b, d); err != nil {
+			return errors.Wrap(err, "[Recorder.record]: failed to record ERC20 backward swap tx")
 		}
 
 		return nil
 	})
 
 	if err := g.Wait(); err != nil {
-		return errors.Wrap(err, "[Recorder.Record]: failed to monitor events")
+		return errors.Wrap(err, "[Recorder.record]: failed to monitor events")
 	}
 
 	return nil
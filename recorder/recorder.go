@@ -1,25 +1,28 @@
 package recorder
 
 import (
+	"context"
 	"math/big"
 
 	"gorm.io/gorm"
 
 	erc1155agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc1155"
+	erc20agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc20"
 	erc721agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc721"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/client"
 	corecommon "github.com/synycboom/bsc-evm-compatible-bridge-core/common"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/head"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
 	erc1155token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc1155"
 	erc721token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc721"
 )
 
 type IRecorder interface {
-	Block(height int64) (*corecommon.Block, error)
+	Block(ctx context.Context, height int64) (*corecommon.Block, error)
 	ChainID() string
 	Delete(tx *gorm.DB, height int64) error
-	LatestBlockCached() *corecommon.Block
-	Record(tx *gorm.DB, block *block.Log) error
+	Record(ctx context.Context, tx *gorm.DB, block *block.Log) error
+	Rescan(ctx context.Context, tx *gorm.DB, block *block.Log) ([]*Discovery, error)
 }
 
 type Config struct {
@@ -29,18 +32,23 @@ type Config struct {
 }
 
 type Dependencies struct {
-	Client           map[string]client.ETHClient
-	DB               *gorm.DB
-	ERC721SwapAgent  map[string]erc721agent.SwapAgent
-	ERC721Token      map[string]erc721token.IToken
-	ERC1155SwapAgent map[string]erc1155agent.SwapAgent
-	ERC1155Token     map[string]erc1155token.IToken
+	Client          map[string]client.ETHClient
+	DB              *gorm.DB
+	ERC721SwapAgent map[string]erc721agent.SwapAgent
+	// ERC721BatchSwapAgent holds the batch swap agents of the chains which have one, keyed by chain id
+	ERC721BatchSwapAgent map[string]erc721agent.BatchSwapAgent
+	ERC721Token          map[string]erc721token.IToken
+	ERC1155SwapAgent     map[string]erc1155agent.SwapAgent
+	ERC1155Token         map[string]erc1155token.IToken
+	// ERC20SwapAgent holds the ERC20 swap agents of the chains which have one, keyed by chain id
+	ERC20SwapAgent map[string]erc20agent.SwapAgent
+	// Head is the head tracker of the chain, it is given every block fetched
+	Head *head.Tracker
 }
 
 type Recorder struct {
-	latestBlockCached *corecommon.Block
-	conf              *Config
-	deps              *Dependencies
+	conf *Config
+	deps *Dependencies
 }
 
 func NewRecorder(c *Config, d *Dependencies) *Recorder {
@@ -0,0 +1,69 @@
+package recorder
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+)
+
+// Discovery is a swap or swap pair event found by a rescan
+type Discovery struct {
+	EntityType transition.EntityType
+	// EntityID is empty when the entity was already recorded
+	EntityID string
+	TxHash   string
+	New      bool
+}
+
+// discoveries collects the entities found by the concurrent record functions, a nil collector ignores them
+type discoveries struct {
+	mutex sync.Mutex
+	dd    []*Discovery
+}
+
+func (d *discoveries) add(t *transition.Transition, isNew bool) {
+	if d == nil {
+		return
+	}
+
+	dd := &Discovery{
+		EntityType: t.EntityType,
+		TxHash:     t.TxHash,
+		New:        isNew,
+	}
+	if isNew {
+		dd.EntityID = t.EntityID
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.dd = append(d.dd, dd)
+}
+
+// Rescan records the events of a block again, like Record, and reports every swap and swap pair found in it.
+// Events that were already recorded are skipped by the unique indexes of their tables, after claiming the rows
+// recorded before migration v2 (see claimLegacyRows), so rescanning a block is safe while the observer runs. The block log does not need to be stored; swaps found in a pruned block then reference
+// no block log.
+func (r *Recorder) Rescan(ctx context.Context, tx *gorm.DB, b *block.Log) ([]*Discovery, error) {
+	var d discoveries
+	if err := r.record(ctx, tx, b, &d); err != nil {
+		return nil, errors.Wrap(err, "[Recorder.Rescan]: failed to record block")
+	}
+
+	return d.dd, nil
+}
+
+// blockLogID returns the ID of a stored block log, or nil for a block that is rescanned without one
+func blockLogID(b *block.Log) *string {
+	if b.ID == "" {
+		return nil
+	}
+
+	return &b.ID
+}
@@ -0,0 +1,73 @@
+package recorder
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+)
+
+// entity is a recorded swap or swap pair
+type entity interface {
+	Transition(actor string) (*transition.Transition, *outbox.Event, error)
+}
+
+// recordCreated records the creation of the entities of a block as their first transition, with the outbox events
+// announcing it. Entities skipped by the bulk create as duplicates kept the fresh ID given by BeforeCreate, so they
+// are not found in the table. Every entity is added to d, when given, as new or already present.
+func (r *Recorder) recordCreated(tx *gorm.DB, m interface{}, ee []entity, d *discoveries) error {
+	if len(ee) == 0 {
+		return nil
+	}
+
+	actor := "recorder/" + r.ChainID()
+	tt := make([]*transition.Transition, 0, len(ee))
+	events := make([]*outbox.Event, 0, len(ee))
+	ids := make([]string, 0, len(ee))
+	for _, e := range ee {
+		t, event, err := e.Transition(actor)
+		if err != nil {
+			return errors.Wrap(err, "[Recorder.recordCreated]: failed to describe transition")
+		}
+
+		tt = append(tt, t)
+		events = append(events, event)
+		ids = append(ids, t.EntityID)
+	}
+
+	var created []string
+	if err := tx.Model(m).Where("id in ?", ids).Pluck("id", &created).Error; err != nil {
+		return errors.Wrap(err, "[Recorder.recordCreated]: failed to query created entities")
+	}
+
+	isCreated := make(map[string]bool, len(created))
+	for _, id := range created {
+		isCreated[id] = true
+	}
+
+	var createdTT []*transition.Transition
+	var createdEvents []*outbox.Event
+	for i, t := range tt {
+		d.add(t, isCreated[t.EntityID])
+		if !isCreated[t.EntityID] {
+			continue
+		}
+
+		createdTT = append(createdTT, t)
+		createdEvents = append(createdEvents, events[i])
+	}
+
+	if len(createdTT) == 0 {
+		return nil
+	}
+
+	if err := tx.CreateInBatches(createdTT, 100).Error; err != nil {
+		return errors.Wrap(err, "[Recorder.recordCreated]: failed to bulk create transitions")
+	}
+	if err := tx.CreateInBatches(createdEvents, 100).Error; err != nil {
+		return errors.Wrap(err, "[Recorder.recordCreated]: failed to bulk create outbox events")
+	}
+
+	return nil
+}
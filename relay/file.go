@@ -0,0 +1,65 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// FilePublisher appends the messages to a file as JSON lines, it stands in for a broker in tests and local runs
+type FilePublisher struct {
+	file  *os.File
+	mutex sync.Mutex
+}
+
+type fileRecord struct {
+	Topic string          `json:"topic"`
+	Key   string          `json:"key"`
+	ID    string          `json:"id"`
+	Value json.RawMessage `json:"value"`
+}
+
+// NewFilePublisher returns a publisher appending to the file at path
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "[NewFilePublisher]: failed to open file")
+	}
+
+	return &FilePublisher{
+		file: f,
+	}, nil
+}
+
+func (p *FilePublisher) Publish(ctx context.Context, mm []*Message) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for i, m := range mm {
+		line, err := json.Marshal(&fileRecord{
+			Topic: m.Topic,
+			Key:   m.Key,
+			ID:    m.ID,
+			Value: json.RawMessage(m.Value),
+		})
+		if err != nil {
+			return i, errors.Wrap(err, "[FilePublisher.Publish]: failed to encode message")
+		}
+		if _, err := p.file.Write(append(line, '\n')); err != nil {
+			return i, errors.Wrap(err, "[FilePublisher.Publish]: failed to write message")
+		}
+	}
+
+	if err := p.file.Sync(); err != nil {
+		return 0, errors.Wrap(err, "[FilePublisher.Publish]: failed to sync file")
+	}
+
+	return len(mm), nil
+}
+
+func (p *FilePublisher) Close() error {
+	return p.file.Close()
+}
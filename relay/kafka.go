@@ -0,0 +1,61 @@
+package relay
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaBatchTimeout bounds the wait for a partial batch, the relay already batches the messages
+const kafkaBatchTimeout = 10 * time.Millisecond
+
+// KafkaPublisher publishes the messages to Kafka, partitioned by key and acknowledged by all in-sync replicas
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher returns a publisher to the Kafka cluster of the brokers
+func NewKafkaPublisher(brokers []string) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			BatchTimeout: kafkaBatchTimeout,
+		},
+	}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, mm []*Message) (int, error) {
+	msgs := make([]kafka.Message, 0, len(mm))
+	for _, m := range mm {
+		msgs = append(msgs, kafka.Message{
+			Topic: m.Topic,
+			Key:   []byte(m.Key),
+			Value: m.Value,
+			Headers: []kafka.Header{
+				{Key: EventIDHeader, Value: []byte(m.ID)},
+			},
+		})
+	}
+
+	err := p.writer.WriteMessages(ctx, msgs...)
+	if err == nil {
+		return len(mm), nil
+	}
+
+	published := 0
+	if werrs, ok := err.(kafka.WriteErrors); ok {
+		for published < len(werrs) && werrs[published] == nil {
+			published++
+		}
+	}
+
+	return published, errors.Wrap(err, "[KafkaPublisher.Publish]: failed to write messages")
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
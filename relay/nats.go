@@ -0,0 +1,54 @@
+package relay
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+)
+
+// NATSPublisher publishes the messages to NATS JetStream, a stream has to capture their subjects. The event id is
+// the JetStream message id, so redelivered messages are dropped within the duplicate window of the stream.
+type NATSPublisher struct {
+	conn *nats.Conn
+	js   nats.JetStreamContext
+}
+
+// NewNATSPublisher connects to one of the NATS servers at urls, and reconnects to the others when it is lost
+func NewNATSPublisher(urls []string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(strings.Join(urls, ","))
+	if err != nil {
+		return nil, errors.Wrap(err, "[NewNATSPublisher]: failed to connect")
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "[NewNATSPublisher]: failed to create JetStream context")
+	}
+
+	return &NATSPublisher{
+		conn: conn,
+		js:   js,
+	}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, mm []*Message) (int, error) {
+	for i, m := range mm {
+		msg := nats.NewMsg(m.Topic)
+		msg.Data = m.Value
+		msg.Header.Set(EventIDHeader, m.ID)
+		msg.Header.Set(KeyHeader, m.Key)
+		if _, err := p.js.PublishMsg(msg, nats.MsgId(m.ID), nats.Context(ctx)); err != nil {
+			return i, errors.Wrapf(err, "[NATSPublisher.Publish]: failed to publish event %s", m.ID)
+		}
+	}
+
+	return len(mm), nil
+}
+
+func (p *NATSPublisher) Close() error {
+	p.conn.Close()
+	return nil
+}
@@ -0,0 +1,28 @@
+package relay
+
+import (
+	"context"
+)
+
+const (
+	// EventIDHeader carries the outbox event id, consumers deduplicate redelivered messages by it
+	EventIDHeader = "Bridge-Event-Id"
+	// KeyHeader carries the message key on brokers without keys
+	KeyHeader = "Bridge-Key"
+)
+
+// Message is an outbox event to be published
+type Message struct {
+	Topic string
+	Key   string
+	// ID is the outbox event id
+	ID    string
+	Value []byte
+}
+
+// Publisher publishes messages to a message broker
+type Publisher interface {
+	// Publish publishes the messages in order and returns how many of the first ones were published
+	Publish(ctx context.Context, mm []*Message) (int, error)
+	Close() error
+}
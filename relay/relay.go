@@ -0,0 +1,195 @@
+package relay
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/lease"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/webhook"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Timeout bounds the publishing of a batch
+	Timeout time.Duration
+	// Topic and Key are templates of the topic and the key of the messages, see Relay.expand
+	Topic string
+	Key   string
+	// Retention is how long published events are kept, they are never deleted when it is 0
+	Retention time.Duration
+	// Webhooks keeps the events until they are fanned out and every delivery of them succeeded
+	Webhooks bool
+}
+
+type Dependencies struct {
+	DB        *gorm.DB
+	Publisher Publisher
+	// Leader is nil when a single replica runs, otherwise only the leader publishes
+	Leader lease.Leader
+}
+
+// pruneInterval is the delay between two deletions of the published events past the retention
+const pruneInterval = 10 * time.Minute
+
+// Relay publishes the outbox events to a message broker in the order they were written. A batch that is not
+// fully published is published again, so messages are delivered at least once.
+type Relay struct {
+	conf *Config
+	deps *Dependencies
+
+	wg         sync.WaitGroup
+	lastPruned time.Time
+}
+
+// NewRelay returns the relay instance
+func NewRelay(c *Config, d *Dependencies) *Relay {
+	return &Relay{
+		conf: c,
+		deps: d,
+	}
+}
+
+// Start starts the publishing loop, it stops once ctx is done
+func (r *Relay) Start(ctx context.Context) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		for util.Sleep(ctx, r.conf.PollInterval) {
+			if r.deps.Leader == nil || r.deps.Leader.IsLeader() {
+				r.relay(ctx)
+				r.prune(ctx)
+			}
+		}
+	}()
+}
+
+// Wait blocks until the loop started by Start has returned
+func (r *Relay) Wait() {
+	r.wg.Wait()
+}
+
+func (r *Relay) relay(ctx context.Context) {
+	var ee []outbox.Event
+	err := r.deps.DB.WithContext(ctx).Where(
+		"published = ?",
+		false,
+	).Order(
+		"id asc",
+	).Limit(
+		r.conf.BatchSize,
+	).Find(
+		&ee,
+	).Error
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Relay.relay]: failed to query outbox events"))
+		return
+	}
+	if len(ee) == 0 {
+		return
+	}
+
+	mm := make([]*Message, 0, len(ee))
+	for i := range ee {
+		value, err := ee[i].Message()
+		if err != nil {
+			util.Logger.Error(errors.Wrap(err, "[Relay.relay]: failed to encode message"))
+			return
+		}
+
+		mm = append(mm, &Message{
+			Topic: r.expand(r.conf.Topic, &ee[i]),
+			Key:   r.expand(r.conf.Key, &ee[i]),
+			ID:    ee[i].ID,
+			Value: value,
+		})
+	}
+
+	// a batch being published is completed, the events published are marked even if ctx is canceled meanwhile
+	ctx = util.WithoutCancel(ctx)
+	pubCtx, cancel := context.WithTimeout(ctx, r.conf.Timeout)
+	defer cancel()
+
+	published, pubErr := r.deps.Publisher.Publish(pubCtx, mm)
+	if published > 0 {
+		ids := make([]string, 0, published)
+		for _, e := range ee[:published] {
+			ids = append(ids, e.ID)
+		}
+
+		err := r.deps.DB.WithContext(ctx).Model(
+			&outbox.Event{},
+		).Where(
+			"id in ?",
+			ids,
+		).Update(
+			"published", true,
+		).Error
+		if err != nil {
+			util.Logger.Error(errors.Wrap(err, "[Relay.relay]: failed to mark published outbox events"))
+		}
+	}
+	if pubErr != nil {
+		util.Logger.Error(errors.Wrapf(pubErr, "[Relay.relay]: published %d of %d outbox events", published, len(ee)))
+	}
+}
+
+// prune deletes the published events older than the retention, at most once per pruneInterval. With webhooks, an
+// event is kept until it is fanned out and while a delivery of it is pending or dead, since replaying it reads it.
+func (r *Relay) prune(ctx context.Context) {
+	if r.conf.Retention == 0 || time.Since(r.lastPruned) < pruneInterval {
+		return
+	}
+	r.lastPruned = time.Now()
+
+	q := r.deps.DB.WithContext(ctx).Where(
+		"published = ? and created_at < ?",
+		true,
+		time.Now().Add(-r.conf.Retention),
+	)
+	if r.conf.Webhooks {
+		q = q.Where(
+			"fanned_out = ? and not exists (?)",
+			true,
+			r.deps.DB.Table(
+				"webhook_deliveries",
+			).Select(
+				"1",
+			).Where(
+				"webhook_deliveries.event_id = outbox_events.id and webhook_deliveries.state <> ?",
+				webhook.DeliveryStateDelivered,
+			),
+		)
+	}
+
+	res := q.Delete(&outbox.Event{})
+	if res.Error != nil {
+		util.Logger.Error(errors.Wrap(res.Error, "[Relay.prune]: failed to delete published outbox events"))
+		return
+	}
+	if res.RowsAffected > 0 {
+		util.Logger.Infof("[Relay.prune]: deleted %d published outbox events older than %s", res.RowsAffected, r.conf.Retention)
+	}
+}
+
+// expand replaces {type}, {entity_type}, {entity_id}, {src_chain_id}, {dst_chain_id} and {tx_hash} in a template
+// with the values of an event. The tx hash is the one of the swap request or the swap pair registration, so a
+// {src_chain_id}:{tx_hash} key keeps the events of a swap in order on a partitioned broker.
+func (r *Relay) expand(template string, e *outbox.Event) string {
+	return strings.NewReplacer(
+		"{type}", e.Type,
+		"{entity_type}", e.EntityType,
+		"{entity_id}", e.EntityID,
+		"{src_chain_id}", e.SrcChainID,
+		"{dst_chain_id}", e.DstChainID,
+		"{tx_hash}", e.RequestTxHash,
+	).Replace(template)
+}
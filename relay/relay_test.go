@@ -0,0 +1,155 @@
+package relay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/webhook"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/testutil"
+)
+
+func createEvent(t *testing.T, db *gorm.DB, entityID, txHash string) *outbox.Event {
+	e := &outbox.Event{
+		Type:          "erc721_swap.request_ongoing",
+		EntityType:    "erc721_swap",
+		EntityID:      entityID,
+		SrcChainID:    "97",
+		DstChainID:    "4",
+		SrcTokenAddr:  "0x0000000000000000000000000000000000000070",
+		RequestTxHash: txHash,
+		Payload:       []byte(`{"transition":{"from_state":"","to_state":"request_ongoing","actor":"test"}}`),
+	}
+	if err := db.Create(e).Error; err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	return e
+}
+
+func newFileRelay(t *testing.T, db *gorm.DB, c *Config) (*Relay, string) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	publisher, err := NewFilePublisher(path)
+	if err != nil {
+		t.Fatalf("failed to create file publisher: %v", err)
+	}
+	t.Cleanup(func() { publisher.Close() })
+
+	c.BatchSize = 2
+	c.Timeout = time.Second
+	c.Topic = "bridge.{entity_type}"
+	c.Key = "{src_chain_id}:{tx_hash}"
+
+	return NewRelay(c, &Dependencies{DB: db, Publisher: publisher}), path
+}
+
+func readRecords(t *testing.T, path string) []fileRecord {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open sink: %v", err)
+	}
+	defer f.Close()
+
+	var rr []fileRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r fileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		rr = append(rr, r)
+	}
+
+	return rr
+}
+
+func TestRelayPublishesInOrderToFile(t *testing.T) {
+	db := testutil.NewDB()
+	var ee []*outbox.Event
+	for i, txHash := range []string{"0x01", "0x02", "0x03"} {
+		ee = append(ee, createEvent(t, db, "01F0000000000000000000000"+string(rune('A'+i)), txHash))
+	}
+
+	r, path := newFileRelay(t, db, &Config{})
+	// a batch holds two events, the third one is published by the next cycle
+	r.relay(context.Background())
+	r.relay(context.Background())
+	r.relay(context.Background())
+
+	rr := readRecords(t, path)
+	if len(rr) != len(ee) {
+		t.Fatalf("expected %d published messages, got %d", len(ee), len(rr))
+	}
+	for i, e := range ee {
+		if rr[i].ID != e.ID || rr[i].Topic != "bridge.erc721_swap" || rr[i].Key != "97:"+e.RequestTxHash {
+			t.Errorf("unexpected message %d: %+v", i, rr[i])
+		}
+
+		var m outbox.Message
+		if err := json.Unmarshal(rr[i].Value, &m); err != nil || m.ID != e.ID || m.Type != e.Type {
+			t.Errorf("unexpected message value %s", string(rr[i].Value))
+		}
+	}
+
+	var unpublished int64
+	if err := db.Model(&outbox.Event{}).Where("published = ?", false).Count(&unpublished).Error; err != nil {
+		t.Fatalf("failed to count events: %v", err)
+	}
+	if unpublished != 0 {
+		t.Errorf("expected every event marked as published, %d are not", unpublished)
+	}
+}
+
+func TestRelayPrunesPublishedEvents(t *testing.T) {
+	db := testutil.NewDB()
+	old := createEvent(t, db, "01F0000000000000000000000A", "0x01")
+	oldDead := createEvent(t, db, "01F0000000000000000000000B", "0x02")
+	oldUnpublished := createEvent(t, db, "01F0000000000000000000000C", "0x03")
+	recent := createEvent(t, db, "01F0000000000000000000000D", "0x04")
+
+	err := db.Model(&outbox.Event{}).Where("id in ?", []string{old.ID, oldDead.ID, recent.ID}).Updates(map[string]interface{}{
+		"published":  true,
+		"fanned_out": true,
+	}).Error
+	if err != nil {
+		t.Fatalf("failed to publish events: %v", err)
+	}
+	err = db.Model(&outbox.Event{}).Where("id <> ?", recent.ID).Update("created_at", time.Now().Add(-48*time.Hour)).Error
+	if err != nil {
+		t.Fatalf("failed to age events: %v", err)
+	}
+	err = db.Create(&webhook.Delivery{
+		SubscriptionID: "01F0000000000000000000000S",
+		EventID:        oldDead.ID,
+		State:          webhook.DeliveryStateDead,
+		NextAttemptAt:  time.Now(),
+	}).Error
+	if err != nil {
+		t.Fatalf("failed to create delivery: %v", err)
+	}
+
+	r, _ := newFileRelay(t, db, &Config{Retention: 24 * time.Hour, Webhooks: true})
+	r.prune(context.Background())
+
+	var ids []string
+	if err := db.Model(&outbox.Event{}).Order("id asc").Pluck("id", &ids).Error; err != nil {
+		t.Fatalf("failed to query events: %v", err)
+	}
+
+	want := []string{oldDead.ID, oldUnpublished.ID, recent.ID}
+	if len(ids) != len(want) {
+		t.Fatalf("unexpected events left %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("unexpected events left %v, want %v", ids, want)
+		}
+	}
+}
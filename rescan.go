@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/recorder"
+)
+
+// runRescan records the swaps and swap pairs of a block range of a chain again and prints every one found, new or
+// already recorded. The range must be below the observer head, which is left untouched. SIGINT or SIGTERM stops it
+// after rolling back the block being rescanned.
+func runRescan(args []string) error {
+	flags := pflag.NewFlagSet("rescan", pflag.ContinueOnError)
+	chainID := flags.String("chain", "", "chain id")
+	from := flags.Int64("from", 0, "first block height")
+	to := flags.Int64("to", 0, "last block height")
+	dryRun := flags.Bool("dry-run", false, "report the swaps and swap pairs without recording them")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return errUsage
+	}
+
+	config, db, err := setup(false)
+	if err != nil {
+		return err
+	}
+
+	cc, err := newChains(config, db)
+	if err != nil {
+		return err
+	}
+	defer cc.close()
+
+	r, ok := cc.recorders[*chainID]
+	if !ok {
+		return errors.Errorf("chain %s is not configured", *chainID)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := cc.verifyChainIDs(ctx, config); err != nil {
+		return err
+	}
+
+	return rescan(ctx, db, r, *from, *to, *dryRun)
+}
+
+func rescan(ctx context.Context, db *gorm.DB, r recorder.IRecorder, from, to int64, dryRun bool) error {
+	if from <= 0 || to < from {
+		return errors.Errorf("invalid block range %d-%d", from, to)
+	}
+
+	head := block.Log{}
+	err := db.Where(
+		"chain_id = ?",
+		r.ChainID(),
+	).Order(
+		"height desc",
+	).First(
+		&head,
+	).Error
+	if err == gorm.ErrRecordNotFound {
+		return errors.Errorf("chain %s has no observed block", r.ChainID())
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get the observer head")
+	}
+	if to > head.Height {
+		return errors.Errorf("height %d is above the observer head %d", to, head.Height)
+	}
+
+	newCount, presentCount := 0, 0
+	for height := from; height <= to; height++ {
+		dd, err := rescanBlock(ctx, db, r, height, dryRun)
+		if err != nil {
+			return errors.Wrapf(err, "failed to rescan height %d", height)
+		}
+
+		for _, d := range dd {
+			status := "present"
+			if d.New {
+				status = "new"
+				newCount++
+			} else {
+				presentCount++
+			}
+			fmt.Printf("%d %-7s %-17s %s %s\n", height, status, d.EntityType, d.TxHash, d.EntityID)
+		}
+	}
+
+	if dryRun {
+		fmt.Printf("dry run: %d new, %d already recorded\n", newCount, presentCount)
+	} else {
+		fmt.Printf("%d new, %d already recorded\n", newCount, presentCount)
+	}
+
+	return nil
+}
+
+var errDryRun = errors.New("dry run")
+
+// rescanBlock rescans a block in a transaction, which is rolled back on a dry run. A block whose log was pruned is
+// rescanned without one.
+func rescanBlock(
+	ctx context.Context,
+	db *gorm.DB,
+	r recorder.IRecorder,
+	height int64,
+	dryRun bool,
+) ([]*recorder.Discovery, error) {
+	b := block.Log{}
+	err := db.WithContext(ctx).Where(
+		"chain_id = ? and height = ?",
+		r.ChainID(),
+		height,
+	).First(
+		&b,
+	).Error
+	if err == gorm.ErrRecordNotFound {
+		b = block.Log{
+			ChainID: r.ChainID(),
+			Height:  height,
+		}
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get block log")
+	}
+
+	var dd []*recorder.Discovery
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		dd, err = r.Rescan(ctx, tx, &b)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && err != errDryRun {
+		return nil, err
+	}
+
+	return dd, nil
+}
@@ -0,0 +1,528 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/admin"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/alert"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/api"
+	corecommon "github.com/synycboom/bsc-evm-compatible-bridge-core/common"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/health"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/lease"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/notify"
+	observer "github.com/synycboom/bsc-evm-compatible-bridge-core/observer"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/ownership"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/relay"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/sla"
+	sengine "github.com/synycboom/bsc-evm-compatible-bridge-core/swap-engine"
+	spengine "github.com/synycboom/bsc-evm-compatible-bridge-core/swap-pair-engine"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/webhook"
+)
+
+const (
+	componentObserver       = "observer"
+	componentSwapPairEngine = "swap-pair-engine"
+	componentSwapEngine     = "swap-engine"
+	componentSLA            = "sla"
+	componentHealth         = "health"
+	componentAPI            = "api"
+	componentWebhook        = "webhook"
+	componentRelay          = "relay"
+	componentAdmin          = "admin"
+)
+
+var components = []string{
+	componentObserver,
+	componentSwapPairEngine,
+	componentSwapEngine,
+	componentSLA,
+	componentHealth,
+	componentAPI,
+	componentWebhook,
+	componentRelay,
+	componentAdmin,
+}
+
+// runServe runs the service. Every component runs by default, --only selects some of them for split deployments;
+// the servers, the webhook dispatcher and the relay still have to be enabled in the config.
+func runServe(args []string) error {
+	flags := pflag.NewFlagSet("serve", pflag.ContinueOnError)
+	only := flags.StringSlice("only", nil, "components to run: "+strings.Join(components, ", "))
+	shutdownTimeout := flags.Duration("shutdown-timeout", 30*time.Second, "time given to the in-flight work to finish on SIGINT or SIGTERM")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return errUsage
+	}
+
+	selected, err := selectComponents(*only)
+	if err != nil {
+		return err
+	}
+
+	config, db, err := setup(false)
+	if err != nil {
+		return err
+	}
+
+	if len(*only) > 0 {
+		if err := checkSelected(config, selected); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return serve(ctx, stop, *shutdownTimeout, config, db, selected)
+}
+
+// selectComponents returns the set of the components to run, all of them when none is given
+func selectComponents(only []string) (map[string]bool, error) {
+	selected := make(map[string]bool)
+	if len(only) == 0 {
+		for _, c := range components {
+			selected[c] = true
+		}
+
+		return selected, nil
+	}
+
+	for _, c := range only {
+		known := false
+		for _, k := range components {
+			known = known || k == c
+		}
+		if !known {
+			return nil, errors.Errorf("unknown component %s, expected one of %s", c, strings.Join(components, ", "))
+		}
+
+		selected[c] = true
+	}
+
+	return selected, nil
+}
+
+// checkSelected fails when an explicitly selected component is disabled in the config
+func checkSelected(config *util.Config, selected map[string]bool) error {
+	disabled := map[string]bool{
+		componentHealth:  config.HealthConfig.ListenAddr == "",
+		componentAPI:     config.APIConfig.ListenAddr == "",
+		componentWebhook: !config.WebhookConfig.Enabled,
+		componentRelay:   !config.RelayConfig.Enabled,
+		componentAdmin:   !config.AdminConfig.Enabled(),
+	}
+	for c := range selected {
+		if disabled[c] {
+			return errors.Errorf("component %s is selected but disabled in the config", c)
+		}
+	}
+
+	return nil
+}
+
+// waiter is a started component, Wait returns once it has stopped
+type waiter interface {
+	Wait()
+}
+
+// serve starts the selected components and runs until ctx is done. It then stops picking new work, waits up to
+// shutdownTimeout for the in-flight work, such as sent transactions being saved, and closes the connections. A
+// second signal after stop is called terminates the process right away.
+func serve(
+	ctx context.Context,
+	stop context.CancelFunc,
+	shutdownTimeout time.Duration,
+	config *util.Config,
+	db *gorm.DB,
+	selected map[string]bool,
+) error {
+	var started []waiter
+
+	alerter := alert.NewManagerFromConfig(config.AlertConfig)
+
+	var leases *lease.Manager
+	if config.LeaseConfig.Enabled {
+		holder := config.LeaseConfig.Holder
+		if holder == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				return errors.Wrap(err, "[serve]: failed to get host name")
+			}
+			holder = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+		}
+
+		leases = lease.NewManager(&lease.Config{
+			Holder:        holder,
+			Duration:      time.Duration(config.LeaseConfig.Duration) * time.Second,
+			RenewInterval: time.Duration(config.LeaseConfig.RenewInterval) * time.Second,
+		}, &lease.Dependencies{
+			DB: db.Session(&gorm.Session{}),
+		})
+	}
+
+	cc, err := newChains(config, db)
+	if err != nil {
+		return errors.Wrap(err, "[serve]: failed to set up chains")
+	}
+	defer cc.close()
+
+	// engines running without an observer follow the block logs written by the observer of another process
+	follow := !selected[componentObserver] && (selected[componentSwapEngine] || selected[componentSwapPairEngine])
+
+	// only the observer and the engines talk to the chains, the other components just read the database
+	if selected[componentObserver] || follow {
+		if err := cc.verifyChainIDs(ctx, config); err != nil {
+			return errors.Wrap(err, "[serve]: failed to verify chains")
+		}
+		if err := cc.verifyAgents(ctx, config); err != nil {
+			return errors.Wrap(err, "[serve]: failed to verify swap agents")
+		}
+	}
+
+	// the observers and engines of this process notify each other, engines of other processes rely on their sweep
+	var bus *notify.Bus
+	if config.EngineConfig.Notify {
+		bus = notify.NewBus()
+	}
+
+	if selected[componentObserver] || follow {
+		for _, c := range config.ChainConfigs {
+			cc.heads[c.ID].Start(ctx)
+			started = append(started, cc.heads[c.ID])
+		}
+	}
+
+	healthChains := make(map[string]*health.ChainDependencies)
+	for _, c := range config.ChainConfigs {
+		chainID := cc.chainIDs[c.ID]
+		healthChains[c.ID] = &health.ChainDependencies{
+			Client:  cc.clients[c.ID],
+			Engines: make(map[string]health.CycleReporter),
+		}
+
+		obDeps := &observer.Dependencies{
+			DB:       db.Session(&gorm.Session{}),
+			Recorder: cc.recorders[c.ID],
+			Alerter:  alerter,
+			Bus:      bus,
+			Head:     cc.heads[c.ID],
+		}
+		if selected[componentObserver] {
+			obDeps.Leader = leader(leases, "observer/"+c.ID)
+		}
+
+		// TODO: implement SwapAgent instance and implement mutex lock to prevent multiple calls
+		// TODO: send tg when logging has error
+		ob := observer.NewObserver(&observer.Config{
+			StartHeight:        c.StartHeight,
+			ConfirmNum:         c.ConfirmNum,
+			FetchInterval:      time.Duration(c.ObserverFetchInterval) * time.Second,
+			BlockUpdateTimeout: time.Duration(config.AlertConfig.BlockUpdateTimeout) * time.Second,
+		}, obDeps)
+		if selected[componentObserver] {
+			ob.Start(ctx)
+			started = append(started, ob)
+		} else if follow {
+			ob.Follow(ctx)
+			started = append(started, ob)
+		}
+		healthChains[c.ID].Observer = ob
+
+		if selected[componentSwapPairEngine] {
+			e := spengine.NewEngine(&spengine.Config{
+				ChainID:                   chainID,
+				ConfirmNum:                c.ConfirmNum,
+				ExplorerURL:               c.ExplorerUrl,
+				PrivateKey:                c.PrivateKey,
+				MaxTrackRetry:             c.MaxTrackRetry,
+				ERC721SwapAgentAddresses:  cc.erc721SwapAgentAddresses,
+				ERC1155SwapAgentAddresses: cc.erc1155SwapAgentAddresses,
+				ERC20SwapAgentAddresses:   cc.erc20SwapAgentAddresses,
+				SweepInterval:             time.Duration(config.EngineConfig.SweepInterval) * time.Second,
+			}, &spengine.Dependencies{
+				Client:           cc.clients,
+				DB:               db.Session(&gorm.Session{}),
+				Recorder:         cc.recorders,
+				ERC721SwapAgent:  cc.erc721SwapAgents,
+				ERC1155SwapAgent: cc.erc1155SwapAgents,
+				ERC20SwapAgent:   cc.erc20SwapAgents,
+				Heads:            cc.heads,
+				Leader:           leader(leases, "swap-pair-engine/"+c.ID),
+				Bus:              bus,
+			})
+			e.Start(ctx)
+			started = append(started, e)
+			healthChains[c.ID].Engines["swap-pair-engine"] = e
+		}
+
+		if selected[componentSwapEngine] {
+			se := sengine.NewEngine(&sengine.Config{
+				ChainID:                   chainID,
+				ConfirmNum:                c.ConfirmNum,
+				ExplorerURL:               c.ExplorerUrl,
+				PrivateKey:                c.PrivateKey,
+				MaxTrackRetry:             c.MaxTrackRetry,
+				ERC721SwapAgentAddresses:  cc.erc721SwapAgentAddresses,
+				ERC1155SwapAgentAddresses: cc.erc1155SwapAgentAddresses,
+				ERC20SwapAgentAddresses:   cc.erc20SwapAgentAddresses,
+				ERC721BatchFillSize:       c.ERC721BatchFillSize,
+				FillBatches:               cc.fillBatches,
+				SweepInterval:             time.Duration(config.EngineConfig.SweepInterval) * time.Second,
+			}, &sengine.Dependencies{
+				Client:               cc.clients,
+				DB:                   db.Session(&gorm.Session{}),
+				Recorder:             cc.recorders,
+				ERC721SwapAgent:      cc.erc721SwapAgents,
+				ERC721BatchSwapAgent: cc.erc721BatchSwapAgents,
+				ERC721Token:          cc.erc721Tokens,
+				ERC1155SwapAgent:     cc.erc1155SwapAgents,
+				ERC1155Token:         cc.erc1155Tokens,
+				ERC20SwapAgent:       cc.erc20SwapAgents,
+				FillBatcher:          cc.fillBatchers,
+				Heads:                cc.heads,
+				Leader:               leader(leases, "swap-engine/"+c.ID),
+				Bus:                  bus,
+			})
+			se.Start(ctx)
+			started = append(started, se)
+			healthChains[c.ID].Engines["swap-engine"] = se
+		}
+	}
+
+	if selected[componentObserver] {
+		for i, c := range config.ChainConfigs {
+			relayerAddrs, err := relayers(config, c.ID)
+			if err != nil {
+				return errors.Wrap(err, "[serve]: failed to get relayers")
+			}
+
+			agents := map[common.Address]string{
+				cc.erc721SwapAgentAddresses[c.ID]:  "ERC721 swap agent",
+				cc.erc1155SwapAgentAddresses[c.ID]: "ERC1155 swap agent",
+			}
+			if c.ERC20SwapAgentAddr != "" {
+				agents[common.HexToAddress(c.ERC20SwapAgentAddr)] = "ERC20 swap agent"
+			}
+			// the batch swap agent is usually the ERC721 one upgraded, which is watched already
+			if addr := common.HexToAddress(c.ERC721BatchSwapAgentAddr); c.ERC721BatchSwapAgentAddr != "" && agents[addr] == "" {
+				agents[addr] = "ERC721 batch swap agent"
+			}
+
+			w := ownership.NewWatcher(&ownership.Config{
+				ChainID:      c.ID,
+				PollInterval: time.Duration(c.ObserverFetchInterval) * time.Second,
+				Agents:       agents,
+				Relayers:     relayerAddrs,
+			}, &ownership.Dependencies{
+				Client:  cc.ethClients[i],
+				Alerter: alerter,
+				Leader:  leader(leases, "ownership/"+c.ID),
+			})
+			w.Start(ctx)
+			started = append(started, w)
+		}
+	}
+
+	if leases != nil {
+		leases.Start()
+	}
+
+	explorerURLs := make(map[string]string)
+	for _, c := range config.ChainConfigs {
+		explorerURLs[c.ID] = c.ExplorerUrl
+	}
+
+	if selected[componentSLA] {
+		watcher := sla.NewWatcher(&sla.Config{
+			CheckInterval:      time.Duration(config.SLAConfig.CheckIntervalOrDefault()) * time.Second,
+			SwapThresholds:     secondsMap(config.SLAConfig.SwapThresholds),
+			SwapPairThresholds: secondsMap(config.SLAConfig.SwapPairThresholds),
+			ExplorerURLs:       explorerURLs,
+			MaxListedIDs:       config.SLAConfig.MaxListedIDs,
+		}, &sla.Dependencies{
+			DB:      db.Session(&gorm.Session{}),
+			Alerter: alerter,
+			Leader:  leader(leases, "sla"),
+		})
+		watcher.Start(ctx)
+		started = append(started, watcher)
+	}
+
+	if selected[componentHealth] && config.HealthConfig.ListenAddr != "" {
+		var healthChainConfigs []health.ChainConfig
+		for _, c := range config.ChainConfigs {
+			healthChainConfigs = append(healthChainConfigs, health.ChainConfig{
+				ChainID:            c.ID,
+				ChainName:          c.Name,
+				BlockUpdateTimeout: time.Duration(config.AlertConfig.BlockUpdateTimeout) * time.Second,
+				Critical:           config.HealthConfig.IsCritical(c.ID),
+			})
+		}
+
+		checker := health.NewChecker(&health.Config{
+			ListenAddr:         config.HealthConfig.ListenAddr,
+			MaxObserverLag:     config.HealthConfig.MaxObserverLag,
+			EngineCycleTimeout: time.Duration(config.HealthConfig.EngineCycleTimeout) * time.Second,
+			CheckTimeout:       time.Duration(config.HealthConfig.CheckTimeout) * time.Second,
+			Chains:             healthChainConfigs,
+		}, &health.Dependencies{
+			DB:     db.Session(&gorm.Session{}),
+			Chains: healthChains,
+		})
+		checker.Start(ctx)
+		started = append(started, checker)
+	}
+
+	if selected[componentAPI] && config.APIConfig.ListenAddr != "" {
+		apiChains := make(map[string]api.ChainConfig)
+		for _, c := range config.ChainConfigs {
+			apiChains[c.ID] = api.ChainConfig{
+				ExplorerURL: c.ExplorerUrl,
+				ConfirmNum:  c.ConfirmNum,
+			}
+		}
+
+		server := api.NewServer(&api.Config{
+			ListenAddr:      config.APIConfig.ListenAddr,
+			DefaultPageSize: config.APIConfig.DefaultPageSize,
+			MaxPageSize:     config.APIConfig.MaxPageSize,
+			Chains:          apiChains,
+		}, &api.Dependencies{
+			DB: db.Session(&gorm.Session{}),
+		})
+		server.Start(ctx)
+		started = append(started, server)
+	}
+
+	if selected[componentWebhook] && config.WebhookConfig.Enabled {
+		dispatcher := webhook.NewDispatcher(&webhook.Config{
+			PollInterval: time.Duration(config.WebhookConfig.PollInterval) * time.Second,
+			BatchSize:    config.WebhookConfig.BatchSize,
+			Timeout:      time.Duration(config.WebhookConfig.Timeout) * time.Second,
+			MaxAttempts:  config.WebhookConfig.MaxAttempts,
+			BackoffBase:  time.Duration(config.WebhookConfig.BackoffBase) * time.Second,
+			BackoffMax:   time.Duration(config.WebhookConfig.BackoffMax) * time.Second,
+		}, &webhook.Dependencies{
+			DB:     db.Session(&gorm.Session{}),
+			Leader: leader(leases, "webhook"),
+		})
+		dispatcher.Start(ctx)
+		started = append(started, dispatcher)
+	}
+
+	if selected[componentRelay] && config.RelayConfig.Enabled {
+		var publisher relay.Publisher
+		switch config.RelayConfig.Broker {
+		case corecommon.BrokerNATS:
+			publisher, err = relay.NewNATSPublisher(config.RelayConfig.URLs)
+		case corecommon.BrokerKafka:
+			publisher = relay.NewKafkaPublisher(config.RelayConfig.URLs)
+		case corecommon.BrokerFile:
+			publisher, err = relay.NewFilePublisher(config.RelayConfig.FilePath)
+		}
+		if err != nil {
+			return errors.Wrap(err, "[serve]: failed to create relay publisher")
+		}
+		defer publisher.Close()
+
+		r := relay.NewRelay(&relay.Config{
+			PollInterval: time.Duration(config.RelayConfig.PollInterval) * time.Second,
+			BatchSize:    config.RelayConfig.BatchSize,
+			Timeout:      time.Duration(config.RelayConfig.Timeout) * time.Second,
+			Topic:        config.RelayConfig.Topic,
+			Key:          config.RelayConfig.Key,
+			Retention:    time.Duration(config.RelayConfig.Retention) * time.Second,
+			Webhooks:     config.WebhookConfig.Enabled,
+		}, &relay.Dependencies{
+			DB:        db.Session(&gorm.Session{}),
+			Publisher: publisher,
+			Leader:    leader(leases, "relay"),
+		})
+		r.Start(ctx)
+		started = append(started, r)
+	}
+
+	if selected[componentAdmin] && config.AdminConfig.ListenAddr != "" && !config.AdminConfig.Enabled() {
+		util.Logger.Warningf("[serve]: the admin api on %s is disabled since admin_config.api_key is empty, set it to serve the admin api",
+			config.AdminConfig.ListenAddr)
+	}
+	if selected[componentAdmin] && config.AdminConfig.Enabled() {
+		adminServer := admin.NewServer(&admin.Config{
+			ListenAddr: config.AdminConfig.ListenAddr,
+			APIKey:     config.AdminConfig.APIKey,
+		}, &admin.Dependencies{
+			DB: db.Session(&gorm.Session{}),
+		})
+		adminServer.Start(ctx)
+		started = append(started, adminServer)
+	}
+
+	<-ctx.Done()
+	// restore the default signal handling, a second signal terminates the process
+	stop()
+	util.Logger.Infof("[serve]: shutting down, waiting up to %s for the in-flight work", shutdownTimeout)
+
+	done := make(chan struct{})
+	go func() {
+		for _, w := range started {
+			w.Wait()
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(shutdownTimeout):
+		// the leases are left to expire, the in-flight work of this process may still be running
+		if leases != nil {
+			leases.Stop()
+		}
+		return errors.Errorf("[serve]: shutdown timed out after %s", shutdownTimeout)
+	}
+
+	if leases != nil {
+		if err := leases.Release(); err != nil {
+			util.Logger.Error(errors.Wrap(err, "[serve]: failed to release leases"))
+		}
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return errors.Wrap(err, "[serve]: failed to get sql db")
+	}
+	if err := sqlDB.Close(); err != nil {
+		return errors.Wrap(err, "[serve]: failed to close db")
+	}
+	util.Logger.Infof("[serve]: shut down")
+
+	return nil
+}
+
+// leader returns the lease of a role, or nil when leader election is disabled
+func leader(m *lease.Manager, name string) lease.Leader {
+	if m == nil {
+		return nil
+	}
+
+	return m.Lease(name)
+}
+
+func secondsMap(m map[string]int64) map[string]time.Duration {
+	durations := make(map[string]time.Duration, len(m))
+	for k, v := range m {
+		durations[k] = time.Duration(v) * time.Second
+	}
+
+	return durations
+}
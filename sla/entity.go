@@ -0,0 +1,168 @@
+package sla
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/alert"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+)
+
+// entity describes a table tracked by the watcher
+type entity struct {
+	name          string
+	table         string
+	entityType    transition.EntityType
+	requestTxHash string
+	fillTxHash    string
+	// fillStates are states whose relevant tx is the fill or creation tx on the destination chain
+	fillStates    []string
+	failureStates map[string]alert.Severity
+}
+
+var (
+	swapFillStates = []string{
+		string(erc721.SwapStateFillTxCreated),
+		string(erc721.SwapStateFillTxSent),
+		string(erc721.SwapStateFillTxConfirmed),
+		string(erc721.SwapStateFillTxFailed),
+		string(erc721.SwapStateFillTxMissing),
+	}
+	swapFailureStates = map[string]alert.Severity{
+		string(erc721.SwapStateRequestRejected):        alert.SeverityWarning,
+		string(erc721.SwapStateFillTxDryRunFailed):     alert.SeverityCritical,
+		string(erc721.SwapStateFillTxFailed):           alert.SeverityCritical,
+		string(erc721.SwapStateFillTxMissing):          alert.SeverityCritical,
+		string(erc721.SwapStateUnsupportedDestination): alert.SeverityWarning,
+	}
+	swapPairFillStates = []string{
+		string(erc721.SwapPairStateCreationTxCreated),
+		string(erc721.SwapPairStateCreationTxSent),
+		string(erc721.SwapPairStateCreationTxConfirmed),
+		string(erc721.SwapPairStateCreationTxFailed),
+		string(erc721.SwapPairStateCreationTxMissing),
+	}
+	swapPairFailureStates = map[string]alert.Severity{
+		string(erc721.SwapPairStateCreationTxDryRunFailed): alert.SeverityCritical,
+		string(erc721.SwapPairStateCreationTxFailed):       alert.SeverityCritical,
+		string(erc721.SwapPairStateCreationTxMissing):      alert.SeverityCritical,
+		string(erc721.SwapPairStateUnsupportedDestination): alert.SeverityWarning,
+	}
+
+	swapEntities = []entity{
+		{
+			name:          "ERC721 swap",
+			table:         erc721.Swap{}.TableName(),
+			entityType:    transition.EntityTypeERC721Swap,
+			requestTxHash: "request_tx_hash",
+			fillTxHash:    "fill_tx_hash",
+			fillStates:    swapFillStates,
+			failureStates: swapFailureStates,
+		},
+		{
+			name:          "ERC721 batch swap",
+			table:         erc721.BatchSwap{}.TableName(),
+			entityType:    transition.EntityTypeERC721BatchSwap,
+			requestTxHash: "request_tx_hash",
+			fillTxHash:    "fill_tx_hash",
+			fillStates:    swapFillStates,
+			failureStates: swapFailureStates,
+		},
+		{
+			name:          "ERC1155 swap",
+			table:         erc1155.Swap{}.TableName(),
+			entityType:    transition.EntityTypeERC1155Swap,
+			requestTxHash: "request_tx_hash",
+			fillTxHash:    "fill_tx_hash",
+			fillStates:    swapFillStates,
+			failureStates: swapFailureStates,
+		},
+		{
+			name:          "ERC20 swap",
+			table:         erc20.Swap{}.TableName(),
+			entityType:    transition.EntityTypeERC20Swap,
+			requestTxHash: "request_tx_hash",
+			fillTxHash:    "fill_tx_hash",
+			fillStates:    swapFillStates,
+			failureStates: swapFailureStates,
+		},
+	}
+	swapPairEntities = []entity{
+		{
+			name:          "ERC721 swap pair",
+			table:         erc721.SwapPair{}.TableName(),
+			entityType:    transition.EntityTypeERC721SwapPair,
+			requestTxHash: "register_tx_hash",
+			fillTxHash:    "create_tx_hash",
+			fillStates:    swapPairFillStates,
+			failureStates: swapPairFailureStates,
+		},
+		{
+			name:          "ERC1155 swap pair",
+			table:         erc1155.SwapPair{}.TableName(),
+			entityType:    transition.EntityTypeERC1155SwapPair,
+			requestTxHash: "register_tx_hash",
+			fillTxHash:    "create_tx_hash",
+			fillStates:    swapPairFillStates,
+			failureStates: swapPairFailureStates,
+		},
+		{
+			name:          "ERC20 swap pair",
+			table:         erc20.SwapPair{}.TableName(),
+			entityType:    transition.EntityTypeERC20SwapPair,
+			requestTxHash: "register_tx_hash",
+			fillTxHash:    "create_tx_hash",
+			fillStates:    swapPairFillStates,
+			failureStates: swapPairFailureStates,
+		},
+	}
+)
+
+// row is the subset of swap and swap pair columns the watcher reads
+type row struct {
+	ID            string
+	SrcChainID    string
+	DstChainID    string
+	State         string
+	RequestTxHash string
+	FillTxHash    string
+	MessageLog    string
+	UpdatedAt     time.Time
+}
+
+func (e *entity) selectColumns() string {
+	return fmt.Sprintf(
+		"id, src_chain_id, dst_chain_id, state, %s as request_tx_hash, %s as fill_tx_hash, message_log, updated_at",
+		e.requestTxHash,
+		e.fillTxHash,
+	)
+}
+
+func (e *entity) isFillState(state string) bool {
+	for _, s := range e.fillStates {
+		if s == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// link returns the explorer link of the transaction relevant to the current state of the row
+func (w *Watcher) link(e *entity, r *row) string {
+	chainID, txHash := r.SrcChainID, r.RequestTxHash
+	if e.isFillState(r.State) && r.FillTxHash != "" {
+		chainID, txHash = r.DstChainID, r.FillTxHash
+	}
+
+	explorerURL, ok := w.conf.ExplorerURLs[chainID]
+	if !ok || explorerURL == "" {
+		return txHash
+	}
+
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(explorerURL, "/"), txHash)
+}
@@ -0,0 +1,161 @@
+package sla
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/alert"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+const (
+	// failureCursorName names the cursor of the failure check in the state_transition_cursors table
+	failureCursorName = "sla-failures"
+	// failureSettleDelay leaves the transitions younger than it to the next check, so that a transition committed
+	// after a younger one is not passed over by the cursor
+	failureSettleDelay = 5 * time.Second
+	failureBatchSize   = 500
+)
+
+// checkFailures alerts on every swap and swap pair transition into a terminal failure state. The position in the
+// state_transitions table is kept in the database, so that a restart or a lease failover neither drops nor repeats
+// a failure.
+func (w *Watcher) checkFailures(ctx context.Context) {
+	db := w.deps.DB.WithContext(ctx)
+	cursor, found, err := transition.LoadCursor(db, failureCursorName)
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Watcher.checkFailures]: failed to load cursor"))
+		return
+	}
+	if !found {
+		// start from the latest transition, failures recorded before the first check are not reported
+		cursor, err = w.latestTransitionID(ctx)
+		if err != nil {
+			util.Logger.Error(errors.Wrap(err, "[Watcher.checkFailures]: failed to query latest transition"))
+			return
+		}
+		if err := transition.SaveCursor(db, failureCursorName, cursor); err != nil {
+			util.Logger.Error(errors.Wrap(err, "[Watcher.checkFailures]: failed to save cursor"))
+		}
+
+		return
+	}
+
+	settled := time.Now().Add(-failureSettleDelay)
+	for {
+		var tt []*transition.Transition
+		err := db.Where(
+			"id > ? and created_at < ?",
+			cursor,
+			settled,
+		).Order(
+			"id asc",
+		).Limit(
+			failureBatchSize,
+		).Find(&tt).Error
+		if err != nil {
+			util.Logger.Error(errors.Wrap(err, "[Watcher.checkFailures]: failed to query transitions"))
+			return
+		}
+
+		for _, t := range tt {
+			// keep the cursor on the transition if its entity could not be read, so that it is reported in the
+			// next check
+			if !w.checkFailureTransition(ctx, t) {
+				break
+			}
+			cursor = t.ID
+		}
+
+		if len(tt) > 0 {
+			if err := transition.SaveCursor(db, failureCursorName, cursor); err != nil {
+				util.Logger.Error(errors.Wrap(err, "[Watcher.checkFailures]: failed to save cursor"))
+				return
+			}
+		}
+		if len(tt) < failureBatchSize || cursor != tt[len(tt)-1].ID {
+			return
+		}
+	}
+}
+
+// checkFailureTransition alerts if the transition enters a failure state, it returns false if the alert could not be
+// built
+func (w *Watcher) checkFailureTransition(ctx context.Context, t *transition.Transition) bool {
+	e := entityOf(t.EntityType)
+	if e == nil {
+		return true
+	}
+	severity, ok := e.failureStates[t.ToState]
+	if !ok {
+		return true
+	}
+
+	var r row
+	err := w.deps.DB.WithContext(ctx).Table(
+		e.table,
+	).Select(
+		e.selectColumns(),
+	).Where(
+		"id = ?",
+		t.EntityID,
+	).Take(&r).Error
+	if err != nil {
+		util.Logger.Error(errors.Wrapf(err, "[Watcher.checkFailureTransition]: failed to query %s %s", e.name, t.EntityID))
+		return false
+	}
+	// the entity may have moved on, the alert is about the state of the transition
+	r.State = t.ToState
+	message := t.Message
+	if message == "" {
+		message = r.MessageLog
+	}
+
+	w.deps.Alerter.Notify(&alert.Alert{
+		Key:      fmt.Sprintf("sla-failure-%s-%s-%s", e.table, r.ID, r.State),
+		Severity: severity,
+		ChainID:  r.SrcChainID,
+		Title:    fmt.Sprintf("%s entered state '%s'", e.name, r.State),
+		Message:  fmt.Sprintf("%s %s (%s): %s", e.name, r.ID, w.link(e, &r), message),
+	})
+
+	return true
+}
+
+func (w *Watcher) latestTransitionID(ctx context.Context) (string, error) {
+	var ids []string
+	err := w.deps.DB.WithContext(ctx).Model(
+		&transition.Transition{},
+	).Order(
+		"id desc",
+	).Limit(
+		1,
+	).Pluck("id", &ids).Error
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", nil
+	}
+
+	return ids[0], nil
+}
+
+func entityOf(entityType transition.EntityType) *entity {
+	for idx := range swapEntities {
+		if swapEntities[idx].entityType == entityType {
+			return &swapEntities[idx]
+		}
+	}
+	for idx := range swapPairEntities {
+		if swapPairEntities[idx].entityType == entityType {
+			return &swapPairEntities[idx]
+		}
+	}
+
+	return nil
+}
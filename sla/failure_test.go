@@ -0,0 +1,144 @@
+package sla
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/testutil"
+)
+
+func newTestWatcher(db *gorm.DB, alerter *testutil.Alerter) *Watcher {
+	return NewWatcher(&Config{
+		CheckInterval: time.Minute,
+	}, &Dependencies{
+		DB:      db,
+		Alerter: alerter,
+	})
+}
+
+// recordTransition records a transition of the swap into the state, created age ago
+func recordTransition(t *testing.T, db *gorm.DB, s *erc721.Swap, state erc721.SwapState, age time.Duration) {
+	t.Helper()
+
+	tr := transition.Transition{
+		EntityType: transition.EntityTypeERC721Swap,
+		EntityID:   s.ID,
+		FromState:  string(s.State),
+		ToState:    string(state),
+		Actor:      "test",
+		Message:    "reverted",
+	}
+	if err := db.Create(&tr).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(&tr).UpdateColumn("created_at", time.Now().Add(-age)).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFailuresAreReportedOnceAcrossRestarts(t *testing.T) {
+	db := testutil.NewDB()
+	alerter := testutil.NewAlerter()
+	ctx := context.Background()
+
+	s := erc721.Swap{
+		SrcChainID:    "97",
+		DstChainID:    "4",
+		State:         erc721.SwapStateFillTxSent,
+		SwapDirection: erc721.SwapDirectionForward,
+		RequestTxHash: "0x01",
+		FillTxHash:    "0x02",
+	}
+	if err := db.Create(&s).Error; err != nil {
+		t.Fatal(err)
+	}
+	// recorded before the first check, it is not reported
+	recordTransition(t, db, &s, erc721.SwapStateRequestRejected, time.Minute)
+
+	first := newTestWatcher(db, alerter)
+	first.checkFailures(ctx)
+
+	recordTransition(t, db, &s, erc721.SwapStateFillTxConfirmed, time.Minute)
+	recordTransition(t, db, &s, erc721.SwapStateFillTxFailed, time.Minute)
+	// younger than the settle delay, it is left to a later check
+	recordTransition(t, db, &s, erc721.SwapStateFillTxMissing, 0)
+
+	first.checkFailures(ctx)
+	alerts := alerter.Alerts()
+	if len(alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d: %+v", len(alerts), alerts)
+	}
+	if alerts[0].Title != "ERC721 swap entered state 'fill_tx_failed'" || alerts[0].ChainID != "97" {
+		t.Errorf("unexpected alert %+v", alerts[0])
+	}
+	if alerts[0].Message != "ERC721 swap "+s.ID+" (0x02): reverted" {
+		t.Errorf("unexpected message %q", alerts[0].Message)
+	}
+
+	// a restarted watcher continues from the persisted cursor
+	restarted := newTestWatcher(db, alerter)
+	restarted.checkFailures(ctx)
+	if n := len(alerter.Alerts()); n != 1 {
+		t.Fatalf("expected no alert to be repeated after a restart, got %d alerts", n)
+	}
+
+	err := db.Model(&transition.Transition{}).Where(
+		"to_state = ?",
+		erc721.SwapStateFillTxMissing,
+	).UpdateColumn("created_at", time.Now().Add(-time.Minute)).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	restarted.checkFailures(ctx)
+	alerts = alerter.Alerts()
+	if len(alerts) != 2 || alerts[1].Title != "ERC721 swap entered state 'fill_tx_missing'" {
+		t.Fatalf("expected the settled transition to be reported, got %+v", alerts)
+	}
+}
+
+type fakeLeader bool
+
+func (l fakeLeader) IsLeader() bool {
+	return bool(l)
+}
+
+func TestOnlyTheLeaderReportsFailures(t *testing.T) {
+	db := testutil.NewDB()
+	alerter := testutil.NewAlerter()
+	ctx := context.Background()
+
+	s := erc721.Swap{
+		SrcChainID:    "97",
+		DstChainID:    "4",
+		State:         erc721.SwapStateFillTxSent,
+		SwapDirection: erc721.SwapDirectionForward,
+		RequestTxHash: "0x01",
+	}
+	if err := db.Create(&s).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	leader := newTestWatcher(db, alerter)
+	leader.deps.Leader = fakeLeader(true)
+	follower := newTestWatcher(db, alerter)
+	follower.deps.Leader = fakeLeader(false)
+
+	leader.check(ctx)
+	recordTransition(t, db, &s, erc721.SwapStateFillTxFailed, time.Minute)
+
+	follower.check(ctx)
+	if n := len(alerter.Alerts()); n != 0 {
+		t.Fatalf("expected no alert from a follower, got %d", n)
+	}
+
+	leader.check(ctx)
+	follower.check(ctx)
+	if n := len(alerter.Alerts()); n != 1 {
+		t.Fatalf("expected 1 alert from the leader, got %d", n)
+	}
+}
@@ -0,0 +1,110 @@
+package sla
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/alert"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// checkStuck alerts on swaps and swap pairs staying in a state longer than its threshold
+func (w *Watcher) checkStuck(ctx context.Context) {
+	for idx := range swapEntities {
+		w.checkStuckEntity(ctx, &swapEntities[idx], w.conf.SwapThresholds)
+	}
+	for idx := range swapPairEntities {
+		w.checkStuckEntity(ctx, &swapPairEntities[idx], w.conf.SwapPairThresholds)
+	}
+}
+
+func (w *Watcher) checkStuckEntity(ctx context.Context, e *entity, thresholds map[string]time.Duration) {
+	states := make([]string, 0, len(thresholds))
+	for state := range thresholds {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+
+	for _, state := range states {
+		threshold := thresholds[state]
+
+		var rr []*row
+		err := w.deps.DB.WithContext(ctx).Table(
+			e.table,
+		).Select(
+			e.selectColumns(),
+		).Where(
+			"state = ? and updated_at < ?",
+			state,
+			time.Now().Add(-threshold),
+		).Order(
+			"updated_at asc",
+		).Find(&rr).Error
+		if err != nil {
+			util.Logger.Error(errors.Wrapf(err, "[Watcher.checkStuckEntity]: failed to query stuck %s in state '%s'", e.name, state))
+			continue
+		}
+
+		byChain := make(map[string][]*row)
+		for _, r := range rr {
+			byChain[r.SrcChainID] = append(byChain[r.SrcChainID], r)
+		}
+
+		// resolve previously stuck chains are not listed anymore
+		for _, chainID := range w.knownChains() {
+			if _, ok := byChain[chainID]; !ok {
+				w.deps.Alerter.Resolve(stuckKey(e, state, chainID), fmt.Sprintf("no %s is stuck in state '%s' anymore", e.name, state))
+			}
+		}
+
+		for chainID, chainRows := range byChain {
+			w.deps.Alerter.Fire(&alert.Alert{
+				Key:      stuckKey(e, state, chainID),
+				Severity: alert.SeverityWarning,
+				ChainID:  chainID,
+				Title:    fmt.Sprintf("%s stuck in state '%s'", e.name, state),
+				Message: fmt.Sprintf(
+					"%d %s(s) have been in state '%s' for more than %s: %s",
+					len(chainRows),
+					e.name,
+					state,
+					threshold.String(),
+					w.describe(e, chainRows),
+				),
+			})
+		}
+	}
+}
+
+// describe lists ids and explorer links of rows
+func (w *Watcher) describe(e *entity, rr []*row) string {
+	var items []string
+	for idx, r := range rr {
+		if w.conf.MaxListedIDs > 0 && idx >= w.conf.MaxListedIDs {
+			items = append(items, fmt.Sprintf("and %d more", len(rr)-idx))
+			break
+		}
+
+		items = append(items, fmt.Sprintf("%s (%s)", r.ID, w.link(e, r)))
+	}
+
+	return strings.Join(items, ", ")
+}
+
+func (w *Watcher) knownChains() []string {
+	chains := make([]string, 0, len(w.conf.ExplorerURLs))
+	for chainID := range w.conf.ExplorerURLs {
+		chains = append(chains, chainID)
+	}
+
+	return chains
+}
+
+func stuckKey(e *entity, state, chainID string) string {
+	return fmt.Sprintf("sla-stuck-%s-%s-%s", e.table, state, chainID)
+}
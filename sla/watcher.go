@@ -0,0 +1,80 @@
+package sla
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/alert"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/lease"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+type Config struct {
+	CheckInterval time.Duration
+	// SwapThresholds is the maximum time a swap may stay in a state, keyed by state
+	SwapThresholds map[string]time.Duration
+	// SwapPairThresholds is the maximum time a swap pair may stay in a state, keyed by state
+	SwapPairThresholds map[string]time.Duration
+	// ExplorerURLs is the transaction explorer URL of each chain, keyed by chain id
+	ExplorerURLs map[string]string
+	// MaxListedIDs limits the number of entities listed in a single alert
+	MaxListedIDs int
+}
+
+type Dependencies struct {
+	DB      *gorm.DB
+	Alerter alert.Dispatcher
+	// Leader is nil when a single replica runs, otherwise only the leader checks and sends alerts
+	Leader lease.Leader
+}
+
+type Watcher struct {
+	conf *Config
+	deps *Dependencies
+
+	wg sync.WaitGroup
+}
+
+// NewWatcher returns the SLA watcher instance
+func NewWatcher(c *Config, d *Dependencies) *Watcher {
+	return &Watcher{
+		conf: c,
+		deps: d,
+	}
+}
+
+// Start starts the routine of the watcher, it stops once ctx is done
+func (w *Watcher) Start(ctx context.Context) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.Watch(ctx)
+	}()
+}
+
+// Wait blocks until the routine started by Start has returned
+func (w *Watcher) Wait() {
+	w.wg.Wait()
+}
+
+// Watch periodically checks stuck swaps and swap pairs, and swaps and swap pairs entering failure states, it
+// returns once ctx is done
+func (w *Watcher) Watch(ctx context.Context) {
+	for util.Sleep(ctx, w.conf.CheckInterval) {
+		w.check(ctx)
+	}
+}
+
+// check runs the checks once if this replica is the leader, the failures not reported yet are left to the next
+// leader through the persisted cursor
+func (w *Watcher) check(ctx context.Context) {
+	if w.deps.Leader != nil && !w.deps.Leader.IsLeader() {
+		return
+	}
+
+	w.checkStuck(ctx)
+	w.checkFailures(ctx)
+}
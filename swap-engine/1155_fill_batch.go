@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// batchERC1155Fills sends the fills of the Swaps towards a chain in batches once their window is over, and returns the
+// Swaps to fill with their own tx
+func (e *Engine) batchERC1155Fills(ctx context.Context, ss []*erc1155.Swap, loopName string) []*erc1155.Swap {
+	var single []*erc1155.Swap
+	groups := make(map[string][]*erc1155.Swap)
+	oldest := make(map[string]time.Time)
+	for _, s := range ss {
+		// a Swap whose fill already failed in a batch is filled on its own
+		if _, ok := e.conf.FillBatches[s.DstChainID]; !ok || s.FillBatchTxHash != "" {
+			single = append(single, s)
+			continue
+		}
+
+		groups[s.DstChainID] = append(groups[s.DstChainID], s)
+		if t, ok := oldest[s.DstChainID]; !ok || s.UpdatedAt.Before(t) {
+			oldest[s.DstChainID] = s.UpdatedAt
+		}
+	}
+
+	for dstChainID, group := range groups {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		b := e.conf.FillBatches[dstChainID]
+		if !b.fillBatchDue(len(group), oldest[dstChainID]) {
+			continue
+		}
+		if len(group) > b.Size {
+			group = group[:b.Size]
+		}
+
+		single = append(single, e.sendERC1155FillBatch(ctx, dstChainID, group, loopName)...)
+	}
+
+	return single
+}
+
+// sendERC1155FillBatch sends the fills of the Swaps in a single tx of their agent, it returns the Swaps to fill with
+// their own tx when the batch cannot be dry run
+func (e *Engine) sendERC1155FillBatch(ctx context.Context, dstChainID string, ss []*erc1155.Swap, loopName string) []*erc1155.Swap {
+	// once picked, the fill tx is sent and its state saved even if the engine is stopping meanwhile
+	ctx = util.WithoutCancel(ctx)
+
+	// every fill is dry run on its own first, so that a Swap which cannot be filled is not part of the batch
+	var batch []*erc1155.Swap
+	var callData [][]byte
+	for _, s := range ss {
+		tx, err := e.sendERC1155FillSwapRequest(ctx, s, true)
+		if err != nil {
+			util.Logger.Warningf("[Engine.sendERC1155FillBatch]: failed to dry run tx of Swap %s", s.ID)
+
+			s.State = erc1155.SwapStateFillTxDryRunFailed
+			s.MessageLog = err.Error()
+			if err := e.save(ctx, s, loopName); err != nil {
+				logSaveError(err, "[Engine.sendERC1155FillBatch]: failed to update Swap %s to '%s' state", s.ID, s.State)
+			}
+
+			continue
+		}
+
+		batch = append(batch, s)
+		callData = append(callData, tx.Data())
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	agentAddr := e.conf.ERC1155SwapAgentAddresses[dstChainID]
+	dryRun, err := e.sendFillBatch(ctx, dstChainID, agentAddr, callData, true)
+	if err != nil {
+		util.Logger.Warning(errors.Wrapf(err, "[Engine.sendERC1155FillBatch]: failed to dry run fill batch tx to chain id %s, the Swaps are filled one by one", dstChainID))
+		return batch
+	}
+
+	// We save the tx as the checkpoint of every Swap of the batch, a Swap failing to save is left out of it
+	var saved []*erc1155.Swap
+	var savedCallData [][]byte
+	for i, s := range batch {
+		s.State = erc1155.SwapStateFillTxCreated
+		s.FillTxHash = dryRun.Hash().String()
+		s.FillBatchTxHash = s.FillTxHash
+		s.FillHeight = math.MaxInt64
+		if err := e.save(ctx, s, loopName); err != nil {
+			logSaveError(err, "[Engine.sendERC1155FillBatch]: failed to update Swap %s to '%s' state", s.ID, s.State)
+
+			continue
+		}
+
+		saved = append(saved, s)
+		savedCallData = append(savedCallData, callData[i])
+	}
+	if len(saved) == 0 {
+		return nil
+	}
+
+	request, err := e.sendFillBatch(ctx, dstChainID, agentAddr, savedCallData, false)
+	if err != nil {
+		util.Logger.Error(
+			errors.Wrapf(err, "[Engine.sendERC1155FillBatch]: failed to send a real fill batch tx %s of %d Swaps", dryRun.Hash().String(), len(saved)),
+		)
+
+		for _, s := range saved {
+			e.retryERC1155FillAlone(ctx, s, err.Error(), loopName)
+		}
+
+		return nil
+	}
+
+	util.Logger.Infof(
+		"[Engine.sendERC1155FillBatch]: sent fill batch tx of %d Swaps to chain id %s, %s/%s",
+		len(saved),
+		dstChainID,
+		e.conf.ExplorerURL,
+		request.Hash().String(),
+	)
+
+	// update tx hash again in case there are some parameters might change tx hash
+	for _, s := range saved {
+		s.FillTxHash = request.Hash().String()
+		s.FillBatchTxHash = s.FillTxHash
+		if err := e.save(ctx, s, loopName); err != nil {
+			logSaveError(err, "[Engine.sendERC1155FillBatch]: failed to update Swap %s fill tx hash %s right after sending out", s.ID, s.FillTxHash)
+		}
+	}
+
+	return nil
+}
+
+// retryERC1155FillAlone moves a Swap whose fill failed in a batch back to request_confirmed, it is then filled with
+// its own tx
+func (e *Engine) retryERC1155FillAlone(ctx context.Context, s *erc1155.Swap, reason, loopName string) {
+	s.State = erc1155.SwapStateRequestConfirmed
+	s.MessageLog = fmt.Sprintf("fill failed in batch tx %s: %s, retrying with its own tx", s.FillBatchTxHash, reason)
+	s.FillTxHash = ""
+	s.FillHeight = math.MaxInt64
+	s.FillTrackRetry = 0
+	if err := e.save(ctx, s, loopName); err != nil {
+		logSaveError(err, "[Engine.retryERC1155FillAlone]: failed to update Swap %s to '%s' state", s.ID, s.State)
+	}
+}
+
+// isERC1155FillBatched tells whether the fill tx in flight of a Swap is a batch
+func isERC1155FillBatched(s *erc1155.Swap) bool {
+	return s.FillBatchTxHash != "" && s.FillBatchTxHash == s.FillTxHash
+}
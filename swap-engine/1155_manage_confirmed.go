@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+
 	"math"
 
 	"github.com/ethereum/go-ethereum/core"
@@ -10,9 +12,9 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC1155ConfirmedSwap() {
+func (e *Engine) manageERC1155ConfirmedSwap(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC1155Swap(fromChainID, []erc1155.SwapState{
+	ss, err := e.queryERC1155Swap(ctx, fromChainID, []erc1155.SwapState{
 		erc1155.SwapStateRequestConfirmed,
 	})
 	if err != nil {
@@ -20,18 +22,26 @@ func (e *Engine) manageERC1155ConfirmedSwap() {
 		return
 	}
 
+	ss = e.rejectERC1155UnsupportedSwaps(ctx, ss, "manageERC1155ConfirmedSwap")
+	ss = e.batchERC1155Fills(ctx, ss, "manageERC1155ConfirmedSwap")
+
 	for _, s := range ss {
-		txHash, err := e.generateERC1155TxHash(s)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// once picked, the fill tx is sent and its state saved even if the engine is stopping meanwhile
+		ctx := util.WithoutCancel(ctx)
+
+		txHash, err := e.generateERC1155TxHash(ctx, s)
 		if err != nil {
 			// this error might comes from gas estimation, so it means we cannot send the real tx to the chain
 			util.Logger.Warningf("[Engine.manageERC1155ConfirmedSwap]: failed to dry run tx of Swap %s", s.ID)
 
 			s.State = erc1155.SwapStateFillTxDryRunFailed
 			s.MessageLog = err.Error()
-			if err := e.deps.DB.Save(s).Error; err != nil {
-				util.Logger.Error(
-					errors.Wrapf(err, "[Engine.manageERC1155ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State),
-				)
+			if err := e.save(ctx, s, "manageERC1155ConfirmedSwap"); err != nil {
+				logSaveError(err, "[Engine.manageERC1155ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 			}
 
 			continue
@@ -43,10 +53,8 @@ func (e *Engine) manageERC1155ConfirmedSwap() {
 		s.State = erc1155.SwapStateFillTxCreated
 		s.FillTxHash = txHash
 		s.FillHeight = math.MaxInt64
-		if err := e.deps.DB.Save(s).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC1155ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State),
-			)
+		if err := e.save(ctx, s, "manageERC1155ConfirmedSwap"); err != nil {
+			logSaveError(err, "[Engine.manageERC1155ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 
 			continue
 		}
@@ -57,17 +65,15 @@ func (e *Engine) manageERC1155ConfirmedSwap() {
 			txHash,
 		)
 
-		request, err := e.sendERC1155FillSwapRequest(s, false)
+		request, err := e.sendERC1155FillSwapRequest(ctx, s, false)
 		if err != nil {
 			// retry when a transaction is attempted to be replaced
 			// with a different one without the required price bump.
 			if errors.Cause(err).Error() == core.ErrReplaceUnderpriced.Error() {
 				s.State = erc1155.SwapStateRequestConfirmed
 				s.MessageLog = err.Error()
-				if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-					util.Logger.Error(
-						errors.Wrapf(dbErr, "[Engine.manageERC1155ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State),
-					)
+				if dbErr := e.save(ctx, s, "manageERC1155ConfirmedSwap"); dbErr != nil {
+					logSaveError(dbErr, "[Engine.manageERC1155ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 
 					continue
 				}
@@ -75,10 +81,8 @@ func (e *Engine) manageERC1155ConfirmedSwap() {
 
 			s.State = erc1155.SwapStateFillTxFailed
 			s.MessageLog = err.Error()
-			if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-				util.Logger.Error(
-					errors.Wrapf(dbErr, "[Engine.manageERC1155ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State),
-				)
+			if dbErr := e.save(ctx, s, "manageERC1155ConfirmedSwap"); dbErr != nil {
+				logSaveError(dbErr, "[Engine.manageERC1155ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 
 				continue
 			}
@@ -100,10 +104,8 @@ func (e *Engine) manageERC1155ConfirmedSwap() {
 		// update tx hash again in case there are some parameters might change tx hash
 		// for example, gas limit which comes from estimation
 		s.FillTxHash = request.Hash().String()
-		if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-			util.Logger.Error(
-				errors.Wrapf(dbErr, "[Engine.manageERC1155ConfirmedSwap]: failed to update Swap %s fill tx hash %s right after sending out", s.ID, s.FillTxHash),
-			)
+		if dbErr := e.save(ctx, s, "manageERC1155ConfirmedSwap"); dbErr != nil {
+			logSaveError(dbErr, "[Engine.manageERC1155ConfirmedSwap]: failed to update Swap %s fill tx hash %s right after sending out", s.ID, s.FillTxHash)
 
 			continue
 		}
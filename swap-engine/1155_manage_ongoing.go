@@ -1,15 +1,17 @@
 package engine
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC1155OngoingRequest() {
+func (e *Engine) manageERC1155OngoingRequest(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC1155Swap(fromChainID, []erc1155.SwapState{
+	ss, err := e.queryERC1155Swap(ctx, fromChainID, []erc1155.SwapState{
 		erc1155.SwapStateRequestOngoing,
 	})
 	if err != nil {
@@ -17,8 +19,10 @@ func (e *Engine) manageERC1155OngoingRequest() {
 		return
 	}
 
+	ss = e.rejectERC1155UnsupportedSwaps(ctx, ss, "manageERC1155OngoingRequest")
+
 	// Fill required information without updating to DB
-	if err := e.fillERC1155RequiredInfo(ss); err != nil {
+	if err := e.fillERC1155RequiredInfo(ctx, ss); err != nil {
 		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC1155OngoingRequest]: failed to fill destination"))
 		return
 	}
@@ -26,33 +30,39 @@ func (e *Engine) manageERC1155OngoingRequest() {
 	// Separate ready Swaps, pending Swaps, and rejected Swaps
 	ss, pp, rr := e.separateERC1155SwapEvents(ss)
 	for _, r := range rr {
+		if ctx.Err() != nil {
+			return
+		}
+
 		r.State = erc1155.SwapStateRequestRejected
-		if err := e.deps.DB.Save(&r).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC1155OngoingRequest]: failed to update Swap %s to state '%s'", r.ID, r.State),
-			)
+		if err := e.save(ctx, r, "manageERC1155OngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC1155OngoingRequest]: failed to update Swap %s to state '%s'", r.ID, r.State)
 		}
 	}
 	for _, p := range pp {
-		if err := e.deps.DB.Save(&p).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC1155OngoingRequest]: failed to update Swap %s", p.ID),
-			)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := e.save(ctx, p, "manageERC1155OngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC1155OngoingRequest]: failed to update Swap %s", p.ID)
 		}
 	}
 
-	ss, err = e.filterERC1155ConfirmedSwapEvents(ss)
+	ss, err = e.filterERC1155ConfirmedSwapEvents(ctx, ss)
 	if err != nil {
 		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC1155OngoingRequest]: failed to filter confirmed Swaps"))
 		return
 	}
 
 	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
+
 		s.State = erc1155.SwapStateRequestConfirmed
-		if err := e.deps.DB.Save(&s).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC1155OngoingRequest]: failed to update Swap %s to state '%s'", s.ID, s.State),
-			)
+		if err := e.save(ctx, s, "manageERC1155OngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC1155OngoingRequest]: failed to update Swap %s to state '%s'", s.ID, s.State)
 		}
 	}
 }
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+
 	"math/big"
 
 	"github.com/pkg/errors"
@@ -11,9 +13,9 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC1155TxCreatedSwap() {
+func (e *Engine) manageERC1155TxCreatedSwap(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC1155Swap(fromChainID, []erc1155.SwapState{
+	ss, err := e.queryERC1155Swap(ctx, fromChainID, []erc1155.SwapState{
 		erc1155.SwapStateFillTxCreated,
 	})
 	if err != nil {
@@ -22,7 +24,11 @@ func (e *Engine) manageERC1155TxCreatedSwap() {
 	}
 
 	for _, s := range ss {
-		ethTx, isPending, err := e.retrieveTx(s.FillTxHash, s.DstChainID)
+		if ctx.Err() != nil {
+			return
+		}
+
+		ethTx, isPending, err := e.retrieveTx(ctx, s.FillTxHash, s.DstChainID)
 		if err != nil {
 			util.Logger.Error(
 				errors.Wrapf(err, "[Engine.manageERC1155TxCreatedSwap]: failed to get Swap creation tx %s", s.FillTxHash),
@@ -35,7 +41,7 @@ func (e *Engine) manageERC1155TxCreatedSwap() {
 			continue
 		}
 
-		receipt, err := e.retrieveTxReceipt(s.FillTxHash, s.DstChainID)
+		receipt, err := e.retrieveTxReceipt(ctx, s.FillTxHash, s.DstChainID)
 		if err != nil {
 			util.Logger.Error(
 				errors.Wrapf(err, "[Engine.manageERC1155TxCreatedSwap]: failed to get Swap creation receipt for tx %s", s.FillTxHash),
@@ -54,21 +60,22 @@ func (e *Engine) manageERC1155TxCreatedSwap() {
 
 		if ethTx == nil || receipt == nil {
 			s.FillTrackRetry += 1
-			if err := e.deps.DB.Save(s).Error; err != nil {
-				util.Logger.Error(
-					errors.Wrapf(err, "[Engine.manageERC1155TxCreatedSwap]: failed to increase create track retry counter %s", s.ID),
-				)
+			if err := e.save(ctx, s, "manageERC1155TxCreatedSwap"); err != nil {
+				logSaveError(err, "[Engine.manageERC1155TxCreatedSwap]: failed to increase create track retry counter %s", s.ID)
 
 				continue
 			}
 
 			if s.FillTrackRetry > e.conf.MaxTrackRetry {
+				if isERC1155FillBatched(s) {
+					e.retryERC1155FillAlone(ctx, s, "tx is missing", "manageERC1155TxCreatedSwap")
+					continue
+				}
+
 				s.State = erc1155.SwapStateFillTxMissing
 				s.MessageLog = "[Engine.manageERC1155TxCreatedSwap]: tx is missing"
-				if err := e.deps.DB.Save(s).Error; err != nil {
-					util.Logger.Error(
-						errors.Wrapf(err, "[Engine.manageERC1155TxCreatedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State),
-					)
+				if err := e.save(ctx, s, "manageERC1155TxCreatedSwap"); err != nil {
+					logSaveError(err, "[Engine.manageERC1155TxCreatedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 
 					continue
 				}
@@ -78,7 +85,7 @@ func (e *Engine) manageERC1155TxCreatedSwap() {
 		}
 
 		var b block.Log
-		err = e.deps.DB.Where(
+		err = e.deps.DB.WithContext(ctx).Where(
 			"chain_id = ? and block_hash = ?",
 			s.DstChainID,
 			receipt.BlockHash.String(),
@@ -103,7 +110,7 @@ func (e *Engine) manageERC1155TxCreatedSwap() {
 		var isValid bool
 		fillBlockHeight := receipt.BlockNumber.Int64()
 		if s.SwapDirection == erc1155.SwapDirectionForward {
-			isValid, err = e.verifyERC1155ForwardSwapFillEvent(uint64(fillBlockHeight), s.RequestTxHash, s.DstChainID)
+			isValid, err = e.verifyERC1155ForwardSwapFillEvent(ctx, uint64(fillBlockHeight), s.RequestTxHash, s.DstChainID)
 			if err != nil {
 				util.Logger.Error(
 					errors.Wrapf(err, "[Engine.manageERC1155TxCreatedSwap]: failed to verify swap fill event for Swap %s", s.ID),
@@ -112,7 +119,7 @@ func (e *Engine) manageERC1155TxCreatedSwap() {
 				continue
 			}
 		} else {
-			isValid, err = e.verifyERC1155BackwardSwapFillEvent(uint64(fillBlockHeight), s.RequestTxHash, s.DstChainID)
+			isValid, err = e.verifyERC1155BackwardSwapFillEvent(ctx, uint64(fillBlockHeight), s.RequestTxHash, s.DstChainID)
 			if err != nil {
 				util.Logger.Error(
 					errors.Wrapf(err, "[Engine.manageERC1155TxCreatedSwap]: failed to verify backward swap fill event for Swap %s", s.ID),
@@ -122,12 +129,15 @@ func (e *Engine) manageERC1155TxCreatedSwap() {
 			}
 		}
 		if !isValid {
+			if isERC1155FillBatched(s) {
+				e.retryERC1155FillAlone(ctx, s, "swap fill event was not found", "manageERC1155TxCreatedSwap")
+				continue
+			}
+
 			s.State = erc1155.SwapStateFillTxFailed
 			s.MessageLog = "[Engine.manageERC1155TxCreatedSwap]: swap fill event was not found!"
-			if err := e.deps.DB.Save(s).Error; err != nil {
-				util.Logger.Error(
-					errors.Wrapf(err, "[Engine.manageERC1155TxCreatedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State),
-				)
+			if err := e.save(ctx, s, "manageERC1155TxCreatedSwap"); err != nil {
+				logSaveError(err, "[Engine.manageERC1155TxCreatedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 			}
 
 			continue
@@ -136,16 +146,18 @@ func (e *Engine) manageERC1155TxCreatedSwap() {
 		gasPrice := big.NewInt(0)
 		gasPrice.SetString(ethTx.GasPrice().String(), 10)
 		s.FillGasPrice = gasPrice.String()
-		s.FillConsumedFeeAmount = big.NewInt(1).Mul(gasPrice, big.NewInt(s.FillGasUsed)).String()
 		s.FillGasUsed = int64(receipt.GasUsed)
+		if isERC1155FillBatched(s) {
+			// the gas of a batch is shared by its fills
+			s.FillGasUsed /= int64(fillBatchCalls(ethTx))
+		}
+		s.FillConsumedFeeAmount = big.NewInt(1).Mul(gasPrice, big.NewInt(s.FillGasUsed)).String()
 		s.FillHeight = fillBlockHeight
 		s.FillBlockHash = receipt.BlockHash.String()
 		s.FillBlockLogID = &b.ID
 		s.State = erc1155.SwapStateFillTxSent
-		if err := e.deps.DB.Save(s).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC1155TxCreatedSwap]: failed to update Swap %s basic info", s.ID),
-			)
+		if err := e.save(ctx, s, "manageERC1155TxCreatedSwap"); err != nil {
+			logSaveError(err, "[Engine.manageERC1155TxCreatedSwap]: failed to update Swap %s basic info", s.ID)
 
 			continue
 		}
@@ -1,15 +1,17 @@
 package engine
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC1155TxSentSwap() {
+func (e *Engine) manageERC1155TxSentSwap(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC1155Swap(fromChainID, []erc1155.SwapState{
+	ss, err := e.queryERC1155Swap(ctx, fromChainID, []erc1155.SwapState{
 		erc1155.SwapStateFillTxSent,
 	})
 	if err != nil {
@@ -17,9 +19,12 @@ func (e *Engine) manageERC1155TxSentSwap() {
 		return
 	}
 
-	var ids []string
 	for _, s := range ss {
-		confirmed, err := e.hasBlockConfirmed(s.FillTxHash, s.DstChainID)
+		if ctx.Err() != nil {
+			return
+		}
+
+		confirmed, err := e.hasBlockConfirmed(ctx, s.FillTxHash, s.DstChainID)
 		if err != nil {
 			util.Logger.Error(
 				errors.Wrapf(err, "[Engine.manageERC1155TxSentSwap]: failed to check block confirmation for Swap %s", s.ID),
@@ -28,24 +33,17 @@ func (e *Engine) manageERC1155TxSentSwap() {
 			continue
 		}
 
-		if confirmed {
-			ids = append(ids, s.ID)
+		if !confirmed {
+			continue
 		}
-	}
 
-	if len(ids) == 0 {
-		return
-	}
+		s.State = erc1155.SwapStateFillTxConfirmed
+		if err := e.save(ctx, s, "manageERC1155TxSentSwap"); err != nil {
+			logSaveError(err, "[Engine.manageERC1155TxSentSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 
-	if err := e.deps.DB.Model(&ss).Where("id in ?", ids).Updates(map[string]interface{}{
-		"state": erc1155.SwapStateFillTxConfirmed,
-	}).Error; err != nil {
-		util.Logger.Error(
-			errors.Wrapf(err, "[Engine.manageERC1155TxSentSwap]: failed to update state '%s'", erc1155.SwapStateFillTxConfirmed),
-		)
-	}
+			continue
+		}
 
-	for _, s := range ss {
 		util.Logger.Infof("[Engine.manageERC1155TxSentSwap]: updated Swap %s state to '%s'", s.ID, s.State)
 	}
 }
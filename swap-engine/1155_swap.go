@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -12,8 +13,8 @@ import (
 	"gorm.io/gorm"
 )
 
-func (e *Engine) generateERC1155TxHash(s *erc1155.Swap) (string, error) {
-	request, err := e.sendERC1155FillSwapRequest(s, true)
+func (e *Engine) generateERC1155TxHash(ctx context.Context, s *erc1155.Swap) (string, error) {
+	request, err := e.sendERC1155FillSwapRequest(ctx, s, true)
 	if err != nil {
 		return "", errors.Wrap(err, "[Engine.generateERC1155TxHash]: failed to dry run sending a request")
 	}
@@ -22,9 +23,12 @@ func (e *Engine) generateERC1155TxHash(s *erc1155.Swap) (string, error) {
 }
 
 // sendERC1155FillSwapRequest sends transaction to fill a swap on destination chain
-func (e *Engine) sendERC1155FillSwapRequest(s *erc1155.Swap, dryRun bool) (*types.Transaction, error) {
+func (e *Engine) sendERC1155FillSwapRequest(ctx context.Context, s *erc1155.Swap, dryRun bool) (*types.Transaction, error) {
 	dstChainID := s.DstChainID
-	dstChainIDInt := util.StrToBigInt(dstChainID)
+	dstChainIDInt, err := util.ParseBigInt(dstChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC1155FillSwapRequest]: invalid destination chain id of Swap %s", s.ID)
+	}
 	if _, ok := e.deps.Client[dstChainID]; !ok {
 		return nil, errors.Errorf("[Engine.sendERC1155FillSwapRequest]: client for chain id %s is not supported", dstChainID)
 	}
@@ -32,7 +36,6 @@ func (e *Engine) sendERC1155FillSwapRequest(s *erc1155.Swap, dryRun bool) (*type
 		return nil, errors.Errorf("[Engine.sendERC1155FillSwapRequest]: swap agent for chain id %s is not supported", dstChainID)
 	}
 
-	ctx := context.Background()
 	txOpts, err := util.TxOpts(ctx, e.deps.Client[dstChainID], e.conf.PrivateKey, dstChainIDInt)
 	if err != nil {
 		return nil, errors.Wrap(err, "[Engine.sendERC1155FillSwapRequest]: failed to create tx opts")
@@ -54,15 +57,28 @@ func (e *Engine) sendERC1155FillSwapRequest(s *erc1155.Swap, dryRun bool) (*type
 		return nil, errors.Wrap(err, "[Engine.sendERC1155FillSwapRequest]: failed to unmarshal ids")
 	}
 
+	tokenChainIDInt, err := util.ParseBigInt(tokenChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC1155FillSwapRequest]: invalid token chain id of Swap %s", s.ID)
+	}
+	idInts, err := util.ParseBigIntSlice(ids)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC1155FillSwapRequest]: invalid ids of Swap %s", s.ID)
+	}
+	amountInts, err := util.ParseBigIntSlice(amounts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC1155FillSwapRequest]: invalid amounts of Swap %s", s.ID)
+	}
+
 	txOpts.NoSend = dryRun
 	tx, err := e.deps.ERC1155SwapAgent[dstChainID].Fill(
 		txOpts,
 		common.HexToHash(s.RequestTxHash),
 		common.HexToAddress(tokenAddr),
 		common.HexToAddress(s.Recipient),
-		util.StrToBigInt(tokenChainID),
-		util.StrSliceToBigIntSlice(ids),
-		util.StrSliceToBigIntSlice(amounts),
+		tokenChainIDInt,
+		idInts,
+		amountInts,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "[Engine.sendERC1155FillSwapRequest]: failed to send swap pair creation tx")
@@ -72,10 +88,10 @@ func (e *Engine) sendERC1155FillSwapRequest(s *erc1155.Swap, dryRun bool) (*type
 }
 
 // queryERC1155Swap queries Swap this engine is responsible
-func (e *Engine) queryERC1155Swap(fromChainID string, states []erc1155.SwapState) ([]*erc1155.Swap, error) {
+func (e *Engine) queryERC1155Swap(ctx context.Context, fromChainID string, states []erc1155.SwapState) ([]*erc1155.Swap, error) {
 	// TODO: check the index
 	var ss []*erc1155.Swap
-	err := e.deps.DB.Where(
+	err := e.deps.DB.WithContext(ctx).Where(
 		"state in ? and src_chain_id = ?",
 		states,
 		fromChainID,
@@ -92,7 +108,7 @@ func (e *Engine) queryERC1155Swap(fromChainID string, states []erc1155.SwapState
 }
 
 // fillERC1155RequiredInfo fills swap destination tokens
-func (e *Engine) fillERC1155RequiredInfo(ss []*erc1155.Swap) error {
+func (e *Engine) fillERC1155RequiredInfo(ctx context.Context, ss []*erc1155.Swap) error {
 	for _, s := range ss {
 		if s.IsRequiredInfoValid() {
 			continue
@@ -103,9 +119,9 @@ func (e *Engine) fillERC1155RequiredInfo(ss []*erc1155.Swap) error {
 		var skip bool
 		var err error
 		if s.SwapDirection == erc1155.SwapDirectionForward {
-			skip, err = e.fillERC1155Forward(s)
+			skip, err = e.fillERC1155Forward(ctx, s)
 		} else {
-			skip, err = e.fillERC1155Backward(s)
+			skip, err = e.fillERC1155Backward(ctx, s)
 		}
 
 		if skip {
@@ -119,10 +135,10 @@ func (e *Engine) fillERC1155RequiredInfo(ss []*erc1155.Swap) error {
 	return nil
 }
 
-func (e *Engine) fillERC1155Forward(s *erc1155.Swap) (skip bool, err error) {
+func (e *Engine) fillERC1155Forward(ctx context.Context, s *erc1155.Swap) (skip bool, err error) {
 	// TODO: check db index
 	var sp erc1155.SwapPair
-	err = e.deps.DB.Where(
+	err = e.deps.DB.WithContext(ctx).Where(
 		"src_token_addr = ? and src_chain_id = ? and dst_chain_id = ? and available = ?",
 		s.SrcTokenAddr,
 		s.SrcChainID,
@@ -142,10 +158,10 @@ func (e *Engine) fillERC1155Forward(s *erc1155.Swap) (skip bool, err error) {
 	return false, nil
 }
 
-func (e *Engine) fillERC1155Backward(s *erc1155.Swap) (skip bool, err error) {
+func (e *Engine) fillERC1155Backward(ctx context.Context, s *erc1155.Swap) (skip bool, err error) {
 	// TODO: check db index
 	var sp erc1155.SwapPair
-	err = e.deps.DB.Where(
+	err = e.deps.DB.WithContext(ctx).Where(
 		"dst_token_addr = ? and dst_chain_id = ? and src_chain_id = ? and available = ?",
 		s.SrcTokenAddr,
 		s.SrcChainID,
@@ -184,9 +200,9 @@ func (e *Engine) separateERC1155SwapEvents(ss []*erc1155.Swap) (pass []*erc1155.
 }
 
 // filterERC1155ConfirmedSwapEvents checks block confirmation of the chain this engine is responsible
-func (e *Engine) filterERC1155ConfirmedSwapEvents(ss []*erc1155.Swap) (events []*erc1155.Swap, err error) {
+func (e *Engine) filterERC1155ConfirmedSwapEvents(ctx context.Context, ss []*erc1155.Swap) (events []*erc1155.Swap, err error) {
 	for _, s := range ss {
-		confirmed, err := e.hasBlockConfirmed(s.RequestTxHash, e.chainID())
+		confirmed, err := e.hasBlockConfirmed(ctx, s.RequestTxHash, e.chainID())
 		if err != nil {
 			util.Logger.Warning(errors.Wrap(err, "[Engine.filterERC1155ConfirmedSwapEvents]: failed to check block confirmation"))
 			continue
@@ -198,3 +214,26 @@ func (e *Engine) filterERC1155ConfirmedSwapEvents(ss []*erc1155.Swap) (events []
 
 	return events, nil
 }
+
+// rejectERC1155UnsupportedSwaps moves the Swaps towards a chain which is not configured to the unsupported destination state and
+// returns the others
+func (e *Engine) rejectERC1155UnsupportedSwaps(ctx context.Context, ss []*erc1155.Swap, loopName string) []*erc1155.Swap {
+	var supported []*erc1155.Swap
+	for _, s := range ss {
+		if e.isSupportedChain(s.DstChainID) {
+			supported = append(supported, s)
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		s.State = erc1155.SwapStateUnsupportedDestination
+		s.MessageLog = fmt.Sprintf("destination chain %s is not configured", s.DstChainID)
+		if err := e.save(ctx, s, loopName); err != nil {
+			logSaveError(err, "[Engine.rejectERC1155UnsupportedSwaps]: failed to update Swap %s to state '%s'", s.ID, s.State)
+		}
+	}
+
+	return supported
+}
@@ -10,12 +10,11 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) verifyERC1155ForwardSwapFillEvent(height uint64, requestTxHash, chainID string) (bool, error) {
+func (e *Engine) verifyERC1155ForwardSwapFillEvent(ctx context.Context, height uint64, requestTxHash, chainID string) (bool, error) {
 	if _, ok := e.deps.Client[chainID]; !ok {
 		return false, errors.Errorf("[Engine.verifyERC1155ForwardSwapFillEvent]: client for chain id %s is not supported", chainID)
 	}
 
-	ctx := context.Background()
 	opts := bind.FilterOpts{
 		Start:   height,
 		End:     &height,
@@ -35,12 +34,11 @@ func (e *Engine) verifyERC1155ForwardSwapFillEvent(height uint64, requestTxHash,
 	return iter.Next(), nil
 }
 
-func (e *Engine) verifyERC1155BackwardSwapFillEvent(height uint64, requestTxHash, chainID string) (bool, error) {
+func (e *Engine) verifyERC1155BackwardSwapFillEvent(ctx context.Context, height uint64, requestTxHash, chainID string) (bool, error) {
 	if _, ok := e.deps.Client[chainID]; !ok {
 		return false, errors.Errorf("[Engine.verifyERC1155BackwardSwapFillEvent]: client for chain id %s is not supported", chainID)
 	}
 
-	ctx := context.Background()
 	opts := bind.FilterOpts{
 		Start:   height,
 		End:     &height,
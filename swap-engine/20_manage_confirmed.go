@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"context"
+
+	"math"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+func (e *Engine) manageERC20ConfirmedSwap(ctx context.Context) {
+	fromChainID := e.chainID()
+	ss, err := e.queryERC20Swap(ctx, fromChainID, []erc20.SwapState{
+		erc20.SwapStateRequestConfirmed,
+	})
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC20ConfirmedSwap]: failed to query confirmed Swaps"))
+		return
+	}
+
+	ss = e.rejectERC20UnsupportedSwaps(ctx, ss, "manageERC20ConfirmedSwap")
+	ss = e.batchERC20Fills(ctx, ss, "manageERC20ConfirmedSwap")
+
+	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// once picked, the fill tx is sent and its state saved even if the engine is stopping meanwhile
+		ctx := util.WithoutCancel(ctx)
+
+		txHash, err := e.generateERC20TxHash(ctx, s)
+		if err != nil {
+			// this error might comes from gas estimation, so it means we cannot send the real tx to the chain
+			util.Logger.Warningf("[Engine.manageERC20ConfirmedSwap]: failed to dry run tx of Swap %s", s.ID)
+
+			s.State = erc20.SwapStateFillTxDryRunFailed
+			s.MessageLog = err.Error()
+			if err := e.save(ctx, s, "manageERC20ConfirmedSwap"); err != nil {
+				logSaveError(err, "[Engine.manageERC20ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
+			}
+
+			continue
+		}
+
+		// We save the tx as our checkpoint to probe the stats later
+		// It tells that this tx might be sent or might not, but it is okay
+		// We will set the state to failed later
+		s.State = erc20.SwapStateFillTxCreated
+		s.FillTxHash = txHash
+		s.FillHeight = math.MaxInt64
+		if err := e.save(ctx, s, "manageERC20ConfirmedSwap"); err != nil {
+			logSaveError(err, "[Engine.manageERC20ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
+
+			continue
+		}
+
+		util.Logger.Infof(
+			"[Engine.manageERC20ConfirmedSwap]: sent dry run tx to chain id %s, %s",
+			e.chainID(),
+			txHash,
+		)
+
+		request, err := e.sendERC20FillSwapRequest(ctx, s, false)
+		if err != nil {
+			// retry when a transaction is attempted to be replaced
+			// with a different one without the required price bump.
+			if errors.Cause(err).Error() == core.ErrReplaceUnderpriced.Error() {
+				s.State = erc20.SwapStateRequestConfirmed
+				s.MessageLog = err.Error()
+				if dbErr := e.save(ctx, s, "manageERC20ConfirmedSwap"); dbErr != nil {
+					logSaveError(dbErr, "[Engine.manageERC20ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
+
+					continue
+				}
+			}
+
+			s.State = erc20.SwapStateFillTxFailed
+			s.MessageLog = err.Error()
+			if dbErr := e.save(ctx, s, "manageERC20ConfirmedSwap"); dbErr != nil {
+				logSaveError(dbErr, "[Engine.manageERC20ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
+
+				continue
+			}
+
+			util.Logger.Error(
+				errors.Wrapf(err, "[Engine.manageERC20ConfirmedSwap]: failed to send a real tx %s of Swap %s", s.FillTxHash, s.ID),
+			)
+
+			continue
+		}
+
+		util.Logger.Infof(
+			"[Engine.manageERC20ConfirmedSwap]: sent tx to chain id %s, %s/%s",
+			e.chainID(),
+			e.conf.ExplorerURL,
+			request.Hash().String(),
+		)
+
+		// update tx hash again in case there are some parameters might change tx hash
+		// for example, gas limit which comes from estimation
+		s.FillTxHash = request.Hash().String()
+		if dbErr := e.save(ctx, s, "manageERC20ConfirmedSwap"); dbErr != nil {
+			logSaveError(dbErr, "[Engine.manageERC20ConfirmedSwap]: failed to update Swap %s fill tx hash %s right after sending out", s.ID, s.FillTxHash)
+
+			continue
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+func (e *Engine) manageERC20OngoingRequest(ctx context.Context) {
+	fromChainID := e.chainID()
+	ss, err := e.queryERC20Swap(ctx, fromChainID, []erc20.SwapState{
+		erc20.SwapStateRequestOngoing,
+	})
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC20OngoingRequest]: failed to query onging Swaps"))
+		return
+	}
+
+	ss = e.rejectERC20UnsupportedSwaps(ctx, ss, "manageERC20OngoingRequest")
+
+	// Fill required information without updating to DB
+	if err := e.fillERC20RequiredInfo(ctx, ss); err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC20OngoingRequest]: failed to fill destination"))
+		return
+	}
+
+	// Separate ready Swaps, pending Swaps, and rejected Swaps
+	ss, pp, rr := e.separateERC20SwapEvents(ss)
+	for _, r := range rr {
+		if ctx.Err() != nil {
+			return
+		}
+
+		r.State = erc20.SwapStateRequestRejected
+		if err := e.save(ctx, r, "manageERC20OngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC20OngoingRequest]: failed to update Swap %s to state '%s'", r.ID, r.State)
+		}
+	}
+	for _, p := range pp {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := e.save(ctx, p, "manageERC20OngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC20OngoingRequest]: failed to update Swap %s", p.ID)
+		}
+	}
+
+	ss, err = e.filterERC20ConfirmedSwapEvents(ctx, ss)
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC20OngoingRequest]: failed to filter confirmed Swaps"))
+		return
+	}
+
+	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.State = erc20.SwapStateRequestConfirmed
+		if err := e.save(ctx, s, "manageERC20OngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC20OngoingRequest]: failed to update Swap %s to state '%s'", s.ID, s.State)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+func (e *Engine) manageERC20TxSentSwap(ctx context.Context) {
+	fromChainID := e.chainID()
+	ss, err := e.queryERC20Swap(ctx, fromChainID, []erc20.SwapState{
+		erc20.SwapStateFillTxSent,
+	})
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC20TxSentSwap]: failed to query tx_sent Swaps"))
+		return
+	}
+
+	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
+
+		confirmed, err := e.hasBlockConfirmed(ctx, s.FillTxHash, s.DstChainID)
+		if err != nil {
+			util.Logger.Error(
+				errors.Wrapf(err, "[Engine.manageERC20TxSentSwap]: failed to check block confirmation for Swap %s", s.ID),
+			)
+
+			continue
+		}
+
+		if !confirmed {
+			continue
+		}
+
+		s.State = erc20.SwapStateFillTxConfirmed
+		if err := e.save(ctx, s, "manageERC20TxSentSwap"); err != nil {
+			logSaveError(err, "[Engine.manageERC20TxSentSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
+
+			continue
+		}
+
+		util.Logger.Infof("[Engine.manageERC20TxSentSwap]: updated Swap %s state to '%s'", s.ID, s.State)
+	}
+}
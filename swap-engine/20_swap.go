@@ -0,0 +1,243 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+	"gorm.io/gorm"
+)
+
+func (e *Engine) generateERC20TxHash(ctx context.Context, s *erc20.Swap) (string, error) {
+	request, err := e.sendERC20FillSwapRequest(ctx, s, true)
+	if err != nil {
+		return "", errors.Wrap(err, "[Engine.generateERC20TxHash]: failed to dry run sending a request")
+	}
+
+	return request.Hash().String(), nil
+}
+
+// sendERC20FillSwapRequest sends transaction to fill a swap on destination chain
+func (e *Engine) sendERC20FillSwapRequest(ctx context.Context, s *erc20.Swap, dryRun bool) (*types.Transaction, error) {
+	dstChainID := s.DstChainID
+	dstChainIDInt, err := util.ParseBigInt(dstChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC20FillSwapRequest]: invalid destination chain id of Swap %s", s.ID)
+	}
+	if _, ok := e.deps.Client[dstChainID]; !ok {
+		return nil, errors.Errorf("[Engine.sendERC20FillSwapRequest]: client for chain id %s is not supported", dstChainID)
+	}
+	if _, ok := e.deps.ERC20SwapAgent[dstChainID]; !ok {
+		return nil, errors.Errorf("[Engine.sendERC20FillSwapRequest]: swap agent for chain id %s is not supported", dstChainID)
+	}
+
+	txOpts, err := util.TxOpts(ctx, e.deps.Client[dstChainID], e.conf.PrivateKey, dstChainIDInt)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.sendERC20FillSwapRequest]: failed to create tx opts")
+	}
+
+	tokenAddr := s.SrcTokenAddr
+	tokenChainID := s.SrcChainID
+	if s.SwapDirection == erc20.SwapDirectionBackward {
+		tokenAddr = s.DstTokenAddr
+		tokenChainID = s.SrcChainID
+	}
+
+	tokenChainIDInt, err := util.ParseBigInt(tokenChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC20FillSwapRequest]: invalid token chain id of Swap %s", s.ID)
+	}
+	amount, err := util.ParseBigInt(s.Amount)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC20FillSwapRequest]: invalid amount of Swap %s", s.ID)
+	}
+
+	txOpts.NoSend = dryRun
+	tx, err := e.deps.ERC20SwapAgent[dstChainID].Fill(
+		txOpts,
+		common.HexToHash(s.RequestTxHash),
+		common.HexToAddress(tokenAddr),
+		common.HexToAddress(s.Recipient),
+		tokenChainIDInt,
+		amount,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.sendERC20FillSwapRequest]: failed to send swap pair creation tx")
+	}
+
+	return tx, nil
+}
+
+// queryERC20Swap queries Swap this engine is responsible
+func (e *Engine) queryERC20Swap(ctx context.Context, fromChainID string, states []erc20.SwapState) ([]*erc20.Swap, error) {
+	// TODO: check the index
+	var ss []*erc20.Swap
+	err := e.deps.DB.WithContext(ctx).Where(
+		"state in ? and src_chain_id = ?",
+		states,
+		fromChainID,
+	).Order(
+		"request_height asc",
+	).Limit(
+		querySwapLimit,
+	).Find(&ss).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.queryERC20Swap]: failed to query Swap")
+	}
+
+	return ss, nil
+}
+
+// fillERC20RequiredInfo fills swap destination tokens
+func (e *Engine) fillERC20RequiredInfo(ctx context.Context, ss []*erc20.Swap) error {
+	for _, s := range ss {
+		if s.IsRequiredInfoValid() {
+			continue
+		}
+
+		s.RequestTrackRetry += 1
+
+		var skip bool
+		var err error
+		if s.SwapDirection == erc20.SwapDirectionForward {
+			skip, err = e.fillERC20Forward(ctx, s)
+		} else {
+			skip, err = e.fillERC20Backward(ctx, s)
+		}
+
+		if skip {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "[Engine.fillERC20RequiredInfo]: failed to fill required information")
+		}
+	}
+
+	return nil
+}
+
+func (e *Engine) fillERC20Forward(ctx context.Context, s *erc20.Swap) (skip bool, err error) {
+	// TODO: check db index
+	var sp erc20.SwapPair
+	err = e.deps.DB.WithContext(ctx).Where(
+		"src_token_addr = ? and src_chain_id = ? and dst_chain_id = ? and available = ?",
+		s.SrcTokenAddr,
+		s.SrcChainID,
+		s.DstChainID,
+		true,
+	).First(&sp).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "[Engine.fillERC20Forward]: failed to query available Swaps")
+	}
+
+	s.SetRequiredInfo(sp.DstTokenAddr)
+	e.checkERC20Amount(s, &sp)
+
+	return false, nil
+}
+
+func (e *Engine) fillERC20Backward(ctx context.Context, s *erc20.Swap) (skip bool, err error) {
+	// TODO: check db index
+	var sp erc20.SwapPair
+	err = e.deps.DB.WithContext(ctx).Where(
+		"dst_token_addr = ? and dst_chain_id = ? and src_chain_id = ? and available = ?",
+		s.SrcTokenAddr,
+		s.SrcChainID,
+		s.DstChainID,
+		true,
+	).First(&sp).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "[Engine.fillERC20Backward]: failed to query available Swaps")
+	}
+
+	s.SetRequiredInfo(sp.SrcTokenAddr)
+	e.checkERC20Amount(s, &sp)
+
+	return false, nil
+}
+
+// checkERC20Amount rejects a Swap whose amount is out of the bounds of its SwapPair
+func (e *Engine) checkERC20Amount(s *erc20.Swap, sp *erc20.SwapPair) {
+	if err := sp.CheckAmount(s.Amount); err != nil {
+		s.State = erc20.SwapStateRequestRejected
+		s.MessageLog = err.Error()
+	}
+}
+
+func (e *Engine) separateERC20SwapEvents(ss []*erc20.Swap) (pass []*erc20.Swap, pending []*erc20.Swap, rejected []*erc20.Swap) {
+	for _, s := range ss {
+		if s.State == erc20.SwapStateRequestRejected {
+			rejected = append(rejected, s)
+
+			continue
+		}
+		if !s.IsRequiredInfoValid() {
+			if s.RequestTrackRetry > e.conf.MaxTrackRetry {
+				rejected = append(rejected, s)
+			} else {
+				pending = append(pending, s)
+			}
+
+			continue
+		}
+
+		pass = append(pass, s)
+	}
+
+	return
+}
+
+// filterERC20ConfirmedSwapEvents checks block confirmation of the chain this engine is responsible
+func (e *Engine) filterERC20ConfirmedSwapEvents(ctx context.Context, ss []*erc20.Swap) (events []*erc20.Swap, err error) {
+	for _, s := range ss {
+		confirmed, err := e.hasBlockConfirmed(ctx, s.RequestTxHash, e.chainID())
+		if err != nil {
+			util.Logger.Warning(errors.Wrap(err, "[Engine.filterERC20ConfirmedSwapEvents]: failed to check block confirmation"))
+			continue
+		}
+		if confirmed {
+			events = append(events, s)
+		}
+	}
+
+	return events, nil
+}
+
+// rejectERC20UnsupportedSwaps moves the Swaps towards a chain which is not configured or has no ERC20 swap agent to the
+// unsupported destination state and returns the others
+func (e *Engine) rejectERC20UnsupportedSwaps(ctx context.Context, ss []*erc20.Swap, loopName string) []*erc20.Swap {
+	var supported []*erc20.Swap
+	for _, s := range ss {
+		_, hasAgent := e.deps.ERC20SwapAgent[s.DstChainID]
+		if e.isSupportedChain(s.DstChainID) && hasAgent {
+			supported = append(supported, s)
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		s.State = erc20.SwapStateUnsupportedDestination
+		s.MessageLog = fmt.Sprintf("destination chain %s is not configured", s.DstChainID)
+		if e.isSupportedChain(s.DstChainID) {
+			s.MessageLog = fmt.Sprintf("destination chain %s has no ERC20 swap agent", s.DstChainID)
+		}
+		if err := e.save(ctx, s, loopName); err != nil {
+			logSaveError(err, "[Engine.rejectERC20UnsupportedSwaps]: failed to update Swap %s to state '%s'", s.ID, s.State)
+		}
+	}
+
+	return supported
+}
@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"context"
+
+	"math"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// manageERC721BatchConfirmedSwap sends a fill tx for the next chunk of the token ids which are not filled yet
+func (e *Engine) manageERC721BatchConfirmedSwap(ctx context.Context) {
+	fromChainID := e.chainID()
+	ss, err := e.queryERC721BatchSwap(ctx, fromChainID, []erc721.SwapState{
+		erc721.SwapStateRequestConfirmed,
+	})
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC721BatchConfirmedSwap]: failed to query confirmed BatchSwaps"))
+		return
+	}
+
+	ss = e.rejectERC721UnsupportedBatchSwaps(ctx, ss, "manageERC721BatchConfirmedSwap")
+
+	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// once picked, the fill tx is sent and its state saved even if the engine is stopping meanwhile
+		ctx := util.WithoutCancel(ctx)
+
+		remaining, err := s.RemainingIDs()
+		if err != nil {
+			util.Logger.Error(errors.Wrapf(err, "[Engine.manageERC721BatchConfirmedSwap]: failed to get remaining token ids of BatchSwap %s", s.ID))
+			continue
+		}
+
+		dryRun, chunk, err := e.nextERC721BatchChunk(ctx, s, remaining)
+		if err != nil {
+			// this error might comes from gas estimation, so it means we cannot send the real tx to the chain
+			util.Logger.Warningf("[Engine.manageERC721BatchConfirmedSwap]: failed to dry run tx of BatchSwap %s", s.ID)
+
+			s.State = erc721.SwapStateFillTxDryRunFailed
+			s.MessageLog = err.Error()
+			if err := e.save(ctx, s, "manageERC721BatchConfirmedSwap"); err != nil {
+				logSaveError(err, "[Engine.manageERC721BatchConfirmedSwap]: failed to update BatchSwap %s to '%s' state", s.ID, s.State)
+			}
+
+			continue
+		}
+
+		// We save the tx with its chunk as our checkpoint to probe the stats later
+		// It tells that this tx might be sent or might not, but it is okay
+		// We will set the state to failed later
+		if err := s.SetChunk(chunk); err != nil {
+			util.Logger.Error(errors.Wrapf(err, "[Engine.manageERC721BatchConfirmedSwap]: failed to set chunk of BatchSwap %s", s.ID))
+			continue
+		}
+		s.State = erc721.SwapStateFillTxCreated
+		s.FillTxHash = dryRun.Hash().String()
+		s.FillHeight = math.MaxInt64
+		if err := e.save(ctx, s, "manageERC721BatchConfirmedSwap"); err != nil {
+			logSaveError(err, "[Engine.manageERC721BatchConfirmedSwap]: failed to update BatchSwap %s to '%s' state", s.ID, s.State)
+
+			continue
+		}
+
+		util.Logger.Infof(
+			"[Engine.manageERC721BatchConfirmedSwap]: sent dry run tx to chain id %s, %s, %d of %d remaining tokens",
+			e.chainID(),
+			s.FillTxHash,
+			len(chunk),
+			len(remaining),
+		)
+
+		request, err := e.sendERC721BatchFillRequest(ctx, s, chunk, false)
+		if err != nil {
+			// retry when a transaction is attempted to be replaced
+			// with a different one without the required price bump.
+			if errors.Cause(err).Error() == core.ErrReplaceUnderpriced.Error() {
+				s.State = erc721.SwapStateRequestConfirmed
+				s.MessageLog = err.Error()
+				if dbErr := e.save(ctx, s, "manageERC721BatchConfirmedSwap"); dbErr != nil {
+					logSaveError(dbErr, "[Engine.manageERC721BatchConfirmedSwap]: failed to update BatchSwap %s to '%s' state", s.ID, s.State)
+
+					continue
+				}
+			}
+
+			s.State = erc721.SwapStateFillTxFailed
+			s.MessageLog = err.Error()
+			if dbErr := e.save(ctx, s, "manageERC721BatchConfirmedSwap"); dbErr != nil {
+				logSaveError(dbErr, "[Engine.manageERC721BatchConfirmedSwap]: failed to update BatchSwap %s to '%s' state", s.ID, s.State)
+
+				continue
+			}
+
+			util.Logger.Error(
+				errors.Wrapf(err, "[Engine.manageERC721BatchConfirmedSwap]: failed to send a real tx %s of BatchSwap %s", s.FillTxHash, s.ID),
+			)
+
+			continue
+		}
+
+		util.Logger.Infof(
+			"[Engine.manageERC721BatchConfirmedSwap]: sent tx to chain id %s, %s/%s",
+			e.chainID(),
+			e.conf.ExplorerURL,
+			request.Hash().String(),
+		)
+
+		// update tx hash again in case there are some parameters might change tx hash
+		// for example, gas limit which comes from estimation
+		s.FillTxHash = request.Hash().String()
+		if dbErr := e.save(ctx, s, "manageERC721BatchConfirmedSwap"); dbErr != nil {
+			logSaveError(dbErr, "[Engine.manageERC721BatchConfirmedSwap]: failed to update BatchSwap %s fill tx hash %s right after sending out", s.ID, s.FillTxHash)
+
+			continue
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+func (e *Engine) manageERC721BatchOngoingRequest(ctx context.Context) {
+	fromChainID := e.chainID()
+	ss, err := e.queryERC721BatchSwap(ctx, fromChainID, []erc721.SwapState{
+		erc721.SwapStateRequestOngoing,
+	})
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC721BatchOngoingRequest]: failed to query onging BatchSwaps"))
+		return
+	}
+
+	ss = e.rejectERC721UnsupportedBatchSwaps(ctx, ss, "manageERC721BatchOngoingRequest")
+
+	// Fill required information without updating to DB
+	if err := e.fillERC721BatchRequiredInfo(ctx, ss); err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC721BatchOngoingRequest]: failed to fill destination"))
+		return
+	}
+
+	// Separate ready BatchSwaps, pending BatchSwaps, and rejected BatchSwaps
+	ss, pp, rr := e.separateERC721BatchSwapEvents(ss)
+	for _, r := range rr {
+		if ctx.Err() != nil {
+			return
+		}
+
+		r.State = erc721.SwapStateRequestRejected
+		if err := e.save(ctx, r, "manageERC721BatchOngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC721BatchOngoingRequest]: failed to update BatchSwap %s to state '%s'", r.ID, r.State)
+		}
+	}
+	for _, p := range pp {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := e.save(ctx, p, "manageERC721BatchOngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC721BatchOngoingRequest]: failed to update BatchSwap %s", p.ID)
+		}
+	}
+
+	ss, err = e.filterERC721BatchConfirmedSwapEvents(ctx, ss)
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC721BatchOngoingRequest]: failed to filter confirmed BatchSwaps"))
+		return
+	}
+
+	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.State = erc721.SwapStateRequestConfirmed
+		if err := e.save(ctx, s, "manageERC721BatchOngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC721BatchOngoingRequest]: failed to update BatchSwap %s to state '%s'", s.ID, s.State)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// manageERC721BatchTxSentSwap records the confirmed chunk of a BatchSwap, the BatchSwap goes back to request_confirmed
+// for its next chunk until every token is filled
+func (e *Engine) manageERC721BatchTxSentSwap(ctx context.Context) {
+	fromChainID := e.chainID()
+	ss, err := e.queryERC721BatchSwap(ctx, fromChainID, []erc721.SwapState{
+		erc721.SwapStateFillTxSent,
+	})
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC721BatchTxSentSwap]: failed to query tx_sent BatchSwaps"))
+		return
+	}
+
+	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
+
+		confirmed, err := e.hasBlockConfirmed(ctx, s.FillTxHash, s.DstChainID)
+		if err != nil {
+			util.Logger.Error(
+				errors.Wrapf(err, "[Engine.manageERC721BatchTxSentSwap]: failed to check block confirmation for BatchSwap %s", s.ID),
+			)
+
+			continue
+		}
+
+		if !confirmed {
+			continue
+		}
+
+		if err := s.AddFill(); err != nil {
+			util.Logger.Error(errors.Wrapf(err, "[Engine.manageERC721BatchTxSentSwap]: failed to add fill of BatchSwap %s", s.ID))
+			continue
+		}
+		remaining, err := s.RemainingIDs()
+		if err != nil {
+			util.Logger.Error(errors.Wrapf(err, "[Engine.manageERC721BatchTxSentSwap]: failed to get remaining token ids of BatchSwap %s", s.ID))
+			continue
+		}
+		ids, err := s.TokenIDs()
+		if err != nil {
+			util.Logger.Error(errors.Wrapf(err, "[Engine.manageERC721BatchTxSentSwap]: failed to get token ids of BatchSwap %s", s.ID))
+			continue
+		}
+
+		s.MessageLog = fmt.Sprintf("filled %d of %d tokens", len(ids)-len(remaining), len(ids))
+		if len(remaining) == 0 {
+			s.State = erc721.SwapStateFillTxConfirmed
+		} else {
+			// the confirmed chunk is kept in the fills, the fill fields are reset for the next chunk
+			s.State = erc721.SwapStateRequestConfirmed
+			s.FillConsumedFeeAmount = ""
+			s.FillGasPrice = ""
+			s.FillGasUsed = 0
+			s.FillHeight = math.MaxInt64
+			s.FillTxHash = ""
+			s.FillTrackRetry = 0
+			s.FillBlockHash = ""
+			s.FillBlockLogID = nil
+		}
+		if err := e.save(ctx, s, "manageERC721BatchTxSentSwap"); err != nil {
+			logSaveError(err, "[Engine.manageERC721BatchTxSentSwap]: failed to update BatchSwap %s to '%s' state", s.ID, s.State)
+
+			continue
+		}
+
+		util.Logger.Infof("[Engine.manageERC721BatchTxSentSwap]: updated BatchSwap %s state to '%s'", s.ID, s.State)
+	}
+}
@@ -0,0 +1,300 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+	"gorm.io/gorm"
+)
+
+// batchFillGasShare is the share of the block gas limit a batch fill tx may use, a chunk using more is halved
+const batchFillGasShare = 2
+
+// sendERC721BatchFillRequest sends transaction to fill a chunk of token ids of a batch swap on destination chain
+func (e *Engine) sendERC721BatchFillRequest(ctx context.Context, s *erc721.BatchSwap, ids []string, dryRun bool) (*types.Transaction, error) {
+	dstChainID := s.DstChainID
+	dstChainIDInt, err := util.ParseBigInt(dstChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC721BatchFillRequest]: invalid destination chain id of BatchSwap %s", s.ID)
+	}
+	if _, ok := e.deps.Client[dstChainID]; !ok {
+		return nil, errors.Errorf("[Engine.sendERC721BatchFillRequest]: client for chain id %s is not supported", dstChainID)
+	}
+	if _, ok := e.deps.ERC721BatchSwapAgent[dstChainID]; !ok {
+		return nil, errors.Errorf("[Engine.sendERC721BatchFillRequest]: batch swap agent for chain id %s is not configured", dstChainID)
+	}
+
+	tokenURIs := make([]string, len(ids))
+	if s.SwapDirection == erc721.SwapDirectionForward {
+		for i, id := range ids {
+			uri, err := s.TokenURI(id)
+			if err != nil {
+				return nil, errors.Wrap(err, "[Engine.sendERC721BatchFillRequest]: failed to get token uri")
+			}
+			tokenURIs[i] = uri
+		}
+	}
+
+	txOpts, err := util.TxOpts(ctx, e.deps.Client[dstChainID], e.conf.PrivateKey, dstChainIDInt)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.sendERC721BatchFillRequest]: failed to create tx opts")
+	}
+
+	tokenAddr := s.SrcTokenAddr
+	if s.SwapDirection == erc721.SwapDirectionBackward {
+		tokenAddr = s.DstTokenAddr
+	}
+
+	srcChainIDInt, err := util.ParseBigInt(s.SrcChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC721BatchFillRequest]: invalid source chain id of BatchSwap %s", s.ID)
+	}
+	idInts, err := util.ParseBigIntSlice(ids)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC721BatchFillRequest]: invalid token ids of BatchSwap %s", s.ID)
+	}
+
+	txOpts.NoSend = dryRun
+	tx, err := e.deps.ERC721BatchSwapAgent[dstChainID].BatchFill(
+		txOpts,
+		common.HexToHash(s.RequestTxHash),
+		common.HexToAddress(tokenAddr),
+		common.HexToAddress(s.Recipient),
+		srcChainIDInt,
+		idInts,
+		tokenURIs,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.sendERC721BatchFillRequest]: failed to send batch fill tx")
+	}
+
+	return tx, nil
+}
+
+// nextERC721BatchChunk dry runs a fill of the remaining token ids, at most ERC721BatchFillSize of them, halving them
+// until the fill tx fits in the share of the block gas limit of the destination chain. It returns the dry run tx and
+// its chunk of token ids.
+func (e *Engine) nextERC721BatchChunk(ctx context.Context, s *erc721.BatchSwap, remaining []string) (*types.Transaction, []string, error) {
+	if _, ok := e.deps.Client[s.DstChainID]; !ok {
+		return nil, nil, errors.Errorf("[Engine.nextERC721BatchChunk]: client for chain id %s is not supported", s.DstChainID)
+	}
+
+	header, err := e.deps.Client[s.DstChainID].HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "[Engine.nextERC721BatchChunk]: failed to get the latest header")
+	}
+	maxGas := header.GasLimit / batchFillGasShare
+
+	n := len(remaining)
+	if e.conf.ERC721BatchFillSize > 0 && n > e.conf.ERC721BatchFillSize {
+		n = e.conf.ERC721BatchFillSize
+	}
+	for {
+		tx, err := e.sendERC721BatchFillRequest(ctx, s, remaining[:n], true)
+		if err == nil && tx.Gas() <= maxGas {
+			return tx, remaining[:n], nil
+		}
+		if n == 1 {
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "[Engine.nextERC721BatchChunk]: failed to dry run a fill of a single token")
+			}
+
+			return nil, nil, errors.Errorf("[Engine.nextERC721BatchChunk]: a fill of a single token needs %d gas, more than %d", tx.Gas(), maxGas)
+		}
+
+		n = (n + 1) / 2
+	}
+}
+
+// queryERC721BatchSwap queries BatchSwap this engine is responsible
+func (e *Engine) queryERC721BatchSwap(ctx context.Context, fromChainID string, states []erc721.SwapState) ([]*erc721.BatchSwap, error) {
+	var ss []*erc721.BatchSwap
+	err := e.deps.DB.WithContext(ctx).Where(
+		"state in ? and src_chain_id = ?",
+		states,
+		fromChainID,
+	).Order(
+		"request_height asc",
+	).Limit(
+		querySwapLimit,
+	).Find(&ss).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.queryERC721BatchSwap]: failed to query BatchSwap")
+	}
+
+	return ss, nil
+}
+
+// fillERC721BatchRequiredInfo fills batch swap destination tokens
+func (e *Engine) fillERC721BatchRequiredInfo(ctx context.Context, ss []*erc721.BatchSwap) error {
+	for _, s := range ss {
+		if s.IsRequiredInfoValid() {
+			continue
+		}
+
+		s.RequestTrackRetry += 1
+
+		var skip bool
+		var err error
+		if s.SwapDirection == erc721.SwapDirectionForward {
+			skip, err = e.fillERC721BatchForward(ctx, s)
+		} else {
+			skip, err = e.fillERC721BatchBackward(ctx, s)
+		}
+
+		if skip {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "[Engine.fillERC721BatchRequiredInfo]: failed to fill required information")
+		}
+	}
+
+	return nil
+}
+
+func (e *Engine) fillERC721BatchForward(ctx context.Context, s *erc721.BatchSwap) (skip bool, err error) {
+	var sp erc721.SwapPair
+	err = e.deps.DB.WithContext(ctx).Where(
+		"src_token_addr = ? and src_chain_id = ? and dst_chain_id = ? and available = ?",
+		s.SrcTokenAddr,
+		s.SrcChainID,
+		s.DstChainID,
+		true,
+	).First(&sp).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "[Engine.fillERC721BatchForward]: failed to query available Swaps")
+	}
+
+	ids, err := s.TokenIDs()
+	if err != nil {
+		return false, errors.Wrap(err, "[Engine.fillERC721BatchForward]: failed to get token ids")
+	}
+
+	tokenURIs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if sp.BaseURI != "" {
+			tokenURIs = append(tokenURIs, id)
+			continue
+		}
+
+		tokenURI, err := e.retrieveERC721TokenURI(ctx, s.SrcTokenAddr, id, s.SrcChainID)
+		if err != nil {
+			return false, errors.Wrapf(err, "[Engine.fillERC721BatchForward]: failed to retrieve token uri of token %s, chain id %s", s.SrcTokenAddr, s.SrcChainID)
+		}
+		if tokenURI == "" {
+			util.Logger.Infof("[Engine.fillERC721BatchForward]: token %s #%s, chain id %s has no token uri", s.SrcTokenAddr, id, s.SrcChainID)
+		}
+		tokenURIs = append(tokenURIs, tokenURI)
+	}
+
+	err = s.SetRequiredInfo(
+		sp.SrcTokenName,
+		sp.DstTokenName,
+		sp.DstTokenAddr,
+		sp.BaseURI,
+		tokenURIs,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "[Engine.fillERC721BatchForward]: failed to set required information")
+	}
+
+	return false, nil
+}
+
+func (e *Engine) fillERC721BatchBackward(ctx context.Context, s *erc721.BatchSwap) (skip bool, err error) {
+	var sp erc721.SwapPair
+	err = e.deps.DB.WithContext(ctx).Where(
+		"dst_token_addr = ? and dst_chain_id = ? and src_chain_id = ? and available = ?",
+		s.SrcTokenAddr,
+		s.SrcChainID,
+		s.DstChainID,
+		true,
+	).First(&sp).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "[Engine.fillERC721BatchBackward]: failed to query available Swaps")
+	}
+
+	err = s.SetRequiredInfo(
+		sp.DstTokenName,
+		sp.SrcTokenName,
+		sp.SrcTokenAddr,
+		"",
+		nil,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "[Engine.fillERC721BatchBackward]: failed to set required information")
+	}
+
+	return false, nil
+}
+
+func (e *Engine) separateERC721BatchSwapEvents(ss []*erc721.BatchSwap) (pass []*erc721.BatchSwap, pending []*erc721.BatchSwap, rejected []*erc721.BatchSwap) {
+	for _, s := range ss {
+		if !s.IsRequiredInfoValid() {
+			if s.RequestTrackRetry > e.conf.MaxTrackRetry {
+				rejected = append(rejected, s)
+			} else {
+				pending = append(pending, s)
+			}
+
+			continue
+		}
+
+		pass = append(pass, s)
+	}
+
+	return
+}
+
+// filterERC721BatchConfirmedSwapEvents checks block confirmation of the chain this engine is responsible
+func (e *Engine) filterERC721BatchConfirmedSwapEvents(ctx context.Context, ss []*erc721.BatchSwap) (events []*erc721.BatchSwap, err error) {
+	for _, s := range ss {
+		confirmed, err := e.hasBlockConfirmed(ctx, s.RequestTxHash, e.chainID())
+		if err != nil {
+			util.Logger.Warning(errors.Wrap(err, "[Engine.filterERC721BatchConfirmedSwapEvents]: failed to check block confirmation"))
+			continue
+		}
+		if confirmed {
+			events = append(events, s)
+		}
+	}
+
+	return events, nil
+}
+
+// rejectERC721UnsupportedBatchSwaps moves the BatchSwaps towards a chain which is not configured to the unsupported
+// destination state and returns the others
+func (e *Engine) rejectERC721UnsupportedBatchSwaps(ctx context.Context, ss []*erc721.BatchSwap, loopName string) []*erc721.BatchSwap {
+	var supported []*erc721.BatchSwap
+	for _, s := range ss {
+		if e.isSupportedChain(s.DstChainID) {
+			supported = append(supported, s)
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		s.State = erc721.SwapStateUnsupportedDestination
+		s.MessageLog = fmt.Sprintf("destination chain %s is not configured", s.DstChainID)
+		if err := e.save(ctx, s, loopName); err != nil {
+			logSaveError(err, "[Engine.rejectERC721UnsupportedBatchSwaps]: failed to update BatchSwap %s to state '%s'", s.ID, s.State)
+		}
+	}
+
+	return supported
+}
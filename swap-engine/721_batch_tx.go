@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// verifyERC721BatchForwardSwapFillEvent checks that the fill tx of a chunk emitted a batch fill event, a batch swap
+// is filled by several txs so the event is matched by the fill tx hash as well
+func (e *Engine) verifyERC721BatchForwardSwapFillEvent(ctx context.Context, height uint64, requestTxHash, fillTxHash, chainID string) (bool, error) {
+	if _, ok := e.deps.Client[chainID]; !ok {
+		return false, errors.Errorf("[Engine.verifyERC721BatchForwardSwapFillEvent]: client for chain id %s is not supported", chainID)
+	}
+
+	opts := bind.FilterOpts{
+		Start:   height,
+		End:     &height,
+		Context: ctx,
+	}
+	txHash := [32]byte(common.HexToHash(requestTxHash))
+	iter, err := e.deps.ERC721BatchSwapAgent[chainID].FilterBatchSwapFilled(&opts, [][32]byte{txHash}, nil, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "[Engine.verifyERC721BatchForwardSwapFillEvent]: failed to filter logs")
+	}
+	defer func() {
+		if err := iter.Close(); err != nil {
+			util.Logger.Errorf("[Engine.verifyERC721BatchForwardSwapFillEvent]: failed to close iterator, %s", err.Error())
+		}
+	}()
+
+	for iter.Next() {
+		if iter.Event.Raw.TxHash == common.HexToHash(fillTxHash) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// verifyERC721BatchBackwardSwapFillEvent checks that the fill tx of a chunk emitted a batch backward fill event
+func (e *Engine) verifyERC721BatchBackwardSwapFillEvent(ctx context.Context, height uint64, requestTxHash, fillTxHash, chainID string) (bool, error) {
+	if _, ok := e.deps.Client[chainID]; !ok {
+		return false, errors.Errorf("[Engine.verifyERC721BatchBackwardSwapFillEvent]: client for chain id %s is not supported", chainID)
+	}
+
+	opts := bind.FilterOpts{
+		Start:   height,
+		End:     &height,
+		Context: ctx,
+	}
+	txHash := [32]byte(common.HexToHash(requestTxHash))
+	iter, err := e.deps.ERC721BatchSwapAgent[chainID].FilterBatchBackwardSwapFilled(&opts, [][32]byte{txHash}, nil, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "[Engine.verifyERC721BatchBackwardSwapFillEvent]: failed to filter logs")
+	}
+	defer func() {
+		if err := iter.Close(); err != nil {
+			util.Logger.Errorf("[Engine.verifyERC721BatchBackwardSwapFillEvent]: failed to close iterator, %s", err.Error())
+		}
+	}()
+
+	for iter.Next() {
+		if iter.Event.Raw.TxHash == common.HexToHash(fillTxHash) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
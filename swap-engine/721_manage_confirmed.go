@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+
 	"math"
 
 	"github.com/ethereum/go-ethereum/core"
@@ -10,9 +12,9 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC721ConfirmedSwap() {
+func (e *Engine) manageERC721ConfirmedSwap(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC721Swap(fromChainID, []erc721.SwapState{
+	ss, err := e.queryERC721Swap(ctx, fromChainID, []erc721.SwapState{
 		erc721.SwapStateRequestConfirmed,
 	})
 	if err != nil {
@@ -20,18 +22,26 @@ func (e *Engine) manageERC721ConfirmedSwap() {
 		return
 	}
 
+	ss = e.rejectERC721UnsupportedSwaps(ctx, ss, "manageERC721ConfirmedSwap")
+	ss = e.batchERC721Fills(ctx, ss, "manageERC721ConfirmedSwap")
+
 	for _, s := range ss {
-		txHash, err := e.generateERC721TxHash(s)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// once picked, the fill tx is sent and its state saved even if the engine is stopping meanwhile
+		ctx := util.WithoutCancel(ctx)
+
+		txHash, err := e.generateERC721TxHash(ctx, s)
 		if err != nil {
 			// this error might comes from gas estimation, so it means we cannot send the real tx to the chain
 			util.Logger.Warningf("[Engine.manageERC721ConfirmedSwap]: failed to dry run tx of Swap %s", s.ID)
 
 			s.State = erc721.SwapStateFillTxDryRunFailed
 			s.MessageLog = err.Error()
-			if err := e.deps.DB.Save(s).Error; err != nil {
-				util.Logger.Error(
-					errors.Wrapf(err, "[Engine.manageERC721ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State),
-				)
+			if err := e.save(ctx, s, "manageERC721ConfirmedSwap"); err != nil {
+				logSaveError(err, "[Engine.manageERC721ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 			}
 
 			continue
@@ -43,10 +53,8 @@ func (e *Engine) manageERC721ConfirmedSwap() {
 		s.State = erc721.SwapStateFillTxCreated
 		s.FillTxHash = txHash
 		s.FillHeight = math.MaxInt64
-		if err := e.deps.DB.Save(s).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC721ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State),
-			)
+		if err := e.save(ctx, s, "manageERC721ConfirmedSwap"); err != nil {
+			logSaveError(err, "[Engine.manageERC721ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 
 			continue
 		}
@@ -57,17 +65,15 @@ func (e *Engine) manageERC721ConfirmedSwap() {
 			txHash,
 		)
 
-		request, err := e.sendERC721FillSwapRequest(s, false)
+		request, err := e.sendERC721FillSwapRequest(ctx, s, false)
 		if err != nil {
 			// retry when a transaction is attempted to be replaced
 			// with a different one without the required price bump.
 			if errors.Cause(err).Error() == core.ErrReplaceUnderpriced.Error() {
 				s.State = erc721.SwapStateRequestConfirmed
 				s.MessageLog = err.Error()
-				if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-					util.Logger.Error(
-						errors.Wrapf(dbErr, "[Engine.manageERC721ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State),
-					)
+				if dbErr := e.save(ctx, s, "manageERC721ConfirmedSwap"); dbErr != nil {
+					logSaveError(dbErr, "[Engine.manageERC721ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 
 					continue
 				}
@@ -75,10 +81,8 @@ func (e *Engine) manageERC721ConfirmedSwap() {
 
 			s.State = erc721.SwapStateFillTxFailed
 			s.MessageLog = err.Error()
-			if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-				util.Logger.Error(
-					errors.Wrapf(dbErr, "[Engine.manageERC721ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State),
-				)
+			if dbErr := e.save(ctx, s, "manageERC721ConfirmedSwap"); dbErr != nil {
+				logSaveError(dbErr, "[Engine.manageERC721ConfirmedSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 
 				continue
 			}
@@ -100,10 +104,8 @@ func (e *Engine) manageERC721ConfirmedSwap() {
 		// update tx hash again in case there are some parameters might change tx hash
 		// for example, gas limit which comes from estimation
 		s.FillTxHash = request.Hash().String()
-		if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-			util.Logger.Error(
-				errors.Wrapf(dbErr, "[Engine.manageERC721ConfirmedSwap]: failed to update Swap %s fill tx hash %s right after sending out", s.ID, s.FillTxHash),
-			)
+		if dbErr := e.save(ctx, s, "manageERC721ConfirmedSwap"); dbErr != nil {
+			logSaveError(dbErr, "[Engine.manageERC721ConfirmedSwap]: failed to update Swap %s fill tx hash %s right after sending out", s.ID, s.FillTxHash)
 
 			continue
 		}
@@ -1,15 +1,17 @@
 package engine
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC721OngoingRequest() {
+func (e *Engine) manageERC721OngoingRequest(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC721Swap(fromChainID, []erc721.SwapState{
+	ss, err := e.queryERC721Swap(ctx, fromChainID, []erc721.SwapState{
 		erc721.SwapStateRequestOngoing,
 	})
 	if err != nil {
@@ -17,8 +19,10 @@ func (e *Engine) manageERC721OngoingRequest() {
 		return
 	}
 
+	ss = e.rejectERC721UnsupportedSwaps(ctx, ss, "manageERC721OngoingRequest")
+
 	// Fill required information without updating to DB
-	if err := e.fillERC721RequiredInfo(ss); err != nil {
+	if err := e.fillERC721RequiredInfo(ctx, ss); err != nil {
 		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC721OngoingRequest]: failed to fill destination"))
 		return
 	}
@@ -26,33 +30,39 @@ func (e *Engine) manageERC721OngoingRequest() {
 	// Separate ready Swaps, pending Swaps, and rejected Swaps
 	ss, pp, rr := e.separateERC721SwapEvents(ss)
 	for _, r := range rr {
+		if ctx.Err() != nil {
+			return
+		}
+
 		r.State = erc721.SwapStateRequestRejected
-		if err := e.deps.DB.Save(&r).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC721OngoingRequest]: failed to update Swap %s to state '%s'", r.ID, r.State),
-			)
+		if err := e.save(ctx, r, "manageERC721OngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC721OngoingRequest]: failed to update Swap %s to state '%s'", r.ID, r.State)
 		}
 	}
 	for _, p := range pp {
-		if err := e.deps.DB.Save(&p).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC721OngoingRequest]: failed to update Swap %s", p.ID),
-			)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := e.save(ctx, p, "manageERC721OngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC721OngoingRequest]: failed to update Swap %s", p.ID)
 		}
 	}
 
-	ss, err = e.filterERC721ConfirmedSwapEvents(ss)
+	ss, err = e.filterERC721ConfirmedSwapEvents(ctx, ss)
 	if err != nil {
 		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC721OngoingRequest]: failed to filter confirmed Swaps"))
 		return
 	}
 
 	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
+
 		s.State = erc721.SwapStateRequestConfirmed
-		if err := e.deps.DB.Save(&s).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC721OngoingRequest]: failed to update Swap %s to state '%s'", s.ID, s.State),
-			)
+		if err := e.save(ctx, s, "manageERC721OngoingRequest"); err != nil {
+			logSaveError(err, "[Engine.manageERC721OngoingRequest]: failed to update Swap %s to state '%s'", s.ID, s.State)
 		}
 	}
 }
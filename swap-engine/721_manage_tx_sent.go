@@ -1,15 +1,17 @@
 package engine
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC721TxSentSwap() {
+func (e *Engine) manageERC721TxSentSwap(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC721Swap(fromChainID, []erc721.SwapState{
+	ss, err := e.queryERC721Swap(ctx, fromChainID, []erc721.SwapState{
 		erc721.SwapStateFillTxSent,
 	})
 	if err != nil {
@@ -17,9 +19,12 @@ func (e *Engine) manageERC721TxSentSwap() {
 		return
 	}
 
-	var ids []string
 	for _, s := range ss {
-		confirmed, err := e.hasBlockConfirmed(s.FillTxHash, s.DstChainID)
+		if ctx.Err() != nil {
+			return
+		}
+
+		confirmed, err := e.hasBlockConfirmed(ctx, s.FillTxHash, s.DstChainID)
 		if err != nil {
 			util.Logger.Error(
 				errors.Wrapf(err, "[Engine.manageERC721TxSentSwap]: failed to check block confirmation for Swap %s", s.ID),
@@ -28,24 +33,17 @@ func (e *Engine) manageERC721TxSentSwap() {
 			continue
 		}
 
-		if confirmed {
-			ids = append(ids, s.ID)
+		if !confirmed {
+			continue
 		}
-	}
 
-	if len(ids) == 0 {
-		return
-	}
+		s.State = erc721.SwapStateFillTxConfirmed
+		if err := e.save(ctx, s, "manageERC721TxSentSwap"); err != nil {
+			logSaveError(err, "[Engine.manageERC721TxSentSwap]: failed to update Swap %s to '%s' state", s.ID, s.State)
 
-	if err := e.deps.DB.Model(&ss).Where("id in ?", ids).Updates(map[string]interface{}{
-		"state": erc721.SwapStateFillTxConfirmed,
-	}).Error; err != nil {
-		util.Logger.Error(
-			errors.Wrapf(err, "[Engine.manageERC721TxSentSwap]: failed to update state '%s'", erc721.SwapStateFillTxConfirmed),
-		)
-	}
+			continue
+		}
 
-	for _, s := range ss {
 		util.Logger.Infof("[Engine.manageERC721TxSentSwap]: updated Swap %s state to '%s'", s.ID, s.State)
 	}
 }
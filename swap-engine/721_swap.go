@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -17,8 +18,8 @@ var (
 	querySwapLimit      = 50
 )
 
-func (e *Engine) generateERC721TxHash(s *erc721.Swap) (string, error) {
-	request, err := e.sendERC721FillSwapRequest(s, true)
+func (e *Engine) generateERC721TxHash(ctx context.Context, s *erc721.Swap) (string, error) {
+	request, err := e.sendERC721FillSwapRequest(ctx, s, true)
 	if err != nil {
 		return "", errors.Wrap(err, "[Engine.generateERC721TxHash]: failed to dry run sending a request")
 	}
@@ -27,9 +28,12 @@ func (e *Engine) generateERC721TxHash(s *erc721.Swap) (string, error) {
 }
 
 // sendERC721FillSwapRequest sends transaction to fill a swap on destination chain
-func (e *Engine) sendERC721FillSwapRequest(s *erc721.Swap, dryRun bool) (*types.Transaction, error) {
+func (e *Engine) sendERC721FillSwapRequest(ctx context.Context, s *erc721.Swap, dryRun bool) (*types.Transaction, error) {
 	dstChainID := s.DstChainID
-	dstChainIDInt := util.StrToBigInt(dstChainID)
+	dstChainIDInt, err := util.ParseBigInt(dstChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC721FillSwapRequest]: invalid destination chain id of Swap %s", s.ID)
+	}
 	if _, ok := e.deps.Client[dstChainID]; !ok {
 		return nil, errors.Errorf("[Engine.sendERC721FillSwapRequest]: client for chain id %s is not supported", dstChainID)
 	}
@@ -37,7 +41,6 @@ func (e *Engine) sendERC721FillSwapRequest(s *erc721.Swap, dryRun bool) (*types.
 		return nil, errors.Errorf("[Engine.sendERC721FillSwapRequest]: swap agent for chain id %s is not supported", dstChainID)
 	}
 
-	ctx := context.Background()
 	txOpts, err := util.TxOpts(ctx, e.deps.Client[dstChainID], e.conf.PrivateKey, dstChainIDInt)
 	if err != nil {
 		return nil, errors.Wrap(err, "[Engine.sendERC721FillSwapRequest]: failed to create tx opts")
@@ -50,14 +53,23 @@ func (e *Engine) sendERC721FillSwapRequest(s *erc721.Swap, dryRun bool) (*types.
 		tokenChainID = s.SrcChainID
 	}
 
+	tokenChainIDInt, err := util.ParseBigInt(tokenChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC721FillSwapRequest]: invalid token chain id of Swap %s", s.ID)
+	}
+	tokenID, err := util.ParseBigInt(s.TokenID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC721FillSwapRequest]: invalid token id of Swap %s", s.ID)
+	}
+
 	txOpts.NoSend = dryRun
 	tx, err := e.deps.ERC721SwapAgent[dstChainID].Fill(
 		txOpts,
 		common.HexToHash(s.RequestTxHash),
 		common.HexToAddress(tokenAddr),
 		common.HexToAddress(s.Recipient),
-		util.StrToBigInt(tokenChainID),
-		util.StrToBigInt(s.TokenID),
+		tokenChainIDInt,
+		tokenID,
 		s.TokenURI,
 	)
 	if err != nil {
@@ -68,10 +80,10 @@ func (e *Engine) sendERC721FillSwapRequest(s *erc721.Swap, dryRun bool) (*types.
 }
 
 // queryERC721Swap queries Swap this engine is responsible
-func (e *Engine) queryERC721Swap(fromChainID string, states []erc721.SwapState) ([]*erc721.Swap, error) {
+func (e *Engine) queryERC721Swap(ctx context.Context, fromChainID string, states []erc721.SwapState) ([]*erc721.Swap, error) {
 	// TODO: check the index
 	var ss []*erc721.Swap
-	err := e.deps.DB.Where(
+	err := e.deps.DB.WithContext(ctx).Where(
 		"state in ? and src_chain_id = ?",
 		states,
 		fromChainID,
@@ -88,7 +100,7 @@ func (e *Engine) queryERC721Swap(fromChainID string, states []erc721.SwapState)
 }
 
 // fillERC721RequiredInfo fills swap destination tokens
-func (e *Engine) fillERC721RequiredInfo(ss []*erc721.Swap) error {
+func (e *Engine) fillERC721RequiredInfo(ctx context.Context, ss []*erc721.Swap) error {
 	for _, s := range ss {
 		if s.IsRequiredInfoValid() {
 			continue
@@ -99,9 +111,9 @@ func (e *Engine) fillERC721RequiredInfo(ss []*erc721.Swap) error {
 		var skip bool
 		var err error
 		if s.SwapDirection == erc721.SwapDirectionForward {
-			skip, err = e.fillERC721Forward(s)
+			skip, err = e.fillERC721Forward(ctx, s)
 		} else {
-			skip, err = e.fillERC721Backward(s)
+			skip, err = e.fillERC721Backward(ctx, s)
 		}
 
 		if skip {
@@ -115,10 +127,10 @@ func (e *Engine) fillERC721RequiredInfo(ss []*erc721.Swap) error {
 	return nil
 }
 
-func (e *Engine) fillERC721Forward(s *erc721.Swap) (skip bool, err error) {
+func (e *Engine) fillERC721Forward(ctx context.Context, s *erc721.Swap) (skip bool, err error) {
 	// TODO: check db index
 	var sp erc721.SwapPair
-	err = e.deps.DB.Where(
+	err = e.deps.DB.WithContext(ctx).Where(
 		"src_token_addr = ? and src_chain_id = ? and dst_chain_id = ? and available = ?",
 		s.SrcTokenAddr,
 		s.SrcChainID,
@@ -135,12 +147,12 @@ func (e *Engine) fillERC721Forward(s *erc721.Swap) (skip bool, err error) {
 
 	var tokenURI string
 	if sp.BaseURI == "" {
-		tokenURI, err = e.retrieveERC721TokenURI(s.SrcTokenAddr, s.TokenID, s.SrcChainID)
+		tokenURI, err = e.retrieveERC721TokenURI(ctx, s.SrcTokenAddr, s.TokenID, s.SrcChainID)
 		if err != nil {
-			return false, errors.Wrapf(err, "[Engine.fillERC721Forward]: failed to retrieve token uri of token %s, chain id %", s.SrcTokenAddr, s.SrcChainID)
+			return false, errors.Wrapf(err, "[Engine.fillERC721Forward]: failed to retrieve token uri of token %s, chain id %s", s.SrcTokenAddr, s.SrcChainID)
 		}
 		if tokenURI == "" {
-			util.Logger.Infof("[Engine.fillERC721Forward]: token %s, chain id % has no token uri", s.SrcTokenAddr, s.SrcChainID)
+			util.Logger.Infof("[Engine.fillERC721Forward]: token %s, chain id %s has no token uri", s.SrcTokenAddr, s.SrcChainID)
 		}
 	} else {
 		tokenURI = s.TokenID
@@ -157,10 +169,10 @@ func (e *Engine) fillERC721Forward(s *erc721.Swap) (skip bool, err error) {
 	return false, nil
 }
 
-func (e *Engine) fillERC721Backward(s *erc721.Swap) (skip bool, err error) {
+func (e *Engine) fillERC721Backward(ctx context.Context, s *erc721.Swap) (skip bool, err error) {
 	// TODO: check db index
 	var sp erc721.SwapPair
-	err = e.deps.DB.Where(
+	err = e.deps.DB.WithContext(ctx).Where(
 		"dst_token_addr = ? and dst_chain_id = ? and src_chain_id = ? and available = ?",
 		s.SrcTokenAddr,
 		s.SrcChainID,
@@ -205,9 +217,9 @@ func (e *Engine) separateERC721SwapEvents(ss []*erc721.Swap) (pass []*erc721.Swa
 }
 
 // filterERC721ConfirmedSwapEvents checks block confirmation of the chain this engine is responsible
-func (e *Engine) filterERC721ConfirmedSwapEvents(ss []*erc721.Swap) (events []*erc721.Swap, err error) {
+func (e *Engine) filterERC721ConfirmedSwapEvents(ctx context.Context, ss []*erc721.Swap) (events []*erc721.Swap, err error) {
 	for _, s := range ss {
-		confirmed, err := e.hasBlockConfirmed(s.RequestTxHash, e.chainID())
+		confirmed, err := e.hasBlockConfirmed(ctx, s.RequestTxHash, e.chainID())
 		if err != nil {
 			util.Logger.Warning(errors.Wrap(err, "[Engine.filterERC721ConfirmedSwapEvents]: failed to check block confirmation"))
 			continue
@@ -219,3 +231,26 @@ func (e *Engine) filterERC721ConfirmedSwapEvents(ss []*erc721.Swap) (events []*e
 
 	return events, nil
 }
+
+// rejectERC721UnsupportedSwaps moves the Swaps towards a chain which is not configured to the unsupported destination state and
+// returns the others
+func (e *Engine) rejectERC721UnsupportedSwaps(ctx context.Context, ss []*erc721.Swap, loopName string) []*erc721.Swap {
+	var supported []*erc721.Swap
+	for _, s := range ss {
+		if e.isSupportedChain(s.DstChainID) {
+			supported = append(supported, s)
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		s.State = erc721.SwapStateUnsupportedDestination
+		s.MessageLog = fmt.Sprintf("destination chain %s is not configured", s.DstChainID)
+		if err := e.save(ctx, s, loopName); err != nil {
+			logSaveError(err, "[Engine.rejectERC721UnsupportedSwaps]: failed to update Swap %s to state '%s'", s.ID, s.State)
+		}
+	}
+
+	return supported
+}
@@ -12,12 +12,11 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) verifyERC721ForwardSwapFillEvent(height uint64, requestTxHash, chainID string) (bool, error) {
+func (e *Engine) verifyERC721ForwardSwapFillEvent(ctx context.Context, height uint64, requestTxHash, chainID string) (bool, error) {
 	if _, ok := e.deps.Client[chainID]; !ok {
 		return false, errors.Errorf("[Engine.verifyERC721ForwardSwapFillEvent]: client for chain id %s is not supported", chainID)
 	}
 
-	ctx := context.Background()
 	opts := bind.FilterOpts{
 		Start:   height,
 		End:     &height,
@@ -37,12 +36,11 @@ func (e *Engine) verifyERC721ForwardSwapFillEvent(height uint64, requestTxHash,
 	return iter.Next(), nil
 }
 
-func (e *Engine) verifyERC721BackwardSwapFillEvent(height uint64, requestTxHash, chainID string) (bool, error) {
+func (e *Engine) verifyERC721BackwardSwapFillEvent(ctx context.Context, height uint64, requestTxHash, chainID string) (bool, error) {
 	if _, ok := e.deps.Client[chainID]; !ok {
 		return false, errors.Errorf("[Engine.verifyERC721BackwardSwapFillEvent]: client for chain id %s is not supported", chainID)
 	}
 
-	ctx := context.Background()
 	opts := bind.FilterOpts{
 		Start:   height,
 		End:     &height,
@@ -62,7 +60,7 @@ func (e *Engine) verifyERC721BackwardSwapFillEvent(height uint64, requestTxHash,
 	return iter.Next(), nil
 }
 
-func (e *Engine) retrieveERC721TokenURI(tokenAddr, tokenID, chainID string) (string, error) {
+func (e *Engine) retrieveERC721TokenURI(ctx context.Context, tokenAddr, tokenID, chainID string) (string, error) {
 	token, ok := e.deps.ERC721Token[chainID]
 	if !ok {
 		return "", errors.Errorf("[Engine.retrieveERC721TokenURI]: unsupported chain id %s", chainID)
@@ -70,8 +68,12 @@ func (e *Engine) retrieveERC721TokenURI(tokenAddr, tokenID, chainID string) (str
 
 	opts := &bind.CallOpts{
 		Pending: true,
+		Context: ctx,
+	}
+	tID, err := util.ParseBigInt(tokenID)
+	if err != nil {
+		return "", errors.Wrap(err, "[Engine.retrieveERC721TokenURI]: invalid token id")
 	}
-	tID := util.StrToBigInt(tokenID)
 	uri, err := token.TokenURI(opts, tokenAddr, tID)
 	if err != nil {
 		if strings.Contains(err.Error(), corecommon.ErrFunctionNotFound.Error()) {
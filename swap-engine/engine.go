@@ -2,13 +2,20 @@ package engine
 
 import (
 	"math/big"
+	"sync"
+	"time"
 
 	"gorm.io/gorm"
 
 	"github.com/ethereum/go-ethereum/common"
 	erc1155agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc1155"
+	erc20agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc20"
 	erc721agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc721"
+	fillbatchagent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/fillbatch"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/client"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/head"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/lease"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/notify"
 	recorder "github.com/synycboom/bsc-evm-compatible-bridge-core/recorder"
 	erc1155token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc1155"
 	erc721token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc721"
@@ -25,26 +32,53 @@ type Config struct {
 	MaxTrackRetry             int64
 	ERC721SwapAgentAddresses  map[string]common.Address
 	ERC1155SwapAgentAddresses map[string]common.Address
+	ERC20SwapAgentAddresses   map[string]common.Address
+	// ERC721BatchFillSize is the most tokens of a BatchSwap filled by a single tx, 0 for no limit but the gas
+	ERC721BatchFillSize int
+	// FillBatches holds the batching of the fills of the destination chains which have one, keyed by chain id
+	FillBatches map[string]*FillBatch
+	// SweepInterval is the delay between the runs of a loop woken up by notifications, the loops poll at their
+	// own delay without a notification bus or when it is 0
+	SweepInterval time.Duration
 }
 
 type Dependencies struct {
-	Client           map[string]client.ETHClient
-	DB               *gorm.DB
-	Recorder         map[string]recorder.IRecorder
-	ERC721SwapAgent  map[string]erc721agent.SwapAgent
-	ERC721Token      map[string]erc721token.IToken
-	ERC1155SwapAgent map[string]erc1155agent.SwapAgent
-	ERC1155Token     map[string]erc1155token.IToken
+	Client          map[string]client.ETHClient
+	DB              *gorm.DB
+	Recorder        map[string]recorder.IRecorder
+	ERC721SwapAgent map[string]erc721agent.SwapAgent
+	// ERC721BatchSwapAgent holds the batch swap agents of the chains which have one, keyed by chain id
+	ERC721BatchSwapAgent map[string]erc721agent.BatchSwapAgent
+	ERC721Token          map[string]erc721token.IToken
+	ERC1155SwapAgent     map[string]erc1155agent.SwapAgent
+	ERC1155Token         map[string]erc1155token.IToken
+	ERC20SwapAgent       map[string]erc20agent.SwapAgent
+	// FillBatcher holds the swap agents of the chains which batch their fills, keyed by chain id and agent address
+	FillBatcher map[string]map[common.Address]fillbatchagent.FillBatcher
+	// Heads holds the head tracker of every chain, keyed by chain id
+	Heads map[string]*head.Tracker
+	// Leader is nil when a single replica runs, otherwise only the leader sends transactions
+	Leader lease.Leader
+	// Bus wakes the loops up on new blocks and state changes, and receives the state changes of the engine. The
+	// loops only poll when it is nil.
+	Bus *notify.Bus
 }
 
 type Engine struct {
 	conf *Config
 	deps *Dependencies
+
+	// cycles holds the time each run loop last completed a cycle, keyed by loop name
+	cycles      map[string]time.Time
+	cyclesMutex sync.RWMutex
+
+	wg sync.WaitGroup
 }
 
 func NewEngine(c *Config, d *Dependencies) *Engine {
 	return &Engine{
-		conf: c,
-		deps: d,
+		conf:   c,
+		deps:   d,
+		cycles: make(map[string]time.Time),
 	}
 }
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	contractabi "github.com/synycboom/bsc-evm-compatible-bridge-core/abi"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/notify"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// FillBatch configures the batching of the fills sent to a destination chain, the swap agents of the chain send the
+// fills of a batch to themselves, see contracts/lib/FillBatcher.sol
+type FillBatch struct {
+	// Window is how long the first confirmed swap of a batch waits for others before the batch is sent
+	Window time.Duration
+	// Size is the max number of fills of a batch, a full batch is sent without waiting for the window
+	Size int
+}
+
+// fillBatchDue tells whether a batch of n confirmed swaps, the oldest confirmed at oldest, is sent now
+func (b *FillBatch) fillBatchDue(n int, oldest time.Time) bool {
+	return n >= b.Size || time.Since(oldest) >= b.Window
+}
+
+// fillMatcher wakes a confirmed loop up on the states it waits for, and on every block as well when fills are
+// batched so that a batch is sent once its window is over
+func (e *Engine) fillMatcher(m notify.Matcher) notify.Matcher {
+	if len(e.conf.FillBatches) == 0 {
+		return m
+	}
+
+	return notify.Any(m, notify.Block(""))
+}
+
+// sendFillBatch sends the fill calls of a batch to the agent of the destination chain, which runs them as sent by
+// the relayer. A failing call does not revert the others, its swap has no fill event and is retried with its own tx.
+func (e *Engine) sendFillBatch(ctx context.Context, dstChainID string, agentAddr common.Address, callData [][]byte, dryRun bool) (*types.Transaction, error) {
+	dstChainIDInt, err := util.ParseBigInt(dstChainID)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.sendFillBatch]: invalid destination chain id")
+	}
+	if _, ok := e.deps.Client[dstChainID]; !ok {
+		return nil, errors.Errorf("[Engine.sendFillBatch]: client for chain id %s is not supported", dstChainID)
+	}
+	agent, ok := e.deps.FillBatcher[dstChainID][agentAddr]
+	if !ok {
+		return nil, errors.Errorf("[Engine.sendFillBatch]: agent %s of chain id %s does not batch fills", agentAddr.String(), dstChainID)
+	}
+
+	txOpts, err := util.TxOpts(ctx, e.deps.Client[dstChainID], e.conf.PrivateKey, dstChainIDInt)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.sendFillBatch]: failed to create tx opts")
+	}
+
+	txOpts.NoSend = dryRun
+	tx, err := agent.FillBatch(txOpts, callData)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.sendFillBatch]: failed to send fill batch tx")
+	}
+
+	return tx, nil
+}
+
+// fillBatchCalls returns the number of calls of a fill batch tx, so that its gas is shared by its swaps
+func fillBatchCalls(tx *types.Transaction) int {
+	fillBatcherABI, err := contractabi.FillBatcherMetaData.GetAbi()
+	if err != nil || len(tx.Data()) < 4 {
+		return 1
+	}
+	method, err := fillBatcherABI.MethodById(tx.Data()[:4])
+	if err != nil {
+		return 1
+	}
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil || len(args) != 1 {
+		return 1
+	}
+	calls := reflect.ValueOf(args[0])
+	if calls.Kind() != reflect.Slice || calls.Len() == 0 {
+		return 1
+	}
+
+	return calls.Len()
+}
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+
 	"math"
 
 	"github.com/ethereum/go-ethereum/core"
@@ -10,9 +12,9 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC1155ConfirmedRegitration() {
+func (e *Engine) manageERC1155ConfirmedRegitration(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC1155SwapPair(fromChainID, []erc1155.SwapPairState{
+	ss, err := e.queryERC1155SwapPair(ctx, fromChainID, []erc1155.SwapPairState{
 		erc1155.SwapPairStateRegistrationConfirmed,
 	})
 	if err != nil {
@@ -20,18 +22,25 @@ func (e *Engine) manageERC1155ConfirmedRegitration() {
 		return
 	}
 
+	ss = e.rejectERC1155UnsupportedSwapPairs(ctx, ss, "manageERC1155ConfirmedRegitration")
+
 	for _, s := range ss {
-		txHash, err := e.generateERC1155TxHash(s)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// once picked, the creation tx is sent and its state saved even if the engine is stopping meanwhile
+		ctx := util.WithoutCancel(ctx)
+
+		txHash, err := e.generateERC1155TxHash(ctx, s)
 		if err != nil {
 			// this error might comes from gas estimation, so it means we cannot send the real tx to the chain
 			util.Logger.Warningf("[Engine.manageERC1155ConfirmedRegitration]: failed to dry run tx of SwapPair %s", s.ID)
 
 			s.State = erc1155.SwapPairStateCreationTxDryRunFailed
 			s.MessageLog = err.Error()
-			if err := e.deps.DB.Save(s).Error; err != nil {
-				util.Logger.Error(
-					errors.Wrapf(err, "[Engine.manageERC1155ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State),
-				)
+			if err := e.save(ctx, s, "manageERC1155ConfirmedRegitration"); err != nil {
+				logSaveError(err, "[Engine.manageERC1155ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 			}
 
 			continue
@@ -43,10 +52,8 @@ func (e *Engine) manageERC1155ConfirmedRegitration() {
 		s.State = erc1155.SwapPairStateCreationTxCreated
 		s.CreateTxHash = txHash
 		s.CreateHeight = math.MaxInt64
-		if err := e.deps.DB.Save(s).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC1155ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State),
-			)
+		if err := e.save(ctx, s, "manageERC1155ConfirmedRegitration"); err != nil {
+			logSaveError(err, "[Engine.manageERC1155ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
 			continue
 		}
@@ -57,17 +64,15 @@ func (e *Engine) manageERC1155ConfirmedRegitration() {
 			txHash,
 		)
 
-		request, err := e.sendERC1155CreatePairRequest(s, false)
+		request, err := e.sendERC1155CreatePairRequest(ctx, s, false)
 		if err != nil {
 			// retry when a transaction is attempted to be replaced
 			// with a different one without the required price bump.
 			if errors.Cause(err).Error() == core.ErrReplaceUnderpriced.Error() {
 				s.State = erc1155.SwapPairStateRegistrationConfirmed
 				s.MessageLog = err.Error()
-				if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-					util.Logger.Error(
-						errors.Wrapf(dbErr, "[Engine.manageERC1155ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State),
-					)
+				if dbErr := e.save(ctx, s, "manageERC1155ConfirmedRegitration"); dbErr != nil {
+					logSaveError(dbErr, "[Engine.manageERC1155ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
 					continue
 				}
@@ -75,10 +80,8 @@ func (e *Engine) manageERC1155ConfirmedRegitration() {
 
 			s.State = erc1155.SwapPairStateCreationTxFailed
 			s.MessageLog = err.Error()
-			if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-				util.Logger.Error(
-					errors.Wrapf(dbErr, "[Engine.manageERC1155ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State),
-				)
+			if dbErr := e.save(ctx, s, "manageERC1155ConfirmedRegitration"); dbErr != nil {
+				logSaveError(dbErr, "[Engine.manageERC1155ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
 				continue
 			}
@@ -100,10 +103,8 @@ func (e *Engine) manageERC1155ConfirmedRegitration() {
 		// update tx hash again in case there are some parameters might change tx hash
 		// for example, gas limit which comes from estimation
 		s.CreateTxHash = request.Hash().String()
-		if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-			util.Logger.Error(
-				errors.Wrapf(dbErr, "[Engine.manageERC1155ConfirmedRegitration]: failed to update SwapPair %s creation tx hash %s right after sending out", s.ID, s.CreateTxHash),
-			)
+		if dbErr := e.save(ctx, s, "manageERC1155ConfirmedRegitration"); dbErr != nil {
+			logSaveError(dbErr, "[Engine.manageERC1155ConfirmedRegitration]: failed to update SwapPair %s creation tx hash %s right after sending out", s.ID, s.CreateTxHash)
 
 			continue
 		}
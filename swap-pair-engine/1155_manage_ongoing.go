@@ -1,15 +1,17 @@
 package engine
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC1155OngoingRegistration() {
+func (e *Engine) manageERC1155OngoingRegistration(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC1155SwapPair(fromChainID, []erc1155.SwapPairState{
+	ss, err := e.queryERC1155SwapPair(ctx, fromChainID, []erc1155.SwapPairState{
 		erc1155.SwapPairStateRegistrationOngoing,
 	})
 	if err != nil {
@@ -17,30 +19,26 @@ func (e *Engine) manageERC1155OngoingRegistration() {
 		return
 	}
 
-	ss, err = e.filterERC1155ConfirmedRegisterEvents(ss)
+	ss = e.rejectERC1155UnsupportedSwapPairs(ctx, ss, "manageERC1155OngoingRegistration")
+
+	ss, err = e.filterERC1155ConfirmedRegisterEvents(ctx, ss)
 	if err != nil {
 		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC1155OngoingRegistration]: failed to filter confirmed SwapPairs"))
 		return
 	}
 
-	if len(ss) == 0 {
-		return
-	}
+	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
 
-	ids := make([]string, len(ss))
-	for idx, s := range ss {
-		ids[idx] = s.ID
-	}
+		s.State = erc1155.SwapPairStateRegistrationConfirmed
+		if err := e.save(ctx, s, "manageERC1155OngoingRegistration"); err != nil {
+			logSaveError(err, "[Engine.manageERC1155OngoingRegistration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
-	if err := e.deps.DB.Model(&ss).Where("id in ?", ids).Updates(map[string]interface{}{
-		"state": erc1155.SwapPairStateRegistrationConfirmed,
-	}).Error; err != nil {
-		util.Logger.Error(
-			errors.Wrapf(err, "[Engine.manageERC1155OngoingRegistration]: failed to update state '%s'", erc1155.SwapPairStateRegistrationConfirmed),
-		)
-	}
+			continue
+		}
 
-	for _, s := range ss {
 		util.Logger.Infof("[Engine.manageERC1155OngoingRegistration]: updated SwapPair %s state to '%s'", s.ID, s.State)
 	}
 }
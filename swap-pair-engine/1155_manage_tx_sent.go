@@ -1,14 +1,16 @@
 package engine
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC1155TxSentRegistration() {
+func (e *Engine) manageERC1155TxSentRegistration(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC1155SwapPair(fromChainID, []erc1155.SwapPairState{
+	ss, err := e.queryERC1155SwapPair(ctx, fromChainID, []erc1155.SwapPairState{
 		erc1155.SwapPairStateCreationTxSent,
 	})
 	if err != nil {
@@ -16,9 +18,12 @@ func (e *Engine) manageERC1155TxSentRegistration() {
 		return
 	}
 
-	var ids []string
 	for _, s := range ss {
-		confirmed, err := e.hasBlockConfirmed(s.CreateTxHash, s.DstChainID)
+		if ctx.Err() != nil {
+			return
+		}
+
+		confirmed, err := e.hasBlockConfirmed(ctx, s.CreateTxHash, s.DstChainID)
 		if err != nil {
 			util.Logger.Error(
 				errors.Wrapf(err, "[Engine.manageERC1155TxSentRegistration]: failed to check block confirmation for SwapPair %s", s.ID),
@@ -27,25 +32,18 @@ func (e *Engine) manageERC1155TxSentRegistration() {
 			continue
 		}
 
-		if confirmed {
-			ids = append(ids, s.ID)
+		if !confirmed {
+			continue
 		}
-	}
 
-	if len(ids) == 0 {
-		return
-	}
+		s.State = erc1155.SwapPairStateCreationTxConfirmed
+		s.Available = true
+		if err := e.save(ctx, s, "manageERC1155TxSentRegistration"); err != nil {
+			logSaveError(err, "[Engine.manageERC1155TxSentRegistration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
-	if err := e.deps.DB.Model(&ss).Where("id in ?", ids).Updates(map[string]interface{}{
-		"state":     erc1155.SwapPairStateCreationTxConfirmed,
-		"available": true,
-	}).Error; err != nil {
-		util.Logger.Error(
-			errors.Wrapf(err, "[Engine.manageERC1155TxSentRegistration]: failed to update state '%s'", erc1155.SwapPairStateCreationTxConfirmed),
-		)
-	}
+			continue
+		}
 
-	for _, s := range ss {
 		util.Logger.Infof("[Engine.manageERC1155TxSentRegistration]: updated SwapPair %s state to '%s'", s.ID, s.State)
 	}
 }
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -12,10 +13,10 @@ import (
 )
 
 // queryERC1155SwapPair queries SwapPair this engine is responsible
-func (e *Engine) queryERC1155SwapPair(fromChainID string, states []erc1155.SwapPairState) ([]*erc1155.SwapPair, error) {
+func (e *Engine) queryERC1155SwapPair(ctx context.Context, fromChainID string, states []erc1155.SwapPairState) ([]*erc1155.SwapPair, error) {
 	// TODO: check the index
 	var ss []*erc1155.SwapPair
-	err := e.deps.DB.Where(
+	err := e.deps.DB.WithContext(ctx).Where(
 		"state in ? and src_chain_id = ?",
 		states,
 		fromChainID,
@@ -32,9 +33,9 @@ func (e *Engine) queryERC1155SwapPair(fromChainID string, states []erc1155.SwapP
 }
 
 // filterERC1155ConfirmedRegisterEvents checks block confirmation of the chain this engine is responsible
-func (e *Engine) filterERC1155ConfirmedRegisterEvents(ss []*erc1155.SwapPair) (events []*erc1155.SwapPair, err error) {
+func (e *Engine) filterERC1155ConfirmedRegisterEvents(ctx context.Context, ss []*erc1155.SwapPair) (events []*erc1155.SwapPair, err error) {
 	for _, s := range ss {
-		confirmed, err := e.hasBlockConfirmed(s.RegisterTxHash, e.chainID())
+		confirmed, err := e.hasBlockConfirmed(ctx, s.RegisterTxHash, e.chainID())
 		if err != nil {
 			util.Logger.Warning(errors.Wrap(err, "[Engine.filterERC1155ConfirmedRegisterEvents]: failed to check block confirmation"))
 			continue
@@ -47,8 +48,8 @@ func (e *Engine) filterERC1155ConfirmedRegisterEvents(ss []*erc1155.SwapPair) (e
 	return events, nil
 }
 
-func (e *Engine) generateERC1155TxHash(s *erc1155.SwapPair) (string, error) {
-	request, err := e.sendERC1155CreatePairRequest(s, true)
+func (e *Engine) generateERC1155TxHash(ctx context.Context, s *erc1155.SwapPair) (string, error) {
+	request, err := e.sendERC1155CreatePairRequest(ctx, s, true)
 	if err != nil {
 		return "", errors.Wrap(err, "[Engine.generateERC1155TxHash]: failed to dry run sending a request")
 	}
@@ -57,9 +58,12 @@ func (e *Engine) generateERC1155TxHash(s *erc1155.SwapPair) (string, error) {
 }
 
 // sendERC1155CreatePairRequest sends transaction to create a swap pair on destination chain
-func (e *Engine) sendERC1155CreatePairRequest(s *erc1155.SwapPair, dryRun bool) (*types.Transaction, error) {
+func (e *Engine) sendERC1155CreatePairRequest(ctx context.Context, s *erc1155.SwapPair, dryRun bool) (*types.Transaction, error) {
 	dstChainID := s.DstChainID
-	dstChainIDInt := util.StrToBigInt(dstChainID)
+	dstChainIDInt, err := util.ParseBigInt(dstChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC1155CreatePairRequest]: invalid destination chain id of SwapPair %s", s.ID)
+	}
 	if _, ok := e.deps.Client[dstChainID]; !ok {
 		return nil, errors.Errorf("[Engine.sendERC1155CreatePairRequest]: client for chain id %s is not supported", dstChainID)
 	}
@@ -67,19 +71,23 @@ func (e *Engine) sendERC1155CreatePairRequest(s *erc1155.SwapPair, dryRun bool)
 		return nil, errors.Errorf("[Engine.sendERC1155CreatePairRequest]: swap agent for chain id %s is not supported", dstChainID)
 	}
 
-	ctx := context.Background()
 	txOpts, err := util.TxOpts(ctx, e.deps.Client[dstChainID], e.conf.PrivateKey, dstChainIDInt)
 	if err != nil {
 		return nil, errors.Wrap(err, "[Engine.sendERC1155CreatePairRequest]: failed to create tx opts")
 	}
 
+	srcChainIDInt, err := util.ParseBigInt(s.SrcChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC1155CreatePairRequest]: invalid source chain id of SwapPair %s", s.ID)
+	}
+
 	txOpts.NoSend = dryRun
 
 	tx, err := e.deps.ERC1155SwapAgent[dstChainID].CreateSwapPair(
 		txOpts,
 		common.HexToHash(s.RegisterTxHash),
 		common.HexToAddress(s.SrcTokenAddr),
-		util.StrToBigInt(s.SrcChainID),
+		srcChainIDInt,
 		s.URI,
 	)
 	if err != nil {
@@ -88,3 +96,26 @@ func (e *Engine) sendERC1155CreatePairRequest(s *erc1155.SwapPair, dryRun bool)
 
 	return tx, nil
 }
+
+// rejectERC1155UnsupportedSwapPairs moves the SwapPairs towards a chain which is not configured to the unsupported destination state and
+// returns the others
+func (e *Engine) rejectERC1155UnsupportedSwapPairs(ctx context.Context, ss []*erc1155.SwapPair, loopName string) []*erc1155.SwapPair {
+	var supported []*erc1155.SwapPair
+	for _, s := range ss {
+		if e.isSupportedChain(s.DstChainID) {
+			supported = append(supported, s)
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		s.State = erc1155.SwapPairStateUnsupportedDestination
+		s.MessageLog = fmt.Sprintf("destination chain %s is not configured", s.DstChainID)
+		if err := e.save(ctx, s, loopName); err != nil {
+			logSaveError(err, "[Engine.rejectERC1155UnsupportedSwapPairs]: failed to update SwapPair %s to state '%s'", s.ID, s.State)
+		}
+	}
+
+	return supported
+}
@@ -9,12 +9,11 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) retrieveERC1155DstTokenAddr(height uint64, fromTokenAddr, registerTxHash, chainID string) (string, error) {
+func (e *Engine) retrieveERC1155DstTokenAddr(ctx context.Context, height uint64, fromTokenAddr, registerTxHash, chainID string) (string, error) {
 	if _, ok := e.deps.Client[chainID]; !ok {
 		return "", errors.Errorf("[Engine.retrieveERC1155DstTokenAddr]: client for chain id %s is not supported", chainID)
 	}
 
-	ctx := context.Background()
 	opts := bind.FilterOpts{
 		Start:   height,
 		End:     &height,
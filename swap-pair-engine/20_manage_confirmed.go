@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"context"
+
+	"math"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+func (e *Engine) manageERC20ConfirmedRegitration(ctx context.Context) {
+	fromChainID := e.chainID()
+	ss, err := e.queryERC20SwapPair(ctx, fromChainID, []erc20.SwapPairState{
+		erc20.SwapPairStateRegistrationConfirmed,
+	})
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC20ConfirmedRegitration]: failed to query confirmed SwapPairs"))
+		return
+	}
+
+	ss = e.rejectERC20UnsupportedSwapPairs(ctx, ss, "manageERC20ConfirmedRegitration")
+
+	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// once picked, the creation tx is sent and its state saved even if the engine is stopping meanwhile
+		ctx := util.WithoutCancel(ctx)
+
+		txHash, err := e.generateERC20TxHash(ctx, s)
+		if err != nil {
+			// this error might comes from gas estimation, so it means we cannot send the real tx to the chain
+			util.Logger.Warningf("[Engine.manageERC20ConfirmedRegitration]: failed to dry run tx of SwapPair %s", s.ID)
+
+			s.State = erc20.SwapPairStateCreationTxDryRunFailed
+			s.MessageLog = err.Error()
+			if err := e.save(ctx, s, "manageERC20ConfirmedRegitration"); err != nil {
+				logSaveError(err, "[Engine.manageERC20ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
+			}
+
+			continue
+		}
+
+		// We save the tx as our checkpoint to probe the stats later
+		// It tells that this tx might be sent or might not, but it is okay
+		// We will set the state to failed later
+		s.State = erc20.SwapPairStateCreationTxCreated
+		s.CreateTxHash = txHash
+		s.CreateHeight = math.MaxInt64
+		if err := e.save(ctx, s, "manageERC20ConfirmedRegitration"); err != nil {
+			logSaveError(err, "[Engine.manageERC20ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
+
+			continue
+		}
+
+		util.Logger.Infof(
+			"[Engine.manageERC20ConfirmedRegitration]: sent dry run tx to chain id %s, %s",
+			e.chainID(),
+			txHash,
+		)
+
+		request, err := e.sendERC20CreatePairRequest(ctx, s, false)
+		if err != nil {
+			// retry when a transaction is attempted to be replaced
+			// with a different one without the required price bump.
+			if errors.Cause(err).Error() == core.ErrReplaceUnderpriced.Error() {
+				s.State = erc20.SwapPairStateRegistrationConfirmed
+				s.MessageLog = err.Error()
+				if dbErr := e.save(ctx, s, "manageERC20ConfirmedRegitration"); dbErr != nil {
+					logSaveError(dbErr, "[Engine.manageERC20ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
+
+					continue
+				}
+			}
+
+			s.State = erc20.SwapPairStateCreationTxFailed
+			s.MessageLog = err.Error()
+			if dbErr := e.save(ctx, s, "manageERC20ConfirmedRegitration"); dbErr != nil {
+				logSaveError(dbErr, "[Engine.manageERC20ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
+
+				continue
+			}
+
+			util.Logger.Error(
+				errors.Wrapf(err, "[Engine.manageERC20ConfirmedRegitration]: failed to send a real tx %s of SwapPair %s", s.CreateTxHash, s.ID),
+			)
+
+			continue
+		}
+
+		util.Logger.Infof(
+			"[Engine.manageERC20ConfirmedRegitration]: sent tx to chain id %s, %s/%s",
+			e.chainID(),
+			e.conf.ExplorerURL,
+			request.Hash().String(),
+		)
+
+		// update tx hash again in case there are some parameters might change tx hash
+		// for example, gas limit which comes from estimation
+		s.CreateTxHash = request.Hash().String()
+		if dbErr := e.save(ctx, s, "manageERC20ConfirmedRegitration"); dbErr != nil {
+			logSaveError(dbErr, "[Engine.manageERC20ConfirmedRegitration]: failed to update SwapPair %s creation tx hash %s right after sending out", s.ID, s.CreateTxHash)
+
+			continue
+		}
+	}
+}
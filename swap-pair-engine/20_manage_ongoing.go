@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+func (e *Engine) manageERC20OngoingRegistration(ctx context.Context) {
+	fromChainID := e.chainID()
+	ss, err := e.queryERC20SwapPair(ctx, fromChainID, []erc20.SwapPairState{
+		erc20.SwapPairStateRegistrationOngoing,
+	})
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC20OngoingRegistration]: failed to query onging SwapPairs"))
+		return
+	}
+
+	ss = e.rejectERC20UnsupportedSwapPairs(ctx, ss, "manageERC20OngoingRegistration")
+
+	ss, err = e.filterERC20ConfirmedRegisterEvents(ctx, ss)
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC20OngoingRegistration]: failed to filter confirmed SwapPairs"))
+		return
+	}
+
+	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.State = erc20.SwapPairStateRegistrationConfirmed
+		if err := e.save(ctx, s, "manageERC20OngoingRegistration"); err != nil {
+			logSaveError(err, "[Engine.manageERC20OngoingRegistration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
+
+			continue
+		}
+
+		util.Logger.Infof("[Engine.manageERC20OngoingRegistration]: updated SwapPair %s state to '%s'", s.ID, s.State)
+	}
+}
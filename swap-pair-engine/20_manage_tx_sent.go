@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+func (e *Engine) manageERC20TxSentRegistration(ctx context.Context) {
+	fromChainID := e.chainID()
+	ss, err := e.queryERC20SwapPair(ctx, fromChainID, []erc20.SwapPairState{
+		erc20.SwapPairStateCreationTxSent,
+	})
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC20TxSentRegistration]: failed to query tx_sent SwapPairs"))
+		return
+	}
+
+	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
+
+		confirmed, err := e.hasBlockConfirmed(ctx, s.CreateTxHash, s.DstChainID)
+		if err != nil {
+			util.Logger.Error(
+				errors.Wrapf(err, "[Engine.manageERC20TxSentRegistration]: failed to check block confirmation for SwapPair %s", s.ID),
+			)
+
+			continue
+		}
+
+		if !confirmed {
+			continue
+		}
+
+		s.State = erc20.SwapPairStateCreationTxConfirmed
+		s.Available = true
+		if err := e.save(ctx, s, "manageERC20TxSentRegistration"); err != nil {
+			logSaveError(err, "[Engine.manageERC20TxSentRegistration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
+
+			continue
+		}
+
+		util.Logger.Infof("[Engine.manageERC20TxSentRegistration]: updated SwapPair %s state to '%s'", s.ID, s.State)
+	}
+}
@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+)
+
+// queryERC20SwapPair queries SwapPair this engine is responsible
+func (e *Engine) queryERC20SwapPair(ctx context.Context, fromChainID string, states []erc20.SwapPairState) ([]*erc20.SwapPair, error) {
+	// TODO: check the index
+	var ss []*erc20.SwapPair
+	err := e.deps.DB.WithContext(ctx).Where(
+		"state in ? and src_chain_id = ?",
+		states,
+		fromChainID,
+	).Order(
+		"register_height asc",
+	).Limit(
+		querySwapPairLimit,
+	).Find(&ss).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.queryERC20SwapPair]: failed to query SwapPair")
+	}
+
+	return ss, nil
+}
+
+// filterERC20ConfirmedRegisterEvents checks block confirmation of the chain this engine is responsible
+func (e *Engine) filterERC20ConfirmedRegisterEvents(ctx context.Context, ss []*erc20.SwapPair) (events []*erc20.SwapPair, err error) {
+	for _, s := range ss {
+		confirmed, err := e.hasBlockConfirmed(ctx, s.RegisterTxHash, e.chainID())
+		if err != nil {
+			util.Logger.Warning(errors.Wrap(err, "[Engine.filterERC20ConfirmedRegisterEvents]: failed to check block confirmation"))
+			continue
+		}
+		if confirmed {
+			events = append(events, s)
+		}
+	}
+
+	return events, nil
+}
+
+func (e *Engine) generateERC20TxHash(ctx context.Context, s *erc20.SwapPair) (string, error) {
+	request, err := e.sendERC20CreatePairRequest(ctx, s, true)
+	if err != nil {
+		return "", errors.Wrap(err, "[Engine.generateERC20TxHash]: failed to dry run sending a request")
+	}
+
+	return request.Hash().String(), nil
+}
+
+// sendERC20CreatePairRequest sends transaction to create a swap pair on destination chain
+func (e *Engine) sendERC20CreatePairRequest(ctx context.Context, s *erc20.SwapPair, dryRun bool) (*types.Transaction, error) {
+	dstChainID := s.DstChainID
+	dstChainIDInt, err := util.ParseBigInt(dstChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC20CreatePairRequest]: invalid destination chain id of SwapPair %s", s.ID)
+	}
+	if _, ok := e.deps.Client[dstChainID]; !ok {
+		return nil, errors.Errorf("[Engine.sendERC20CreatePairRequest]: client for chain id %s is not supported", dstChainID)
+	}
+	if _, ok := e.deps.ERC20SwapAgent[dstChainID]; !ok {
+		return nil, errors.Errorf("[Engine.sendERC20CreatePairRequest]: swap agent for chain id %s is not supported", dstChainID)
+	}
+
+	txOpts, err := util.TxOpts(ctx, e.deps.Client[dstChainID], e.conf.PrivateKey, dstChainIDInt)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.sendERC20CreatePairRequest]: failed to create tx opts")
+	}
+
+	srcChainIDInt, err := util.ParseBigInt(s.SrcChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC20CreatePairRequest]: invalid source chain id of SwapPair %s", s.ID)
+	}
+
+	txOpts.NoSend = dryRun
+
+	tx, err := e.deps.ERC20SwapAgent[dstChainID].CreateSwapPair(
+		txOpts,
+		common.HexToHash(s.RegisterTxHash),
+		common.HexToAddress(s.SrcTokenAddr),
+		srcChainIDInt,
+		s.SrcTokenName,
+		s.Symbol,
+		s.Decimals,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Engine.sendERC20CreatePairRequest]: failed to send swap pair creation tx")
+	}
+
+	return tx, nil
+}
+
+// rejectERC20UnsupportedSwapPairs moves the SwapPairs towards a chain which is not configured or has no ERC20 swap agent
+// to the unsupported destination state and returns the others
+func (e *Engine) rejectERC20UnsupportedSwapPairs(ctx context.Context, ss []*erc20.SwapPair, loopName string) []*erc20.SwapPair {
+	var supported []*erc20.SwapPair
+	for _, s := range ss {
+		_, hasAgent := e.deps.ERC20SwapAgent[s.DstChainID]
+		if e.isSupportedChain(s.DstChainID) && hasAgent {
+			supported = append(supported, s)
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		s.State = erc20.SwapPairStateUnsupportedDestination
+		s.MessageLog = fmt.Sprintf("destination chain %s is not configured", s.DstChainID)
+		if e.isSupportedChain(s.DstChainID) {
+			s.MessageLog = fmt.Sprintf("destination chain %s has no ERC20 swap agent", s.DstChainID)
+		}
+		if err := e.save(ctx, s, loopName); err != nil {
+			logSaveError(err, "[Engine.rejectERC20UnsupportedSwapPairs]: failed to update SwapPair %s to state '%s'", s.ID, s.State)
+		}
+	}
+
+	return supported
+}
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+
 	"math"
 
 	"github.com/ethereum/go-ethereum/core"
@@ -10,9 +12,9 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC721ConfirmedRegitration() {
+func (e *Engine) manageERC721ConfirmedRegitration(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC721SwapPair(fromChainID, []erc721.SwapPairState{
+	ss, err := e.queryERC721SwapPair(ctx, fromChainID, []erc721.SwapPairState{
 		erc721.SwapPairStateRegistrationConfirmed,
 	})
 	if err != nil {
@@ -20,18 +22,25 @@ func (e *Engine) manageERC721ConfirmedRegitration() {
 		return
 	}
 
+	ss = e.rejectERC721UnsupportedSwapPairs(ctx, ss, "manageERC721ConfirmedRegitration")
+
 	for _, s := range ss {
-		txHash, err := e.generateERC721TxHash(s)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// once picked, the creation tx is sent and its state saved even if the engine is stopping meanwhile
+		ctx := util.WithoutCancel(ctx)
+
+		txHash, err := e.generateERC721TxHash(ctx, s)
 		if err != nil {
 			// this error might comes from gas estimation, so it means we cannot send the real tx to the chain
 			util.Logger.Warningf("[Engine.manageERC721ConfirmedRegitration]: failed to dry run tx of SwapPair %s", s.ID)
 
 			s.State = erc721.SwapPairStateCreationTxDryRunFailed
 			s.MessageLog = err.Error()
-			if err := e.deps.DB.Save(s).Error; err != nil {
-				util.Logger.Error(
-					errors.Wrapf(err, "[Engine.manageERC721ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State),
-				)
+			if err := e.save(ctx, s, "manageERC721ConfirmedRegitration"); err != nil {
+				logSaveError(err, "[Engine.manageERC721ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 			}
 
 			continue
@@ -43,10 +52,8 @@ func (e *Engine) manageERC721ConfirmedRegitration() {
 		s.State = erc721.SwapPairStateCreationTxCreated
 		s.CreateTxHash = txHash
 		s.CreateHeight = math.MaxInt64
-		if err := e.deps.DB.Save(s).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC721ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State),
-			)
+		if err := e.save(ctx, s, "manageERC721ConfirmedRegitration"); err != nil {
+			logSaveError(err, "[Engine.manageERC721ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
 			continue
 		}
@@ -57,17 +64,15 @@ func (e *Engine) manageERC721ConfirmedRegitration() {
 			txHash,
 		)
 
-		request, err := e.sendERC721CreatePairRequest(s, false)
+		request, err := e.sendERC721CreatePairRequest(ctx, s, false)
 		if err != nil {
 			// retry when a transaction is attempted to be replaced
 			// with a different one without the required price bump.
 			if errors.Cause(err).Error() == core.ErrReplaceUnderpriced.Error() {
 				s.State = erc721.SwapPairStateRegistrationConfirmed
 				s.MessageLog = err.Error()
-				if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-					util.Logger.Error(
-						errors.Wrapf(dbErr, "[Engine.manageERC721ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State),
-					)
+				if dbErr := e.save(ctx, s, "manageERC721ConfirmedRegitration"); dbErr != nil {
+					logSaveError(dbErr, "[Engine.manageERC721ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
 					continue
 				}
@@ -75,10 +80,8 @@ func (e *Engine) manageERC721ConfirmedRegitration() {
 
 			s.State = erc721.SwapPairStateCreationTxFailed
 			s.MessageLog = err.Error()
-			if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-				util.Logger.Error(
-					errors.Wrapf(dbErr, "[Engine.manageERC721ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State),
-				)
+			if dbErr := e.save(ctx, s, "manageERC721ConfirmedRegitration"); dbErr != nil {
+				logSaveError(dbErr, "[Engine.manageERC721ConfirmedRegitration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
 				continue
 			}
@@ -100,10 +103,8 @@ func (e *Engine) manageERC721ConfirmedRegitration() {
 		// update tx hash again in case there are some parameters might change tx hash
 		// for example, gas limit which comes from estimation
 		s.CreateTxHash = request.Hash().String()
-		if dbErr := e.deps.DB.Save(s).Error; dbErr != nil {
-			util.Logger.Error(
-				errors.Wrapf(dbErr, "[Engine.manageERC721ConfirmedRegitration]: failed to update SwapPair %s creation tx hash %s right after sending out", s.ID, s.CreateTxHash),
-			)
+		if dbErr := e.save(ctx, s, "manageERC721ConfirmedRegitration"); dbErr != nil {
+			logSaveError(dbErr, "[Engine.manageERC721ConfirmedRegitration]: failed to update SwapPair %s creation tx hash %s right after sending out", s.ID, s.CreateTxHash)
 
 			continue
 		}
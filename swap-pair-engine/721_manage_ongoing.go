@@ -1,15 +1,17 @@
 package engine
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC721OngoingRegistration() {
+func (e *Engine) manageERC721OngoingRegistration(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC721SwapPair(fromChainID, []erc721.SwapPairState{
+	ss, err := e.queryERC721SwapPair(ctx, fromChainID, []erc721.SwapPairState{
 		erc721.SwapPairStateRegistrationOngoing,
 	})
 	if err != nil {
@@ -17,30 +19,26 @@ func (e *Engine) manageERC721OngoingRegistration() {
 		return
 	}
 
-	ss, err = e.filterERC721ConfirmedRegisterEvents(ss)
+	ss = e.rejectERC721UnsupportedSwapPairs(ctx, ss, "manageERC721OngoingRegistration")
+
+	ss, err = e.filterERC721ConfirmedRegisterEvents(ctx, ss)
 	if err != nil {
 		util.Logger.Error(errors.Wrap(err, "[Engine.manageERC721OngoingRegistration]: failed to filter confirmed SwapPairs"))
 		return
 	}
 
-	if len(ss) == 0 {
-		return
-	}
+	for _, s := range ss {
+		if ctx.Err() != nil {
+			return
+		}
 
-	ids := make([]string, len(ss))
-	for idx, s := range ss {
-		ids[idx] = s.ID
-	}
+		s.State = erc721.SwapPairStateRegistrationConfirmed
+		if err := e.save(ctx, s, "manageERC721OngoingRegistration"); err != nil {
+			logSaveError(err, "[Engine.manageERC721OngoingRegistration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
-	if err := e.deps.DB.Model(&ss).Where("id in ?", ids).Updates(map[string]interface{}{
-		"state": erc721.SwapPairStateRegistrationConfirmed,
-	}).Error; err != nil {
-		util.Logger.Error(
-			errors.Wrapf(err, "[Engine.manageERC721OngoingRegistration]: failed to update state '%s'", erc721.SwapPairStateRegistrationConfirmed),
-		)
-	}
+			continue
+		}
 
-	for _, s := range ss {
 		util.Logger.Infof("[Engine.manageERC721OngoingRegistration]: updated SwapPair %s state to '%s'", s.ID, s.State)
 	}
 }
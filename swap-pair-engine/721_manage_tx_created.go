@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+
 	"math/big"
 
 	"github.com/pkg/errors"
@@ -11,9 +13,9 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC721TxCreatedRegistration() {
+func (e *Engine) manageERC721TxCreatedRegistration(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC721SwapPair(fromChainID, []erc721.SwapPairState{
+	ss, err := e.queryERC721SwapPair(ctx, fromChainID, []erc721.SwapPairState{
 		erc721.SwapPairStateCreationTxCreated,
 	})
 	if err != nil {
@@ -22,7 +24,11 @@ func (e *Engine) manageERC721TxCreatedRegistration() {
 	}
 
 	for _, s := range ss {
-		ethTx, isPending, err := e.retrieveTx(s.CreateTxHash, s.DstChainID)
+		if ctx.Err() != nil {
+			return
+		}
+
+		ethTx, isPending, err := e.retrieveTx(ctx, s.CreateTxHash, s.DstChainID)
 		if err != nil {
 			util.Logger.Error(
 				errors.Wrapf(err, "[Engine.manageERC721TxCreatedRegistration]: failed to get SwapPair creation tx %s", s.CreateTxHash),
@@ -35,7 +41,7 @@ func (e *Engine) manageERC721TxCreatedRegistration() {
 			continue
 		}
 
-		receipt, err := e.retrieveTxReceipt(s.CreateTxHash, s.DstChainID)
+		receipt, err := e.retrieveTxReceipt(ctx, s.CreateTxHash, s.DstChainID)
 		if err != nil {
 			util.Logger.Error(
 				errors.Wrapf(err, "[Engine.manageERC721TxCreatedRegistration]: failed to get SwapPair creation receipt for tx %s", s.CreateTxHash),
@@ -54,10 +60,8 @@ func (e *Engine) manageERC721TxCreatedRegistration() {
 
 		if ethTx == nil || receipt == nil {
 			s.CreateTrackRetry += 1
-			if err := e.deps.DB.Save(s).Error; err != nil {
-				util.Logger.Error(
-					errors.Wrapf(err, "[Engine.manageERC721TxCreatedRegistration]: failed to increase create track retry counter %s", s.ID),
-				)
+			if err := e.save(ctx, s, "manageERC721TxCreatedRegistration"); err != nil {
+				logSaveError(err, "[Engine.manageERC721TxCreatedRegistration]: failed to increase create track retry counter %s", s.ID)
 
 				continue
 			}
@@ -65,10 +69,8 @@ func (e *Engine) manageERC721TxCreatedRegistration() {
 			if s.CreateTrackRetry > e.conf.MaxTrackRetry {
 				s.State = erc721.SwapPairStateCreationTxMissing
 				s.MessageLog = "[Engine.manageERC721TxCreatedRegistration]: tx is missing"
-				if err := e.deps.DB.Save(s).Error; err != nil {
-					util.Logger.Error(
-						errors.Wrapf(err, "[Engine.manageERC721TxCreatedRegistration]: failed to update SwapPair %s to '%s' state", s.ID, s.State),
-					)
+				if err := e.save(ctx, s, "manageERC721TxCreatedRegistration"); err != nil {
+					logSaveError(err, "[Engine.manageERC721TxCreatedRegistration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
 					continue
 				}
@@ -78,7 +80,7 @@ func (e *Engine) manageERC721TxCreatedRegistration() {
 		}
 
 		var b block.Log
-		err = e.deps.DB.Where(
+		err = e.deps.DB.WithContext(ctx).Where(
 			"chain_id = ? and block_hash = ?",
 			s.DstChainID,
 			receipt.BlockHash.String(),
@@ -101,7 +103,7 @@ func (e *Engine) manageERC721TxCreatedRegistration() {
 		}
 
 		createBlockHeight := receipt.BlockNumber.Int64()
-		dstTokenAddr, err := e.retrieveERC721DstTokenAddr(uint64(createBlockHeight), s.SrcTokenAddr, s.RegisterTxHash, s.DstChainID)
+		dstTokenAddr, err := e.retrieveERC721DstTokenAddr(ctx, uint64(createBlockHeight), s.SrcTokenAddr, s.RegisterTxHash, s.DstChainID)
 		if err != nil {
 			util.Logger.Error(
 				errors.Wrapf(err, "[Engine.manageERC721TxCreatedRegistration]: failed to get destination token address for SwapPair %s", s.ID),
@@ -113,10 +115,8 @@ func (e *Engine) manageERC721TxCreatedRegistration() {
 		if dstTokenAddr == "" {
 			s.State = erc721.SwapPairStateCreationTxFailed
 			s.MessageLog = "[Engine.manageERC721TxCreatedRegistration]: destination token address was not found"
-			if err := e.deps.DB.Save(s).Error; err != nil {
-				util.Logger.Error(
-					errors.Wrapf(err, "[Engine.manageERC721TxCreatedRegistration]: failed to update SwapPair %s to '%s' state", s.ID, s.State),
-				)
+			if err := e.save(ctx, s, "manageERC721TxCreatedRegistration"); err != nil {
+				logSaveError(err, "[Engine.manageERC721TxCreatedRegistration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
 				continue
 			}
@@ -126,16 +126,14 @@ func (e *Engine) manageERC721TxCreatedRegistration() {
 		gasPrice.SetString(ethTx.GasPrice().String(), 10)
 		s.DstTokenAddr = dstTokenAddr
 		s.CreateGasPrice = gasPrice.String()
-		s.CreateConsumedFeeAmount = big.NewInt(1).Mul(gasPrice, big.NewInt(s.CreateGasUsed)).String()
 		s.CreateGasUsed = int64(receipt.GasUsed)
+		s.CreateConsumedFeeAmount = big.NewInt(1).Mul(gasPrice, big.NewInt(s.CreateGasUsed)).String()
 		s.CreateHeight = createBlockHeight
 		s.CreateBlockHash = receipt.BlockHash.String()
 		s.CreateBlockLogID = &b.ID
 		s.State = erc721.SwapPairStateCreationTxSent
-		if err := e.deps.DB.Save(s).Error; err != nil {
-			util.Logger.Error(
-				errors.Wrapf(err, "[Engine.manageERC721TxCreatedRegistration]: failed to update SwapPair %s basic info", s.ID),
-			)
+		if err := e.save(ctx, s, "manageERC721TxCreatedRegistration"); err != nil {
+			logSaveError(err, "[Engine.manageERC721TxCreatedRegistration]: failed to update SwapPair %s basic info", s.ID)
 
 			continue
 		}
@@ -1,14 +1,16 @@
 package engine
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) manageERC721TxSentRegistration() {
+func (e *Engine) manageERC721TxSentRegistration(ctx context.Context) {
 	fromChainID := e.chainID()
-	ss, err := e.queryERC721SwapPair(fromChainID, []erc721.SwapPairState{
+	ss, err := e.queryERC721SwapPair(ctx, fromChainID, []erc721.SwapPairState{
 		erc721.SwapPairStateCreationTxSent,
 	})
 	if err != nil {
@@ -16,9 +18,12 @@ func (e *Engine) manageERC721TxSentRegistration() {
 		return
 	}
 
-	var ids []string
 	for _, s := range ss {
-		confirmed, err := e.hasBlockConfirmed(s.CreateTxHash, s.DstChainID)
+		if ctx.Err() != nil {
+			return
+		}
+
+		confirmed, err := e.hasBlockConfirmed(ctx, s.CreateTxHash, s.DstChainID)
 		if err != nil {
 			util.Logger.Error(
 				errors.Wrapf(err, "[Engine.manageERC721TxSentRegistration]: failed to check block confirmation for SwapPair %s", s.ID),
@@ -27,25 +32,18 @@ func (e *Engine) manageERC721TxSentRegistration() {
 			continue
 		}
 
-		if confirmed {
-			ids = append(ids, s.ID)
+		if !confirmed {
+			continue
 		}
-	}
 
-	if len(ids) == 0 {
-		return
-	}
+		s.State = erc721.SwapPairStateCreationTxConfirmed
+		s.Available = true
+		if err := e.save(ctx, s, "manageERC721TxSentRegistration"); err != nil {
+			logSaveError(err, "[Engine.manageERC721TxSentRegistration]: failed to update SwapPair %s to '%s' state", s.ID, s.State)
 
-	if err := e.deps.DB.Model(&ss).Where("id in ?", ids).Updates(map[string]interface{}{
-		"state":     erc721.SwapPairStateCreationTxConfirmed,
-		"available": true,
-	}).Error; err != nil {
-		util.Logger.Error(
-			errors.Wrapf(err, "[Engine.manageERC721TxSentRegistration]: failed to update state '%s'", erc721.SwapPairStateCreationTxConfirmed),
-		)
-	}
+			continue
+		}
 
-	for _, s := range ss {
 		util.Logger.Infof("[Engine.manageERC721TxSentRegistration]: updated SwapPair %s state to '%s'", s.ID, s.State)
 	}
 }
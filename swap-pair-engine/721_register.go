@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -18,10 +19,10 @@ var (
 )
 
 // queryERC721SwapPair queries SwapPair this engine is responsible
-func (e *Engine) queryERC721SwapPair(fromChainID string, states []erc721.SwapPairState) ([]*erc721.SwapPair, error) {
+func (e *Engine) queryERC721SwapPair(ctx context.Context, fromChainID string, states []erc721.SwapPairState) ([]*erc721.SwapPair, error) {
 	// TODO: check the index
 	var ss []*erc721.SwapPair
-	err := e.deps.DB.Where(
+	err := e.deps.DB.WithContext(ctx).Where(
 		"state in ? and src_chain_id = ?",
 		states,
 		fromChainID,
@@ -38,9 +39,9 @@ func (e *Engine) queryERC721SwapPair(fromChainID string, states []erc721.SwapPai
 }
 
 // filterERC721ConfirmedRegisterEvents checks block confirmation of the chain this engine is responsible
-func (e *Engine) filterERC721ConfirmedRegisterEvents(ss []*erc721.SwapPair) (events []*erc721.SwapPair, err error) {
+func (e *Engine) filterERC721ConfirmedRegisterEvents(ctx context.Context, ss []*erc721.SwapPair) (events []*erc721.SwapPair, err error) {
 	for _, s := range ss {
-		confirmed, err := e.hasBlockConfirmed(s.RegisterTxHash, e.chainID())
+		confirmed, err := e.hasBlockConfirmed(ctx, s.RegisterTxHash, e.chainID())
 		if err != nil {
 			util.Logger.Warning(errors.Wrap(err, "[Engine.filterERC721ConfirmedRegisterEvents]: failed to check block confirmation"))
 			continue
@@ -53,8 +54,8 @@ func (e *Engine) filterERC721ConfirmedRegisterEvents(ss []*erc721.SwapPair) (eve
 	return events, nil
 }
 
-func (e *Engine) generateERC721TxHash(s *erc721.SwapPair) (string, error) {
-	request, err := e.sendERC721CreatePairRequest(s, true)
+func (e *Engine) generateERC721TxHash(ctx context.Context, s *erc721.SwapPair) (string, error) {
+	request, err := e.sendERC721CreatePairRequest(ctx, s, true)
 	if err != nil {
 		return "", errors.Wrap(err, "[Engine.generateERC721TxHash]: failed to dry run sending a request")
 	}
@@ -63,9 +64,12 @@ func (e *Engine) generateERC721TxHash(s *erc721.SwapPair) (string, error) {
 }
 
 // sendERC721CreatePairRequest sends transaction to create a swap pair on destination chain
-func (e *Engine) sendERC721CreatePairRequest(s *erc721.SwapPair, dryRun bool) (*types.Transaction, error) {
+func (e *Engine) sendERC721CreatePairRequest(ctx context.Context, s *erc721.SwapPair, dryRun bool) (*types.Transaction, error) {
 	dstChainID := s.DstChainID
-	dstChainIDInt := util.StrToBigInt(dstChainID)
+	dstChainIDInt, err := util.ParseBigInt(dstChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC721CreatePairRequest]: invalid destination chain id of SwapPair %s", s.ID)
+	}
 	if _, ok := e.deps.Client[dstChainID]; !ok {
 		return nil, errors.Errorf("[Engine.sendERC721CreatePairRequest]: client for chain id %s is not supported", dstChainID)
 	}
@@ -73,19 +77,23 @@ func (e *Engine) sendERC721CreatePairRequest(s *erc721.SwapPair, dryRun bool) (*
 		return nil, errors.Errorf("[Engine.sendERC721CreatePairRequest]: swap agent for chain id %s is not supported", dstChainID)
 	}
 
-	ctx := context.Background()
 	txOpts, err := util.TxOpts(ctx, e.deps.Client[dstChainID], e.conf.PrivateKey, dstChainIDInt)
 	if err != nil {
 		return nil, errors.Wrap(err, "[Engine.sendERC721CreatePairRequest]: failed to create tx opts")
 	}
 
+	srcChainIDInt, err := util.ParseBigInt(s.SrcChainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[Engine.sendERC721CreatePairRequest]: invalid source chain id of SwapPair %s", s.ID)
+	}
+
 	txOpts.NoSend = dryRun
 
 	tx, err := e.deps.ERC721SwapAgent[dstChainID].CreateSwapPair(
 		txOpts,
 		common.HexToHash(s.RegisterTxHash),
 		common.HexToAddress(s.SrcTokenAddr),
-		util.StrToBigInt(s.SrcChainID),
+		srcChainIDInt,
 		s.BaseURI,
 		s.SrcTokenName,
 		s.Symbol,
@@ -96,3 +104,26 @@ func (e *Engine) sendERC721CreatePairRequest(s *erc721.SwapPair, dryRun bool) (*
 
 	return tx, nil
 }
+
+// rejectERC721UnsupportedSwapPairs moves the SwapPairs towards a chain which is not configured to the unsupported destination state and
+// returns the others
+func (e *Engine) rejectERC721UnsupportedSwapPairs(ctx context.Context, ss []*erc721.SwapPair, loopName string) []*erc721.SwapPair {
+	var supported []*erc721.SwapPair
+	for _, s := range ss {
+		if e.isSupportedChain(s.DstChainID) {
+			supported = append(supported, s)
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		s.State = erc721.SwapPairStateUnsupportedDestination
+		s.MessageLog = fmt.Sprintf("destination chain %s is not configured", s.DstChainID)
+		if err := e.save(ctx, s, loopName); err != nil {
+			logSaveError(err, "[Engine.rejectERC721UnsupportedSwapPairs]: failed to update SwapPair %s to state '%s'", s.ID, s.State)
+		}
+	}
+
+	return supported
+}
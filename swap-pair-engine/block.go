@@ -9,24 +9,25 @@ import (
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
-func (e *Engine) hasBlockConfirmed(txHash, chainID string) (bool, error) {
-	if _, ok := e.deps.Recorder[chainID]; !ok {
+func (e *Engine) hasBlockConfirmed(ctx context.Context, txHash, chainID string) (bool, error) {
+	tracker, ok := e.deps.Heads[chainID]
+	if !ok {
 		return false, errors.Errorf("[Engine.hasBlockConfirmed]: chain id %s is not supported", chainID)
 	}
 
-	block := e.deps.Recorder[chainID].LatestBlockCached()
-	if block == nil {
-		util.Logger.Infof("[Engine.hasBlockConfirmed]:: no latest block cache found for chain id %s", chainID)
+	// the chain head is used rather than the recorded block, which lags behind while the observer catches up
+	h, err := tracker.Current()
+	if err != nil {
+		util.Logger.Infof("[Engine.hasBlockConfirmed]: no reliable head for chain id %s, err=%s", chainID, err.Error())
 
 		return false, nil
 	}
 
-	ctx := context.Background()
 	txRecipient, err := e.deps.Client[chainID].TransactionReceipt(ctx, common.HexToHash(txHash))
 	if err != nil {
 		return false, errors.Wrap(err, "[Engine.hasBlockConfirmed]: failed to get tx receipt")
 	}
-	if block.Height < txRecipient.BlockNumber.Int64()+e.conf.ConfirmNum {
+	if h.Height() < txRecipient.BlockNumber.Int64()+e.conf.ConfirmNum {
 		return false, nil
 	}
 
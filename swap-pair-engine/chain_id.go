@@ -3,3 +3,9 @@ package engine
 func (e *Engine) chainID() string {
 	return e.conf.ChainID.String()
 }
+
+// isSupportedChain reports whether the chain is configured
+func (e *Engine) isSupportedChain(chainID string) bool {
+	_, ok := e.deps.Client[chainID]
+	return ok
+}
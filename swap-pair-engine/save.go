@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/notify"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// logSaveError logs an error returned by model.CompareAndSave. Losing a race to another replica or an admin edit
+// is a no-op since the winner already moved the row on, so it is logged as a warning rather than an error.
+func logSaveError(err error, format string, args ...interface{}) {
+	if errors.Is(err, model.ErrVersionConflict) {
+		util.Logger.Warningf("%s, skip: %s", fmt.Sprintf(format, args...), err.Error())
+		return
+	}
+
+	util.Logger.Error(errors.Wrapf(err, format, args...))
+}
+
+// actor names the run loop changing a state in the state transitions
+func actor(loopName string) string {
+	return "swap-pair-engine/" + loopName
+}
+
+// save compares and saves a SwapPair, the loops waiting for its new state are notified once it is saved
+func (e *Engine) save(ctx context.Context, v model.Versioned, loopName string) error {
+	changed := v.StateChanged()
+	if err := model.CompareAndSave(e.deps.DB.WithContext(ctx), v, actor(loopName)); err != nil {
+		return err
+	}
+
+	if changed {
+		entityType, id, chainID := v.Entity()
+		state, _ := v.Loaded()
+		e.deps.Bus.Publish(&notify.Notification{
+			ChainID:    chainID,
+			EntityType: entityType,
+			EntityID:   id,
+			State:      state,
+		})
+	}
+
+	return nil
+}
@@ -1,10 +1,20 @@
 package engine
 
 import (
+	"context"
 	"reflect"
 	"runtime"
+	"strings"
 	"time"
 
+	"github.com/pkg/errors"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/head"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/notify"
 	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
 )
 
@@ -12,35 +22,110 @@ const (
 	watchEventDelay = time.Duration(5) * time.Second
 )
 
-func (e *Engine) Start() {
+// Start starts the run loops, they stop picking new work once ctx is done. A loop runs as soon as a notification
+// it waits for is published, or after the sweep interval otherwise.
+func (e *Engine) Start(ctx context.Context) {
 	// ERC721
-	go e.run(e.manageERC721OngoingRegistration, watchRegisterEventDelay)
-	go e.run(e.manageERC721ConfirmedRegitration, watchRegisterEventDelay)
-	go e.run(e.manageERC721TxCreatedRegistration, watchRegisterEventDelay)
-	go e.run(e.manageERC721TxSentRegistration, watchRegisterEventDelay)
+	e.goRun(ctx, e.manageERC721OngoingRegistration, watchRegisterEventDelay, notify.Block(e.chainID()))
+	e.goRun(ctx, e.manageERC721ConfirmedRegitration, watchRegisterEventDelay,
+		notify.State(transition.EntityTypeERC721SwapPair, e.chainID(), string(erc721.SwapPairStateRegistrationConfirmed)),
+	)
+	e.goRun(ctx, e.manageERC721TxCreatedRegistration, watchRegisterEventDelay, notify.Any(
+		notify.Block(""),
+		notify.State(transition.EntityTypeERC721SwapPair, e.chainID(), string(erc721.SwapPairStateCreationTxCreated)),
+	))
+	e.goRun(ctx, e.manageERC721TxSentRegistration, watchRegisterEventDelay, notify.Block(""))
 
 	// ERC1155
-	go e.run(e.manageERC1155OngoingRegistration, watchRegisterEventDelay)
-	go e.run(e.manageERC1155ConfirmedRegitration, watchRegisterEventDelay)
-	go e.run(e.manageERC1155TxCreatedRegistration, watchRegisterEventDelay)
-	go e.run(e.manageERC1155TxSentRegistration, watchRegisterEventDelay)
+	e.goRun(ctx, e.manageERC1155OngoingRegistration, watchRegisterEventDelay, notify.Block(e.chainID()))
+	e.goRun(ctx, e.manageERC1155ConfirmedRegitration, watchRegisterEventDelay,
+		notify.State(transition.EntityTypeERC1155SwapPair, e.chainID(), string(erc1155.SwapPairStateRegistrationConfirmed)),
+	)
+	e.goRun(ctx, e.manageERC1155TxCreatedRegistration, watchRegisterEventDelay, notify.Any(
+		notify.Block(""),
+		notify.State(transition.EntityTypeERC1155SwapPair, e.chainID(), string(erc1155.SwapPairStateCreationTxCreated)),
+	))
+	e.goRun(ctx, e.manageERC1155TxSentRegistration, watchRegisterEventDelay, notify.Block(""))
+
+	// ERC20, the registrations are only recorded on a chain with an ERC20 swap agent
+	if _, ok := e.deps.ERC20SwapAgent[e.chainID()]; !ok {
+		return
+	}
+	e.goRun(ctx, e.manageERC20OngoingRegistration, watchRegisterEventDelay, notify.Block(e.chainID()))
+	e.goRun(ctx, e.manageERC20ConfirmedRegitration, watchRegisterEventDelay,
+		notify.State(transition.EntityTypeERC20SwapPair, e.chainID(), string(erc20.SwapPairStateRegistrationConfirmed)),
+	)
+	e.goRun(ctx, e.manageERC20TxCreatedRegistration, watchRegisterEventDelay, notify.Any(
+		notify.Block(""),
+		notify.State(transition.EntityTypeERC20SwapPair, e.chainID(), string(erc20.SwapPairStateCreationTxCreated)),
+	))
+	e.goRun(ctx, e.manageERC20TxSentRegistration, watchRegisterEventDelay, notify.Block(""))
+}
+
+// Wait blocks until the run loops have returned, which is after their in-flight work once ctx is done
+func (e *Engine) Wait() {
+	e.wg.Wait()
 }
 
-func (e *Engine) run(fn func(), delay time.Duration) {
+func (e *Engine) goRun(ctx context.Context, fn func(context.Context), delay time.Duration, m notify.Matcher) {
+	// subscribe before the loop starts so that no notification published meanwhile is missed
+	sub := e.deps.Bus.Subscribe(m)
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		e.run(ctx, fn, delay, sub)
+	}()
+}
+
+func (e *Engine) run(ctx context.Context, fn func(context.Context), delay time.Duration, sub *notify.Subscription) {
 	fnName := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	loopName := strings.TrimSuffix(fnName[strings.LastIndex(fnName, ".")+1:], "-fm")
 	if delay.Seconds() == 0 {
 		delay = watchEventDelay
 	}
 
-	for {
-		time.Sleep(watchEventDelay)
+	interval := watchEventDelay
+	// notified loops only sweep periodically in case a notification was missed, e.g. published by another process
+	if sub != nil && e.conf.SweepInterval > 0 {
+		interval = e.conf.SweepInterval
+	}
 
-		if e.deps.Recorder[e.chainID()].LatestBlockCached() == nil {
-			util.Logger.Infof("[Engine.run][%s]: no latest block cache found for chain id %s", fnName, e.chainID())
+	for sub.Wait(ctx, interval) {
+		if _, err := e.deps.Heads[e.chainID()].Current(); errors.Is(err, head.ErrUnknown) {
+			util.Logger.Infof("[Engine.run][%s]: head of chain id %s is unknown yet", fnName, e.chainID())
 
 			continue
 		}
 
-		fn()
+		// followers stay idle, the loop is alive nonetheless
+		if e.isLeader() {
+			fn(ctx)
+		}
+		e.markCycle(loopName)
+	}
+}
+
+func (e *Engine) isLeader() bool {
+	return e.deps.Leader == nil || e.deps.Leader.IsLeader()
+}
+
+func (e *Engine) markCycle(loopName string) {
+	e.cyclesMutex.Lock()
+	defer e.cyclesMutex.Unlock()
+
+	e.cycles[loopName] = time.Now()
+}
+
+// LastCycles returns the time each run loop last completed a cycle
+func (e *Engine) LastCycles() map[string]time.Time {
+	e.cyclesMutex.RLock()
+	defer e.cyclesMutex.RUnlock()
+
+	cycles := make(map[string]time.Time, len(e.cycles))
+	for name, t := range e.cycles {
+		cycles[name] = t
 	}
+
+	return cycles
 }
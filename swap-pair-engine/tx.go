@@ -9,12 +9,11 @@ import (
 	"github.com/pkg/errors"
 )
 
-func (e *Engine) retrieveTx(txHash, chainID string) (*types.Transaction, bool, error) {
+func (e *Engine) retrieveTx(ctx context.Context, txHash, chainID string) (*types.Transaction, bool, error) {
 	if _, ok := e.deps.Client[chainID]; !ok {
 		return nil, false, errors.Errorf("[Engine.retrieveTx]: client for chain id %s is not supported", chainID)
 	}
 
-	ctx := context.Background()
 	tx, isPending, err := e.deps.Client[chainID].TransactionByHash(ctx, common.HexToHash(txHash))
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
@@ -27,12 +26,11 @@ func (e *Engine) retrieveTx(txHash, chainID string) (*types.Transaction, bool, e
 	return tx, isPending, nil
 }
 
-func (e *Engine) retrieveTxReceipt(txHash, chainID string) (*types.Receipt, error) {
+func (e *Engine) retrieveTxReceipt(ctx context.Context, txHash, chainID string) (*types.Receipt, error) {
 	if _, ok := e.deps.Client[chainID]; !ok {
 		return nil, errors.Errorf("[Engine.retrieveTxReceipt]: client for chain id %s is not supported", chainID)
 	}
 
-	ctx := context.Background()
 	txRecipient, err := e.deps.Client[chainID].TransactionReceipt(ctx, common.HexToHash(txHash))
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
@@ -0,0 +1,51 @@
+package testutil
+
+import (
+	"sync"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/alert"
+)
+
+// Alerter is an alert.Dispatcher recording every alert it receives
+type Alerter struct {
+	mutex  sync.RWMutex
+	alerts []*alert.Alert
+}
+
+func NewAlerter() *Alerter {
+	return &Alerter{}
+}
+
+func (a *Alerter) Fire(al *alert.Alert) {
+	a.record(al)
+}
+
+func (a *Alerter) Resolve(key, message string) {
+	a.record(&alert.Alert{
+		Key:      key,
+		Message:  message,
+		Resolved: true,
+	})
+}
+
+func (a *Alerter) Notify(al *alert.Alert) {
+	a.record(al)
+}
+
+// Alerts returns the alerts received so far
+func (a *Alerter) Alerts() []*alert.Alert {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	alerts := make([]*alert.Alert, len(a.alerts))
+	copy(alerts, a.alerts)
+
+	return alerts
+}
+
+func (a *Alerter) record(al *alert.Alert) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.alerts = append(a.alerts, al)
+}
@@ -0,0 +1,36 @@
+// Package testutil provides the helpers to run the observer, recorder and engine pipeline
+// in-process against fake chains and a SQLite database.
+package testutil
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/common"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/migration"
+)
+
+var dbCount uint64
+
+// NewDB returns a new in-memory SQLite database with all migrations applied.
+// Every call returns a separate database.
+func NewDB() *gorm.DB {
+	name := fmt.Sprintf("file:testutil%d?mode=memory&cache=shared&_busy_timeout=5000", atomic.AddUint64(&dbCount, 1))
+	db, err := model.Open(common.DBDialectSqlite3, name, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		panic(errors.Wrap(err, "[NewDB]: open db error"))
+	}
+
+	if err := migration.Up(db); err != nil {
+		panic(errors.Wrap(err, "[NewDB]: migrate error"))
+	}
+
+	return db
+}
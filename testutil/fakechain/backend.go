@@ -0,0 +1,245 @@
+package fakechain
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	corecommon "github.com/synycboom/bsc-evm-compatible-bridge-core/common"
+)
+
+// CallHandler answers eth_call requests sent to an address
+type CallHandler func(call ethereum.CallMsg) ([]byte, error)
+
+// SetCallHandler sets the handler answering eth_call requests sent to an address
+func (c *Chain) SetCallHandler(addr common.Address, h CallHandler) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.handlers[addr] = h
+}
+
+func (c *Chain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if number == nil {
+		return types.CopyHeader(c.blocks[len(c.blocks)-1].header), nil
+	}
+	if !number.IsUint64() || number.Uint64() > c.head() {
+		return nil, corecommon.ErrBlockNotFound
+	}
+
+	return types.CopyHeader(c.blocks[number.Uint64()].header), nil
+}
+
+func (c *Chain) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.nonces[account], nil
+}
+
+func (c *Chain) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(c.conf.GasPrice), nil
+}
+
+func (c *Chain) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(c.conf.GasPrice), nil
+}
+
+func (c *Chain) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// events queued by Emit have a receipt but no transaction body
+	if m, ok := c.mined[hash]; ok && m.tx != nil {
+		return m.tx, false, nil
+	}
+	for _, p := range c.pool {
+		if p.tx.Hash() == hash {
+			return p.tx, true, nil
+		}
+	}
+
+	return nil, false, ethereum.NotFound
+}
+
+func (c *Chain) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	m, ok := c.mined[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+
+	return m.receipt, nil
+}
+
+func (c *Chain) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if msg.To == nil {
+		return c.conf.GasLimit, nil
+	}
+
+	call, err := c.decodeCall(*msg.To, msg.From, msg.Data)
+	if err != nil {
+		return 0, errors.Wrap(err, "[Chain.EstimateGas]: execution reverted")
+	}
+	if f, ok := c.takeFault(call.Method, FaultEstimateGas); ok {
+		return 0, errors.Errorf("execution reverted: %s", f.Reason)
+	}
+	if call.Method == "batchFill" {
+		tokenIDs := call.Args["tokenIds"].([]*big.Int)
+		return c.conf.GasLimit + c.conf.TokenGas*uint64(len(tokenIDs)), nil
+	}
+
+	return c.conf.GasLimit, nil
+}
+
+func (c *Chain) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.code[contract], nil
+}
+
+func (c *Chain) PendingCodeAt(ctx context.Context, contract common.Address) ([]byte, error) {
+	return c.CodeAt(ctx, contract, nil)
+}
+
+func (c *Chain) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.mutex.RLock()
+	h, ok := c.handlers[*call.To]
+	c.mutex.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("[Chain.CallContract]: no call handler for %s", call.To.String())
+	}
+
+	return h(call)
+}
+
+func (c *Chain) PendingCallContract(ctx context.Context, call ethereum.CallMsg) ([]byte, error) {
+	return c.CallContract(ctx, call, nil)
+}
+
+func (c *Chain) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	signer := types.LatestSignerForChainID(c.conf.ChainID)
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return errors.Wrap(err, "[Chain.SendTransaction]: invalid sender")
+	}
+	if tx.Nonce() != c.nonces[from] {
+		return errors.Errorf("nonce too low: address %s, tx: %d state: %d", from.String(), tx.Nonce(), c.nonces[from])
+	}
+	if tx.To() == nil {
+		return errors.New("[Chain.SendTransaction]: contract creation is not supported")
+	}
+
+	call, err := c.decodeCall(*tx.To(), from, tx.Data())
+	if err != nil {
+		return errors.Wrap(err, "[Chain.SendTransaction]: failed to decode call")
+	}
+	if f, ok := c.takeFault(call.Method, FaultSend); ok {
+		return errors.New(f.Reason)
+	}
+
+	c.nonces[from]++
+	if _, ok := c.takeFault(call.Method, FaultDrop); ok {
+		return nil
+	}
+
+	_, reverts := c.takeFault(call.Method, FaultRevert)
+	for _, inner := range call.Calls {
+		_, inner.Reverted = c.takeFault(inner.Method, FaultRevert)
+	}
+	c.pool = append(c.pool, &pendingTx{
+		tx:      tx,
+		call:    call,
+		from:    from,
+		mineAt:  c.head() + 1 + c.conf.MineDelay,
+		reverts: reverts,
+	})
+
+	return nil
+}
+
+func (c *Chain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	from, to := uint64(0), c.head()
+	if q.FromBlock != nil {
+		from = q.FromBlock.Uint64()
+	}
+	if q.ToBlock != nil && q.ToBlock.Uint64() < to {
+		to = q.ToBlock.Uint64()
+	}
+
+	var logs []types.Log
+	for h := from; h <= to && h <= c.head(); h++ {
+		b := c.blocks[h]
+		if q.BlockHash != nil && b.header.Hash() != *q.BlockHash {
+			continue
+		}
+
+		for _, l := range b.logs {
+			if matchLog(l, q) {
+				logs = append(logs, *l)
+			}
+		}
+	}
+
+	return logs, nil
+}
+
+func (c *Chain) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("[Chain.SubscribeFilterLogs]: subscriptions are not supported")
+}
+
+func matchLog(l *types.Log, q ethereum.FilterQuery) bool {
+	if len(q.Addresses) > 0 {
+		var ok bool
+		for _, addr := range q.Addresses {
+			if addr == l.Address {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	if len(q.Topics) > len(l.Topics) {
+		return false
+	}
+	for idx, alternatives := range q.Topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+
+		var ok bool
+		for _, topic := range alternatives {
+			if topic == l.Topics[idx] {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
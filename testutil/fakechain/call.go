@@ -0,0 +1,244 @@
+package fakechain
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	StandardERC721  = "erc721"
+	StandardERC1155 = "erc1155"
+	StandardERC20   = "erc20"
+)
+
+// Call is a decoded contract call sent to the chain
+type Call struct {
+	Contract common.Address
+	Standard string
+	Method   string
+	From     common.Address
+	Args     map[string]interface{}
+	// TxHash is the hash of the tx which made the call, it is set once the call is mined
+	TxHash common.Hash
+	// Calls holds the fills of a fillBatch
+	Calls []*Call
+	// Reverted is set on a fill of a fillBatch which reverted without reverting the batch
+	Reverted bool
+}
+
+type pairKey struct {
+	standard  string
+	chainID   string
+	tokenAddr common.Address
+}
+
+// Calls returns the contract calls mined so far, reverted calls are not included
+func (c *Chain) Calls() []*Call {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	calls := make([]*Call, len(c.calls))
+	copy(calls, c.calls)
+
+	return calls
+}
+
+// MirroredToken returns the token created by createSwapPair for a token of another chain
+func (c *Chain) MirroredToken(standard string, fromChainID *big.Int, fromTokenAddr common.Address) (common.Address, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	addr, ok := c.pairs[pairKey{standard, fromChainID.String(), fromTokenAddr}]
+	return addr, ok
+}
+
+func (c *Chain) agentABI(contract common.Address) (string, abi.ABI, error) {
+	switch contract {
+	case c.conf.ERC721SwapAgentAddr:
+		return StandardERC721, erc721AgentABI, nil
+	case c.conf.ERC1155SwapAgentAddr:
+		return StandardERC1155, erc1155AgentABI, nil
+	}
+	if contract == c.conf.ERC20SwapAgentAddr && contract != (common.Address{}) {
+		return StandardERC20, erc20AgentABI, nil
+	}
+	if contract == c.conf.ERC721BatchSwapAgentAddr && contract != (common.Address{}) {
+		return StandardERC721, erc721BatchAgentABI, nil
+	}
+
+	return "", abi.ABI{}, errors.Errorf("[Chain.agentABI]: %s is not a swap agent", contract.String())
+}
+
+func (c *Chain) decodeCall(contract, from common.Address, data []byte) (*Call, error) {
+	standard, contractABI, err := c.agentABI(contract)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 {
+		return nil, errors.New("[Chain.decodeCall]: call data is too short")
+	}
+	if bytes.Equal(data[:4], fillBatcherABI.Methods["fillBatch"].ID) {
+		return c.decodeFillBatch(contract, from, data)
+	}
+
+	method, err := contractABI.MethodById(data[:4])
+	if err != nil {
+		return nil, errors.Wrap(err, "[Chain.decodeCall]: unknown method")
+	}
+
+	args := make(map[string]interface{})
+	if err := method.Inputs.UnpackIntoMap(args, data[4:]); err != nil {
+		return nil, errors.Wrap(err, "[Chain.decodeCall]: failed to unpack arguments")
+	}
+
+	return &Call{
+		Contract: contract,
+		Standard: standard,
+		Method:   method.Name,
+		From:     from,
+		Args:     args,
+	}, nil
+}
+
+// decodeFillBatch decodes a fillBatch call together with its fills, which the agent runs as sent by the sender of the
+// batch
+func (c *Chain) decodeFillBatch(contract, from common.Address, data []byte) (*Call, error) {
+	method := fillBatcherABI.Methods["fillBatch"]
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, errors.Wrap(err, "[Chain.decodeFillBatch]: failed to unpack arguments")
+	}
+
+	fills := args[0].([][]byte)
+	call := &Call{
+		Contract: contract,
+		Method:   method.Name,
+		From:     from,
+		Args:     map[string]interface{}{"fills": fills},
+	}
+	for _, f := range fills {
+		inner, err := c.decodeCall(contract, from, f)
+		if err != nil {
+			return nil, errors.Wrap(err, "[Chain.decodeFillBatch]: failed to decode a fill")
+		}
+		call.Calls = append(call.Calls, inner)
+	}
+
+	return call, nil
+}
+
+// execute applies the effects of a mined call and returns the events it emits
+func (c *Chain) execute(call *Call) []*types.Log {
+	if call.Method == "fillBatch" {
+		var logs []*types.Log
+		for _, inner := range call.Calls {
+			if !inner.Reverted {
+				logs = append(logs, c.execute(inner)...)
+			}
+		}
+
+		return logs
+	}
+
+	var log *types.Log
+	var err error
+	switch call.Method {
+	case "createSwapPair":
+		log, err = c.executeCreateSwapPair(call)
+	case "fill":
+		log, err = c.executeFill(call)
+	case "batchFill":
+		log, err = c.executeBatchFill(call)
+	default:
+		return nil
+	}
+	if err != nil {
+		panic(errors.Wrapf(err, "[Chain.execute]: failed to execute %s", call.Method))
+	}
+
+	return []*types.Log{log}
+}
+
+func (c *Chain) executeCreateSwapPair(call *Call) (*types.Log, error) {
+	registerTxHash := call.Args["registerTxHash"].([32]byte)
+	fromTokenAddr := call.Args["fromTokenAddr"].(common.Address)
+	fromChainID := call.Args["fromChainId"].(*big.Int)
+
+	mirrored := c.newAddress(call.Contract)
+	c.pairs[pairKey{call.Standard, fromChainID.String(), fromTokenAddr}] = mirrored
+
+	switch call.Standard {
+	case StandardERC721:
+		return makeLog(call.Contract, erc721AgentABI, "SwapPairCreated",
+			registerTxHash, fromTokenAddr, mirrored, fromChainID, call.Args["tokenSymbol"], call.Args["tokenName"])
+	case StandardERC20:
+		return makeLog(call.Contract, erc20AgentABI, "SwapPairCreated",
+			registerTxHash, fromTokenAddr, mirrored, fromChainID, call.Args["tokenSymbol"], call.Args["tokenName"], call.Args["tokenDecimals"])
+	}
+
+	return makeLog(call.Contract, erc1155AgentABI, "SwapPairCreated",
+		registerTxHash, fromTokenAddr, mirrored, fromChainID)
+}
+
+func (c *Chain) executeBatchFill(call *Call) (*types.Log, error) {
+	swapTxHash := call.Args["swapTxHash"].([32]byte)
+	fromTokenAddr := call.Args["fromTokenAddr"].(common.Address)
+	recipient := call.Args["recipient"].(common.Address)
+	fromChainID := call.Args["fromChainId"].(*big.Int)
+	tokenIDs := call.Args["tokenIds"].([]*big.Int)
+
+	mirrored, forward := c.pairs[pairKey{call.Standard, fromChainID.String(), fromTokenAddr}]
+	if forward {
+		return makeLog(call.Contract, erc721BatchAgentABI, "BatchSwapFilled",
+			swapTxHash, fromTokenAddr, recipient, mirrored, fromChainID, tokenIDs)
+	}
+
+	return makeLog(call.Contract, erc721BatchAgentABI, "BatchBackwardSwapFilled",
+		swapTxHash, fromTokenAddr, recipient, fromChainID, tokenIDs)
+}
+
+func (c *Chain) executeFill(call *Call) (*types.Log, error) {
+	swapTxHash := call.Args["swapTxHash"].([32]byte)
+	fromTokenAddr := call.Args["fromTokenAddr"].(common.Address)
+	recipient := call.Args["recipient"].(common.Address)
+	fromChainID := call.Args["fromChainId"].(*big.Int)
+
+	// a token registered from another chain has a mirrored token, otherwise the token is swapped back
+	mirrored, forward := c.pairs[pairKey{call.Standard, fromChainID.String(), fromTokenAddr}]
+	if call.Standard == StandardERC721 {
+		tokenID := call.Args["tokenId"].(*big.Int)
+		if forward {
+			return makeLog(call.Contract, erc721AgentABI, "SwapFilled",
+				swapTxHash, fromTokenAddr, recipient, mirrored, fromChainID, tokenID)
+		}
+
+		return makeLog(call.Contract, erc721AgentABI, "BackwardSwapFilled",
+			swapTxHash, fromTokenAddr, recipient, fromChainID, tokenID)
+	}
+
+	if call.Standard == StandardERC20 {
+		amount := call.Args["amount"].(*big.Int)
+		if forward {
+			return makeLog(call.Contract, erc20AgentABI, "SwapFilled",
+				swapTxHash, fromTokenAddr, recipient, mirrored, fromChainID, amount)
+		}
+
+		return makeLog(call.Contract, erc20AgentABI, "BackwardSwapFilled",
+			swapTxHash, fromTokenAddr, recipient, fromChainID, amount)
+	}
+
+	ids := call.Args["ids"].([]*big.Int)
+	amounts := call.Args["amounts"].([]*big.Int)
+	if forward {
+		return makeLog(call.Contract, erc1155AgentABI, "SwapFilled",
+			swapTxHash, fromTokenAddr, recipient, mirrored, fromChainID, ids, amounts)
+	}
+
+	return makeLog(call.Contract, erc1155AgentABI, "BackwardSwapFilled",
+		swapTxHash, fromTokenAddr, recipient, fromChainID, ids, amounts)
+}
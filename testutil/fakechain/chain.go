@@ -0,0 +1,302 @@
+// Package fakechain provides a scriptable in-memory chain implementing client.ETHClient and
+// bind.ContractBackend, so that the abigen swap agent bindings can be used against it in tests.
+package fakechain
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	contractabi "github.com/synycboom/bsc-evm-compatible-bridge-core/abi"
+)
+
+var (
+	erc721AgentABI      = mustParseABI(contractabi.ERC721SwapAgentMetaData.ABI)
+	erc721BatchAgentABI = mustParseABI(contractabi.ERC721BatchSwapAgentMetaData.ABI)
+	erc1155AgentABI     = mustParseABI(contractabi.ERC1155SwapAgentMetaData.ABI)
+	erc20AgentABI       = mustParseABI(contractabi.ERC20SwapAgentMetaData.ABI)
+	fillBatcherABI      = mustParseABI(contractabi.FillBatcherMetaData.ABI)
+)
+
+type Config struct {
+	ChainID *big.Int
+	// MineDelay is the number of blocks a submitted transaction waits in the pool before it is mined
+	MineDelay uint64
+	// BlockTime is the number of seconds between two block timestamps
+	BlockTime uint64
+	GasPrice  *big.Int
+	GasLimit  uint64
+	// TokenGas is the gas a batch fill needs for each of its tokens on top of GasLimit
+	TokenGas             uint64
+	ERC721SwapAgentAddr  common.Address
+	ERC1155SwapAgentAddr common.Address
+	// ERC20SwapAgentAddr deploys the ERC20 swap agent when it is set
+	ERC20SwapAgentAddr common.Address
+	// ERC721BatchSwapAgentAddr deploys the batch swap agent when it is set
+	ERC721BatchSwapAgentAddr common.Address
+}
+
+type block struct {
+	header *types.Header
+	logs   []*types.Log
+	// hashes holds the hashes of the mined transactions, including the ones emitting the queued events
+	hashes []common.Hash
+}
+
+type pendingTx struct {
+	tx      *types.Transaction
+	call    *Call
+	from    common.Address
+	mineAt  uint64
+	reverts bool
+}
+
+type minedTx struct {
+	tx      *types.Transaction
+	receipt *types.Receipt
+}
+
+type Chain struct {
+	conf *Config
+
+	mutex    sync.RWMutex
+	blocks   []*block
+	salt     uint64
+	queued   []*types.Log
+	pool     []*pendingTx
+	mined    map[common.Hash]*minedTx
+	nonces   map[common.Address]uint64
+	code     map[common.Address][]byte
+	calls    []*Call
+	faults   map[string][]Fault
+	pairs    map[pairKey]common.Address
+	handlers map[common.Address]CallHandler
+	created  uint64
+}
+
+// NewChain returns a chain holding only the genesis block
+func NewChain(c *Config) *Chain {
+	if c.GasPrice == nil {
+		c.GasPrice = big.NewInt(1000000000)
+	}
+	if c.GasLimit == 0 {
+		c.GasLimit = 200000
+	}
+	if c.BlockTime == 0 {
+		c.BlockTime = 3
+	}
+
+	ch := &Chain{
+		conf:     c,
+		mined:    make(map[common.Hash]*minedTx),
+		nonces:   make(map[common.Address]uint64),
+		code:     make(map[common.Address][]byte),
+		faults:   make(map[string][]Fault),
+		pairs:    make(map[pairKey]common.Address),
+		handlers: make(map[common.Address]CallHandler),
+	}
+	ch.code[c.ERC721SwapAgentAddr] = []byte{0x1}
+	ch.code[c.ERC1155SwapAgentAddr] = []byte{0x1}
+	if c.ERC20SwapAgentAddr != (common.Address{}) {
+		ch.code[c.ERC20SwapAgentAddr] = []byte{0x1}
+	}
+	if c.ERC721BatchSwapAgentAddr != (common.Address{}) {
+		ch.code[c.ERC721BatchSwapAgentAddr] = []byte{0x1}
+	}
+	ch.blocks = append(ch.blocks, &block{
+		header: &types.Header{
+			Number:     big.NewInt(0),
+			Time:       0,
+			Difficulty: big.NewInt(1),
+			GasLimit:   30000000,
+		},
+	})
+
+	return ch
+}
+
+// ChainID returns the chain id of the chain
+func (c *Chain) ChainID() *big.Int {
+	return new(big.Int).Set(c.conf.ChainID)
+}
+
+// Head returns the height of the latest block
+func (c *Chain) Head() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.head()
+}
+
+func (c *Chain) head() uint64 {
+	return uint64(len(c.blocks) - 1)
+}
+
+// SetCode sets the code of an address, an address without code fails gas estimation
+func (c *Chain) SetCode(addr common.Address, code []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.code[addr] = code
+}
+
+// Mine mines n blocks, each block includes the queued events and the due transactions
+func (c *Chain) Mine(n int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i := 0; i < n; i++ {
+		c.mine()
+	}
+}
+
+func (c *Chain) mine() {
+	parent := c.blocks[len(c.blocks)-1].header
+	c.salt++
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+		Time:       parent.Time + c.conf.BlockTime,
+		Difficulty: big.NewInt(1),
+		GasLimit:   parent.GasLimit,
+		Extra:      new(big.Int).SetUint64(c.salt).Bytes(),
+	}
+	b := &block{header: header}
+	height := header.Number.Uint64()
+
+	var logIndex uint
+	var txIndex uint
+	for _, l := range c.queued {
+		l.BlockNumber = height
+		l.TxIndex = txIndex
+		l.Index = logIndex
+		b.logs = append(b.logs, l)
+		b.hashes = append(b.hashes, l.TxHash)
+		c.mined[l.TxHash] = &minedTx{
+			receipt: &types.Receipt{
+				Status:           types.ReceiptStatusSuccessful,
+				Logs:             []*types.Log{l},
+				TxHash:           l.TxHash,
+				BlockNumber:      new(big.Int).SetUint64(height),
+				TransactionIndex: txIndex,
+			},
+		}
+		logIndex++
+		txIndex++
+	}
+	c.queued = nil
+
+	var remaining []*pendingTx
+	for _, p := range c.pool {
+		if p.mineAt > height {
+			remaining = append(remaining, p)
+			continue
+		}
+
+		status := types.ReceiptStatusSuccessful
+		var logs []*types.Log
+		if p.reverts {
+			status = types.ReceiptStatusFailed
+		} else {
+			p.call.TxHash = p.tx.Hash()
+			c.calls = append(c.calls, p.call)
+			logs = c.execute(p.call)
+		}
+		for _, l := range logs {
+			l.BlockNumber = height
+			l.TxHash = p.tx.Hash()
+			l.TxIndex = txIndex
+			l.Index = logIndex
+			logIndex++
+		}
+
+		b.hashes = append(b.hashes, p.tx.Hash())
+		b.logs = append(b.logs, logs...)
+		c.mined[p.tx.Hash()] = &minedTx{
+			tx: p.tx,
+			receipt: &types.Receipt{
+				Type:              p.tx.Type(),
+				Status:            status,
+				CumulativeGasUsed: p.tx.Gas(),
+				Logs:              logs,
+				TxHash:            p.tx.Hash(),
+				GasUsed:           p.tx.Gas(),
+				BlockNumber:       new(big.Int).SetUint64(height),
+				TransactionIndex:  txIndex,
+			},
+		}
+		txIndex++
+	}
+	c.pool = remaining
+
+	blockHash := header.Hash()
+	for _, l := range b.logs {
+		l.BlockHash = blockHash
+	}
+	for _, hash := range b.hashes {
+		c.mined[hash].receipt.BlockHash = blockHash
+	}
+
+	c.blocks = append(c.blocks, b)
+}
+
+// Reorg drops the latest depth blocks together with their events and transactions,
+// and mines the same number of empty blocks with different hashes
+func (c *Chain) Reorg(depth int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if depth >= len(c.blocks) {
+		depth = len(c.blocks) - 1
+	}
+
+	for _, b := range c.blocks[len(c.blocks)-depth:] {
+		for _, hash := range b.hashes {
+			delete(c.mined, hash)
+		}
+	}
+	c.blocks = c.blocks[:len(c.blocks)-depth]
+
+	queued := c.queued
+	c.queued = nil
+	for i := 0; i < depth; i++ {
+		c.mine()
+	}
+	c.queued = queued
+}
+
+func mustParseABI(raw string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(raw))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+// newAddress derives a deterministic address for contracts created by the fake chain
+func (c *Chain) newAddress(creator common.Address) common.Address {
+	c.created++
+	return crypto.CreateAddress(creator, c.created)
+}
+
+func (c *Chain) ERC721SwapAgentAddr() common.Address {
+	return c.conf.ERC721SwapAgentAddr
+}
+
+func (c *Chain) ERC721BatchSwapAgentAddr() common.Address {
+	return c.conf.ERC721BatchSwapAgentAddr
+}
+
+func (c *Chain) ERC1155SwapAgentAddr() common.Address {
+	return c.conf.ERC1155SwapAgentAddr
+}
+
+func (c *Chain) ERC20SwapAgentAddr() common.Address {
+	return c.conf.ERC20SwapAgentAddr
+}
@@ -0,0 +1,121 @@
+package fakechain
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// Emit queues an event emitted by a contract, it is included in the next mined block.
+// Arguments are given in the order of the event inputs. It returns the hash of the emitting transaction.
+func (c *Chain) Emit(contract common.Address, contractABI abi.ABI, event string, args ...interface{}) common.Hash {
+	log, err := makeLog(contract, contractABI, event, args...)
+	if err != nil {
+		panic(errors.Wrapf(err, "[Chain.Emit]: failed to make %s log", event))
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.salt++
+	log.TxHash = crypto.Keccak256Hash(c.conf.ChainID.Bytes(), new(big.Int).SetUint64(c.salt).Bytes())
+	c.queued = append(c.queued, log)
+
+	return log.TxHash
+}
+
+func (c *Chain) RegisterERC721SwapPair(sponsor, tokenAddr common.Address, tokenName, tokenSymbol string, toChainID *big.Int) common.Hash {
+	return c.Emit(c.conf.ERC721SwapAgentAddr, erc721AgentABI, "SwapPairRegister",
+		sponsor, tokenAddr, tokenName, tokenSymbol, toChainID, big.NewInt(0))
+}
+
+func (c *Chain) StartERC721Swap(tokenAddr, sender, recipient common.Address, dstChainID, tokenID *big.Int) common.Hash {
+	return c.Emit(c.conf.ERC721SwapAgentAddr, erc721AgentABI, "SwapStarted",
+		tokenAddr, sender, recipient, dstChainID, tokenID, big.NewInt(0))
+}
+
+func (c *Chain) StartERC721BackwardSwap(mirroredTokenAddr, sender, recipient common.Address, dstChainID, tokenID *big.Int) common.Hash {
+	return c.Emit(c.conf.ERC721SwapAgentAddr, erc721AgentABI, "BackwardSwapStarted",
+		mirroredTokenAddr, sender, recipient, dstChainID, tokenID, big.NewInt(0))
+}
+
+func (c *Chain) StartERC721BatchSwap(tokenAddr, sender, recipient common.Address, dstChainID *big.Int, tokenIDs []*big.Int) common.Hash {
+	return c.Emit(c.conf.ERC721BatchSwapAgentAddr, erc721BatchAgentABI, "BatchSwapStarted",
+		tokenAddr, sender, recipient, dstChainID, tokenIDs, big.NewInt(0))
+}
+
+func (c *Chain) StartERC721BatchBackwardSwap(mirroredTokenAddr, sender, recipient common.Address, dstChainID *big.Int, tokenIDs []*big.Int) common.Hash {
+	return c.Emit(c.conf.ERC721BatchSwapAgentAddr, erc721BatchAgentABI, "BatchBackwardSwapStarted",
+		mirroredTokenAddr, sender, recipient, dstChainID, tokenIDs, big.NewInt(0))
+}
+
+func (c *Chain) RegisterERC1155SwapPair(sponsor, tokenAddr common.Address, toChainID *big.Int) common.Hash {
+	return c.Emit(c.conf.ERC1155SwapAgentAddr, erc1155AgentABI, "SwapPairRegister",
+		sponsor, tokenAddr, toChainID, big.NewInt(0))
+}
+
+func (c *Chain) StartERC1155Swap(tokenAddr, sender, recipient common.Address, dstChainID *big.Int, ids, amounts []*big.Int) common.Hash {
+	return c.Emit(c.conf.ERC1155SwapAgentAddr, erc1155AgentABI, "SwapStarted",
+		tokenAddr, sender, recipient, dstChainID, ids, amounts, big.NewInt(0))
+}
+
+func (c *Chain) StartERC1155BackwardSwap(mirroredTokenAddr, sender, recipient common.Address, dstChainID *big.Int, ids, amounts []*big.Int) common.Hash {
+	return c.Emit(c.conf.ERC1155SwapAgentAddr, erc1155AgentABI, "BackwardSwapStarted",
+		mirroredTokenAddr, sender, recipient, dstChainID, ids, amounts, big.NewInt(0))
+}
+
+func (c *Chain) RegisterERC20SwapPair(sponsor, tokenAddr common.Address, tokenName, tokenSymbol string, tokenDecimals uint8, toChainID *big.Int) common.Hash {
+	return c.Emit(c.conf.ERC20SwapAgentAddr, erc20AgentABI, "SwapPairRegister",
+		sponsor, tokenAddr, tokenName, tokenSymbol, tokenDecimals, toChainID, big.NewInt(0))
+}
+
+func (c *Chain) StartERC20Swap(tokenAddr, sender, recipient common.Address, dstChainID, amount *big.Int) common.Hash {
+	return c.Emit(c.conf.ERC20SwapAgentAddr, erc20AgentABI, "SwapStarted",
+		tokenAddr, sender, recipient, dstChainID, amount, big.NewInt(0))
+}
+
+func (c *Chain) StartERC20BackwardSwap(mirroredTokenAddr, sender, recipient common.Address, dstChainID, amount *big.Int) common.Hash {
+	return c.Emit(c.conf.ERC20SwapAgentAddr, erc20AgentABI, "BackwardSwapStarted",
+		mirroredTokenAddr, sender, recipient, dstChainID, amount, big.NewInt(0))
+}
+
+// makeLog encodes an event, indexed arguments become topics and the others are packed into data
+func makeLog(contract common.Address, contractABI abi.ABI, event string, args ...interface{}) (*types.Log, error) {
+	ev, ok := contractABI.Events[event]
+	if !ok {
+		return nil, errors.Errorf("[makeLog]: event %s is not found", event)
+	}
+	if len(args) != len(ev.Inputs) {
+		return nil, errors.Errorf("[makeLog]: event %s expects %d arguments, got %d", event, len(ev.Inputs), len(args))
+	}
+
+	topics := []common.Hash{ev.ID}
+	var data []interface{}
+	for idx, input := range ev.Inputs {
+		if !input.Indexed {
+			data = append(data, args[idx])
+			continue
+		}
+
+		topic, err := abi.MakeTopics([]interface{}{args[idx]})
+		if err != nil {
+			return nil, errors.Wrapf(err, "[makeLog]: failed to make topic of %s", input.Name)
+		}
+		topics = append(topics, topic[0][0])
+	}
+
+	packed, err := ev.Inputs.NonIndexed().Pack(data...)
+	if err != nil {
+		return nil, errors.Wrap(err, "[makeLog]: failed to pack data")
+	}
+
+	return &types.Log{
+		Address: contract,
+		Topics:  topics,
+		Data:    packed,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package fakechain
+
+type FaultKind string
+
+const (
+	// FaultEstimateGas fails gas estimation, which makes dry runs fail
+	FaultEstimateGas FaultKind = "estimate_gas"
+	// FaultSend rejects the transaction when it is sent
+	FaultSend FaultKind = "send"
+	// FaultDrop accepts the transaction but never mines it
+	FaultDrop FaultKind = "drop"
+	// FaultRevert mines the transaction with a failed status and no events
+	FaultRevert FaultKind = "revert"
+)
+
+// Fault makes the next call of a contract method misbehave
+type Fault struct {
+	Kind   FaultKind
+	Reason string
+}
+
+// InjectFault makes the next call of method, for example "fill" or "createSwapPair", misbehave. A reverting fill
+// of a fillBatch only reverts itself.
+func (c *Chain) InjectFault(method string, f Fault) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if f.Reason == "" {
+		f.Reason = string(f.Kind)
+	}
+
+	c.faults[method] = append(c.faults[method], f)
+}
+
+func (c *Chain) takeFault(method string, kind FaultKind) (Fault, bool) {
+	faults := c.faults[method]
+	for idx, f := range faults {
+		if f.Kind != kind {
+			continue
+		}
+
+		c.faults[method] = append(faults[:idx:idx], faults[idx+1:]...)
+		return f, true
+	}
+
+	return Fault{}, false
+}
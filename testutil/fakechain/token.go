@@ -0,0 +1,71 @@
+package fakechain
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// Token implements the ERC721 and ERC1155 token readers with scripted metadata
+type Token struct {
+	mutex     sync.RWMutex
+	baseURIs  map[string]string
+	tokenURIs map[string]map[string]string
+	uris      map[string]string
+}
+
+func NewToken() *Token {
+	return &Token{
+		baseURIs:  make(map[string]string),
+		tokenURIs: make(map[string]map[string]string),
+		uris:      make(map[string]string),
+	}
+}
+
+func (t *Token) SetBaseURI(tokenAddr, uri string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.baseURIs[strings.ToLower(tokenAddr)] = uri
+}
+
+func (t *Token) SetTokenURI(tokenAddr string, tokenID *big.Int, uri string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	addr := strings.ToLower(tokenAddr)
+	if _, ok := t.tokenURIs[addr]; !ok {
+		t.tokenURIs[addr] = make(map[string]string)
+	}
+	t.tokenURIs[addr][tokenID.String()] = uri
+}
+
+func (t *Token) SetURI(tokenAddr, uri string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.uris[strings.ToLower(tokenAddr)] = uri
+}
+
+func (t *Token) TokenURI(opts *bind.CallOpts, tokenAddr string, tokenId *big.Int) (string, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.tokenURIs[strings.ToLower(tokenAddr)][tokenId.String()], nil
+}
+
+func (t *Token) BaseURI(opts *bind.CallOpts, tokenAddr string) (string, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.baseURIs[strings.ToLower(tokenAddr)], nil
+}
+
+func (t *Token) URI(opts *bind.CallOpts, tokenAddr string) (string, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.uris[strings.ToLower(tokenAddr)], nil
+}
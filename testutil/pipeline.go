@@ -0,0 +1,335 @@
+package testutil
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	contractabi "github.com/synycboom/bsc-evm-compatible-bridge-core/abi"
+	erc1155agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc1155"
+	erc20agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc20"
+	erc721agent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/erc721"
+	fillbatchagent "github.com/synycboom/bsc-evm-compatible-bridge-core/agent/fillbatch"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/alert"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/client"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/head"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/lease"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/notify"
+	observer "github.com/synycboom/bsc-evm-compatible-bridge-core/observer"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/recorder"
+	sengine "github.com/synycboom/bsc-evm-compatible-bridge-core/swap-engine"
+	spengine "github.com/synycboom/bsc-evm-compatible-bridge-core/swap-pair-engine"
+	erc1155token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc1155"
+	erc721token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc721"
+)
+
+// Chain is a chain the pipeline runs against, e.g. a fakechain.Chain or a simchain.Chain
+type Chain interface {
+	client.ETHClient
+	bind.ContractBackend
+	ChainID() *big.Int
+	ERC721SwapAgentAddr() common.Address
+	ERC721BatchSwapAgentAddr() common.Address
+	ERC1155SwapAgentAddr() common.Address
+	ERC20SwapAgentAddr() common.Address
+}
+
+// Token reads the ERC721 and ERC1155 tokens of a chain
+type Token interface {
+	erc721token.IToken
+	erc1155token.IToken
+}
+
+type ChainConfig struct {
+	Name        string
+	Chain       Chain
+	Token       Token
+	StartHeight int64
+	ConfirmNum  int64
+	// ERC721BatchFillSize caps the chunks of the batch swaps requested on the chain, see util.ChainConfig
+	ERC721BatchFillSize int
+	// FillBatchSize batches the fills sent to the chain when it is larger than 1, see util.ChainConfig
+	FillBatchSize   int
+	FillBatchWindow time.Duration
+}
+
+type Config struct {
+	// PrivateKey is the hex encoded key the engines sign transactions with
+	PrivateKey         string
+	HMACKey            string
+	FetchInterval      time.Duration
+	BlockUpdateTimeout time.Duration
+	MaxTrackRetry      int64
+	// LeaseHolder enables leader election when it is set, pipelines sharing a database then act as replicas
+	LeaseHolder   string
+	LeaseDuration time.Duration
+	// SweepInterval enables the notification bus when it is set, the engine loops then run on notifications
+	SweepInterval time.Duration
+	Chains        []*ChainConfig
+}
+
+type Dependencies struct {
+	DB      *gorm.DB
+	Alerter alert.Dispatcher
+}
+
+// Pipeline wires the observers, recorders and engines of every chain the same way main does
+type Pipeline struct {
+	conf *Config
+	deps *Dependencies
+
+	Leases          *lease.Manager
+	Bus             *notify.Bus
+	Heads           map[string]*head.Tracker
+	Recorders       map[string]recorder.IRecorder
+	Observers       map[string]*observer.Observer
+	SwapEngines     map[string]*sengine.Engine
+	SwapPairEngines map[string]*spengine.Engine
+}
+
+func NewPipeline(c *Config, d *Dependencies) *Pipeline {
+	if c.FetchInterval == 0 {
+		c.FetchInterval = 100 * time.Millisecond
+	}
+	if c.BlockUpdateTimeout == 0 {
+		c.BlockUpdateTimeout = time.Minute
+	}
+	if c.MaxTrackRetry == 0 {
+		c.MaxTrackRetry = 3
+	}
+
+	erc721SwapAgents := make(map[string]erc721agent.SwapAgent)
+	erc721SwapAgentAddresses := make(map[string]common.Address)
+	erc721Tokens := make(map[string]erc721token.IToken)
+	erc721BatchSwapAgents := make(map[string]erc721agent.BatchSwapAgent)
+	erc1155SwapAgents := make(map[string]erc1155agent.SwapAgent)
+	erc1155SwapAgentAddresses := make(map[string]common.Address)
+	erc1155Tokens := make(map[string]erc1155token.IToken)
+	erc20SwapAgents := make(map[string]erc20agent.SwapAgent)
+	erc20SwapAgentAddresses := make(map[string]common.Address)
+	fillBatchers := make(map[string]map[common.Address]fillbatchagent.FillBatcher)
+	fillBatches := make(map[string]*sengine.FillBatch)
+	clients := make(map[string]client.ETHClient)
+	for _, cc := range c.Chains {
+		id := cc.Chain.ChainID().String()
+		erc721SwapAgentAddr := cc.Chain.ERC721SwapAgentAddr()
+		erc721SwapAgent, err := contractabi.NewERC721SwapAgent(erc721SwapAgentAddr, cc.Chain)
+		if err != nil {
+			panic(errors.Wrap(err, "[NewPipeline]: failed to create ERC721 swap agent"))
+		}
+
+		erc1155SwapAgentAddr := cc.Chain.ERC1155SwapAgentAddr()
+		erc1155SwapAgent, err := contractabi.NewERC1155SwapAgent(erc1155SwapAgentAddr, cc.Chain)
+		if err != nil {
+			panic(errors.Wrap(err, "[NewPipeline]: failed to create ERC1155 swap agent"))
+		}
+
+		clients[id] = cc.Chain
+		erc721Tokens[id] = cc.Token
+		erc721SwapAgents[id] = erc721SwapAgent
+		erc721SwapAgentAddresses[id] = erc721SwapAgentAddr
+
+		if addr := cc.Chain.ERC721BatchSwapAgentAddr(); addr != (common.Address{}) {
+			erc721BatchSwapAgent, err := contractabi.NewERC721BatchSwapAgent(addr, cc.Chain)
+			if err != nil {
+				panic(errors.Wrap(err, "[NewPipeline]: failed to create ERC721 batch swap agent"))
+			}
+
+			erc721BatchSwapAgents[id] = erc721BatchSwapAgent
+		}
+
+		erc1155Tokens[id] = cc.Token
+		erc1155SwapAgents[id] = erc1155SwapAgent
+		erc1155SwapAgentAddresses[id] = erc1155SwapAgentAddr
+
+		if addr := cc.Chain.ERC20SwapAgentAddr(); addr != (common.Address{}) {
+			erc20SwapAgent, err := contractabi.NewERC20SwapAgent(addr, cc.Chain)
+			if err != nil {
+				panic(errors.Wrap(err, "[NewPipeline]: failed to create ERC20 swap agent"))
+			}
+
+			erc20SwapAgents[id] = erc20SwapAgent
+			erc20SwapAgentAddresses[id] = addr
+		}
+
+		if cc.FillBatchSize > 1 {
+			fillBatchers[id] = make(map[common.Address]fillbatchagent.FillBatcher)
+			for _, addr := range []common.Address{erc721SwapAgentAddr, erc1155SwapAgentAddr, cc.Chain.ERC20SwapAgentAddr()} {
+				if addr == (common.Address{}) {
+					continue
+				}
+
+				fillBatcher, err := contractabi.NewFillBatcher(addr, cc.Chain)
+				if err != nil {
+					panic(errors.Wrap(err, "[NewPipeline]: failed to create fill batcher"))
+				}
+
+				fillBatchers[id][addr] = fillBatcher
+			}
+			fillBatches[id] = &sengine.FillBatch{
+				Window: cc.FillBatchWindow,
+				Size:   cc.FillBatchSize,
+			}
+		}
+	}
+
+	p := &Pipeline{
+		conf:            c,
+		deps:            d,
+		Heads:           make(map[string]*head.Tracker),
+		Recorders:       make(map[string]recorder.IRecorder),
+		Observers:       make(map[string]*observer.Observer),
+		SwapEngines:     make(map[string]*sengine.Engine),
+		SwapPairEngines: make(map[string]*spengine.Engine),
+	}
+	if c.LeaseHolder != "" {
+		if c.LeaseDuration == 0 {
+			c.LeaseDuration = 2 * time.Second
+		}
+
+		p.Leases = lease.NewManager(&lease.Config{
+			Holder:        c.LeaseHolder,
+			Duration:      c.LeaseDuration,
+			RenewInterval: c.LeaseDuration / 4,
+		}, &lease.Dependencies{
+			DB: d.DB.Session(&gorm.Session{}),
+		})
+	}
+
+	if c.SweepInterval != 0 {
+		p.Bus = notify.NewBus()
+	}
+
+	for _, cc := range c.Chains {
+		id := cc.Chain.ChainID().String()
+		p.Heads[id] = head.NewTracker(&head.Config{
+			ChainID:      id,
+			PollInterval: c.FetchInterval,
+			StaleAfter:   c.BlockUpdateTimeout,
+		}, &head.Dependencies{
+			Client: cc.Chain,
+		})
+
+		p.Recorders[id] = recorder.NewRecorder(&recorder.Config{
+			ChainID:   cc.Chain.ChainID(),
+			ChainName: cc.Name,
+			HMACKey:   c.HMACKey,
+		}, &recorder.Dependencies{
+			Client:               clients,
+			DB:                   d.DB.Session(&gorm.Session{}),
+			ERC721SwapAgent:      erc721SwapAgents,
+			ERC721BatchSwapAgent: erc721BatchSwapAgents,
+			ERC721Token:          erc721Tokens,
+			ERC1155SwapAgent:     erc1155SwapAgents,
+			ERC1155Token:         erc1155Tokens,
+			ERC20SwapAgent:       erc20SwapAgents,
+			Head:                 p.Heads[id],
+		})
+	}
+
+	for _, cc := range c.Chains {
+		id := cc.Chain.ChainID().String()
+		p.Observers[id] = observer.NewObserver(&observer.Config{
+			StartHeight:        cc.StartHeight,
+			ConfirmNum:         cc.ConfirmNum,
+			FetchInterval:      c.FetchInterval,
+			BlockUpdateTimeout: c.BlockUpdateTimeout,
+		}, &observer.Dependencies{
+			DB:       d.DB.Session(&gorm.Session{}),
+			Recorder: p.Recorders[id],
+			Alerter:  d.Alerter,
+			Leader:   p.leader("observer/" + id),
+			Bus:      p.Bus,
+			Head:     p.Heads[id],
+		})
+
+		p.SwapPairEngines[id] = spengine.NewEngine(&spengine.Config{
+			ChainID:                   cc.Chain.ChainID(),
+			ConfirmNum:                cc.ConfirmNum,
+			PrivateKey:                c.PrivateKey,
+			MaxTrackRetry:             c.MaxTrackRetry,
+			ERC721SwapAgentAddresses:  erc721SwapAgentAddresses,
+			ERC1155SwapAgentAddresses: erc1155SwapAgentAddresses,
+			ERC20SwapAgentAddresses:   erc20SwapAgentAddresses,
+			SweepInterval:             c.SweepInterval,
+		}, &spengine.Dependencies{
+			Client:           clients,
+			DB:               d.DB.Session(&gorm.Session{}),
+			Recorder:         p.Recorders,
+			ERC721SwapAgent:  erc721SwapAgents,
+			ERC1155SwapAgent: erc1155SwapAgents,
+			ERC20SwapAgent:   erc20SwapAgents,
+			Heads:            p.Heads,
+			Leader:           p.leader("swap-pair-engine/" + id),
+			Bus:              p.Bus,
+		})
+
+		p.SwapEngines[id] = sengine.NewEngine(&sengine.Config{
+			ChainID:                   cc.Chain.ChainID(),
+			ConfirmNum:                cc.ConfirmNum,
+			PrivateKey:                c.PrivateKey,
+			MaxTrackRetry:             c.MaxTrackRetry,
+			ERC721SwapAgentAddresses:  erc721SwapAgentAddresses,
+			ERC1155SwapAgentAddresses: erc1155SwapAgentAddresses,
+			ERC20SwapAgentAddresses:   erc20SwapAgentAddresses,
+			ERC721BatchFillSize:       cc.ERC721BatchFillSize,
+			FillBatches:               fillBatches,
+			SweepInterval:             c.SweepInterval,
+		}, &sengine.Dependencies{
+			Client:               clients,
+			DB:                   d.DB.Session(&gorm.Session{}),
+			Recorder:             p.Recorders,
+			ERC721SwapAgent:      erc721SwapAgents,
+			ERC721BatchSwapAgent: erc721BatchSwapAgents,
+			ERC721Token:          erc721Tokens,
+			ERC1155SwapAgent:     erc1155SwapAgents,
+			ERC1155Token:         erc1155Tokens,
+			ERC20SwapAgent:       erc20SwapAgents,
+			FillBatcher:          fillBatchers,
+			Heads:                p.Heads,
+			Leader:               p.leader("swap-engine/" + id),
+			Bus:                  p.Bus,
+		})
+	}
+
+	return p
+}
+
+// Start starts the head trackers, observers and engines of every chain, they stop once ctx is done
+func (p *Pipeline) Start(ctx context.Context) {
+	if p.Leases != nil {
+		p.Leases.Start()
+	}
+
+	for _, cc := range p.conf.Chains {
+		id := cc.Chain.ChainID().String()
+		p.Heads[id].Start(ctx)
+		p.Observers[id].Start(ctx)
+		p.SwapPairEngines[id].Start(ctx)
+		p.SwapEngines[id].Start(ctx)
+	}
+}
+
+// Wait blocks until the head trackers, observers and engines started by Start have returned
+func (p *Pipeline) Wait() {
+	for _, cc := range p.conf.Chains {
+		id := cc.Chain.ChainID().String()
+		p.Heads[id].Wait()
+		p.Observers[id].Wait()
+		p.SwapPairEngines[id].Wait()
+		p.SwapEngines[id].Wait()
+	}
+}
+
+func (p *Pipeline) leader(name string) lease.Leader {
+	if p.Leases == nil {
+		return nil
+	}
+
+	return p.Leases.Lease(name)
+}
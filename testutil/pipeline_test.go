@@ -0,0 +1,623 @@
+package testutil_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/block"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/recorder"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/testutil"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/testutil/fakechain"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+var (
+	chainIDA = big.NewInt(97)
+	chainIDB = big.NewInt(4)
+
+	sponsor   = common.HexToAddress("0x5")
+	sender    = common.HexToAddress("0x5")
+	recipient = common.HexToAddress("0x6")
+)
+
+// harness runs a pipeline over two fake chains, A and B, which are mined every 200ms until the test ends
+type harness struct {
+	t *testing.T
+
+	DB       *gorm.DB
+	A        *fakechain.Chain
+	B        *fakechain.Chain
+	TokenA   *fakechain.Token
+	TokenB   *fakechain.Token
+	Alerter  *testutil.Alerter
+	Pipeline *testutil.Pipeline
+}
+
+// newHarness starts the pipeline, configure may change the configs of the chains and of the pipeline over them before
+// they are created
+func newHarness(t *testing.T, configure ...func(a, b *fakechain.Config, pa, pb *testutil.ChainConfig)) *harness {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	confA := &fakechain.Config{
+		ChainID:              chainIDA,
+		ERC721SwapAgentAddr:  common.HexToAddress("0xa1"),
+		ERC1155SwapAgentAddr: common.HexToAddress("0xa2"),
+		ERC20SwapAgentAddr:   common.HexToAddress("0xa3"),
+		// batch swaps are enabled on both chains
+		ERC721BatchSwapAgentAddr: common.HexToAddress("0xa4"),
+	}
+	confB := &fakechain.Config{
+		ChainID:                  chainIDB,
+		MineDelay:                1,
+		ERC721SwapAgentAddr:      common.HexToAddress("0xb1"),
+		ERC1155SwapAgentAddr:     common.HexToAddress("0xb2"),
+		ERC20SwapAgentAddr:       common.HexToAddress("0xb3"),
+		ERC721BatchSwapAgentAddr: common.HexToAddress("0xb4"),
+	}
+	pipelineA := &testutil.ChainConfig{Name: "A", StartHeight: 1, ConfirmNum: 2}
+	pipelineB := &testutil.ChainConfig{Name: "B", StartHeight: 1, ConfirmNum: 2}
+	for _, c := range configure {
+		c(confA, confB, pipelineA, pipelineB)
+	}
+
+	h := &harness{
+		t:       t,
+		DB:      testutil.NewDB(),
+		A:       fakechain.NewChain(confA),
+		B:       fakechain.NewChain(confB),
+		TokenA:  fakechain.NewToken(),
+		TokenB:  fakechain.NewToken(),
+		Alerter: testutil.NewAlerter(),
+	}
+
+	pipelineA.Chain, pipelineA.Token = h.A, h.TokenA
+	pipelineB.Chain, pipelineB.Token = h.B, h.TokenB
+
+	p := testutil.NewPipeline(&testutil.Config{
+		PrivateKey:    common.Bytes2Hex(crypto.FromECDSA(key)),
+		Chains:        []*testutil.ChainConfig{pipelineA, pipelineB},
+		SweepInterval: 30 * time.Second,
+	}, &testutil.Dependencies{
+		DB:      h.DB,
+		Alerter: h.Alerter,
+	})
+
+	h.Pipeline = p
+
+	h.A.Mine(1)
+	h.B.Mine(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.Start(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(200 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				h.A.Mine(1)
+				h.B.Mine(1)
+			}
+		}
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		p.Wait()
+	})
+
+	return h
+}
+
+// wait polls cond until it holds, failing the test after a minute
+func (h *harness) wait(what string, cond func() bool) {
+	h.t.Helper()
+
+	deadline := time.Now().Add(time.Minute)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	h.t.Fatalf("timed out waiting for %s", what)
+}
+
+// states returns the states an entity went through, oldest first
+func (h *harness) states(entityType transition.EntityType, entityID string) []string {
+	h.t.Helper()
+
+	tt, err := transition.Timeline(h.DB, entityType, entityID)
+	if err != nil {
+		h.t.Fatalf("failed to query transitions: %v", err)
+	}
+
+	states := make([]string, 0, len(tt))
+	for _, t := range tt {
+		states = append(states, t.ToState)
+	}
+
+	return states
+}
+
+func (h *harness) assertStates(entityType transition.EntityType, entityID string, want ...string) {
+	h.t.Helper()
+
+	got := h.states(entityType, entityID)
+	if len(got) != len(want) {
+		h.t.Fatalf("unexpected transitions of %s %s, got %v, want %v", entityType, entityID, got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			h.t.Fatalf("unexpected transitions of %s %s, got %v, want %v", entityType, entityID, got, want)
+		}
+	}
+}
+
+func (h *harness) erc721Swap(requestTxHash common.Hash) *erc721.Swap {
+	var s erc721.Swap
+	if err := h.DB.Where("request_tx_hash = ?", requestTxHash.String()).First(&s).Error; err != nil {
+		return nil
+	}
+
+	return &s
+}
+
+func (h *harness) erc721SwapInState(requestTxHash common.Hash, state erc721.SwapState) func() bool {
+	return func() bool {
+		s := h.erc721Swap(requestTxHash)
+		return s != nil && s.State == state
+	}
+}
+
+func (h *harness) erc1155Swap(requestTxHash common.Hash) *erc1155.Swap {
+	var s erc1155.Swap
+	if err := h.DB.Where("request_tx_hash = ?", requestTxHash.String()).First(&s).Error; err != nil {
+		return nil
+	}
+
+	return &s
+}
+
+func (h *harness) erc20Swap(requestTxHash common.Hash) *erc20.Swap {
+	var s erc20.Swap
+	if err := h.DB.Where("request_tx_hash = ?", requestTxHash.String()).First(&s).Error; err != nil {
+		return nil
+	}
+
+	return &s
+}
+
+func (h *harness) erc20SwapInState(requestTxHash common.Hash, state erc20.SwapState) func() bool {
+	return func() bool {
+		s := h.erc20Swap(requestTxHash)
+		return s != nil && s.State == state
+	}
+}
+
+// createERC721Pair registers an ERC721 token of chain A on chain B and returns the mirrored token
+func (h *harness) createERC721Pair(token common.Address) common.Address {
+	h.t.Helper()
+
+	txHash := h.A.RegisterERC721SwapPair(sponsor, token, "Token", "TKN", chainIDB)
+	h.wait("the ERC721 swap pair creation", func() bool {
+		var sp erc721.SwapPair
+		err := h.DB.Where("register_tx_hash = ?", txHash.String()).First(&sp).Error
+		return err == nil && sp.State == erc721.SwapPairStateCreationTxConfirmed
+	})
+
+	mirrored, ok := h.B.MirroredToken(fakechain.StandardERC721, chainIDA, token)
+	if !ok {
+		h.t.Fatal("mirrored ERC721 token was not created on chain B")
+	}
+
+	return mirrored
+}
+
+func TestERC721SwapRoundTrip(t *testing.T) {
+	h := newHarness(t)
+	token := common.HexToAddress("0x70")
+	tokenID := big.NewInt(7)
+	h.TokenA.SetTokenURI(token.String(), tokenID, "ipfs://7")
+
+	mirrored := h.createERC721Pair(token)
+
+	var sp erc721.SwapPair
+	if err := h.DB.Where("src_token_addr = ?", token.String()).First(&sp).Error; err != nil {
+		t.Fatalf("failed to query swap pair: %v", err)
+	}
+	if sp.DstTokenAddr != mirrored.String() {
+		t.Errorf("unexpected mirrored token %s, want %s", sp.DstTokenAddr, mirrored.String())
+	}
+	h.assertStates(transition.EntityTypeERC721SwapPair, sp.ID,
+		string(erc721.SwapPairStateRegistrationOngoing),
+		string(erc721.SwapPairStateRegistrationConfirmed),
+		string(erc721.SwapPairStateCreationTxCreated),
+		string(erc721.SwapPairStateCreationTxSent),
+		string(erc721.SwapPairStateCreationTxConfirmed),
+	)
+
+	forward := h.A.StartERC721Swap(token, sender, recipient, chainIDB, tokenID)
+	h.wait("the forward swap", h.erc721SwapInState(forward, erc721.SwapStateFillTxConfirmed))
+
+	s := h.erc721Swap(forward)
+	if s.TokenURI != "ipfs://7" {
+		t.Errorf("unexpected token uri %q", s.TokenURI)
+	}
+	h.assertStates(transition.EntityTypeERC721Swap, s.ID,
+		string(erc721.SwapStateRequestOngoing),
+		string(erc721.SwapStateRequestConfirmed),
+		string(erc721.SwapStateFillTxCreated),
+		string(erc721.SwapStateFillTxSent),
+		string(erc721.SwapStateFillTxConfirmed),
+	)
+
+	backward := h.B.StartERC721BackwardSwap(mirrored, recipient, sender, chainIDA, tokenID)
+	h.wait("the backward swap", h.erc721SwapInState(backward, erc721.SwapStateFillTxConfirmed))
+
+	var fills int
+	for _, c := range append(h.A.Calls(), h.B.Calls()...) {
+		if c.Method == "fill" {
+			fills++
+		}
+	}
+	if fills != 2 {
+		t.Errorf("expected 2 fill calls, got %d", fills)
+	}
+}
+
+func TestRescanClaimsRowsRecordedBeforeLogIndex(t *testing.T) {
+	h := newHarness(t)
+	token := common.HexToAddress("0x73")
+	tokenID := big.NewInt(3)
+	h.TokenA.SetTokenURI(token.String(), tokenID, "ipfs://3")
+	h.createERC721Pair(token)
+
+	txHash := h.A.StartERC721Swap(token, sender, recipient, chainIDB, tokenID)
+	h.wait("the swap", h.erc721SwapInState(txHash, erc721.SwapStateFillTxConfirmed))
+	s := h.erc721Swap(txHash)
+
+	// rows recorded before migration 2 have no log index
+	err := h.DB.Exec("update erc721_swaps set request_log_index = null, request_contract_addr = '' where id = ?", s.ID).Error
+	if err != nil {
+		t.Fatalf("failed to clear the log index: %v", err)
+	}
+
+	var dd []*recorder.Discovery
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		dd, err = h.Pipeline.Recorders[chainIDA.String()].Rescan(context.Background(), tx, &block.Log{
+			ChainID: chainIDA.String(),
+			Height:  s.RequestHeight,
+		})
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to rescan: %v", err)
+	}
+
+	for _, d := range dd {
+		if d.New {
+			t.Errorf("rescan recorded %s %s again", d.EntityType, d.TxHash)
+		}
+	}
+
+	var ss []erc721.Swap
+	if err := h.DB.Where("request_tx_hash = ?", txHash.String()).Find(&ss).Error; err != nil {
+		t.Fatalf("failed to query swaps: %v", err)
+	}
+	if len(ss) != 1 {
+		t.Fatalf("expected a single swap, got %d", len(ss))
+	}
+	if ss[0].RequestLogIndex == nil || *ss[0].RequestLogIndex != *s.RequestLogIndex {
+		t.Errorf("log index was not stored again, got %v", ss[0].RequestLogIndex)
+	}
+	if ss[0].RequestContractAddr != s.RequestContractAddr {
+		t.Errorf("contract address was not stored again, got %q", ss[0].RequestContractAddr)
+	}
+}
+
+func TestERC721SwapWithoutPairIsRejected(t *testing.T) {
+	h := newHarness(t)
+
+	txHash := h.A.StartERC721Swap(common.HexToAddress("0x71"), sender, recipient, chainIDB, big.NewInt(1))
+	h.wait("the swap rejection", h.erc721SwapInState(txHash, erc721.SwapStateRequestRejected))
+}
+
+func TestERC721SwapFillFaults(t *testing.T) {
+	h := newHarness(t)
+	token := common.HexToAddress("0x72")
+	h.TokenA.SetTokenURI(token.String(), big.NewInt(1), "ipfs://1")
+	h.TokenA.SetTokenURI(token.String(), big.NewInt(2), "ipfs://2")
+	h.createERC721Pair(token)
+
+	h.B.InjectFault("fill", fakechain.Fault{Kind: fakechain.FaultEstimateGas})
+	dryRun := h.A.StartERC721Swap(token, sender, recipient, chainIDB, big.NewInt(1))
+	h.wait("the failed dry run", h.erc721SwapInState(dryRun, erc721.SwapStateFillTxDryRunFailed))
+
+	h.B.InjectFault("fill", fakechain.Fault{Kind: fakechain.FaultRevert})
+	reverted := h.A.StartERC721Swap(token, sender, recipient, chainIDB, big.NewInt(2))
+	h.wait("the reverted fill", h.erc721SwapInState(reverted, erc721.SwapStateFillTxFailed))
+}
+
+func TestERC721FillBatch(t *testing.T) {
+	h := newHarness(t, func(_, _ *fakechain.Config, _, pb *testutil.ChainConfig) {
+		pb.FillBatchSize = 3
+		pb.FillBatchWindow = time.Minute
+	})
+	token := common.HexToAddress("0x75")
+	ids := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	for _, id := range ids {
+		h.TokenA.SetTokenURI(token.String(), id, "ipfs://"+id.String())
+	}
+	h.createERC721Pair(token)
+
+	// a fill reverting in the batch does not revert the others
+	h.B.InjectFault("fill", fakechain.Fault{Kind: fakechain.FaultRevert})
+	var txHashes []common.Hash
+	for _, id := range ids {
+		txHashes = append(txHashes, h.A.StartERC721Swap(token, sender, recipient, chainIDB, id))
+	}
+	for _, txHash := range txHashes {
+		h.wait("the batched swap", h.erc721SwapInState(txHash, erc721.SwapStateFillTxConfirmed))
+	}
+
+	var batches, fills []*fakechain.Call
+	for _, c := range h.B.Calls() {
+		switch c.Method {
+		case "fillBatch":
+			batches = append(batches, c)
+		case "fill":
+			fills = append(fills, c)
+		}
+	}
+	if len(batches) != 1 || len(batches[0].Calls) != 3 {
+		t.Fatalf("expected a single fillBatch call of 3 fills, got %d", len(batches))
+	}
+	if len(fills) != 1 {
+		t.Fatalf("expected the reverted fill to be sent again on its own, got %d fill calls", len(fills))
+	}
+	batch := batches[0]
+	if batch.From != fills[0].From {
+		t.Errorf("expected the fills to be sent by the relayer, got %s and %s", batch.From.String(), fills[0].From.String())
+	}
+
+	for _, inner := range batch.Calls {
+		s := h.erc721Swap(inner.Args["swapTxHash"].([32]byte))
+		if s == nil {
+			t.Fatalf("unexpected swap tx hash %x in the batch", inner.Args["swapTxHash"])
+		}
+		if s.FillBatchTxHash != batch.TxHash.String() {
+			t.Errorf("expected Swap %s to be batched in %s, got %q", s.ID, batch.TxHash.String(), s.FillBatchTxHash)
+		}
+
+		want := batch.TxHash
+		if inner.Reverted {
+			// the batch has no fill event of the Swap, which is filled again on its own
+			want = fills[0].TxHash
+			h.assertStates(transition.EntityTypeERC721Swap, s.ID,
+				string(erc721.SwapStateRequestOngoing),
+				string(erc721.SwapStateRequestConfirmed),
+				string(erc721.SwapStateFillTxCreated),
+				string(erc721.SwapStateRequestConfirmed),
+				string(erc721.SwapStateFillTxCreated),
+				string(erc721.SwapStateFillTxSent),
+				string(erc721.SwapStateFillTxConfirmed),
+			)
+		}
+		if s.FillTxHash != want.String() {
+			t.Errorf("expected Swap %s to be filled by %s, got %s", s.ID, want.String(), s.FillTxHash)
+		}
+	}
+}
+
+func TestERC721BatchSwap(t *testing.T) {
+	// a fill of 3 tokens or more needs more than half of the block gas limit of chain B
+	h := newHarness(t, func(a, b *fakechain.Config, _, _ *testutil.ChainConfig) {
+		b.TokenGas = 6000000
+	})
+	token := common.HexToAddress("0x74")
+	ids := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+	for _, id := range ids {
+		h.TokenA.SetTokenURI(token.String(), id, "ipfs://"+id.String())
+	}
+	h.createERC721Pair(token)
+
+	txHash := h.A.StartERC721BatchSwap(token, sender, recipient, chainIDB, ids)
+	h.wait("the batch swap", func() bool {
+		var s erc721.BatchSwap
+		err := h.DB.Where("request_tx_hash = ?", txHash.String()).First(&s).Error
+		return err == nil && s.State == erc721.SwapStateFillTxConfirmed
+	})
+
+	var s erc721.BatchSwap
+	if err := h.DB.Where("request_tx_hash = ?", txHash.String()).First(&s).Error; err != nil {
+		t.Fatalf("failed to query batch swap: %v", err)
+	}
+	h.assertStates(transition.EntityTypeERC721BatchSwap, s.ID,
+		string(erc721.SwapStateRequestOngoing),
+		string(erc721.SwapStateRequestConfirmed),
+		string(erc721.SwapStateFillTxCreated),
+		string(erc721.SwapStateFillTxSent),
+		string(erc721.SwapStateRequestConfirmed),
+		string(erc721.SwapStateFillTxCreated),
+		string(erc721.SwapStateFillTxSent),
+		string(erc721.SwapStateRequestConfirmed),
+		string(erc721.SwapStateFillTxCreated),
+		string(erc721.SwapStateFillTxSent),
+		string(erc721.SwapStateFillTxConfirmed),
+	)
+
+	// the tokens are filled in chunks of 2, 2 and 1 keyed by the swap tx hash
+	var fills []*fakechain.Call
+	for _, c := range h.B.Calls() {
+		if c.Method == "batchFill" {
+			fills = append(fills, c)
+		}
+	}
+	if len(fills) != 3 {
+		t.Fatalf("expected 3 batchFill calls, got %d", len(fills))
+	}
+	fillTxHashes := make(map[string]string)
+	for i, want := range [][]string{{"1", "2"}, {"3", "4"}, {"5"}} {
+		got := fills[i].Args["tokenIds"].([]*big.Int)
+		if len(got) != len(want) {
+			t.Fatalf("expected token ids %v in fill %d, got %v", want, i, got)
+		}
+		for j := range want {
+			if got[j].String() != want[j] {
+				t.Errorf("expected token ids %v in fill %d, got %v", want, i, got)
+			}
+			fillTxHashes[want[j]] = fills[i].TxHash.String()
+		}
+		if got := fills[i].Args["swapTxHash"].([32]byte); common.Hash(got) != txHash {
+			t.Errorf("unexpected swap tx hash %s", common.Hash(got).String())
+		}
+	}
+	if got := fills[1].Args["tokenURIs"].([]string); got[1] != "ipfs://4" {
+		t.Errorf("unexpected token uris %v", got)
+	}
+
+	tokens, err := s.Tokens()
+	if err != nil {
+		t.Fatalf("failed to get tokens: %v", err)
+	}
+	for _, tk := range tokens {
+		if tk.State != erc721.BatchTokenStateFilled || tk.FillTxHash != fillTxHashes[tk.TokenID] {
+			t.Errorf("unexpected token %+v", tk)
+		}
+	}
+	if s.FillTxHash != fills[2].TxHash.String() {
+		t.Errorf("expected the last fill tx %s, got %s", fills[2].TxHash.String(), s.FillTxHash)
+	}
+}
+
+func TestERC20Swap(t *testing.T) {
+	h := newHarness(t)
+	token := common.HexToAddress("0x90")
+
+	pairTx := h.A.RegisterERC20SwapPair(sponsor, token, "USD Coin", "USDC", 6, chainIDB)
+	var sp erc20.SwapPair
+	h.wait("the ERC20 swap pair creation", func() bool {
+		err := h.DB.Where("register_tx_hash = ?", pairTx.String()).First(&sp).Error
+		return err == nil && sp.State == erc20.SwapPairStateCreationTxConfirmed
+	})
+	if sp.Decimals != 6 || sp.Symbol != "USDC" {
+		t.Errorf("unexpected decimals %d and symbol %q", sp.Decimals, sp.Symbol)
+	}
+	mirrored, ok := h.B.MirroredToken(fakechain.StandardERC20, chainIDA, token)
+	if !ok {
+		t.Fatal("mirrored ERC20 token was not created on chain B")
+	}
+
+	if err := sp.SetAmountBounds("10", "1000"); err != nil {
+		t.Fatalf("failed to set amount bounds: %v", err)
+	}
+	sp.UpdateSignature("")
+	if err := model.CompareAndSave(h.DB, &sp, "test"); err != nil {
+		t.Fatalf("failed to save swap pair: %v", err)
+	}
+
+	below := h.A.StartERC20Swap(token, sender, recipient, chainIDB, big.NewInt(5))
+	forward := h.A.StartERC20Swap(token, sender, recipient, chainIDB, big.NewInt(100))
+	h.wait("the rejected swap", h.erc20SwapInState(below, erc20.SwapStateRequestRejected))
+	h.wait("the forward swap", h.erc20SwapInState(forward, erc20.SwapStateFillTxConfirmed))
+
+	s := h.erc20Swap(forward)
+	h.assertStates(transition.EntityTypeERC20Swap, s.ID,
+		string(erc20.SwapStateRequestOngoing),
+		string(erc20.SwapStateRequestConfirmed),
+		string(erc20.SwapStateFillTxCreated),
+		string(erc20.SwapStateFillTxSent),
+		string(erc20.SwapStateFillTxConfirmed),
+	)
+	// the fee is the gas used by the fill at its gas price
+	gasPrice, err := util.ParseBigInt(s.FillGasPrice)
+	if err != nil {
+		t.Fatalf("invalid gas price: %v", err)
+	}
+	fee := new(big.Int).Mul(gasPrice, big.NewInt(s.FillGasUsed))
+	if s.FillGasUsed == 0 || s.FillConsumedFeeAmount != fee.String() {
+		t.Errorf("unexpected fee %s for %d gas at %s", s.FillConsumedFeeAmount, s.FillGasUsed, s.FillGasPrice)
+	}
+
+	backward := h.B.StartERC20BackwardSwap(mirrored, recipient, sender, chainIDA, big.NewInt(50))
+	h.wait("the backward swap", h.erc20SwapInState(backward, erc20.SwapStateFillTxConfirmed))
+
+	var fills int
+	for _, c := range append(h.A.Calls(), h.B.Calls()...) {
+		if c.Standard == fakechain.StandardERC20 && c.Method == "fill" {
+			fills++
+		}
+	}
+	if fills != 2 {
+		t.Errorf("expected 2 ERC20 fill calls, got %d", fills)
+	}
+}
+
+func TestERC20SwapPairWithoutAgentOnDestination(t *testing.T) {
+	h := newHarness(t, func(a, b *fakechain.Config, _, _ *testutil.ChainConfig) {
+		b.ERC20SwapAgentAddr = common.Address{}
+	})
+
+	pairTx := h.A.RegisterERC20SwapPair(sponsor, common.HexToAddress("0x91"), "USD Coin", "USDC", 6, chainIDB)
+	h.wait("the ERC20 swap pair rejection", func() bool {
+		var sp erc20.SwapPair
+		err := h.DB.Where("register_tx_hash = ?", pairTx.String()).First(&sp).Error
+		return err == nil && sp.State == erc20.SwapPairStateUnsupportedDestination
+	})
+}
+
+func TestERC1155Swap(t *testing.T) {
+	h := newHarness(t)
+	token := common.HexToAddress("0x80")
+	h.TokenA.SetURI(token.String(), "ipfs://{id}")
+
+	pairTx := h.A.RegisterERC1155SwapPair(sponsor, token, chainIDB)
+	h.wait("the ERC1155 swap pair creation", func() bool {
+		var sp erc1155.SwapPair
+		err := h.DB.Where("register_tx_hash = ?", pairTx.String()).First(&sp).Error
+		return err == nil && sp.State == erc1155.SwapPairStateCreationTxConfirmed
+	})
+
+	ids := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	amounts := []*big.Int{big.NewInt(10), big.NewInt(20)}
+	txHash := h.A.StartERC1155Swap(token, sender, recipient, chainIDB, ids, amounts)
+	h.wait("the ERC1155 swap", func() bool {
+		s := h.erc1155Swap(txHash)
+		return s != nil && s.State == erc1155.SwapStateFillTxConfirmed
+	})
+
+	s := h.erc1155Swap(txHash)
+	h.assertStates(transition.EntityTypeERC1155Swap, s.ID,
+		string(erc1155.SwapStateRequestOngoing),
+		string(erc1155.SwapStateRequestConfirmed),
+		string(erc1155.SwapStateFillTxCreated),
+		string(erc1155.SwapStateFillTxSent),
+		string(erc1155.SwapStateFillTxConfirmed),
+	)
+}
@@ -0,0 +1,266 @@
+// Package simchain runs the swap agent and token contracts of contracts/ on go-ethereum's simulated backend. The
+// creation bytecode in testdata is built by contracts/compile.js, which also checks the contracts against the ABIs
+// bundled in abi/.
+package simchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"embed"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+
+	contractabi "github.com/synycboom/bsc-evm-compatible-bridge-core/abi"
+	corecommon "github.com/synycboom/bsc-evm-compatible-bridge-core/common"
+)
+
+//go:embed testdata/*.bin
+var bytecode embed.FS
+
+// chainConfigMutex serializes the creation of backends, see newBackend
+var chainConfigMutex sync.Mutex
+
+type Config struct {
+	ChainID *big.Int
+	// Owner deploys and initializes the swap agents, it is the key the engines sign with
+	Owner *ecdsa.PrivateKey
+	// Accounts are funded in the genesis block along with the owner
+	Accounts []common.Address
+	GasLimit uint64
+}
+
+// Chain is a simulated chain with the ERC721, ERC1155 and ERC20 swap agents deployed, the ERC721 one being
+// contracts/ERC721BatchSwapAgent.sol so that it is the batch swap agent too. It implements client.ETHClient and
+// bind.ContractBackend, and answers like a node does where the simulated backend differs.
+type Chain struct {
+	*backends.SimulatedBackend
+
+	conf *Config
+
+	erc721SwapAgentAddr  common.Address
+	erc1155SwapAgentAddr common.Address
+	erc20SwapAgentAddr   common.Address
+}
+
+// NewChain returns a chain with the swap agents deployed and initialized by the owner
+func NewChain(c *Config) (*Chain, error) {
+	if c.GasLimit == 0 {
+		c.GasLimit = 30000000
+	}
+
+	balance := new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+	alloc := core.GenesisAlloc{
+		crypto.PubkeyToAddress(c.Owner.PublicKey): {Balance: balance},
+	}
+	for _, addr := range c.Accounts {
+		alloc[addr] = core.GenesisAccount{Balance: balance}
+	}
+
+	ch := &Chain{
+		SimulatedBackend: newBackend(c.ChainID, alloc, c.GasLimit),
+		conf:             c,
+	}
+
+	var err error
+	ch.erc721SwapAgentAddr, err = ch.deployAgent("ERC721BatchSwapAgent", contractabi.ERC721SwapAgentMetaData.ABI)
+	if err != nil {
+		return nil, errors.Wrap(err, "[NewChain]: failed to deploy ERC721 swap agent")
+	}
+	ch.erc1155SwapAgentAddr, err = ch.deployAgent("ERC1155SwapAgent", contractabi.ERC1155SwapAgentMetaData.ABI)
+	if err != nil {
+		return nil, errors.Wrap(err, "[NewChain]: failed to deploy ERC1155 swap agent")
+	}
+	ch.erc20SwapAgentAddr, err = ch.deployAgent("ERC20SwapAgent", contractabi.ERC20SwapAgentMetaData.ABI)
+	if err != nil {
+		return nil, errors.Wrap(err, "[NewChain]: failed to deploy ERC20 swap agent")
+	}
+
+	return ch, nil
+}
+
+// newBackend returns a simulated backend running the chain id. The simulated backend of go-ethereum v1.10.10 takes
+// its chain config from params.AllEthashProtocolChanges, which always has chain id 1337, so the variable is swapped
+// for a copy with the chain id while the backend is created. The backend keeps the copy.
+func newBackend(chainID *big.Int, alloc core.GenesisAlloc, gasLimit uint64) *backends.SimulatedBackend {
+	chainConfigMutex.Lock()
+	defer chainConfigMutex.Unlock()
+
+	original := params.AllEthashProtocolChanges
+	defer func() {
+		params.AllEthashProtocolChanges = original
+	}()
+
+	config := *original
+	config.ChainID = new(big.Int).Set(chainID)
+	params.AllEthashProtocolChanges = &config
+
+	return backends.NewSimulatedBackend(alloc, gasLimit)
+}
+
+func (c *Chain) ChainID() *big.Int {
+	return new(big.Int).Set(c.conf.ChainID)
+}
+
+func (c *Chain) ERC721SwapAgentAddr() common.Address {
+	return c.erc721SwapAgentAddr
+}
+
+func (c *Chain) ERC1155SwapAgentAddr() common.Address {
+	return c.erc1155SwapAgentAddr
+}
+
+// ERC721BatchSwapAgentAddr returns the address of the ERC721 swap agent, which implements the batch swaps
+func (c *Chain) ERC721BatchSwapAgentAddr() common.Address {
+	return c.erc721SwapAgentAddr
+}
+
+func (c *Chain) ERC20SwapAgentAddr() common.Address {
+	return c.erc20SwapAgentAddr
+}
+
+// Mine mines the pending transactions into n blocks, the first one holds all of them
+func (c *Chain) Mine(n int) {
+	for i := 0; i < n; i++ {
+		c.Commit()
+	}
+}
+
+// TransactOpts returns the options of a transaction signed by the key for this chain
+func (c *Chain) TransactOpts(key *ecdsa.PrivateKey) *bind.TransactOpts {
+	opts, err := bind.NewKeyedTransactorWithChainID(key, c.conf.ChainID)
+	if err != nil {
+		panic(errors.Wrap(err, "[Chain.TransactOpts]: failed to create transactor"))
+	}
+
+	return opts
+}
+
+// Deploy deploys a contract of contracts/ compiled in testdata, mines it and returns its address
+func (c *Chain) Deploy(key *ecdsa.PrivateKey, name, abiJSON string, args ...interface{}) (common.Address, error) {
+	bin, err := bytecode.ReadFile(fmt.Sprintf("testdata/%s.bin", name))
+	if err != nil {
+		return common.Address{}, errors.Wrapf(err, "[Chain.Deploy]: no bytecode of %s", name)
+	}
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return common.Address{}, errors.Wrapf(err, "[Chain.Deploy]: failed to parse abi of %s", name)
+	}
+
+	addr, tx, _, err := bind.DeployContract(c.TransactOpts(key), parsed, common.FromHex(strings.TrimSpace(string(bin))), c, args...)
+	if err != nil {
+		return common.Address{}, errors.Wrapf(err, "[Chain.Deploy]: failed to deploy %s", name)
+	}
+	if err := c.MineTx(tx); err != nil {
+		return common.Address{}, errors.Wrapf(err, "[Chain.Deploy]: failed to deploy %s", name)
+	}
+
+	return addr, nil
+}
+
+// MineTx mines a block with the transaction and fails if it reverted
+func (c *Chain) MineTx(tx *types.Transaction) error {
+	c.Commit()
+
+	receipt, err := c.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return errors.Wrapf(err, "[Chain.MineTx]: no receipt of tx %s", tx.Hash().String())
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return errors.Errorf("[Chain.MineTx]: tx %s reverted", tx.Hash().String())
+	}
+
+	return nil
+}
+
+func (c *Chain) deployAgent(name, abiJSON string) (common.Address, error) {
+	addr, err := c.Deploy(c.conf.Owner, name, abiJSON)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	agent := bind.NewBoundContract(addr, mustParseABI(abiJSON), c, c, c)
+	tx, err := agent.Transact(c.TransactOpts(c.conf.Owner), "initialize")
+	if err != nil {
+		return common.Address{}, errors.Wrapf(err, "[Chain.deployAgent]: failed to initialize %s", name)
+	}
+	if err := c.MineTx(tx); err != nil {
+		return common.Address{}, errors.Wrapf(err, "[Chain.deployAgent]: failed to initialize %s", name)
+	}
+
+	return addr, nil
+}
+
+// HeaderByNumber returns corecommon.ErrBlockNotFound for blocks that are not mined yet like client.Client, the
+// simulated backend returns no header or the latest one
+func (c *Chain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return c.Blockchain().CurrentHeader(), nil
+	}
+	if !number.IsUint64() || number.Uint64() > c.Blockchain().CurrentHeader().Number.Uint64() {
+		return nil, corecommon.ErrBlockNotFound
+	}
+
+	header := c.Blockchain().GetHeaderByNumber(number.Uint64())
+	if header == nil {
+		return nil, corecommon.ErrBlockNotFound
+	}
+
+	return header, nil
+}
+
+// TransactionReceipt returns ethereum.NotFound for transactions that are not mined yet like a node does
+func (c *Chain) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, err := c.SimulatedBackend.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return receipt, nil
+}
+
+// SuggestGasPrice returns twice the base fee of the latest block, the simulated backend suggests 1 wei which is
+// below the base fee of a london block
+func (c *Chain) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	baseFee := c.Blockchain().CurrentHeader().BaseFee
+	if baseFee == nil {
+		return big.NewInt(1), nil
+	}
+
+	return new(big.Int).Mul(baseFee, big.NewInt(2)), nil
+}
+
+// SendTransaction returns an error for an invalid transaction, the simulated backend panics
+func (c *Chain) SendTransaction(ctx context.Context, tx *types.Transaction) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("[Chain.SendTransaction]: %v", r)
+		}
+	}()
+
+	return c.SimulatedBackend.SendTransaction(ctx, tx)
+}
+
+func mustParseABI(abiJSON string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(errors.Wrap(err, "[mustParseABI]: failed to parse abi"))
+	}
+
+	return parsed
+}
@@ -0,0 +1,35 @@
+package simchain
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	erc1155token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc1155"
+	erc721token "github.com/synycboom/bsc-evm-compatible-bridge-core/token/erc721"
+)
+
+// Token reads the ERC721 and ERC1155 token contracts of a simulated chain with the token readers main uses
+type Token struct {
+	erc721  *erc721token.Token
+	erc1155 *erc1155token.Token
+}
+
+func NewToken(c bind.ContractCaller) *Token {
+	return &Token{
+		erc721:  erc721token.NewToken(c),
+		erc1155: erc1155token.NewToken(c),
+	}
+}
+
+func (t *Token) TokenURI(opts *bind.CallOpts, tokenAddr string, tokenId *big.Int) (string, error) {
+	return t.erc721.TokenURI(opts, tokenAddr, tokenId)
+}
+
+func (t *Token) BaseURI(opts *bind.CallOpts, tokenAddr string) (string, error) {
+	return t.erc721.BaseURI(opts, tokenAddr)
+}
+
+func (t *Token) URI(opts *bind.CallOpts, tokenAddr string) (string, error) {
+	return t.erc1155.URI(opts, tokenAddr)
+}
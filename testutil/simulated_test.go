@@ -0,0 +1,547 @@
+package testutil_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	contractabi "github.com/synycboom/bsc-evm-compatible-bridge-core/abi"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc1155"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc20"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/erc721"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/transition"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/testutil"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/testutil/simchain"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// simHarness runs a pipeline over two simulated chains running the swap agent contracts, A and B, which are mined
+// every 200ms until the test ends. The engines sign with the owner key of the agents.
+type simHarness struct {
+	*harness
+
+	A    *simchain.Chain
+	B    *simchain.Chain
+	User *ecdsa.PrivateKey
+	// Recipient receives the swapped tokens on the other chain
+	Recipient *ecdsa.PrivateKey
+}
+
+// newSimHarness starts the pipeline, configure may change the configs of the chains before the pipeline is created
+func newSimHarness(t *testing.T, configure ...func(a, b *testutil.ChainConfig)) *simHarness {
+	owner := newKey(t)
+	h := &simHarness{
+		harness: &harness{
+			t:       t,
+			DB:      testutil.NewDB(),
+			Alerter: testutil.NewAlerter(),
+		},
+		User:      newKey(t),
+		Recipient: newKey(t),
+	}
+
+	accounts := []common.Address{
+		crypto.PubkeyToAddress(h.User.PublicKey),
+		crypto.PubkeyToAddress(h.Recipient.PublicKey),
+	}
+	var err error
+	h.A, err = simchain.NewChain(&simchain.Config{ChainID: chainIDA, Owner: owner, Accounts: accounts})
+	if err != nil {
+		t.Fatalf("failed to create chain A: %v", err)
+	}
+	h.B, err = simchain.NewChain(&simchain.Config{ChainID: chainIDB, Owner: owner, Accounts: accounts})
+	if err != nil {
+		t.Fatalf("failed to create chain B: %v", err)
+	}
+
+	confA := &testutil.ChainConfig{Name: "A", Chain: h.A, Token: simchain.NewToken(h.A), StartHeight: 1, ConfirmNum: 2}
+	confB := &testutil.ChainConfig{Name: "B", Chain: h.B, Token: simchain.NewToken(h.B), StartHeight: 1, ConfirmNum: 2}
+	for _, c := range configure {
+		c(confA, confB)
+	}
+
+	p := testutil.NewPipeline(&testutil.Config{
+		PrivateKey:    common.Bytes2Hex(crypto.FromECDSA(owner)),
+		Chains:        []*testutil.ChainConfig{confA, confB},
+		SweepInterval: 30 * time.Second,
+	}, &testutil.Dependencies{
+		DB:      h.DB,
+		Alerter: h.Alerter,
+	})
+	h.Pipeline = p
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.Start(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(200 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				h.A.Commit()
+				h.B.Commit()
+			}
+		}
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		p.Wait()
+		h.A.Close()
+		h.B.Close()
+	})
+
+	return h
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return key
+}
+
+// mined returns a function mining a transaction sent to the chain, it fails the test if the transaction reverted
+func (h *simHarness) mined(c *simchain.Chain) func(tx *types.Transaction, err error) *types.Transaction {
+	return func(tx *types.Transaction, err error) *types.Transaction {
+		h.t.Helper()
+
+		if err != nil {
+			h.t.Fatalf("failed to send tx: %v", err)
+		}
+		if err := c.MineTx(tx); err != nil {
+			h.t.Fatal(err)
+		}
+
+		return tx
+	}
+}
+
+// assertFee checks that the fee of a tx is the gas it used at its gas price, the gas used comes from the receipt
+func (h *simHarness) assertFee(what, gasPrice string, gasUsed int64, fee string) {
+	h.t.Helper()
+
+	price, err := util.ParseBigInt(gasPrice)
+	if err != nil {
+		h.t.Fatalf("invalid gas price of %s: %v", what, err)
+	}
+	want := new(big.Int).Mul(price, big.NewInt(gasUsed))
+	if gasUsed == 0 || fee != want.String() {
+		h.t.Errorf("unexpected fee %s of %s for %d gas at %s", fee, what, gasUsed, gasPrice)
+	}
+}
+
+func TestSimulatedERC721SwapRoundTrip(t *testing.T) {
+	h := newSimHarness(t)
+	user := crypto.PubkeyToAddress(h.User.PublicKey)
+	recipientAddr := crypto.PubkeyToAddress(h.Recipient.PublicKey)
+	tokenID := big.NewInt(7)
+
+	tokenAddr, err := h.A.Deploy(h.User, "ERC721Token", contractabi.ERC721TokenMetaData.ABI, "Token", "TKN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, _ := contractabi.NewERC721Token(tokenAddr, h.A)
+	h.mined(h.A)(token.SafeMint(h.A.TransactOpts(h.User), user, tokenID))
+	h.mined(h.A)(token.SetTokenURI(h.A.TransactOpts(h.User), tokenID, "ipfs://7"))
+
+	agentA, _ := contractabi.NewERC721SwapAgent(h.A.ERC721SwapAgentAddr(), h.A)
+	agentB, _ := contractabi.NewERC721SwapAgent(h.B.ERC721SwapAgentAddr(), h.B)
+
+	register := h.mined(h.A)(agentA.RegisterSwapPair(h.A.TransactOpts(h.User), tokenAddr, chainIDB))
+	h.wait("the ERC721 swap pair creation", func() bool {
+		var sp erc721.SwapPair
+		err := h.DB.Where("register_tx_hash = ?", register.Hash().String()).First(&sp).Error
+		return err == nil && sp.State == erc721.SwapPairStateCreationTxConfirmed
+	})
+
+	mirroredAddr, err := agentB.SwapMappingIncoming(nil, chainIDA, tokenAddr)
+	if err != nil || mirroredAddr == (common.Address{}) {
+		t.Fatalf("mirrored ERC721 token was not created on chain B: %v", err)
+	}
+	var sp erc721.SwapPair
+	if err := h.DB.Where("src_token_addr = ?", tokenAddr.String()).First(&sp).Error; err != nil {
+		t.Fatalf("failed to query swap pair: %v", err)
+	}
+	if sp.DstTokenAddr != mirroredAddr.String() {
+		t.Errorf("unexpected mirrored token %s, want %s", sp.DstTokenAddr, mirroredAddr.String())
+	}
+	h.assertFee("the swap pair creation", sp.CreateGasPrice, sp.CreateGasUsed, sp.CreateConsumedFeeAmount)
+	mirrored, _ := contractabi.NewERC721Token(mirroredAddr, h.B)
+	if name, _ := mirrored.Name(nil); name != "Token" {
+		t.Errorf("unexpected mirrored token name %q", name)
+	}
+
+	h.mined(h.A)(token.Approve(h.A.TransactOpts(h.User), h.A.ERC721SwapAgentAddr(), tokenID))
+	forward := h.mined(h.A)(agentA.Swap(h.A.TransactOpts(h.User), tokenAddr, recipientAddr, tokenID, chainIDB))
+	h.wait("the forward swap", h.erc721SwapInState(forward.Hash(), erc721.SwapStateFillTxConfirmed))
+
+	if owner, err := mirrored.OwnerOf(nil, tokenID); err != nil || owner != recipientAddr {
+		t.Errorf("unexpected owner %s of the mirrored token: %v", owner.String(), err)
+	}
+	if uri, err := mirrored.TokenURI(nil, tokenID); err != nil || uri != "ipfs://7" {
+		t.Errorf("unexpected uri %q of the mirrored token: %v", uri, err)
+	}
+	if owner, err := token.OwnerOf(nil, tokenID); err != nil || owner != h.A.ERC721SwapAgentAddr() {
+		t.Errorf("unexpected owner %s of the locked token: %v", owner.String(), err)
+	}
+	s := h.erc721Swap(forward.Hash())
+	h.assertFee("the forward fill", s.FillGasPrice, s.FillGasUsed, s.FillConsumedFeeAmount)
+	h.assertStates(transition.EntityTypeERC721Swap, s.ID,
+		string(erc721.SwapStateRequestOngoing),
+		string(erc721.SwapStateRequestConfirmed),
+		string(erc721.SwapStateFillTxCreated),
+		string(erc721.SwapStateFillTxSent),
+		string(erc721.SwapStateFillTxConfirmed),
+	)
+
+	h.mined(h.B)(mirrored.Approve(h.B.TransactOpts(h.Recipient), h.B.ERC721SwapAgentAddr(), tokenID))
+	backward := h.mined(h.B)(agentB.Swap(h.B.TransactOpts(h.Recipient), mirroredAddr, user, tokenID, chainIDA))
+	h.wait("the backward swap", h.erc721SwapInState(backward.Hash(), erc721.SwapStateFillTxConfirmed))
+
+	if owner, err := token.OwnerOf(nil, tokenID); err != nil || owner != user {
+		t.Errorf("unexpected owner %s of the released token: %v", owner.String(), err)
+	}
+	if _, err := mirrored.OwnerOf(nil, tokenID); err == nil {
+		t.Error("the mirrored token was not burned")
+	}
+}
+
+// erc721BatchSwapInState returns a condition for h.wait, true once the batch swap requested by the tx is in the state
+func (h *simHarness) erc721BatchSwapInState(requestTxHash common.Hash, state erc721.SwapState) func() bool {
+	return func() bool {
+		s := h.erc721BatchSwap(requestTxHash)
+		return s != nil && s.State == state
+	}
+}
+
+func (h *simHarness) erc721BatchSwap(requestTxHash common.Hash) *erc721.BatchSwap {
+	var s erc721.BatchSwap
+	if err := h.DB.Where("request_tx_hash = ?", requestTxHash.String()).First(&s).Error; err != nil {
+		return nil
+	}
+
+	return &s
+}
+
+// assertBatchFills checks that a batch swap was filled in chunks of the sizes and that every token is filled by the
+// fill tx of its chunk
+func (h *simHarness) assertBatchFills(s *erc721.BatchSwap, sizes ...int) {
+	h.t.Helper()
+
+	ff, err := s.BatchFills()
+	if err != nil {
+		h.t.Fatalf("failed to get fills: %v", err)
+	}
+	if len(ff) != len(sizes) {
+		h.t.Fatalf("expected %d fills, got %d", len(sizes), len(ff))
+	}
+	fillTxHashes := make(map[string]string)
+	for i, f := range ff {
+		if len(f.IDs) != sizes[i] {
+			h.t.Errorf("expected %d tokens in fill %d, got %v", sizes[i], i, f.IDs)
+		}
+		for _, id := range f.IDs {
+			fillTxHashes[id] = f.TxHash
+		}
+	}
+
+	tokens, err := s.Tokens()
+	if err != nil {
+		h.t.Fatalf("failed to get tokens: %v", err)
+	}
+	for _, tk := range tokens {
+		if tk.State != erc721.BatchTokenStateFilled || tk.FillTxHash == "" || tk.FillTxHash != fillTxHashes[tk.TokenID] {
+			h.t.Errorf("unexpected token %+v", tk)
+		}
+	}
+}
+
+func TestSimulatedERC721BatchSwapRoundTrip(t *testing.T) {
+	// a fill tx takes 2 tokens at most, so 5 tokens are filled by 3 txs each way
+	h := newSimHarness(t, func(a, b *testutil.ChainConfig) {
+		a.ERC721BatchFillSize = 2
+		b.ERC721BatchFillSize = 2
+	})
+	user := crypto.PubkeyToAddress(h.User.PublicKey)
+	recipientAddr := crypto.PubkeyToAddress(h.Recipient.PublicKey)
+	ids := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+
+	tokenAddr, err := h.A.Deploy(h.User, "ERC721Token", contractabi.ERC721TokenMetaData.ABI, "Token", "TKN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, _ := contractabi.NewERC721Token(tokenAddr, h.A)
+	for _, id := range ids {
+		h.mined(h.A)(token.SafeMint(h.A.TransactOpts(h.User), user, id))
+		h.mined(h.A)(token.SetTokenURI(h.A.TransactOpts(h.User), id, "ipfs://"+id.String()))
+	}
+
+	agentA, _ := contractabi.NewERC721SwapAgent(h.A.ERC721SwapAgentAddr(), h.A)
+	agentB, _ := contractabi.NewERC721SwapAgent(h.B.ERC721SwapAgentAddr(), h.B)
+	batchAgentA, _ := contractabi.NewERC721BatchSwapAgent(h.A.ERC721BatchSwapAgentAddr(), h.A)
+	batchAgentB, _ := contractabi.NewERC721BatchSwapAgent(h.B.ERC721BatchSwapAgentAddr(), h.B)
+
+	register := h.mined(h.A)(agentA.RegisterSwapPair(h.A.TransactOpts(h.User), tokenAddr, chainIDB))
+	h.wait("the ERC721 swap pair creation", func() bool {
+		var sp erc721.SwapPair
+		err := h.DB.Where("register_tx_hash = ?", register.Hash().String()).First(&sp).Error
+		return err == nil && sp.State == erc721.SwapPairStateCreationTxConfirmed
+	})
+
+	mirroredAddr, err := agentB.SwapMappingIncoming(nil, chainIDA, tokenAddr)
+	if err != nil || mirroredAddr == (common.Address{}) {
+		t.Fatalf("mirrored ERC721 token was not created on chain B: %v", err)
+	}
+	mirrored, _ := contractabi.NewERC721Token(mirroredAddr, h.B)
+
+	h.mined(h.A)(token.SetApprovalForAll(h.A.TransactOpts(h.User), h.A.ERC721BatchSwapAgentAddr(), true))
+	forward := h.mined(h.A)(batchAgentA.BatchSwap(h.A.TransactOpts(h.User), tokenAddr, recipientAddr, ids, chainIDB))
+	h.wait("the forward batch swap", h.erc721BatchSwapInState(forward.Hash(), erc721.SwapStateFillTxConfirmed))
+
+	for _, id := range ids {
+		if owner, err := mirrored.OwnerOf(nil, id); err != nil || owner != recipientAddr {
+			t.Errorf("unexpected owner %s of the mirrored token %s: %v", owner.String(), id, err)
+		}
+		if uri, err := mirrored.TokenURI(nil, id); err != nil || uri != "ipfs://"+id.String() {
+			t.Errorf("unexpected uri %q of the mirrored token %s: %v", uri, id, err)
+		}
+		if filled, err := batchAgentB.FilledToken(nil, forward.Hash(), id); err != nil || !filled {
+			t.Errorf("token %s is not filled on chain B: %v", id, err)
+		}
+	}
+	s := h.erc721BatchSwap(forward.Hash())
+	h.assertBatchFills(s, 2, 2, 1)
+	h.assertStates(transition.EntityTypeERC721BatchSwap, s.ID,
+		string(erc721.SwapStateRequestOngoing),
+		string(erc721.SwapStateRequestConfirmed),
+		string(erc721.SwapStateFillTxCreated),
+		string(erc721.SwapStateFillTxSent),
+		string(erc721.SwapStateRequestConfirmed),
+		string(erc721.SwapStateFillTxCreated),
+		string(erc721.SwapStateFillTxSent),
+		string(erc721.SwapStateRequestConfirmed),
+		string(erc721.SwapStateFillTxCreated),
+		string(erc721.SwapStateFillTxSent),
+		string(erc721.SwapStateFillTxConfirmed),
+	)
+
+	h.mined(h.B)(mirrored.SetApprovalForAll(h.B.TransactOpts(h.Recipient), h.B.ERC721BatchSwapAgentAddr(), true))
+	backward := h.mined(h.B)(batchAgentB.BatchSwap(h.B.TransactOpts(h.Recipient), mirroredAddr, user, ids, chainIDA))
+	h.wait("the backward batch swap", h.erc721BatchSwapInState(backward.Hash(), erc721.SwapStateFillTxConfirmed))
+
+	for _, id := range ids {
+		if owner, err := token.OwnerOf(nil, id); err != nil || owner != user {
+			t.Errorf("unexpected owner %s of the released token %s: %v", owner.String(), id, err)
+		}
+		if _, err := mirrored.OwnerOf(nil, id); err == nil {
+			t.Errorf("the mirrored token %s was not burned", id)
+		}
+	}
+	h.assertBatchFills(h.erc721BatchSwap(backward.Hash()), 2, 2, 1)
+}
+
+func TestSimulatedERC1155Swap(t *testing.T) {
+	h := newSimHarness(t)
+	user := crypto.PubkeyToAddress(h.User.PublicKey)
+	recipientAddr := crypto.PubkeyToAddress(h.Recipient.PublicKey)
+	ids := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	amounts := []*big.Int{big.NewInt(10), big.NewInt(20)}
+
+	tokenAddr, err := h.A.Deploy(h.User, "ERC1155Token", contractabi.ERC1155TokenMetaData.ABI, "ipfs://{id}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, _ := contractabi.NewERC1155Token(tokenAddr, h.A)
+	h.mined(h.A)(token.MintBatch(h.A.TransactOpts(h.User), user, ids, amounts, nil))
+
+	agentA, _ := contractabi.NewERC1155SwapAgent(h.A.ERC1155SwapAgentAddr(), h.A)
+	agentB, _ := contractabi.NewERC1155SwapAgent(h.B.ERC1155SwapAgentAddr(), h.B)
+
+	register := h.mined(h.A)(agentA.RegisterSwapPair(h.A.TransactOpts(h.User), tokenAddr, chainIDB))
+	h.wait("the ERC1155 swap pair creation", func() bool {
+		var sp erc1155.SwapPair
+		err := h.DB.Where("register_tx_hash = ?", register.Hash().String()).First(&sp).Error
+		return err == nil && sp.State == erc1155.SwapPairStateCreationTxConfirmed
+	})
+
+	mirroredAddr, err := agentB.SwapMappingIncoming(nil, chainIDA, tokenAddr)
+	if err != nil || mirroredAddr == (common.Address{}) {
+		t.Fatalf("mirrored ERC1155 token was not created on chain B: %v", err)
+	}
+	mirrored, _ := contractabi.NewERC1155Token(mirroredAddr, h.B)
+	if uri, _ := mirrored.Uri(nil, ids[0]); uri != "ipfs://{id}" {
+		t.Errorf("unexpected mirrored token uri %q", uri)
+	}
+
+	h.mined(h.A)(token.SetApprovalForAll(h.A.TransactOpts(h.User), h.A.ERC1155SwapAgentAddr(), true))
+	swap := h.mined(h.A)(agentA.Swap(h.A.TransactOpts(h.User), tokenAddr, recipientAddr, ids, amounts, chainIDB))
+	h.wait("the ERC1155 swap", func() bool {
+		s := h.erc1155Swap(swap.Hash())
+		return s != nil && s.State == erc1155.SwapStateFillTxConfirmed
+	})
+
+	balances, err := mirrored.BalanceOfBatch(nil, []common.Address{recipientAddr, recipientAddr}, ids)
+	if err != nil {
+		t.Fatalf("failed to query balances: %v", err)
+	}
+	for i := range ids {
+		if balances[i].Cmp(amounts[i]) != 0 {
+			t.Errorf("unexpected balance %s of id %s, want %s", balances[i], ids[i], amounts[i])
+		}
+	}
+	s := h.erc1155Swap(swap.Hash())
+	h.assertFee("the fill", s.FillGasPrice, s.FillGasUsed, s.FillConsumedFeeAmount)
+	h.assertStates(transition.EntityTypeERC1155Swap, s.ID,
+		string(erc1155.SwapStateRequestOngoing),
+		string(erc1155.SwapStateRequestConfirmed),
+		string(erc1155.SwapStateFillTxCreated),
+		string(erc1155.SwapStateFillTxSent),
+		string(erc1155.SwapStateFillTxConfirmed),
+	)
+}
+
+func TestSimulatedERC20SwapRoundTrip(t *testing.T) {
+	h := newSimHarness(t)
+	user := crypto.PubkeyToAddress(h.User.PublicKey)
+	recipientAddr := crypto.PubkeyToAddress(h.Recipient.PublicKey)
+
+	tokenAddr, err := h.A.Deploy(h.User, "ERC20Token", contractabi.ERC20TokenMetaData.ABI, "Token", "TKN", uint8(6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, _ := contractabi.NewERC20Token(tokenAddr, h.A)
+	h.mined(h.A)(token.Mint(h.A.TransactOpts(h.User), user, big.NewInt(1000)))
+
+	agentA, _ := contractabi.NewERC20SwapAgent(h.A.ERC20SwapAgentAddr(), h.A)
+	agentB, _ := contractabi.NewERC20SwapAgent(h.B.ERC20SwapAgentAddr(), h.B)
+
+	register := h.mined(h.A)(agentA.RegisterSwapPair(h.A.TransactOpts(h.User), tokenAddr, chainIDB))
+	h.wait("the ERC20 swap pair creation", func() bool {
+		var sp erc20.SwapPair
+		err := h.DB.Where("register_tx_hash = ?", register.Hash().String()).First(&sp).Error
+		return err == nil && sp.State == erc20.SwapPairStateCreationTxConfirmed
+	})
+
+	mirroredAddr, err := agentB.SwapMappingIncoming(nil, chainIDA, tokenAddr)
+	if err != nil || mirroredAddr == (common.Address{}) {
+		t.Fatalf("mirrored ERC20 token was not created on chain B: %v", err)
+	}
+	mirrored, _ := contractabi.NewERC20Token(mirroredAddr, h.B)
+	if decimals, _ := mirrored.Decimals(nil); decimals != 6 {
+		t.Errorf("unexpected mirrored token decimals %d", decimals)
+	}
+
+	h.mined(h.A)(token.Approve(h.A.TransactOpts(h.User), h.A.ERC20SwapAgentAddr(), big.NewInt(300)))
+	forward := h.mined(h.A)(agentA.Swap(h.A.TransactOpts(h.User), tokenAddr, recipientAddr, big.NewInt(300), chainIDB))
+	h.wait("the forward swap", h.erc20SwapInState(forward.Hash(), erc20.SwapStateFillTxConfirmed))
+
+	if balance, err := mirrored.BalanceOf(nil, recipientAddr); err != nil || balance.Int64() != 300 {
+		t.Errorf("unexpected balance %s of the mirrored token: %v", balance, err)
+	}
+	if balance, err := token.BalanceOf(nil, h.A.ERC20SwapAgentAddr()); err != nil || balance.Int64() != 300 {
+		t.Errorf("unexpected locked balance %s: %v", balance, err)
+	}
+	s := h.erc20Swap(forward.Hash())
+	h.assertFee("the forward fill", s.FillGasPrice, s.FillGasUsed, s.FillConsumedFeeAmount)
+	h.assertStates(transition.EntityTypeERC20Swap, s.ID,
+		string(erc20.SwapStateRequestOngoing),
+		string(erc20.SwapStateRequestConfirmed),
+		string(erc20.SwapStateFillTxCreated),
+		string(erc20.SwapStateFillTxSent),
+		string(erc20.SwapStateFillTxConfirmed),
+	)
+
+	h.mined(h.B)(mirrored.Approve(h.B.TransactOpts(h.Recipient), h.B.ERC20SwapAgentAddr(), big.NewInt(100)))
+	backward := h.mined(h.B)(agentB.Swap(h.B.TransactOpts(h.Recipient), mirroredAddr, user, big.NewInt(100), chainIDA))
+	h.wait("the backward swap", h.erc20SwapInState(backward.Hash(), erc20.SwapStateFillTxConfirmed))
+
+	if balance, err := token.BalanceOf(nil, user); err != nil || balance.Int64() != 800 {
+		t.Errorf("unexpected balance %s of the released token: %v", balance, err)
+	}
+	if supply, err := mirrored.TotalSupply(nil); err != nil || supply.Int64() != 200 {
+		t.Errorf("unexpected mirrored supply %s: %v", supply, err)
+	}
+}
+
+func TestSimulatedERC20FillBatch(t *testing.T) {
+	h := newSimHarness(t, func(_, b *testutil.ChainConfig) {
+		b.FillBatchSize = 3
+		b.FillBatchWindow = time.Minute
+	})
+	user := crypto.PubkeyToAddress(h.User.PublicKey)
+	recipientAddr := crypto.PubkeyToAddress(h.Recipient.PublicKey)
+
+	tokenAddr, err := h.A.Deploy(h.User, "ERC20Token", contractabi.ERC20TokenMetaData.ABI, "Token", "TKN", uint8(6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, _ := contractabi.NewERC20Token(tokenAddr, h.A)
+	h.mined(h.A)(token.Mint(h.A.TransactOpts(h.User), user, big.NewInt(1000)))
+
+	agentA, _ := contractabi.NewERC20SwapAgent(h.A.ERC20SwapAgentAddr(), h.A)
+	agentB, _ := contractabi.NewERC20SwapAgent(h.B.ERC20SwapAgentAddr(), h.B)
+
+	register := h.mined(h.A)(agentA.RegisterSwapPair(h.A.TransactOpts(h.User), tokenAddr, chainIDB))
+	h.wait("the ERC20 swap pair creation", func() bool {
+		var sp erc20.SwapPair
+		err := h.DB.Where("register_tx_hash = ?", register.Hash().String()).First(&sp).Error
+		return err == nil && sp.State == erc20.SwapPairStateCreationTxConfirmed
+	})
+	mirroredAddr, err := agentB.SwapMappingIncoming(nil, chainIDA, tokenAddr)
+	if err != nil || mirroredAddr == (common.Address{}) {
+		t.Fatalf("mirrored ERC20 token was not created on chain B: %v", err)
+	}
+	mirrored, _ := contractabi.NewERC20Token(mirroredAddr, h.B)
+
+	// only the owner of the agent fills through the batch entry point
+	batcher, _ := contractabi.NewFillBatcher(h.B.ERC20SwapAgentAddr(), h.B)
+	if _, err := batcher.FillBatch(h.B.TransactOpts(h.User), nil); err == nil {
+		t.Error("a fill batch of another account than the owner was accepted")
+	}
+
+	h.mined(h.A)(token.Approve(h.A.TransactOpts(h.User), h.A.ERC20SwapAgentAddr(), big.NewInt(600)))
+	var swaps []*types.Transaction
+	for i := 0; i < 3; i++ {
+		swaps = append(swaps, h.mined(h.A)(agentA.Swap(h.A.TransactOpts(h.User), tokenAddr, recipientAddr, big.NewInt(200), chainIDB)))
+	}
+	for _, swap := range swaps {
+		h.wait("the batched swap", h.erc20SwapInState(swap.Hash(), erc20.SwapStateFillTxConfirmed))
+	}
+
+	if balance, err := mirrored.BalanceOf(nil, recipientAddr); err != nil || balance.Int64() != 600 {
+		t.Errorf("unexpected balance %s of the mirrored token: %v", balance, err)
+	}
+
+	// the swaps share the fill batch tx and its gas
+	fillTxHash := h.erc20Swap(swaps[0].Hash()).FillTxHash
+	receipt, err := h.B.TransactionReceipt(context.Background(), common.HexToHash(fillTxHash))
+	if err != nil {
+		t.Fatalf("failed to get the receipt of the fill batch tx: %v", err)
+	}
+	for _, swap := range swaps {
+		s := h.erc20Swap(swap.Hash())
+		if s.FillTxHash != fillTxHash || s.FillBatchTxHash != fillTxHash {
+			t.Errorf("expected Swap %s to be filled by the batch %s, got %s", s.ID, fillTxHash, s.FillTxHash)
+		}
+		if s.FillGasUsed != int64(receipt.GasUsed)/3 {
+			t.Errorf("expected Swap %s to use %d gas of the batch, got %d", s.ID, receipt.GasUsed/3, s.FillGasUsed)
+		}
+		h.assertFee("the batched fill", s.FillGasPrice, s.FillGasUsed, s.FillConsumedFeeAmount)
+	}
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/pkg/errors"
 
 	contractabi "github.com/synycboom/bsc-evm-compatible-bridge-core/abi"
@@ -16,18 +15,19 @@ type IToken interface {
 }
 
 type Token struct {
-	client *ethclient.Client
+	client bind.ContractCaller
 }
 
-func NewToken(c *ethclient.Client) *Token {
+// NewToken returns a token reader using any contract caller, e.g. an ethclient or a simulated backend
+func NewToken(c bind.ContractCaller) *Token {
 	return &Token{
 		client: c,
 	}
 }
 
-func (t *Token) bind(addr string) (*contractabi.ERC1155Token, error) {
+func (t *Token) bind(addr string) (*contractabi.ERC1155TokenCaller, error) {
 	tokenAddr := common.HexToAddress(addr)
-	token, err := contractabi.NewERC1155Token(tokenAddr, t.client)
+	token, err := contractabi.NewERC1155TokenCaller(tokenAddr, t.client)
 	if err != nil {
 		return nil, errors.Wrap(err, "[Token.bind]: failed to bind token address")
 	}
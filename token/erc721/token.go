@@ -6,7 +6,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/pkg/errors"
 
 	contractabi "github.com/synycboom/bsc-evm-compatible-bridge-core/abi"
@@ -18,19 +17,20 @@ type IToken interface {
 }
 
 type Token struct {
-	client *ethclient.Client
+	client bind.ContractCaller
 	mutex  sync.RWMutex
 }
 
-func NewToken(c *ethclient.Client) *Token {
+// NewToken returns a token reader using any contract caller, e.g. an ethclient or a simulated backend
+func NewToken(c bind.ContractCaller) *Token {
 	return &Token{
 		client: c,
 	}
 }
 
-func (t *Token) bind(addr string) (*contractabi.ERC721Token, error) {
+func (t *Token) bind(addr string) (*contractabi.ERC721TokenCaller, error) {
 	tokenAddr := common.HexToAddress(addr)
-	token, err := contractabi.NewERC721Token(tokenAddr, t.client)
+	token, err := contractabi.NewERC721TokenCaller(tokenAddr, t.client)
 	if err != nil {
 		return nil, errors.Wrap(err, "[Token.bind]: failed to bind token address")
 	}
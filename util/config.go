@@ -17,12 +17,30 @@ type Config struct {
 	LogConfig        LogConfig        `json:"log_config"`
 	AlertConfig      AlertConfig      `json:"alert_config"`
 	AdminConfig      AdminConfig      `json:"admin_config"`
+	HealthConfig     HealthConfig     `json:"health_config"`
+	SLAConfig        SLAConfig        `json:"sla_config"`
+	LeaseConfig      LeaseConfig      `json:"lease_config"`
+	APIConfig        APIConfig        `json:"api_config"`
+	WebhookConfig    WebhookConfig    `json:"webhook_config"`
+	RelayConfig      RelayConfig      `json:"relay_config"`
+	EngineConfig     EngineConfig     `json:"engine_config"`
 }
 
 func (cfg *Config) Validate() {
 	cfg.DBConfig.Validate()
 	cfg.LogConfig.Validate()
 	cfg.AlertConfig.Validate()
+	cfg.HealthConfig.Validate()
+	cfg.SLAConfig.Validate()
+	cfg.LeaseConfig.Validate()
+	cfg.APIConfig.Validate()
+	cfg.WebhookConfig.Validate()
+	cfg.RelayConfig.Validate()
+	cfg.EngineConfig.Validate()
+
+	if cfg.APIConfig.ListenAddr != "" && cfg.AdminConfig.Enabled() && cfg.APIConfig.ListenAddr == cfg.AdminConfig.ListenAddr {
+		panic("api listen_addr should differ from the admin one")
+	}
 
 	ids := make(map[string]struct{})
 	for _, c := range cfg.ChainConfigs {
@@ -34,6 +52,18 @@ func (cfg *Config) Validate() {
 
 		ids[c.ID] = struct{}{}
 	}
+
+	// a loop marks a cycle on every run, so it must sweep within the engine cycle timeout
+	if cfg.EngineConfig.Notify && cfg.HealthConfig.ListenAddr != "" &&
+		cfg.EngineConfig.SweepInterval >= cfg.HealthConfig.EngineCycleTimeout {
+		panic("engine sweep_interval should be less than the health engine_cycle_timeout")
+	}
+
+	for _, id := range cfg.HealthConfig.CriticalChains {
+		if _, ok := ids[id]; !ok {
+			panic(fmt.Sprintf("critical chain %s is not configured", id))
+		}
+	}
 }
 
 type AlertConfig struct {
@@ -41,12 +71,115 @@ type AlertConfig struct {
 	TelegramChatId string `json:"telegram_chat_id"`
 
 	BlockUpdateTimeout int64 `json:"block_update_timeout"`
+
+	Cooldown    int64                `json:"cooldown"`
+	SendTimeout int64                `json:"send_timeout"`
+	Backends    []AlertBackendConfig `json:"backends"`
+	Routes      []AlertRouteConfig   `json:"routes"`
 }
 
 func (cfg AlertConfig) Validate() {
 	if cfg.BlockUpdateTimeout <= 0 {
 		panic("block_update_timeout should be larger than 0")
 	}
+	if cfg.Cooldown < 0 {
+		panic("cooldown should not be less than 0")
+	}
+	if cfg.SendTimeout < 0 {
+		panic("send_timeout should not be less than 0")
+	}
+
+	names := make(map[string]struct{})
+	for _, b := range cfg.Backends {
+		b.Validate()
+
+		if _, ok := names[b.Name]; ok {
+			panic(fmt.Sprintf("alert backend %s is duplicated", b.Name))
+		}
+
+		names[b.Name] = struct{}{}
+	}
+	if cfg.TelegramBotId != "" && cfg.TelegramChatId != "" {
+		names["telegram"] = struct{}{}
+	}
+
+	for _, r := range cfg.Routes {
+		for _, name := range r.Backends {
+			if _, ok := names[name]; !ok {
+				panic(fmt.Sprintf("alert route refers to unknown backend %s", name))
+			}
+		}
+		for _, s := range r.Severities {
+			if s != "info" && s != "warning" && s != "critical" {
+				panic(fmt.Sprintf("alert route has invalid severity %s", s))
+			}
+		}
+	}
+}
+
+// SendTimeoutOrDefault returns the configured send timeout in seconds, falling back to 10 seconds for configs
+// written before send_timeout existed
+func (cfg AlertConfig) SendTimeoutOrDefault() int64 {
+	if cfg.SendTimeout == 0 {
+		return 10
+	}
+
+	return cfg.SendTimeout
+}
+
+type AlertBackendConfig struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// telegram and pagerduty, an empty endpoint uses the public API
+	Endpoint string `json:"endpoint"`
+	// telegram
+	BotID  string `json:"bot_id"`
+	ChatID string `json:"chat_id"`
+	// slack and webhook
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	// email
+	SMTPAddr string   `json:"smtp_addr"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+	// pagerduty
+	RoutingKey string `json:"routing_key"`
+}
+
+func (cfg AlertBackendConfig) Validate() {
+	if cfg.Name == "" {
+		panic("alert backend name should not be empty")
+	}
+
+	switch cfg.Type {
+	case "telegram":
+		if cfg.BotID == "" || cfg.ChatID == "" {
+			panic(fmt.Sprintf("alert backend %s requires bot_id and chat_id", cfg.Name))
+		}
+	case "slack", "webhook":
+		if cfg.URL == "" {
+			panic(fmt.Sprintf("alert backend %s requires url", cfg.Name))
+		}
+	case "email":
+		if cfg.SMTPAddr == "" || cfg.From == "" || len(cfg.To) == 0 {
+			panic(fmt.Sprintf("alert backend %s requires smtp_addr, from and to", cfg.Name))
+		}
+	case "pagerduty":
+		if cfg.RoutingKey == "" {
+			panic(fmt.Sprintf("alert backend %s requires routing_key", cfg.Name))
+		}
+	default:
+		panic(fmt.Sprintf("alert backend %s has unknown type %s", cfg.Name, cfg.Type))
+	}
+}
+
+type AlertRouteConfig struct {
+	Severities []string `json:"severities"`
+	Chains     []string `json:"chains"`
+	Backends   []string `json:"backends"`
 }
 
 type KeyManagerConfig struct {
@@ -95,16 +228,32 @@ type TokenSecretKey struct {
 }
 
 type DBConfig struct {
+	// Dialect is one of mysql, sqlite3 and postgres, mysql is used when it is empty
+	Dialect  string `json:"dialect"`
 	LogLevel string `json:"log_level"`
 	DSN      string `json:"dsn"`
 }
 
 func (cfg DBConfig) Validate() {
+	switch cfg.Dialect {
+	case "", common.DBDialectMysql, common.DBDialectSqlite3, common.DBDialectPostgres:
+	default:
+		panic(fmt.Sprintf("db dialect %s is not supported", cfg.Dialect))
+	}
 	if cfg.DSN == "" {
 		panic("db path should not be empty")
 	}
 }
 
+// DialectOrDefault returns the configured dialect, falling back to mysql
+func (cfg DBConfig) DialectOrDefault() string {
+	if cfg.Dialect == "" {
+		return common.DBDialectMysql
+	}
+
+	return cfg.Dialect
+}
+
 type ChainConfig struct {
 	BalanceAlertThreshold  string `json:"balance_alert_threshold"`
 	BalanceMonitorInterval int64  `json:"balance_monitor_interval"`
@@ -117,18 +266,42 @@ type ChainConfig struct {
 	ConfirmNum             int64  `json:"confirm_num"`
 	ERC721SwapAgentAddr    string `json:"erc_721_swap_agent_addr"`
 	ERC1155SwapAgentAddr   string `json:"erc_1155_swap_agent_addr"`
-	ExplorerUrl            string `json:"explorer_url"`
-	MaxTrackRetry          int64  `json:"max_track_retry"`
-	WaitMilliSecBetweenTx  int64  `json:"wait_milli_sec_between_tx"`
+	// ERC20SwapAgentAddr enables the ERC20 swaps when it is set
+	ERC20SwapAgentAddr    string `json:"erc_20_swap_agent_addr"`
+	ExplorerUrl           string `json:"explorer_url"`
+	MaxTrackRetry         int64  `json:"max_track_retry"`
+	WaitMilliSecBetweenTx int64  `json:"wait_milli_sec_between_tx"`
+
+	// ERC721BatchSwapAgentAddr enables the ERC721 batch swaps when it is set, the agent implements
+	// abi/ERC721BatchSwapAgent.json like contracts/ERC721BatchSwapAgent.sol. That contract is an upgrade of the ERC721
+	// swap agent, so the address is usually erc_721_swap_agent_addr.
+	ERC721BatchSwapAgentAddr string `json:"erc_721_batch_swap_agent_addr"`
+	// ERC721BatchFillSize is the most tokens of a batch swap requested on this chain filled by a single tx, 0 only
+	// limits a fill tx to half of the block gas limit
+	ERC721BatchFillSize int `json:"erc_721_batch_fill_size"`
+
+	// FillBatchSize enables the batching of the fills sent to this chain when it is larger than 1, it is the most fills
+	// of a batch. The agents of the chain must implement abi/FillBatcher.json like the ones of contracts/.
+	FillBatchSize int `json:"fill_batch_size"`
+	// FillBatchWindow is the number of seconds the first confirmed swap of a batch waits for others
+	FillBatchWindow int64 `json:"fill_batch_window"`
 }
 
 func (cfg ChainConfig) Validate() {
 	if cfg.Name == "" {
 		panic("name should not be empty")
 	}
+	// chain ids are compared as strings, so they must be written in their canonical form
+	id, err := ParseBigInt(cfg.ID)
+	if err != nil || id.Sign() <= 0 || id.String() != cfg.ID {
+		panic(fmt.Sprintf("invalid id of chain %s: %q", cfg.Name, cfg.ID))
+	}
 	if cfg.StartHeight < 0 {
 		panic("start_height should not be less than 0")
 	}
+	if cfg.ObserverFetchInterval <= 0 {
+		panic("observer_fetch_interval should be larger than 0")
+	}
 	if cfg.Provider == "" {
 		panic("provider should not be empty")
 	}
@@ -136,11 +309,29 @@ func (cfg ChainConfig) Validate() {
 		panic("confirm_num should be larger than 0")
 	}
 	if !ethcom.IsHexAddress(cfg.ERC721SwapAgentAddr) {
-		panic(fmt.Sprintf("invalid swap_contract_addr: %s", cfg.ERC721SwapAgentAddr))
+		panic(fmt.Sprintf("invalid erc_721_swap_agent_addr: %s", cfg.ERC721SwapAgentAddr))
+	}
+	if cfg.ERC721BatchSwapAgentAddr != "" && !ethcom.IsHexAddress(cfg.ERC721BatchSwapAgentAddr) {
+		panic(fmt.Sprintf("invalid erc_721_batch_swap_agent_addr: %s", cfg.ERC721BatchSwapAgentAddr))
+	}
+	if cfg.ERC721BatchFillSize < 0 {
+		panic("erc_721_batch_fill_size should not be less than 0")
+	}
+	if !ethcom.IsHexAddress(cfg.ERC1155SwapAgentAddr) {
+		panic(fmt.Sprintf("invalid erc_1155_swap_agent_addr: %s", cfg.ERC1155SwapAgentAddr))
+	}
+	if cfg.ERC20SwapAgentAddr != "" && !ethcom.IsHexAddress(cfg.ERC20SwapAgentAddr) {
+		panic(fmt.Sprintf("invalid erc_20_swap_agent_addr: %s", cfg.ERC20SwapAgentAddr))
 	}
 	if cfg.MaxTrackRetry <= 0 {
 		panic("max_track_retry should be larger than 0")
 	}
+	if cfg.FillBatchSize < 0 || cfg.FillBatchSize == 1 {
+		panic("fill_batch_size should be 0 or larger than 1")
+	}
+	if cfg.FillBatchWindow < 0 {
+		panic("fill_batch_window should not be less than 0")
+	}
 }
 
 type LogConfig struct {
@@ -170,6 +361,224 @@ func (cfg LogConfig) Validate() {
 
 type AdminConfig struct {
 	ListenAddr string `json:"listen_addr"`
+	// APIKey must be sent as a bearer token to the admin api
+	APIKey string `json:"api_key"`
+}
+
+// Enabled tells whether the admin api is served. It needs an api key, configs written before the key was introduced
+// have a listen address only, so the admin api is disabled rather than served without authentication.
+func (cfg AdminConfig) Enabled() bool {
+	return cfg.ListenAddr != "" && cfg.APIKey != ""
+}
+
+type WebhookConfig struct {
+	Enabled      bool  `json:"enabled"`
+	PollInterval int64 `json:"poll_interval"`
+	BatchSize    int   `json:"batch_size"`
+	Timeout      int64 `json:"timeout"`
+	MaxAttempts  int   `json:"max_attempts"`
+	BackoffBase  int64 `json:"backoff_base"`
+	BackoffMax   int64 `json:"backoff_max"`
+}
+
+func (cfg WebhookConfig) Validate() {
+	if !cfg.Enabled {
+		return
+	}
+	if cfg.PollInterval <= 0 {
+		panic("webhook poll_interval should be larger than 0")
+	}
+	if cfg.BatchSize <= 0 {
+		panic("webhook batch_size should be larger than 0")
+	}
+	if cfg.Timeout <= 0 {
+		panic("webhook timeout should be larger than 0")
+	}
+	if cfg.MaxAttempts <= 0 {
+		panic("webhook max_attempts should be larger than 0")
+	}
+	if cfg.BackoffBase <= 0 || cfg.BackoffMax < cfg.BackoffBase {
+		panic("webhook backoff_base should be larger than 0 and not larger than backoff_max")
+	}
+}
+
+type LeaseConfig struct {
+	Enabled bool `json:"enabled"`
+	// Holder identifies the replica, the host name and process id are used when it is empty
+	Holder        string `json:"holder"`
+	Duration      int64  `json:"duration"`
+	RenewInterval int64  `json:"renew_interval"`
+}
+
+func (cfg LeaseConfig) Validate() {
+	if !cfg.Enabled {
+		return
+	}
+	if cfg.Duration <= 0 {
+		panic("lease duration should be larger than 0")
+	}
+	if cfg.RenewInterval <= 0 {
+		panic("lease renew_interval should be larger than 0")
+	}
+	if cfg.RenewInterval*2 > cfg.Duration {
+		panic("lease renew_interval should not be larger than half of the duration")
+	}
+}
+
+type APIConfig struct {
+	ListenAddr      string `json:"listen_addr"`
+	DefaultPageSize int    `json:"default_page_size"`
+	MaxPageSize     int    `json:"max_page_size"`
+}
+
+func (cfg APIConfig) Validate() {
+	if cfg.ListenAddr == "" {
+		return
+	}
+	if cfg.DefaultPageSize <= 0 {
+		panic("default_page_size should be larger than 0")
+	}
+	if cfg.MaxPageSize < cfg.DefaultPageSize {
+		panic("max_page_size should not be less than default_page_size")
+	}
+}
+
+type RelayConfig struct {
+	Enabled bool `json:"enabled"`
+	// Broker is nats, kafka or file
+	Broker string `json:"broker"`
+	// URLs holds the NATS server url or the Kafka brokers, FilePath is the file the file broker appends to
+	URLs         []string `json:"urls"`
+	FilePath     string   `json:"file_path"`
+	Topic        string   `json:"topic"`
+	Key          string   `json:"key"`
+	PollInterval int64    `json:"poll_interval"`
+	BatchSize    int      `json:"batch_size"`
+	Timeout      int64    `json:"timeout"`
+	// Retention is how many seconds published events are kept, 0 keeps them forever
+	Retention int64 `json:"retention"`
+}
+
+func (cfg RelayConfig) Validate() {
+	if !cfg.Enabled {
+		return
+	}
+
+	switch cfg.Broker {
+	case common.BrokerNATS:
+		if len(cfg.URLs) == 0 {
+			panic("relay urls should hold the nats server urls")
+		}
+	case common.BrokerKafka:
+		if len(cfg.URLs) == 0 {
+			panic("relay urls should hold the kafka brokers")
+		}
+	case common.BrokerFile:
+		if cfg.FilePath == "" {
+			panic("relay file_path should not be empty")
+		}
+	default:
+		panic(fmt.Sprintf("unknown relay broker %s", cfg.Broker))
+	}
+
+	if cfg.Topic == "" {
+		panic("relay topic should not be empty")
+	}
+	if cfg.PollInterval <= 0 {
+		panic("relay poll_interval should be larger than 0")
+	}
+	if cfg.BatchSize <= 0 {
+		panic("relay batch_size should be larger than 0")
+	}
+	if cfg.Timeout <= 0 {
+		panic("relay timeout should be larger than 0")
+	}
+	if cfg.Retention < 0 {
+		panic("relay retention should not be negative")
+	}
+}
+
+type HealthConfig struct {
+	ListenAddr         string   `json:"listen_addr"`
+	MaxObserverLag     int64    `json:"max_observer_lag"`
+	EngineCycleTimeout int64    `json:"engine_cycle_timeout"`
+	CheckTimeout       int64    `json:"check_timeout"`
+	CriticalChains     []string `json:"critical_chains"`
+}
+
+func (cfg HealthConfig) Validate() {
+	if cfg.ListenAddr == "" {
+		return
+	}
+	if cfg.MaxObserverLag < 0 {
+		panic("max_observer_lag should not be less than 0")
+	}
+	if cfg.EngineCycleTimeout <= 0 {
+		panic("engine_cycle_timeout should be larger than 0")
+	}
+	if cfg.CheckTimeout <= 0 {
+		panic("check_timeout should be larger than 0")
+	}
+}
+
+func (cfg HealthConfig) IsCritical(chainID string) bool {
+	for _, id := range cfg.CriticalChains {
+		if id == chainID {
+			return true
+		}
+	}
+
+	return false
+}
+
+type SLAConfig struct {
+	CheckInterval int64 `json:"check_interval"`
+	MaxListedIDs  int   `json:"max_listed_ids"`
+	// SwapThresholds and SwapPairThresholds are the maximum seconds an entity may stay in a state, keyed by state
+	SwapThresholds     map[string]int64 `json:"swap_thresholds"`
+	SwapPairThresholds map[string]int64 `json:"swap_pair_thresholds"`
+}
+
+func (cfg SLAConfig) Validate() {
+	if cfg.CheckInterval < 0 {
+		panic("check_interval should not be less than 0")
+	}
+	for state, seconds := range cfg.SwapThresholds {
+		if seconds <= 0 {
+			panic(fmt.Sprintf("swap threshold of state %s should be larger than 0", state))
+		}
+	}
+	for state, seconds := range cfg.SwapPairThresholds {
+		if seconds <= 0 {
+			panic(fmt.Sprintf("swap pair threshold of state %s should be larger than 0", state))
+		}
+	}
+}
+
+// CheckIntervalOrDefault returns the configured check interval in seconds, falling back to 60 seconds when it is
+// left out
+func (cfg SLAConfig) CheckIntervalOrDefault() int64 {
+	if cfg.CheckInterval == 0 {
+		return 60
+	}
+
+	return cfg.CheckInterval
+}
+
+type EngineConfig struct {
+	// Notify wakes the engine loops up on new blocks and state changes instead of polling
+	Notify bool `json:"notify"`
+	// SweepInterval is the seconds between the runs of a notified loop, in case a notification was missed
+	SweepInterval int64 `json:"sweep_interval"`
+}
+
+func (cfg EngineConfig) Validate() {
+	if !cfg.Notify {
+		return
+	}
+	if cfg.SweepInterval <= 0 {
+		panic("engine sweep_interval should be larger than 0")
+	}
 }
 
 func ParseConfigFromFile(filePath string) *Config {
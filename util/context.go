@@ -0,0 +1,45 @@
+package util
+
+import (
+	"context"
+	"time"
+)
+
+// WithoutCancel returns a context carrying the values of parent that is never canceled. Work that must finish once
+// started, such as recording a sent transaction, uses it so a shutdown does not interrupt it halfway.
+func WithoutCancel(parent context.Context) context.Context {
+	return withoutCancel{parent: parent}
+}
+
+type withoutCancel struct {
+	parent context.Context
+}
+
+func (withoutCancel) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (withoutCancel) Done() <-chan struct{} {
+	return nil
+}
+
+func (withoutCancel) Err() error {
+	return nil
+}
+
+func (c withoutCancel) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
+
+// Sleep pauses for d and returns true, or returns false as soon as ctx is done
+func Sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
-	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -32,13 +31,14 @@ func init() {
 	}
 }
 
-func StrToBigInt(val string) *big.Int {
-	integer, err := strconv.Atoi(val)
-	if err != nil {
-		return big.NewInt(0)
+// ParseBigInt parses a base 10 integer of any size
+func ParseBigInt(val string) (*big.Int, error) {
+	integer, ok := new(big.Int).SetString(val, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid integer: %q", val)
 	}
 
-	return big.NewInt(int64(integer))
+	return integer, nil
 }
 
 func BigIntSliceToStrSlice(vv []*big.Int) []string {
@@ -50,13 +50,18 @@ func BigIntSliceToStrSlice(vv []*big.Int) []string {
 	return ss
 }
 
-func StrSliceToBigIntSlice(ss []string) []*big.Int {
+// ParseBigIntSlice parses base 10 integers of any size
+func ParseBigIntSlice(ss []string) ([]*big.Int, error) {
 	vv := make([]*big.Int, len(ss))
 	for idx, s := range ss {
-		vv[idx] = StrToBigInt(s)
+		v, err := ParseBigInt(s)
+		if err != nil {
+			return nil, err
+		}
+		vv[idx] = v
 	}
 
-	return vv
+	return vv, nil
 }
 
 func TxOpts(ctx context.Context, ethClient client.ETHClient, privateKey string, chainID *big.Int) (*bind.TransactOpts, error) {
@@ -70,12 +75,12 @@ func TxOpts(ctx context.Context, ethClient client.ETHClient, privateKey string,
 		return nil, errors.Wrap(err, "[TxOpts]: unable to create a new auth")
 	}
 
-	nonce, err := ethClient.PendingNonceAt(context.Background(), txOpts.From)
+	nonce, err := ethClient.PendingNonceAt(ctx, txOpts.From)
 	if err != nil {
 		return nil, errors.Wrap(err, "[TxOpts]: failed to get nonce")
 	}
 
-	gasPrice, err := ethClient.SuggestGasPrice(context.Background())
+	gasPrice, err := ethClient.SuggestGasPrice(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "[TxOpts]: failed to gas price")
 	}
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"context"
+	"net/http"
+)
+
+// ListenAndServe serves h on addr until ctx is done, it then stops accepting connections and returns once the
+// in-flight requests have been answered
+func ListenAndServe(ctx context.Context, addr string, h http.Handler) error {
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: h,
+	}
+
+	shutdown := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		shutdown <- srv.Shutdown(WithoutCancel(ctx))
+	}()
+
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+
+	return <-shutdown
+}
@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/webhook"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// maxLastErrorLength bounds the response body kept as the last error of a delivery
+const maxLastErrorLength = 512
+
+// deliver attempts the pending deliveries that are due
+func (d *Dispatcher) deliver(ctx context.Context) {
+	var dd []webhook.Delivery
+	err := d.deps.DB.WithContext(ctx).Where(
+		"state = ? and next_attempt_at <= ?",
+		webhook.DeliveryStatePending,
+		time.Now(),
+	).Order(
+		"next_attempt_at asc",
+	).Limit(
+		d.conf.BatchSize,
+	).Find(
+		&dd,
+	).Error
+	if err != nil {
+		util.Logger.Error(errors.Wrap(err, "[Dispatcher.deliver]: failed to query pending deliveries"))
+		return
+	}
+
+	for i := range dd {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := d.attempt(ctx, &dd[i]); err != nil {
+			util.Logger.Error(errors.Wrapf(err, "[Dispatcher.deliver]: failed to attempt Delivery %s", dd[i].ID))
+		}
+	}
+}
+
+// attempt posts a delivery once and schedules the next attempt if it fails. A started attempt is completed and
+// saved even if ctx is canceled meanwhile, so a shutdown is not counted as a failed attempt.
+func (d *Dispatcher) attempt(ctx context.Context, dl *webhook.Delivery) error {
+	ctx = util.WithoutCancel(ctx)
+
+	var s webhook.Subscription
+	err := d.deps.DB.WithContext(ctx).Where("id = ?", dl.SubscriptionID).First(&s).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return errors.Wrap(err, "[Dispatcher.attempt]: failed to query subscription")
+	}
+	if err == gorm.ErrRecordNotFound || !s.Enabled {
+		dl.State = webhook.DeliveryStateDead
+		dl.LastError = "subscription is deleted or disabled"
+		return d.save(ctx, dl)
+	}
+
+	var e outbox.Event
+	if err := d.deps.DB.WithContext(ctx).Where("id = ?", dl.EventID).First(&e).Error; err != nil {
+		return errors.Wrap(err, "[Dispatcher.attempt]: failed to query outbox event")
+	}
+
+	body, err := e.Message()
+	if err != nil {
+		return errors.Wrap(err, "[Dispatcher.attempt]: failed to encode message")
+	}
+
+	dl.Attempts += 1
+	status, err := d.post(ctx, &s, dl, &e, body)
+	dl.LastStatusCode = status
+	if err == nil {
+		now := time.Now()
+		dl.State = webhook.DeliveryStateDelivered
+		dl.DeliveredAt = &now
+		dl.LastError = ""
+		return d.save(ctx, dl)
+	}
+
+	dl.LastError = err.Error()
+	if dl.Attempts >= d.conf.MaxAttempts {
+		dl.State = webhook.DeliveryStateDead
+		util.Logger.Errorf("[Dispatcher.attempt]: dead-lettered Delivery %s of event %s to Subscription %s after %d attempts, %s",
+			dl.ID, e.ID, s.ID, dl.Attempts, dl.LastError)
+	} else {
+		dl.NextAttemptAt = time.Now().Add(d.backoff(dl.Attempts))
+	}
+
+	return d.save(ctx, dl)
+}
+
+func (d *Dispatcher) post(
+	ctx context.Context,
+	s *webhook.Subscription,
+	dl *webhook.Delivery,
+	e *outbox.Event,
+	body []byte,
+) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.conf.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(s.Secret, timestamp, body))
+	req.Header.Set(EventIDHeader, e.ID)
+	req.Header.Set(EventTypeHeader, e.Type)
+	req.Header.Set(DeliveryIDHeader, dl.ID)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to post")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxLastErrorLength))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay before the next attempt, doubling after every failed attempt
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.conf.BackoffBase
+	for i := 1; i < attempts && delay < d.conf.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > d.conf.BackoffMax {
+		delay = d.conf.BackoffMax
+	}
+
+	return delay
+}
+
+func (d *Dispatcher) save(ctx context.Context, dl *webhook.Delivery) error {
+	if err := d.deps.DB.WithContext(ctx).Save(dl).Error; err != nil {
+		return errors.Wrap(err, "[Dispatcher.save]: failed to save delivery")
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/webhook"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+// fanOut creates a delivery of every new outbox event for each subscription it matches
+func (d *Dispatcher) fanOut(ctx context.Context) {
+	err := d.deps.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ee []outbox.Event
+		err := tx.Where(
+			"fanned_out = ?",
+			false,
+		).Order(
+			"id asc",
+		).Limit(
+			d.conf.BatchSize,
+		).Find(
+			&ee,
+		).Error
+		if err != nil {
+			return errors.Wrap(err, "[Dispatcher.fanOut]: failed to query outbox events")
+		}
+		if len(ee) == 0 {
+			return nil
+		}
+
+		var ss []webhook.Subscription
+		if err := tx.Where("enabled = ?", true).Find(&ss).Error; err != nil {
+			return errors.Wrap(err, "[Dispatcher.fanOut]: failed to query subscriptions")
+		}
+
+		ff := make([]*filter, 0, len(ss))
+		for i := range ss {
+			f, err := newFilter(&ss[i])
+			if err != nil {
+				util.Logger.Error(errors.Wrap(err, "[Dispatcher.fanOut]: skip subscription"))
+				continue
+			}
+			ff = append(ff, f)
+		}
+
+		now := time.Now()
+		var dd []webhook.Delivery
+		ids := make([]string, 0, len(ee))
+		for i := range ee {
+			for _, f := range ff {
+				if !f.matches(&ee[i]) {
+					continue
+				}
+
+				dd = append(dd, webhook.Delivery{
+					SubscriptionID: f.subscriptionID,
+					EventID:        ee[i].ID,
+					State:          webhook.DeliveryStatePending,
+					NextAttemptAt:  now,
+				})
+			}
+			ids = append(ids, ee[i].ID)
+		}
+
+		if len(dd) > 0 {
+			err := tx.Clauses(
+				clause.OnConflict{DoNothing: true},
+			).CreateInBatches(
+				&dd, 100,
+			).Error
+			if err != nil {
+				return errors.Wrap(err, "[Dispatcher.fanOut]: failed to create deliveries")
+			}
+		}
+
+		err = tx.Model(
+			&outbox.Event{},
+		).Where(
+			"id in ?",
+			ids,
+		).Update(
+			"fanned_out", true,
+		).Error
+		if err != nil {
+			return errors.Wrap(err, "[Dispatcher.fanOut]: failed to mark outbox events")
+		}
+
+		return nil
+	})
+	if err != nil {
+		util.Logger.Error(err)
+	}
+}
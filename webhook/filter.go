@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/webhook"
+)
+
+// filter holds the decoded filters of a subscription
+type filter struct {
+	subscriptionID string
+	eventTypes     []string
+	chainIDs       []string
+	tokenAddrs     []string
+}
+
+func newFilter(s *webhook.Subscription) (*filter, error) {
+	f := filter{
+		subscriptionID: s.ID,
+	}
+	if err := decodeStrings(s.EventTypes, &f.eventTypes); err != nil {
+		return nil, errors.Wrapf(err, "[newFilter]: invalid event types of Subscription %s", s.ID)
+	}
+	if err := decodeStrings(s.ChainIDs, &f.chainIDs); err != nil {
+		return nil, errors.Wrapf(err, "[newFilter]: invalid chain ids of Subscription %s", s.ID)
+	}
+	if err := decodeStrings(s.TokenAddrs, &f.tokenAddrs); err != nil {
+		return nil, errors.Wrapf(err, "[newFilter]: invalid token addresses of Subscription %s", s.ID)
+	}
+
+	return &f, nil
+}
+
+func decodeStrings(j datatypes.JSON, v *[]string) error {
+	if len(j) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(j, v)
+}
+
+func (f *filter) matches(e *outbox.Event) bool {
+	return f.matchesEventType(e.Type) &&
+		matchesAny(f.chainIDs, e.SrcChainID, e.DstChainID) &&
+		matchesAny(f.tokenAddrs, e.SrcTokenAddr, e.DstTokenAddr)
+}
+
+func (f *filter) matchesEventType(eventType string) bool {
+	if len(f.eventTypes) == 0 {
+		return true
+	}
+
+	for _, t := range f.eventTypes {
+		if t == eventType {
+			return true
+		}
+		if strings.HasSuffix(t, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(t, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesAny tells whether one of the values is allowed, every value is allowed when there are no allowed ones
+func matchesAny(allowed []string, values ...string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	for _, a := range allowed {
+		for _, v := range values {
+			if v != "" && strings.EqualFold(a, v) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/webhook"
+)
+
+// ReplayDelivery schedules a delivery again with a fresh attempt budget, whatever its state. It returns
+// gorm.ErrRecordNotFound if there is no such delivery.
+func ReplayDelivery(db *gorm.DB, id string) error {
+	res := db.Model(
+		&webhook.Delivery{},
+	).Where(
+		"id = ?",
+		id,
+	).Updates(map[string]interface{}{
+		"state":           webhook.DeliveryStatePending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+		"last_error":      "",
+	})
+	if res.Error != nil {
+		return errors.Wrap(res.Error, "[ReplayDelivery]: failed to update delivery")
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// ReplayDeadDeliveries schedules the dead deliveries of a subscription again and returns how many there were
+func ReplayDeadDeliveries(db *gorm.DB, subscriptionID string) (int64, error) {
+	res := db.Model(
+		&webhook.Delivery{},
+	).Where(
+		"subscription_id = ? and state = ?",
+		subscriptionID,
+		webhook.DeliveryStateDead,
+	).Updates(map[string]interface{}{
+		"state":           webhook.DeliveryStatePending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+		"last_error":      "",
+	})
+	if res.Error != nil {
+		return 0, errors.Wrap(res.Error, "[ReplayDeadDeliveries]: failed to update deliveries")
+	}
+
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader carries sha256=<hex HMAC-SHA256 of "<timestamp>.<body>" keyed by the subscription secret>
+	SignatureHeader = "X-Bridge-Signature"
+	// TimestampHeader carries the unix time in seconds at which the delivery attempt was signed
+	TimestampHeader  = "X-Bridge-Timestamp"
+	EventIDHeader    = "X-Bridge-Event-Id"
+	EventTypeHeader  = "X-Bridge-Event-Type"
+	DeliveryIDHeader = "X-Bridge-Delivery-Id"
+
+	// Tolerance is how far the timestamp of a delivery may be from the receiver clock, receivers reject older
+	// deliveries so that a captured request cannot be replayed later
+	Tolerance = 5 * time.Minute
+)
+
+// Sign returns the signature header value of a body sent with a timestamp header value
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify tells whether a signature header value matches a body and its timestamp header value, and whether the
+// timestamp is within tolerance of now
+func Verify(secret, timestamp string, body []byte, signature string, now time.Time, tolerance time.Duration) bool {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	skew := now.Sub(time.Unix(unix, 0))
+	if skew > tolerance || skew < -tolerance {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/lease"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/util"
+)
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Timeout bounds a single delivery attempt
+	Timeout time.Duration
+	// MaxAttempts is the number of failed attempts after which a delivery is dead-lettered
+	MaxAttempts int
+	// BackoffBase is the delay before the first retry, it doubles on every retry up to BackoffMax
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+}
+
+type Dependencies struct {
+	DB *gorm.DB
+	// Leader is nil when a single replica runs, otherwise only the leader delivers webhooks
+	Leader lease.Leader
+}
+
+// Dispatcher fans the outbox events out to the matching subscriptions and delivers them
+type Dispatcher struct {
+	conf   *Config
+	deps   *Dependencies
+	client *http.Client
+
+	wg sync.WaitGroup
+}
+
+// NewDispatcher returns the webhook dispatcher instance
+func NewDispatcher(c *Config, d *Dependencies) *Dispatcher {
+	return &Dispatcher{
+		conf: c,
+		deps: d,
+		client: &http.Client{
+			Timeout: c.Timeout,
+		},
+	}
+}
+
+// Start starts the fan out and delivery loops, they stop once ctx is done
+func (d *Dispatcher) Start(ctx context.Context) {
+	for _, fn := range []func(context.Context){d.fanOut, d.deliver} {
+		fn := fn
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.run(ctx, fn)
+		}()
+	}
+}
+
+// Wait blocks until the loops started by Start have returned
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) run(ctx context.Context, fn func(context.Context)) {
+	for util.Sleep(ctx, d.conf.PollInterval) {
+		if d.deps.Leader == nil || d.deps.Leader.IsLeader() {
+			fn(ctx)
+		}
+	}
+}
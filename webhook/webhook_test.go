@@ -0,0 +1,173 @@
+package webhook_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/model/outbox"
+	mwebhook "github.com/synycboom/bsc-evm-compatible-bridge-core/model/webhook"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/testutil"
+	"github.com/synycboom/bsc-evm-compatible-bridge-core/webhook"
+)
+
+const secret = "s3cret"
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := webhook.Sign(secret, timestamp, body)
+
+	if !webhook.Verify(secret, timestamp, body, signature, now.Add(time.Minute), webhook.Tolerance) {
+		t.Error("valid signature was rejected")
+	}
+	if webhook.Verify(secret, timestamp, []byte(`{"id":"2"}`), signature, now, webhook.Tolerance) {
+		t.Error("signature of another body was accepted")
+	}
+	if webhook.Verify("other", timestamp, body, signature, now, webhook.Tolerance) {
+		t.Error("signature with another secret was accepted")
+	}
+	if webhook.Verify(secret, "1700000001", body, signature, now, webhook.Tolerance) {
+		t.Error("signature with another timestamp was accepted")
+	}
+	if webhook.Verify(secret, timestamp, body, signature, now.Add(webhook.Tolerance+time.Second), webhook.Tolerance) {
+		t.Error("replayed delivery outside the tolerance was accepted")
+	}
+}
+
+// receiver fails the first failures requests, then verifies and accepts the others
+type receiver struct {
+	t        *testing.T
+	failures int
+
+	mutex    sync.Mutex
+	requests int
+	accepted int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.requests++
+	if r.requests <= r.failures {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		r.t.Errorf("failed to read body: %v", err)
+	}
+	timestamp := req.Header.Get(webhook.TimestampHeader)
+	signature := req.Header.Get(webhook.SignatureHeader)
+	if !webhook.Verify(secret, timestamp, body, signature, time.Now(), webhook.Tolerance) {
+		http.Error(w, "bad signature", http.StatusUnauthorized)
+		return
+	}
+	if req.Header.Get(webhook.EventIDHeader) == "" || req.Header.Get(webhook.DeliveryIDHeader) == "" {
+		r.t.Error("event or delivery id header is missing")
+	}
+
+	r.accepted++
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setup(t *testing.T, failures int) (*gorm.DB, *receiver) {
+	db := testutil.NewDB()
+	r := &receiver{t: t, failures: failures}
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	if err := db.Create(&mwebhook.Subscription{URL: srv.URL, Secret: secret, Enabled: true}).Error; err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+	err := db.Create(&outbox.Event{
+		Type:         "erc721_swap.fill_tx_confirmed",
+		EntityType:   "erc721_swap",
+		EntityID:     "01F00000000000000000000000",
+		SrcChainID:   "97",
+		DstChainID:   "4",
+		SrcTokenAddr: "0x0000000000000000000000000000000000000070",
+		Payload:      []byte(`{"transition":{"from_state":"fill_tx_sent","to_state":"fill_tx_confirmed","actor":"test"}}`),
+	}).Error
+	if err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	return db, r
+}
+
+// run runs a dispatcher until the delivery reaches a final state and returns it
+func run(t *testing.T, db *gorm.DB, maxAttempts int) *mwebhook.Delivery {
+	d := webhook.NewDispatcher(&webhook.Config{
+		PollInterval: 10 * time.Millisecond,
+		BatchSize:    10,
+		Timeout:      time.Second,
+		MaxAttempts:  maxAttempts,
+		BackoffBase:  10 * time.Millisecond,
+		BackoffMax:   40 * time.Millisecond,
+	}, &webhook.Dependencies{
+		DB: db,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.Start(ctx)
+	defer func() {
+		cancel()
+		d.Wait()
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		var dl mwebhook.Delivery
+		err := db.First(&dl).Error
+		if err == nil && dl.State != mwebhook.DeliveryStatePending {
+			return &dl
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("delivery did not reach a final state")
+
+	return nil
+}
+
+func TestDeliveryRetriedUntilAccepted(t *testing.T) {
+	db, r := setup(t, 2)
+
+	dl := run(t, db, 5)
+	if dl.State != mwebhook.DeliveryStateDelivered {
+		t.Fatalf("expected delivered, got %s: %s", dl.State, dl.LastError)
+	}
+	if dl.Attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", dl.Attempts)
+	}
+	if r.accepted != 1 {
+		t.Errorf("expected 1 accepted request, got %d", r.accepted)
+	}
+}
+
+func TestDeliveryDeadLettered(t *testing.T) {
+	db, r := setup(t, 100)
+
+	dl := run(t, db, 3)
+	if dl.State != mwebhook.DeliveryStateDead {
+		t.Fatalf("expected dead, got %s", dl.State)
+	}
+	if dl.Attempts != 3 || dl.LastStatusCode != http.StatusServiceUnavailable {
+		t.Errorf("unexpected attempts %d, status %d", dl.Attempts, dl.LastStatusCode)
+	}
+	if r.requests != 3 {
+		t.Errorf("expected 3 requests, got %d", r.requests)
+	}
+}